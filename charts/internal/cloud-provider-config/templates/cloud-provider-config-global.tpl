@@ -1,7 +1,24 @@
 {{- define "cloud-provider-config-credentials" -}}
 auth-url="{{ .Values.authUrl }}"
+{{- if or .Values.userDomainID .Values.projectDomainID }}
+{{- if .Values.userDomainID }}
+user-domain-id="{{ .Values.userDomainID }}"
+{{- else }}
+user-domain-name="{{ .Values.domainName }}"
+{{- end }}
+{{- if .Values.projectDomainID }}
+tenant-domain-id="{{ .Values.projectDomainID }}"
+{{- else }}
+tenant-domain-name="{{ .Values.domainName }}"
+{{- end }}
+{{- else }}
 domain-name="{{ .Values.domainName }}"
+{{- end }}
+{{- if .Values.tenantID }}
+tenant-id="{{ .Values.tenantID }}"
+{{- else }}
 tenant-name="{{ .Values.tenantName }}"
+{{- end }}
 username="{{ .Values.username }}"
 {{- if .Values.password }}
 password="{{ .Values.password }}"
@@ -12,6 +29,9 @@ application-credential-name="{{ .Values.applicationCredentialName }}"
 application-credential-secret="{{ .Values.applicationCredentialSecret }}"
 {{- end }}
 region="{{ .Values.region }}"
+{{- if .Values.interface }}
+os-endpoint-type="{{ .Values.interface }}"
+{{- end }}
 {{- if .Values.insecure }}
 tls-insecure={{ .Values.insecure }}
 {{- end }}
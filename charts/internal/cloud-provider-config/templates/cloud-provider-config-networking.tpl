@@ -3,4 +3,10 @@
 {{- if .Values.internalNetworkName }}
 internal-network-name="{{ .Values.internalNetworkName }}"
 {{- end }}
+{{- if .Values.dualStack }}
+ipv6-support-disabled=false
+{{- end }}
+{{- if .Values.addressSortOrder }}
+address-sort-order="{{ .Values.addressSortOrder }}"
+{{- end }}
 {{- end -}}
@@ -5,3 +5,25 @@ extensions.gardener.cloud
 {{- define "csi-driver-node.name" -}}
 provider-openstack
 {{- end -}}
+
+{{- define "csi-driver-manila.project" -}}
+{{- if .Values.openstack.projectID }}
+  os-projectID: {{ .Values.openstack.projectID | b64enc }}
+{{- else }}
+  os-projectName: {{ required "openstack.projectName needs to be set" .Values.openstack.projectName | b64enc }}
+{{- end }}
+{{- if or .Values.openstack.userDomainID .Values.openstack.projectDomainID }}
+{{- if .Values.openstack.userDomainID }}
+  os-userDomainID: {{ .Values.openstack.userDomainID | b64enc }}
+{{- else }}
+  os-userDomainName: {{ required "openstack.domainName needs to be set" .Values.openstack.domainName | b64enc }}
+{{- end }}
+{{- if .Values.openstack.projectDomainID }}
+  os-projectDomainID: {{ .Values.openstack.projectDomainID | b64enc }}
+{{- else }}
+  os-projectDomainName: {{ required "openstack.domainName needs to be set" .Values.openstack.domainName | b64enc }}
+{{- end }}
+{{- else }}
+  os-domainName: {{ required "openstack.domainName needs to be set" .Values.openstack.domainName | b64enc }}
+{{- end }}
+{{- end -}}
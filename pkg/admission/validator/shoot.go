@@ -108,6 +108,7 @@ func (s *shoot) validateShootCreation(ctx context.Context, shoot *core.Shoot, cr
 		allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfigAgainstCloudProfile(nil, valContext.infraConfig, credentials.DomainName, valContext.shoot.Spec.Region, valContext.cloudProfileConfig, infraConfigPath)...)
 		allErrs = append(allErrs, openstackvalidation.ValidateControlPlaneConfigAgainstCloudProfile(nil, valContext.cpConfig, credentials.DomainName, valContext.shoot.Spec.Region, valContext.infraConfig.FloatingPoolName, valContext.cloudProfileConfig, cpConfigPath)...)
 	}
+	allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfigAgainstUseFlow(nil, valContext.infraConfig, valContext.shoot.Annotations, infraConfigPath)...)
 	allErrs = append(allErrs, s.validateShoot(valContext)...)
 	return allErrs.ToAggregate()
 }
@@ -126,6 +127,7 @@ func (s *shoot) validateShootUpdate(ctx context.Context, oldShoot, shoot *core.S
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfigUpdate(oldValContext.infraConfig, valContext.infraConfig, infraConfigPath)...)
+	allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfigAgainstUseFlow(oldValContext.infraConfig, valContext.infraConfig, valContext.shoot.Annotations, infraConfigPath)...)
 	if credentials != nil {
 		allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfigAgainstCloudProfile(oldValContext.infraConfig, valContext.infraConfig, credentials.DomainName, valContext.shoot.Spec.Region, valContext.cloudProfileConfig, infraConfigPath)...)
 	}
@@ -161,6 +163,7 @@ func (s *shoot) validateShoot(context *validationContext) field.ErrorList {
 	if context.shoot.Spec.Networking != nil {
 		allErrs = append(allErrs, openstackvalidation.ValidateNetworking(context.shoot.Spec.Networking, nwPath)...)
 		allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfig(context.infraConfig, context.shoot.Spec.Networking.Nodes, infraConfigPath)...)
+		allErrs = append(allErrs, openstackvalidation.ValidateInfrastructureConfigAgainstNetworking(context.infraConfig, context.shoot.Spec.Networking, infraConfigPath)...)
 	}
 	allErrs = append(allErrs, openstackvalidation.ValidateControlPlaneConfig(context.cpConfig, context.infraConfig, context.shoot.Spec.Kubernetes.Version, cpConfigPath)...)
 	allErrs = append(allErrs, openstackvalidation.ValidateWorkers(context.shoot.Spec.Provider.Workers, context.cloudProfileConfig, workersPath)...)
@@ -26,6 +26,12 @@ type ControllerConfiguration struct {
 	HealthCheckConfig *healthcheckconfig.HealthCheckConfig
 	// BastionConfig is the config for the Bastion
 	BastionConfig *BastionConfig
+	// ApplicationCredentialRotation is the config for the rotation of application credentials in cloud provider secrets.
+	ApplicationCredentialRotation *ApplicationCredentialRotationConfig
+	// OrphanedResources is the config for the detection of orphaned resources in the OpenStack projects of the shoots.
+	OrphanedResources *OrphanedResourcesConfig
+	// FlowMigration is the config for the migration of the infrastructures from the Terraformer to the flow.
+	FlowMigration *FlowMigrationConfig
 }
 
 // ETCD is an etcd configuration.
@@ -57,3 +63,52 @@ type BastionConfig struct {
 	// FlavorRef is the openstack flavorRef reference
 	FlavorRef string
 }
+
+// ApplicationCredentialRotationConfig is the config for the rotation of application credentials in cloud provider secrets.
+type ApplicationCredentialRotationConfig struct {
+	// Enabled enables the rotation of application credentials.
+	Enabled bool
+	// MaxAge is the age after which an application credential is rotated.
+	MaxAge *metav1.Duration
+	// RenewBefore is the duration before the expiration of an application credential at which it is rotated.
+	RenewBefore *metav1.Duration
+	// Lifetime is the lifetime of new application credentials. They do not expire if it is not set.
+	Lifetime *metav1.Duration
+}
+
+// OrphanedResourcesConfig is the config for the detection of orphaned resources in the OpenStack projects of the shoots.
+type OrphanedResourcesConfig struct {
+	// Enabled enables the detection of orphaned resources.
+	Enabled bool
+	// SyncPeriod is the interval in which each OpenStack project is scanned.
+	SyncPeriod *metav1.Duration
+	// Delete enables the deletion of orphaned resources which were detected at least the grace period ago.
+	Delete bool
+	// GracePeriod is the duration for which a resource has to be orphaned before it is deleted.
+	GracePeriod *metav1.Duration
+	// DeleteUntagged also enables the deletion of orphaned resources which are only recognized by their names. It should
+	// only be enabled if the OpenStack projects are not shared with shoots of other seeds.
+	DeleteUntagged bool
+}
+
+// FlowMigrationConfig is the config for the migration of the infrastructures from the Terraformer to the flow.
+type FlowMigrationConfig struct {
+	// Policy selects the infrastructures which are reconciled with the flow.
+	Policy FlowMigrationPolicy
+	// Percentage is the percentage of the shoots which are reconciled with the flow for the policy "Percentage".
+	Percentage *int32
+}
+
+// FlowMigrationPolicy is a policy selecting the infrastructures which are reconciled with the flow.
+type FlowMigrationPolicy string
+
+const (
+	// FlowMigrationPolicyNever only uses the flow for infrastructures which are annotated or require it.
+	FlowMigrationPolicyNever FlowMigrationPolicy = "Never"
+	// FlowMigrationPolicyNewShoots uses the flow for all infrastructures which were not created by the Terraformer.
+	FlowMigrationPolicyNewShoots FlowMigrationPolicy = "NewShoots"
+	// FlowMigrationPolicyPercentage uses the flow for a percentage of the shoots, which are selected by the hash of their UID.
+	FlowMigrationPolicyPercentage FlowMigrationPolicy = "Percentage"
+	// FlowMigrationPolicyAll uses the flow for all infrastructures.
+	FlowMigrationPolicyAll FlowMigrationPolicy = "All"
+)
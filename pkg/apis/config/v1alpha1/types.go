@@ -30,6 +30,15 @@ type ControllerConfiguration struct {
 	// BastionConfig the config for the Bastion
 	// +optional
 	BastionConfig *BastionConfig `json:"bastionConfig,omitempty"`
+	// ApplicationCredentialRotation is the config for the rotation of application credentials in cloud provider secrets.
+	// +optional
+	ApplicationCredentialRotation *ApplicationCredentialRotationConfig `json:"applicationCredentialRotation,omitempty"`
+	// OrphanedResources is the config for the detection of orphaned resources in the OpenStack projects of the shoots.
+	// +optional
+	OrphanedResources *OrphanedResourcesConfig `json:"orphanedResources,omitempty"`
+	// FlowMigration is the config for the migration of the infrastructures from the Terraformer to the flow.
+	// +optional
+	FlowMigration *FlowMigrationConfig `json:"flowMigration,omitempty"`
 }
 
 // ETCD is an etcd configuration.
@@ -64,3 +73,63 @@ type BastionConfig struct {
 	// FlavorRef is the openstack flavorRef reference
 	FlavorRef string `json:"flavorRef,omitempty"`
 }
+
+// ApplicationCredentialRotationConfig is the config for the rotation of application credentials in cloud provider secrets.
+type ApplicationCredentialRotationConfig struct {
+	// Enabled enables the rotation of application credentials.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxAge is the age after which an application credential is rotated.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// RenewBefore is the duration before the expiration of an application credential at which it is rotated.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// Lifetime is the lifetime of new application credentials. They do not expire if it is not set.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+}
+
+// OrphanedResourcesConfig is the config for the detection of orphaned resources in the OpenStack projects of the shoots.
+type OrphanedResourcesConfig struct {
+	// Enabled enables the detection of orphaned resources.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// SyncPeriod is the interval in which each OpenStack project is scanned.
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+	// Delete enables the deletion of orphaned resources which were detected at least the grace period ago.
+	// +optional
+	Delete bool `json:"delete,omitempty"`
+	// GracePeriod is the duration for which a resource has to be orphaned before it is deleted.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// DeleteUntagged also enables the deletion of orphaned resources which are only recognized by their names. It should
+	// only be enabled if the OpenStack projects are not shared with shoots of other seeds.
+	// +optional
+	DeleteUntagged bool `json:"deleteUntagged,omitempty"`
+}
+
+// FlowMigrationConfig is the config for the migration of the infrastructures from the Terraformer to the flow.
+type FlowMigrationConfig struct {
+	// Policy selects the infrastructures which are reconciled with the flow.
+	// +optional
+	Policy FlowMigrationPolicy `json:"policy,omitempty"`
+	// Percentage is the percentage of the shoots which are reconciled with the flow for the policy "Percentage".
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+// FlowMigrationPolicy is a policy selecting the infrastructures which are reconciled with the flow.
+type FlowMigrationPolicy string
+
+const (
+	// FlowMigrationPolicyNever only uses the flow for infrastructures which are annotated or require it.
+	FlowMigrationPolicyNever FlowMigrationPolicy = "Never"
+	// FlowMigrationPolicyNewShoots uses the flow for all infrastructures which were not created by the Terraformer.
+	FlowMigrationPolicyNewShoots FlowMigrationPolicy = "NewShoots"
+	// FlowMigrationPolicyPercentage uses the flow for a percentage of the shoots, which are selected by the hash of their UID.
+	FlowMigrationPolicyPercentage FlowMigrationPolicy = "Percentage"
+	// FlowMigrationPolicyAll uses the flow for all infrastructures.
+	FlowMigrationPolicyAll FlowMigrationPolicy = "All"
+)
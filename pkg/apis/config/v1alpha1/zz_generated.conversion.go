@@ -16,6 +16,7 @@ import (
 	apisconfig "github.com/gardener/gardener/extensions/pkg/apis/config"
 	apisconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -29,6 +30,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ApplicationCredentialRotationConfig)(nil), (*config.ApplicationCredentialRotationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ApplicationCredentialRotationConfig_To_config_ApplicationCredentialRotationConfig(a.(*ApplicationCredentialRotationConfig), b.(*config.ApplicationCredentialRotationConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ApplicationCredentialRotationConfig)(nil), (*ApplicationCredentialRotationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ApplicationCredentialRotationConfig_To_v1alpha1_ApplicationCredentialRotationConfig(a.(*config.ApplicationCredentialRotationConfig), b.(*ApplicationCredentialRotationConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*BastionConfig)(nil), (*config.BastionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_BastionConfig_To_config_BastionConfig(a.(*BastionConfig), b.(*config.BastionConfig), scope)
 	}); err != nil {
@@ -79,9 +90,55 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FlowMigrationConfig)(nil), (*config.FlowMigrationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlowMigrationConfig_To_config_FlowMigrationConfig(a.(*FlowMigrationConfig), b.(*config.FlowMigrationConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.FlowMigrationConfig)(nil), (*FlowMigrationConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_FlowMigrationConfig_To_v1alpha1_FlowMigrationConfig(a.(*config.FlowMigrationConfig), b.(*FlowMigrationConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OrphanedResourcesConfig)(nil), (*config.OrphanedResourcesConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OrphanedResourcesConfig_To_config_OrphanedResourcesConfig(a.(*OrphanedResourcesConfig), b.(*config.OrphanedResourcesConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.OrphanedResourcesConfig)(nil), (*OrphanedResourcesConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_OrphanedResourcesConfig_To_v1alpha1_OrphanedResourcesConfig(a.(*config.OrphanedResourcesConfig), b.(*OrphanedResourcesConfig), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_ApplicationCredentialRotationConfig_To_config_ApplicationCredentialRotationConfig(in *ApplicationCredentialRotationConfig, out *config.ApplicationCredentialRotationConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MaxAge = (*v1.Duration)(unsafe.Pointer(in.MaxAge))
+	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Lifetime = (*v1.Duration)(unsafe.Pointer(in.Lifetime))
+	return nil
+}
+
+// Convert_v1alpha1_ApplicationCredentialRotationConfig_To_config_ApplicationCredentialRotationConfig is an autogenerated conversion function.
+func Convert_v1alpha1_ApplicationCredentialRotationConfig_To_config_ApplicationCredentialRotationConfig(in *ApplicationCredentialRotationConfig, out *config.ApplicationCredentialRotationConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ApplicationCredentialRotationConfig_To_config_ApplicationCredentialRotationConfig(in, out, s)
+}
+
+func autoConvert_config_ApplicationCredentialRotationConfig_To_v1alpha1_ApplicationCredentialRotationConfig(in *config.ApplicationCredentialRotationConfig, out *ApplicationCredentialRotationConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MaxAge = (*v1.Duration)(unsafe.Pointer(in.MaxAge))
+	out.RenewBefore = (*v1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.Lifetime = (*v1.Duration)(unsafe.Pointer(in.Lifetime))
 	return nil
 }
 
+// Convert_config_ApplicationCredentialRotationConfig_To_v1alpha1_ApplicationCredentialRotationConfig is an autogenerated conversion function.
+func Convert_config_ApplicationCredentialRotationConfig_To_v1alpha1_ApplicationCredentialRotationConfig(in *config.ApplicationCredentialRotationConfig, out *ApplicationCredentialRotationConfig, s conversion.Scope) error {
+	return autoConvert_config_ApplicationCredentialRotationConfig_To_v1alpha1_ApplicationCredentialRotationConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_BastionConfig_To_config_BastionConfig(in *BastionConfig, out *config.BastionConfig, s conversion.Scope) error {
 	out.ImageRef = in.ImageRef
 	out.FlavorRef = in.FlavorRef
@@ -111,6 +168,9 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfigurat
 	}
 	out.HealthCheckConfig = (*apisconfig.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.BastionConfig = (*config.BastionConfig)(unsafe.Pointer(in.BastionConfig))
+	out.ApplicationCredentialRotation = (*config.ApplicationCredentialRotationConfig)(unsafe.Pointer(in.ApplicationCredentialRotation))
+	out.OrphanedResources = (*config.OrphanedResourcesConfig)(unsafe.Pointer(in.OrphanedResources))
+	out.FlowMigration = (*config.FlowMigrationConfig)(unsafe.Pointer(in.FlowMigration))
 	return nil
 }
 
@@ -126,6 +186,9 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 	}
 	out.HealthCheckConfig = (*apisconfigv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.BastionConfig = (*BastionConfig)(unsafe.Pointer(in.BastionConfig))
+	out.ApplicationCredentialRotation = (*ApplicationCredentialRotationConfig)(unsafe.Pointer(in.ApplicationCredentialRotation))
+	out.OrphanedResources = (*OrphanedResourcesConfig)(unsafe.Pointer(in.OrphanedResources))
+	out.FlowMigration = (*FlowMigrationConfig)(unsafe.Pointer(in.FlowMigration))
 	return nil
 }
 
@@ -205,3 +268,53 @@ func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStora
 func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
+
+func autoConvert_v1alpha1_FlowMigrationConfig_To_config_FlowMigrationConfig(in *FlowMigrationConfig, out *config.FlowMigrationConfig, s conversion.Scope) error {
+	out.Policy = config.FlowMigrationPolicy(in.Policy)
+	out.Percentage = (*int32)(unsafe.Pointer(in.Percentage))
+	return nil
+}
+
+// Convert_v1alpha1_FlowMigrationConfig_To_config_FlowMigrationConfig is an autogenerated conversion function.
+func Convert_v1alpha1_FlowMigrationConfig_To_config_FlowMigrationConfig(in *FlowMigrationConfig, out *config.FlowMigrationConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlowMigrationConfig_To_config_FlowMigrationConfig(in, out, s)
+}
+
+func autoConvert_config_FlowMigrationConfig_To_v1alpha1_FlowMigrationConfig(in *config.FlowMigrationConfig, out *FlowMigrationConfig, s conversion.Scope) error {
+	out.Policy = FlowMigrationPolicy(in.Policy)
+	out.Percentage = (*int32)(unsafe.Pointer(in.Percentage))
+	return nil
+}
+
+// Convert_config_FlowMigrationConfig_To_v1alpha1_FlowMigrationConfig is an autogenerated conversion function.
+func Convert_config_FlowMigrationConfig_To_v1alpha1_FlowMigrationConfig(in *config.FlowMigrationConfig, out *FlowMigrationConfig, s conversion.Scope) error {
+	return autoConvert_config_FlowMigrationConfig_To_v1alpha1_FlowMigrationConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_OrphanedResourcesConfig_To_config_OrphanedResourcesConfig(in *OrphanedResourcesConfig, out *config.OrphanedResourcesConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	out.Delete = in.Delete
+	out.GracePeriod = (*v1.Duration)(unsafe.Pointer(in.GracePeriod))
+	out.DeleteUntagged = in.DeleteUntagged
+	return nil
+}
+
+// Convert_v1alpha1_OrphanedResourcesConfig_To_config_OrphanedResourcesConfig is an autogenerated conversion function.
+func Convert_v1alpha1_OrphanedResourcesConfig_To_config_OrphanedResourcesConfig(in *OrphanedResourcesConfig, out *config.OrphanedResourcesConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OrphanedResourcesConfig_To_config_OrphanedResourcesConfig(in, out, s)
+}
+
+func autoConvert_config_OrphanedResourcesConfig_To_v1alpha1_OrphanedResourcesConfig(in *config.OrphanedResourcesConfig, out *OrphanedResourcesConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	out.Delete = in.Delete
+	out.GracePeriod = (*v1.Duration)(unsafe.Pointer(in.GracePeriod))
+	out.DeleteUntagged = in.DeleteUntagged
+	return nil
+}
+
+// Convert_config_OrphanedResourcesConfig_To_v1alpha1_OrphanedResourcesConfig is an autogenerated conversion function.
+func Convert_config_OrphanedResourcesConfig_To_v1alpha1_OrphanedResourcesConfig(in *config.OrphanedResourcesConfig, out *OrphanedResourcesConfig, s conversion.Scope) error {
+	return autoConvert_config_OrphanedResourcesConfig_To_v1alpha1_OrphanedResourcesConfig(in, out, s)
+}
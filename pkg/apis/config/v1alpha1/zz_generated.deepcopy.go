@@ -11,10 +11,42 @@ package v1alpha1
 
 import (
 	apisconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCredentialRotationConfig) DeepCopyInto(out *ApplicationCredentialRotationConfig) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCredentialRotationConfig.
+func (in *ApplicationCredentialRotationConfig) DeepCopy() *ApplicationCredentialRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCredentialRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BastionConfig) DeepCopyInto(out *BastionConfig) {
 	*out = *in
@@ -51,6 +83,21 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(BastionConfig)
 		**out = **in
 	}
+	if in.ApplicationCredentialRotation != nil {
+		in, out := &in.ApplicationCredentialRotation, &out.ApplicationCredentialRotation
+		*out = new(ApplicationCredentialRotationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedResources != nil {
+		in, out := &in.OrphanedResources, &out.OrphanedResources
+		*out = new(OrphanedResourcesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowMigration != nil {
+		in, out := &in.FlowMigration, &out.FlowMigration
+		*out = new(FlowMigrationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -136,3 +183,50 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowMigrationConfig) DeepCopyInto(out *FlowMigrationConfig) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowMigrationConfig.
+func (in *FlowMigrationConfig) DeepCopy() *FlowMigrationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowMigrationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedResourcesConfig) DeepCopyInto(out *OrphanedResourcesConfig) {
+	*out = *in
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedResourcesConfig.
+func (in *OrphanedResourcesConfig) DeepCopy() *OrphanedResourcesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedResourcesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+)
+
+var availableFlowMigrationPolicies = sets.New(
+	config.FlowMigrationPolicyNever,
+	config.FlowMigrationPolicyNewShoots,
+	config.FlowMigrationPolicyPercentage,
+	config.FlowMigrationPolicyAll,
+)
+
+// ValidateControllerConfiguration validates the ControllerConfiguration of the extension.
+func ValidateControllerConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.FlowMigration != nil {
+		allErrs = append(allErrs, ValidateFlowMigrationConfig(cfg.FlowMigration, field.NewPath("flowMigration"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateFlowMigrationConfig validates the FlowMigrationConfig. An empty policy is treated like "Never".
+func ValidateFlowMigrationConfig(flowMigration *config.FlowMigrationConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if flowMigration.Policy != "" && !availableFlowMigrationPolicies.Has(flowMigration.Policy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("policy"), flowMigration.Policy, sets.List(availableFlowMigrationPolicies)))
+	}
+
+	percentagePath := fldPath.Child("percentage")
+	if flowMigration.Percentage == nil {
+		if flowMigration.Policy == config.FlowMigrationPolicyPercentage {
+			allErrs = append(allErrs, field.Required(percentagePath, "must be set for the policy Percentage"))
+		}
+	} else if percentage := *flowMigration.Percentage; percentage < 0 || percentage > 100 {
+		allErrs = append(allErrs, field.Invalid(percentagePath, percentage, "must be between 0 and 100"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	. "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config/validation"
+)
+
+var _ = Describe("ControllerConfiguration validation", func() {
+	Describe("#ValidateControllerConfiguration", func() {
+		It("should allow a configuration without flow migration", func() {
+			Expect(ValidateControllerConfiguration(&config.ControllerConfiguration{})).To(BeEmpty())
+		})
+
+		It("should validate the flow migration", func() {
+			errorList := ValidateControllerConfiguration(&config.ControllerConfiguration{
+				FlowMigration: &config.FlowMigrationConfig{Policy: "Sometimes"},
+			})
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("flowMigration.policy"),
+			}))
+		})
+	})
+
+	DescribeTable("#ValidateFlowMigrationConfig",
+		func(flowMigration config.FlowMigrationConfig, matcher OmegaMatcher) {
+			Expect(ValidateFlowMigrationConfig(&flowMigration, field.NewPath("flowMigration"))).To(matcher)
+		},
+
+		Entry("empty policy", config.FlowMigrationConfig{}, BeEmpty()),
+		Entry("policy Never", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyNever}, BeEmpty()),
+		Entry("policy NewShoots", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyNewShoots}, BeEmpty()),
+		Entry("policy All", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyAll}, BeEmpty()),
+		Entry("policy Percentage", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyPercentage, Percentage: ptr.To[int32](100)}, BeEmpty()),
+		Entry("unknown policy", config.FlowMigrationConfig{Policy: "newShoots"}, ConsistOfFields(Fields{
+			"Type":  Equal(field.ErrorTypeNotSupported),
+			"Field": Equal("flowMigration.policy"),
+		})),
+		Entry("policy Percentage without percentage", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyPercentage}, ConsistOfFields(Fields{
+			"Type":  Equal(field.ErrorTypeRequired),
+			"Field": Equal("flowMigration.percentage"),
+		})),
+		Entry("negative percentage", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyPercentage, Percentage: ptr.To[int32](-1)}, ConsistOfFields(Fields{
+			"Type":  Equal(field.ErrorTypeInvalid),
+			"Field": Equal("flowMigration.percentage"),
+		})),
+		Entry("percentage above 100", config.FlowMigrationConfig{Policy: config.FlowMigrationPolicyPercentage, Percentage: ptr.To[int32](101)}, ConsistOfFields(Fields{
+			"Type":  Equal(field.ErrorTypeInvalid),
+			"Field": Equal("flowMigration.percentage"),
+		})),
+	)
+})
@@ -6,6 +6,7 @@ package helper
 
 import (
 	"fmt"
+	"net/netip"
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"k8s.io/utils/ptr"
@@ -15,17 +16,44 @@ import (
 )
 
 // FindSubnetByPurpose takes a list of subnets and tries to find the first entry
-// whose purpose matches with the given purpose. If no such entry is found then an error will be
-// returned.
+// whose purpose matches with the given purpose and which is not scoped to a zone. If no such entry is found then an
+// error will be returned.
 func FindSubnetByPurpose(subnets []api.Subnet, purpose api.Purpose) (*api.Subnet, error) {
 	for _, subnet := range subnets {
-		if subnet.Purpose == purpose {
+		if subnet.Purpose == purpose && subnet.Zone == nil {
 			return &subnet, nil
 		}
 	}
 	return nil, fmt.Errorf("cannot find subnet with purpose %q", purpose)
 }
 
+// FindSubnetByPurposeAndZone takes a list of subnets and tries to find the entry whose purpose matches with the given
+// purpose and which is scoped to the given zone. If there is no such entry, the subnet found by FindSubnetByPurpose is
+// returned.
+func FindSubnetByPurposeAndZone(subnets []api.Subnet, purpose api.Purpose, zone string) (*api.Subnet, error) {
+	for _, subnet := range subnets {
+		if subnet.Purpose == purpose && subnet.Zone != nil && *subnet.Zone == zone {
+			return &subnet, nil
+		}
+	}
+	return FindSubnetByPurpose(subnets, purpose)
+}
+
+// UsesExistingRouter returns true if the InfrastructureConfig references an existing router instead of creating one.
+// The router may be configured without an ID to declare the routes of the created router.
+func UsesExistingRouter(config *api.InfrastructureConfig) bool {
+	return config.Networks.Router != nil && len(config.Networks.Router.ID) > 0
+}
+
+// RequiresFlow returns true if the InfrastructureConfig uses features which are only supported by the flow-based
+// infrastructure reconciliation.
+func RequiresFlow(config *api.InfrastructureConfig) bool {
+	networks := config.Networks
+	return networks.IPv6 != nil || len(networks.Zones) > 0 || networks.SubnetID != nil ||
+		len(networks.SecurityGroupRules) > 0 || networks.DisableDefaultNodePortRules ||
+		(networks.Router != nil && len(networks.Router.Routes) > 0)
+}
+
 // FindSecurityGroupByPurpose takes a list of security groups and tries to find the first entry
 // whose purpose matches with the given purpose. If no such entry is found then an error will be
 // returned.
@@ -38,6 +66,20 @@ func FindSecurityGroupByPurpose(securityGroups []api.SecurityGroup, purpose api.
 	return nil, fmt.Errorf("cannot find security group with purpose %q", purpose)
 }
 
+// SecurityGroupRuleEtherType returns the ether type of the given security group rule. If it is not set explicitly, it
+// is derived from the remote CIDR and defaults to IPv4.
+func SecurityGroupRuleEtherType(rule api.SecurityGroupRule) api.SecurityGroupRuleEtherType {
+	if rule.EtherType != nil {
+		return *rule.EtherType
+	}
+	if rule.RemoteCIDR != nil {
+		if prefix, err := netip.ParsePrefix(*rule.RemoteCIDR); err == nil && prefix.Addr().Is6() {
+			return api.SecurityGroupRuleEtherTypeIPv6
+		}
+	}
+	return api.SecurityGroupRuleEtherTypeIPv4
+}
+
 // FindMachineImage takes a list of machine images and tries to find the first entry
 // whose name, version, and zone matches with the given name, version, and cloud profile. If no such
 // entry is found then an error will be returned.
@@ -29,6 +29,20 @@ var _ = Describe("Helper", func() {
 		Entry("empty list", []api.Subnet{}, purpose, nil, true),
 		Entry("entry not found", []api.Subnet{{ID: "bar", Purpose: purposeWrong}}, purpose, nil, true),
 		Entry("entry exists", []api.Subnet{{ID: "bar", Purpose: purpose}}, purpose, &api.Subnet{ID: "bar", Purpose: purpose}, false),
+		Entry("only zone-scoped entry exists", []api.Subnet{{ID: "bar", Purpose: purpose, Zone: ptr.To("zone1")}}, purpose, nil, true),
+	)
+
+	DescribeTable("#FindSubnetByPurposeAndZone",
+		func(subnets []api.Subnet, purpose api.Purpose, zone string, expectedSubnet *api.Subnet, expectErr bool) {
+			subnet, err := FindSubnetByPurposeAndZone(subnets, purpose, zone)
+			expectResults(subnet, expectedSubnet, err, expectErr)
+		},
+
+		Entry("list is nil", nil, purpose, "zone1", nil, true),
+		Entry("zone-scoped entry exists", []api.Subnet{{ID: "bar", Purpose: purpose}, {ID: "baz", Purpose: purpose, Zone: ptr.To("zone1")}}, purpose, "zone1",
+			&api.Subnet{ID: "baz", Purpose: purpose, Zone: ptr.To("zone1")}, false),
+		Entry("fallback to entry without zone", []api.Subnet{{ID: "bar", Purpose: purpose}, {ID: "baz", Purpose: purpose, Zone: ptr.To("zone2")}}, purpose, "zone1",
+			&api.Subnet{ID: "bar", Purpose: purpose}, false),
 	)
 
 	DescribeTable("#FindSecurityGroupByPurpose",
@@ -43,6 +57,32 @@ var _ = Describe("Helper", func() {
 		Entry("entry exists", []api.SecurityGroup{{Name: "bar", Purpose: purpose}}, purpose, &api.SecurityGroup{Name: "bar", Purpose: purpose}, false),
 	)
 
+	DescribeTable("#RequiresFlow",
+		func(networks api.Networks, expected bool) {
+			Expect(RequiresFlow(&api.InfrastructureConfig{Networks: networks})).To(Equal(expected))
+		},
+
+		Entry("plain networks", api.Networks{Workers: "10.250.0.0/16"}, false),
+		Entry("existing router", api.Networks{Router: &api.Router{ID: "router"}}, false),
+		Entry("existing subnet", api.Networks{SubnetID: ptr.To("subnet")}, true),
+		Entry("static routes", api.Networks{Router: &api.Router{Routes: []api.Route{{Destination: "10.180.0.0/16", NextHop: "10.250.0.10"}}}}, true),
+		Entry("IPv6 subnet", api.Networks{IPv6: &api.IPv6Network{CIDR: ptr.To("2001:db8::/64")}}, true),
+		Entry("zone subnets", api.Networks{Zones: []api.Zone{{Name: "zone1", Workers: "10.250.32.0/21"}}}, true),
+		Entry("user-defined rules", api.Networks{SecurityGroupRules: []api.SecurityGroupRule{{Direction: "ingress"}}}, true),
+		Entry("default NodePort rules disabled", api.Networks{DisableDefaultNodePortRules: true}, true),
+	)
+
+	DescribeTable("#SecurityGroupRuleEtherType",
+		func(rule api.SecurityGroupRule, expected api.SecurityGroupRuleEtherType) {
+			Expect(SecurityGroupRuleEtherType(rule)).To(Equal(expected))
+		},
+
+		Entry("default", api.SecurityGroupRule{}, api.SecurityGroupRuleEtherTypeIPv4),
+		Entry("explicit ether type", api.SecurityGroupRule{EtherType: ptr.To(api.SecurityGroupRuleEtherTypeIPv6)}, api.SecurityGroupRuleEtherTypeIPv6),
+		Entry("IPv4 remote CIDR", api.SecurityGroupRule{RemoteCIDR: ptr.To("10.0.0.0/8")}, api.SecurityGroupRuleEtherTypeIPv4),
+		Entry("IPv6 remote CIDR", api.SecurityGroupRule{RemoteCIDR: ptr.To("2001:db8::/32")}, api.SecurityGroupRuleEtherTypeIPv6),
+	)
+
 	DescribeTable("#FindMachineImage",
 		func(machineImages []api.MachineImage, name, version, architecture string, expectedMachineImage *api.MachineImage, expectErr bool) {
 			machineImage, err := FindMachineImage(machineImages, name, version, architecture)
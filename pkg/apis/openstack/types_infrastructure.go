@@ -33,14 +33,83 @@ type Networks struct {
 	Workers string
 	// ID is the ID of an existing private network.
 	ID *string
+	// SubnetID is the ID of an existing subnet of the private network, which is used instead of creating one.
+	SubnetID *string
 	// ShareNetwork holds information about the share network (used for shared file systems like NFS)
 	ShareNetwork *ShareNetwork
+	// IPv6 holds information about the IPv6 subnet which is created in addition to the IPv4 worker subnet (dual-stack).
+	IPv6 *IPv6Network
+	// Zones is a list of zone-scoped worker subnets. Machines of an availability zone with a configured subnet are
+	// created in it, machines of other zones in the subnet of the workers CIDR.
+	Zones []Zone
+	// SecurityGroupRules is a list of additional rules of the security group of the worker nodes.
+	SecurityGroupRules []SecurityGroupRule
+	// DisableDefaultNodePortRules removes the default rules allowing access to the NodePort range from everywhere.
+	DisableDefaultNodePortRules bool
 }
 
+// Zone describes the worker subnet of an availability zone.
+type Zone struct {
+	// Name is the name of the availability zone.
+	Name string
+	// Workers is the CIDR of the worker subnet (private) of the zone.
+	Workers string
+}
+
+// SecurityGroupRule is a rule of the security group of the worker nodes.
+type SecurityGroupRule struct {
+	// Direction is the direction of the traffic the rule applies to.
+	Direction SecurityGroupRuleDirection
+	// EtherType is the IP version of the traffic the rule applies to. Defaults to the IP version of the remote CIDR or
+	// `IPv4`.
+	EtherType *SecurityGroupRuleEtherType
+	// Protocol is the IP protocol of the traffic the rule applies to. The rule applies to all protocols if it is empty.
+	Protocol *string
+	// PortRangeMin is the lower bound of the port range of the tcp or udp traffic the rule applies to.
+	PortRangeMin *int32
+	// PortRangeMax is the upper bound of the port range of the tcp or udp traffic the rule applies to. Defaults to
+	// the lower bound.
+	PortRangeMax *int32
+	// RemoteCIDR is the CIDR of the remote addresses the rule applies to.
+	RemoteCIDR *string
+	// RemoteGroupID is the ID of the security group of the remote addresses the rule applies to.
+	RemoteGroupID *string
+}
+
+// SecurityGroupRuleDirection is the direction of the traffic of a security group rule.
+type SecurityGroupRuleDirection string
+
+const (
+	// SecurityGroupRuleDirectionIngress is the direction of incoming traffic.
+	SecurityGroupRuleDirectionIngress SecurityGroupRuleDirection = "ingress"
+	// SecurityGroupRuleDirectionEgress is the direction of outgoing traffic.
+	SecurityGroupRuleDirectionEgress SecurityGroupRuleDirection = "egress"
+)
+
+// SecurityGroupRuleEtherType is the IP version of the traffic of a security group rule.
+type SecurityGroupRuleEtherType string
+
+const (
+	// SecurityGroupRuleEtherTypeIPv4 is the ether type of IPv4 traffic.
+	SecurityGroupRuleEtherTypeIPv4 SecurityGroupRuleEtherType = "IPv4"
+	// SecurityGroupRuleEtherTypeIPv6 is the ether type of IPv6 traffic.
+	SecurityGroupRuleEtherTypeIPv6 SecurityGroupRuleEtherType = "IPv6"
+)
+
 // Router indicates whether to use an existing router or create a new one.
 type Router struct {
-	// ID is the router id of an existing OpenStack router.
+	// ID is the router id of an existing OpenStack router. A new router is created if it is empty.
 	ID string
+	// Routes is a list of additional static routes of the router.
+	Routes []Route
+}
+
+// Route is a static route of the router.
+type Route struct {
+	// Destination is the destination CIDR of the route.
+	Destination string
+	// NextHop is the IP address of the next hop, which must be in one of the worker subnets, e.g. a VPN gateway.
+	NextHop string
 }
 
 // ShareNetwork holds information about the share network (used for shared file systems like NFS)
@@ -49,6 +118,27 @@ type ShareNetwork struct {
 	Enabled bool
 }
 
+// IPv6Network holds information about the IPv6 subnet of the worker network.
+type IPv6Network struct {
+	// CIDR is the CIDR of the IPv6 subnet to create. It must be a /64 network.
+	CIDR *string
+	// SubnetPoolID is the ID of an existing subnet pool the IPv6 subnet is allocated from.
+	SubnetPoolID *string
+	// AddressMode is the mode in which IPv6 addresses are assigned to the machines.
+	AddressMode *IPv6AddressMode
+}
+
+// IPv6AddressMode is a mode in which IPv6 addresses are assigned.
+type IPv6AddressMode string
+
+const (
+	// IPv6AddressModeSLAAC assigns addresses with stateless address autoconfiguration.
+	IPv6AddressModeSLAAC IPv6AddressMode = "slaac"
+	// IPv6AddressModeDHCPv6Stateless assigns addresses with stateless address autoconfiguration and provides additional
+	// information like DNS servers via DHCPv6.
+	IPv6AddressModeDHCPv6Stateless IPv6AddressMode = "dhcpv6-stateless"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // InfrastructureStatus contains information about created infrastructure resources.
@@ -114,6 +204,8 @@ type Purpose string
 const (
 	// PurposeNodes is a Purpose for node resources.
 	PurposeNodes Purpose = "nodes"
+	// PurposeNodesIPv6 is a Purpose for IPv6 node resources.
+	PurposeNodesIPv6 Purpose = "nodes-ipv6"
 )
 
 // Subnet is an OpenStack subnet related to a Network.
@@ -122,6 +214,8 @@ type Subnet struct {
 	Purpose Purpose
 	// ID is the subnet id.
 	ID string
+	// Zone is the availability zone of zone-scoped subnets.
+	Zone *string
 }
 
 // SecurityGroup is an OpenStack security group related to a Network.
@@ -37,15 +37,97 @@ type Networks struct {
 	// ID is the ID of an existing private network.
 	// +optional
 	ID *string `json:"id,omitempty"`
+	// SubnetID is the ID of an existing subnet of the private network, which is used instead of creating one.
+	// +optional
+	SubnetID *string `json:"subnetID,omitempty"`
 	// ShareNetwork holds information about the share network (used for shared file systems like NFS)
 	// +optional
 	ShareNetwork *ShareNetwork `json:"shareNetwork,omitempty"`
+	// IPv6 holds information about the IPv6 subnet which is created in addition to the IPv4 worker subnet (dual-stack).
+	// +optional
+	IPv6 *IPv6Network `json:"ipv6,omitempty"`
+	// Zones is a list of zone-scoped worker subnets. Machines of an availability zone with a configured subnet are
+	// created in it, machines of other zones in the subnet of the workers CIDR.
+	// +optional
+	Zones []Zone `json:"zones,omitempty"`
+	// SecurityGroupRules is a list of additional rules of the security group of the worker nodes.
+	// +optional
+	SecurityGroupRules []SecurityGroupRule `json:"securityGroupRules,omitempty"`
+	// DisableDefaultNodePortRules removes the default rules allowing access to the NodePort range from everywhere.
+	// +optional
+	DisableDefaultNodePortRules bool `json:"disableDefaultNodePortRules,omitempty"`
+}
+
+// Zone describes the worker subnet of an availability zone.
+type Zone struct {
+	// Name is the name of the availability zone.
+	Name string `json:"name"`
+	// Workers is the CIDR of the worker subnet (private) of the zone.
+	Workers string `json:"workers"`
+}
+
+// SecurityGroupRule is a rule of the security group of the worker nodes.
+type SecurityGroupRule struct {
+	// Direction is the direction of the traffic the rule applies to.
+	Direction SecurityGroupRuleDirection `json:"direction"`
+	// EtherType is the IP version of the traffic the rule applies to. Defaults to the IP version of the remote CIDR or
+	// `IPv4`.
+	// +optional
+	EtherType *SecurityGroupRuleEtherType `json:"etherType,omitempty"`
+	// Protocol is the IP protocol of the traffic the rule applies to. The rule applies to all protocols if it is empty.
+	// +optional
+	Protocol *string `json:"protocol,omitempty"`
+	// PortRangeMin is the lower bound of the port range of the tcp or udp traffic the rule applies to.
+	// +optional
+	PortRangeMin *int32 `json:"portRangeMin,omitempty"`
+	// PortRangeMax is the upper bound of the port range of the tcp or udp traffic the rule applies to. Defaults to
+	// the lower bound.
+	// +optional
+	PortRangeMax *int32 `json:"portRangeMax,omitempty"`
+	// RemoteCIDR is the CIDR of the remote addresses the rule applies to.
+	// +optional
+	RemoteCIDR *string `json:"remoteCIDR,omitempty"`
+	// RemoteGroupID is the ID of the security group of the remote addresses the rule applies to.
+	// +optional
+	RemoteGroupID *string `json:"remoteGroupID,omitempty"`
 }
 
+// SecurityGroupRuleDirection is the direction of the traffic of a security group rule.
+type SecurityGroupRuleDirection string
+
+const (
+	// SecurityGroupRuleDirectionIngress is the direction of incoming traffic.
+	SecurityGroupRuleDirectionIngress SecurityGroupRuleDirection = "ingress"
+	// SecurityGroupRuleDirectionEgress is the direction of outgoing traffic.
+	SecurityGroupRuleDirectionEgress SecurityGroupRuleDirection = "egress"
+)
+
+// SecurityGroupRuleEtherType is the IP version of the traffic of a security group rule.
+type SecurityGroupRuleEtherType string
+
+const (
+	// SecurityGroupRuleEtherTypeIPv4 is the ether type of IPv4 traffic.
+	SecurityGroupRuleEtherTypeIPv4 SecurityGroupRuleEtherType = "IPv4"
+	// SecurityGroupRuleEtherTypeIPv6 is the ether type of IPv6 traffic.
+	SecurityGroupRuleEtherTypeIPv6 SecurityGroupRuleEtherType = "IPv6"
+)
+
 // Router indicates whether to use an existing router or create a new one.
 type Router struct {
-	// ID is the router id of an existing OpenStack router.
+	// ID is the router id of an existing OpenStack router. A new router is created if it is empty.
+	// +optional
 	ID string `json:"id"`
+	// Routes is a list of additional static routes of the router.
+	// +optional
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// Route is a static route of the router.
+type Route struct {
+	// Destination is the destination CIDR of the route.
+	Destination string `json:"destination"`
+	// NextHop is the IP address of the next hop, which must be in one of the worker subnets, e.g. a VPN gateway.
+	NextHop string `json:"nextHop"`
 }
 
 // ShareNetwork holds information about the share network (used for shared file systems like NFS)
@@ -54,6 +136,30 @@ type ShareNetwork struct {
 	Enabled bool `json:"enabled"`
 }
 
+// IPv6Network holds information about the IPv6 subnet of the worker network.
+type IPv6Network struct {
+	// CIDR is the CIDR of the IPv6 subnet to create. It must be a /64 network.
+	// +optional
+	CIDR *string `json:"cidr,omitempty"`
+	// SubnetPoolID is the ID of an existing subnet pool the IPv6 subnet is allocated from.
+	// +optional
+	SubnetPoolID *string `json:"subnetPoolID,omitempty"`
+	// AddressMode is the mode in which IPv6 addresses are assigned to the machines. Defaults to `slaac`.
+	// +optional
+	AddressMode *IPv6AddressMode `json:"addressMode,omitempty"`
+}
+
+// IPv6AddressMode is a mode in which IPv6 addresses are assigned.
+type IPv6AddressMode string
+
+const (
+	// IPv6AddressModeSLAAC assigns addresses with stateless address autoconfiguration.
+	IPv6AddressModeSLAAC IPv6AddressMode = "slaac"
+	// IPv6AddressModeDHCPv6Stateless assigns addresses with stateless address autoconfiguration and provides additional
+	// information like DNS servers via DHCPv6.
+	IPv6AddressModeDHCPv6Stateless IPv6AddressMode = "dhcpv6-stateless"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // InfrastructureStatus contains information about created infrastructure resources.
@@ -120,6 +226,8 @@ type Purpose string
 const (
 	// PurposeNodes is a Purpose for node resources.
 	PurposeNodes Purpose = "nodes"
+	// PurposeNodesIPv6 is a Purpose for IPv6 node resources.
+	PurposeNodesIPv6 Purpose = "nodes-ipv6"
 )
 
 // Subnet is an OpenStack subnet related to a Network.
@@ -128,6 +236,9 @@ type Subnet struct {
 	Purpose Purpose `json:"purpose"`
 	// ID is the subnet id.
 	ID string `json:"id"`
+	// Zone is the availability zone of zone-scoped subnets.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
 }
 
 // SecurityGroup is an OpenStack security group related to a Network.
@@ -96,6 +96,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IPv6Network)(nil), (*openstack.IPv6Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_IPv6Network_To_openstack_IPv6Network(a.(*IPv6Network), b.(*openstack.IPv6Network), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.IPv6Network)(nil), (*IPv6Network)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_IPv6Network_To_v1alpha1_IPv6Network(a.(*openstack.IPv6Network), b.(*IPv6Network), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InfrastructureConfig)(nil), (*openstack.InfrastructureConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_InfrastructureConfig_To_openstack_InfrastructureConfig(a.(*InfrastructureConfig), b.(*openstack.InfrastructureConfig), scope)
 	}); err != nil {
@@ -226,6 +236,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Route)(nil), (*openstack.Route)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Route_To_openstack_Route(a.(*Route), b.(*openstack.Route), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.Route)(nil), (*Route)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_Route_To_v1alpha1_Route(a.(*openstack.Route), b.(*Route), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Router)(nil), (*openstack.Router)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Router_To_openstack_Router(a.(*Router), b.(*openstack.Router), scope)
 	}); err != nil {
@@ -256,6 +276,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SecurityGroupRule)(nil), (*openstack.SecurityGroupRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecurityGroupRule_To_openstack_SecurityGroupRule(a.(*SecurityGroupRule), b.(*openstack.SecurityGroupRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.SecurityGroupRule)(nil), (*SecurityGroupRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_SecurityGroupRule_To_v1alpha1_SecurityGroupRule(a.(*openstack.SecurityGroupRule), b.(*SecurityGroupRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServerGroup)(nil), (*openstack.ServerGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ServerGroup_To_openstack_ServerGroup(a.(*ServerGroup), b.(*openstack.ServerGroup), scope)
 	}); err != nil {
@@ -346,6 +376,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Zone)(nil), (*openstack.Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Zone_To_openstack_Zone(a.(*Zone), b.(*openstack.Zone), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.Zone)(nil), (*Zone)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_Zone_To_v1alpha1_Zone(a.(*openstack.Zone), b.(*Zone), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -547,6 +587,30 @@ func Convert_openstack_FloatingPoolStatus_To_v1alpha1_FloatingPoolStatus(in *ope
 	return autoConvert_openstack_FloatingPoolStatus_To_v1alpha1_FloatingPoolStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_IPv6Network_To_openstack_IPv6Network(in *IPv6Network, out *openstack.IPv6Network, s conversion.Scope) error {
+	out.CIDR = (*string)(unsafe.Pointer(in.CIDR))
+	out.SubnetPoolID = (*string)(unsafe.Pointer(in.SubnetPoolID))
+	out.AddressMode = (*openstack.IPv6AddressMode)(unsafe.Pointer(in.AddressMode))
+	return nil
+}
+
+// Convert_v1alpha1_IPv6Network_To_openstack_IPv6Network is an autogenerated conversion function.
+func Convert_v1alpha1_IPv6Network_To_openstack_IPv6Network(in *IPv6Network, out *openstack.IPv6Network, s conversion.Scope) error {
+	return autoConvert_v1alpha1_IPv6Network_To_openstack_IPv6Network(in, out, s)
+}
+
+func autoConvert_openstack_IPv6Network_To_v1alpha1_IPv6Network(in *openstack.IPv6Network, out *IPv6Network, s conversion.Scope) error {
+	out.CIDR = (*string)(unsafe.Pointer(in.CIDR))
+	out.SubnetPoolID = (*string)(unsafe.Pointer(in.SubnetPoolID))
+	out.AddressMode = (*IPv6AddressMode)(unsafe.Pointer(in.AddressMode))
+	return nil
+}
+
+// Convert_openstack_IPv6Network_To_v1alpha1_IPv6Network is an autogenerated conversion function.
+func Convert_openstack_IPv6Network_To_v1alpha1_IPv6Network(in *openstack.IPv6Network, out *IPv6Network, s conversion.Scope) error {
+	return autoConvert_openstack_IPv6Network_To_v1alpha1_IPv6Network(in, out, s)
+}
+
 func autoConvert_v1alpha1_InfrastructureConfig_To_openstack_InfrastructureConfig(in *InfrastructureConfig, out *openstack.InfrastructureConfig, s conversion.Scope) error {
 	out.FloatingPoolName = in.FloatingPoolName
 	out.FloatingPoolSubnetName = (*string)(unsafe.Pointer(in.FloatingPoolSubnetName))
@@ -826,7 +890,12 @@ func autoConvert_v1alpha1_Networks_To_openstack_Networks(in *Networks, out *open
 	out.Worker = in.Worker
 	out.Workers = in.Workers
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.SubnetID = (*string)(unsafe.Pointer(in.SubnetID))
 	out.ShareNetwork = (*openstack.ShareNetwork)(unsafe.Pointer(in.ShareNetwork))
+	out.IPv6 = (*openstack.IPv6Network)(unsafe.Pointer(in.IPv6))
+	out.Zones = *(*[]openstack.Zone)(unsafe.Pointer(&in.Zones))
+	out.SecurityGroupRules = *(*[]openstack.SecurityGroupRule)(unsafe.Pointer(&in.SecurityGroupRules))
+	out.DisableDefaultNodePortRules = in.DisableDefaultNodePortRules
 	return nil
 }
 
@@ -840,7 +909,12 @@ func autoConvert_openstack_Networks_To_v1alpha1_Networks(in *openstack.Networks,
 	out.Worker = in.Worker
 	out.Workers = in.Workers
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.SubnetID = (*string)(unsafe.Pointer(in.SubnetID))
 	out.ShareNetwork = (*ShareNetwork)(unsafe.Pointer(in.ShareNetwork))
+	out.IPv6 = (*IPv6Network)(unsafe.Pointer(in.IPv6))
+	out.Zones = *(*[]Zone)(unsafe.Pointer(&in.Zones))
+	out.SecurityGroupRules = *(*[]SecurityGroupRule)(unsafe.Pointer(&in.SecurityGroupRules))
+	out.DisableDefaultNodePortRules = in.DisableDefaultNodePortRules
 	return nil
 }
 
@@ -893,8 +967,31 @@ func Convert_openstack_RegionIDMapping_To_v1alpha1_RegionIDMapping(in *openstack
 	return autoConvert_openstack_RegionIDMapping_To_v1alpha1_RegionIDMapping(in, out, s)
 }
 
+func autoConvert_v1alpha1_Route_To_openstack_Route(in *Route, out *openstack.Route, s conversion.Scope) error {
+	out.Destination = in.Destination
+	out.NextHop = in.NextHop
+	return nil
+}
+
+// Convert_v1alpha1_Route_To_openstack_Route is an autogenerated conversion function.
+func Convert_v1alpha1_Route_To_openstack_Route(in *Route, out *openstack.Route, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Route_To_openstack_Route(in, out, s)
+}
+
+func autoConvert_openstack_Route_To_v1alpha1_Route(in *openstack.Route, out *Route, s conversion.Scope) error {
+	out.Destination = in.Destination
+	out.NextHop = in.NextHop
+	return nil
+}
+
+// Convert_openstack_Route_To_v1alpha1_Route is an autogenerated conversion function.
+func Convert_openstack_Route_To_v1alpha1_Route(in *openstack.Route, out *Route, s conversion.Scope) error {
+	return autoConvert_openstack_Route_To_v1alpha1_Route(in, out, s)
+}
+
 func autoConvert_v1alpha1_Router_To_openstack_Router(in *Router, out *openstack.Router, s conversion.Scope) error {
 	out.ID = in.ID
+	out.Routes = *(*[]openstack.Route)(unsafe.Pointer(&in.Routes))
 	return nil
 }
 
@@ -905,6 +1002,7 @@ func Convert_v1alpha1_Router_To_openstack_Router(in *Router, out *openstack.Rout
 
 func autoConvert_openstack_Router_To_v1alpha1_Router(in *openstack.Router, out *Router, s conversion.Scope) error {
 	out.ID = in.ID
+	out.Routes = *(*[]Route)(unsafe.Pointer(&in.Routes))
 	return nil
 }
 
@@ -959,6 +1057,38 @@ func Convert_openstack_SecurityGroup_To_v1alpha1_SecurityGroup(in *openstack.Sec
 	return autoConvert_openstack_SecurityGroup_To_v1alpha1_SecurityGroup(in, out, s)
 }
 
+func autoConvert_v1alpha1_SecurityGroupRule_To_openstack_SecurityGroupRule(in *SecurityGroupRule, out *openstack.SecurityGroupRule, s conversion.Scope) error {
+	out.Direction = openstack.SecurityGroupRuleDirection(in.Direction)
+	out.EtherType = (*openstack.SecurityGroupRuleEtherType)(unsafe.Pointer(in.EtherType))
+	out.Protocol = (*string)(unsafe.Pointer(in.Protocol))
+	out.PortRangeMin = (*int32)(unsafe.Pointer(in.PortRangeMin))
+	out.PortRangeMax = (*int32)(unsafe.Pointer(in.PortRangeMax))
+	out.RemoteCIDR = (*string)(unsafe.Pointer(in.RemoteCIDR))
+	out.RemoteGroupID = (*string)(unsafe.Pointer(in.RemoteGroupID))
+	return nil
+}
+
+// Convert_v1alpha1_SecurityGroupRule_To_openstack_SecurityGroupRule is an autogenerated conversion function.
+func Convert_v1alpha1_SecurityGroupRule_To_openstack_SecurityGroupRule(in *SecurityGroupRule, out *openstack.SecurityGroupRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecurityGroupRule_To_openstack_SecurityGroupRule(in, out, s)
+}
+
+func autoConvert_openstack_SecurityGroupRule_To_v1alpha1_SecurityGroupRule(in *openstack.SecurityGroupRule, out *SecurityGroupRule, s conversion.Scope) error {
+	out.Direction = SecurityGroupRuleDirection(in.Direction)
+	out.EtherType = (*SecurityGroupRuleEtherType)(unsafe.Pointer(in.EtherType))
+	out.Protocol = (*string)(unsafe.Pointer(in.Protocol))
+	out.PortRangeMin = (*int32)(unsafe.Pointer(in.PortRangeMin))
+	out.PortRangeMax = (*int32)(unsafe.Pointer(in.PortRangeMax))
+	out.RemoteCIDR = (*string)(unsafe.Pointer(in.RemoteCIDR))
+	out.RemoteGroupID = (*string)(unsafe.Pointer(in.RemoteGroupID))
+	return nil
+}
+
+// Convert_openstack_SecurityGroupRule_To_v1alpha1_SecurityGroupRule is an autogenerated conversion function.
+func Convert_openstack_SecurityGroupRule_To_v1alpha1_SecurityGroupRule(in *openstack.SecurityGroupRule, out *SecurityGroupRule, s conversion.Scope) error {
+	return autoConvert_openstack_SecurityGroupRule_To_v1alpha1_SecurityGroupRule(in, out, s)
+}
+
 func autoConvert_v1alpha1_ServerGroup_To_openstack_ServerGroup(in *ServerGroup, out *openstack.ServerGroup, s conversion.Scope) error {
 	out.Policy = in.Policy
 	return nil
@@ -1102,6 +1232,7 @@ func Convert_openstack_StorageClassDefinition_To_v1alpha1_StorageClassDefinition
 func autoConvert_v1alpha1_Subnet_To_openstack_Subnet(in *Subnet, out *openstack.Subnet, s conversion.Scope) error {
 	out.Purpose = openstack.Purpose(in.Purpose)
 	out.ID = in.ID
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
 	return nil
 }
 
@@ -1113,6 +1244,7 @@ func Convert_v1alpha1_Subnet_To_openstack_Subnet(in *Subnet, out *openstack.Subn
 func autoConvert_openstack_Subnet_To_v1alpha1_Subnet(in *openstack.Subnet, out *Subnet, s conversion.Scope) error {
 	out.Purpose = Purpose(in.Purpose)
 	out.ID = in.ID
+	out.Zone = (*string)(unsafe.Pointer(in.Zone))
 	return nil
 }
 
@@ -1166,3 +1298,25 @@ func autoConvert_openstack_WorkerStatus_To_v1alpha1_WorkerStatus(in *openstack.W
 func Convert_openstack_WorkerStatus_To_v1alpha1_WorkerStatus(in *openstack.WorkerStatus, out *WorkerStatus, s conversion.Scope) error {
 	return autoConvert_openstack_WorkerStatus_To_v1alpha1_WorkerStatus(in, out, s)
 }
+
+func autoConvert_v1alpha1_Zone_To_openstack_Zone(in *Zone, out *openstack.Zone, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Workers = in.Workers
+	return nil
+}
+
+// Convert_v1alpha1_Zone_To_openstack_Zone is an autogenerated conversion function.
+func Convert_v1alpha1_Zone_To_openstack_Zone(in *Zone, out *openstack.Zone, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Zone_To_openstack_Zone(in, out, s)
+}
+
+func autoConvert_openstack_Zone_To_v1alpha1_Zone(in *openstack.Zone, out *Zone, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Workers = in.Workers
+	return nil
+}
+
+// Convert_openstack_Zone_To_v1alpha1_Zone is an autogenerated conversion function.
+func Convert_openstack_Zone_To_v1alpha1_Zone(in *openstack.Zone, out *Zone, s conversion.Scope) error {
+	return autoConvert_openstack_Zone_To_v1alpha1_Zone(in, out, s)
+}
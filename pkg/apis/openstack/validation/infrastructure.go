@@ -5,16 +5,24 @@
 package validation
 
 import (
+	"fmt"
+	"net/netip"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 
+	"github.com/gardener/gardener/pkg/apis/core"
 	cidrvalidation "github.com/gardener/gardener/pkg/utils/validation/cidr"
 	"github.com/google/uuid"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/utils"
 )
 
@@ -53,23 +61,255 @@ func ValidateInfrastructureConfig(infra *api.InfrastructureConfig, nodesCIDR *st
 		allErrs = append(allErrs, nodes.ValidateSubset(workerCIDR)...)
 	}
 
+	zoneCIDRs := []cidrvalidation.CIDR{workerCIDR}
+	zoneNames := sets.New[string]()
+	for i, zone := range infra.Networks.Zones {
+		zonePath := networksPath.Child("zones").Index(i)
+		if len(zone.Name) == 0 {
+			allErrs = append(allErrs, field.Required(zonePath.Child("name"), "must specify the name of the availability zone"))
+		} else if zoneNames.Has(zone.Name) {
+			allErrs = append(allErrs, field.Duplicate(zonePath.Child("name"), zone.Name))
+		}
+		zoneNames.Insert(zone.Name)
+
+		if len(zone.Workers) == 0 {
+			allErrs = append(allErrs, field.Required(zonePath.Child("workers"), "must specify the network range for the worker subnet of the zone"))
+			continue
+		}
+		zoneCIDR := cidrvalidation.NewCIDR(zone.Workers, zonePath.Child("workers"))
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRParse(zoneCIDR)...)
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(zonePath.Child("workers"), zone.Workers)...)
+		if nodes != nil {
+			allErrs = append(allErrs, nodes.ValidateSubset(zoneCIDR)...)
+		}
+		zoneCIDRs = append(zoneCIDRs, zoneCIDR)
+	}
+	allErrs = append(allErrs, cidrvalidation.ValidateCIDROverlap(zoneCIDRs, false)...)
+
+	if infra.Networks.IPv6 != nil {
+		allErrs = append(allErrs, validateIPv6Network(infra.Networks.IPv6, networksPath.Child("ipv6"))...)
+	}
+
 	if infra.Networks.ID != nil {
 		if _, err := uuid.Parse(*infra.Networks.ID); err != nil {
 			allErrs = append(allErrs, field.Invalid(networksPath.Child("id"), infra.Networks.ID, "if network ID is provided it must be a valid OpenStack UUID"))
 		}
 	}
 
-	if infra.Networks.Router != nil && len(infra.Networks.Router.ID) == 0 {
-		allErrs = append(allErrs, field.Invalid(networksPath.Child("router", "id"), infra.Networks.Router.ID, "router id must not be empty when router key is provided"))
+	allErrs = append(allErrs, validateSecurityGroupRules(infra.Networks.SecurityGroupRules, networksPath.Child("securityGroupRules"))...)
+
+	if infra.Networks.SubnetID != nil {
+		if infra.Networks.ID == nil {
+			allErrs = append(allErrs, field.Required(networksPath.Child("id"), "must specify the network ID if a subnet ID is provided"))
+		}
+		if _, err := uuid.Parse(*infra.Networks.SubnetID); err != nil {
+			allErrs = append(allErrs, field.Invalid(networksPath.Child("subnetID"), infra.Networks.SubnetID, "if subnet ID is provided it must be a valid OpenStack UUID"))
+		}
 	}
 
-	if infra.FloatingPoolSubnetName != nil && infra.Networks.Router != nil && len(infra.Networks.Router.ID) > 0 {
+	if infra.Networks.Router != nil {
+		allErrs = append(allErrs, validateRouter(infra.Networks, networksPath.Child("router"))...)
+	}
+
+	if infra.FloatingPoolSubnetName != nil && helper.UsesExistingRouter(infra) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("floatingPoolSubnetName"), infra.FloatingPoolSubnetName, "router id must be empty when a floating subnet name is provided"))
 	}
 
 	return allErrs
 }
 
+func validateRouter(networks api.Networks, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	router := networks.Router
+
+	if len(router.ID) == 0 && len(router.Routes) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), router.ID, "router id must not be empty when router key is provided without routes"))
+	}
+
+	// the next hops must be reachable by the router, i.e. in one of the worker subnets attached to it
+	workerCIDRs := []string{networks.Worker, networks.Workers}
+	for _, zone := range networks.Zones {
+		workerCIDRs = append(workerCIDRs, zone.Workers)
+	}
+	if networks.IPv6 != nil && networks.IPv6.CIDR != nil {
+		workerCIDRs = append(workerCIDRs, *networks.IPv6.CIDR)
+	}
+	var workerSubnets []netip.Prefix
+	for _, cidr := range workerCIDRs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			workerSubnets = append(workerSubnets, prefix)
+		}
+	}
+	// the CIDR of an IPv6 subnet allocated from a subnet pool is not known in advance
+	ipv6SubnetFromPool := networks.IPv6 != nil && networks.IPv6.CIDR == nil
+
+	seen := sets.New[api.Route]()
+	for i, route := range router.Routes {
+		routePath := fldPath.Child("routes").Index(i)
+
+		destinationPath := routePath.Child("destination")
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRParse(cidrvalidation.NewCIDR(route.Destination, destinationPath))...)
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(destinationPath, route.Destination)...)
+
+		nextHopPath := routePath.Child("nextHop")
+		if nextHop, err := netip.ParseAddr(route.NextHop); err != nil {
+			allErrs = append(allErrs, field.Invalid(nextHopPath, route.NextHop, "must be a valid IP address"))
+		} else if destination, err := netip.ParsePrefix(route.Destination); err == nil && destination.Addr().Is6() != nextHop.Is6() {
+			allErrs = append(allErrs, field.Invalid(nextHopPath, route.NextHop, "must match the IP family of the destination"))
+		} else if !(nextHop.Is6() && ipv6SubnetFromPool) &&
+			!slices.ContainsFunc(workerSubnets, func(subnet netip.Prefix) bool { return subnet.Contains(nextHop) }) {
+			allErrs = append(allErrs, field.Invalid(nextHopPath, route.NextHop, "must be in one of the worker subnets"))
+		}
+
+		if seen.Has(route) {
+			allErrs = append(allErrs, field.Duplicate(routePath, route))
+		}
+		seen.Insert(route)
+	}
+
+	return allErrs
+}
+
+var availableIPv6AddressModes = sets.New(
+	string(api.IPv6AddressModeSLAAC),
+	string(api.IPv6AddressModeDHCPv6Stateless),
+)
+
+func validateIPv6Network(ipv6 *api.IPv6Network, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ipv6.CIDR == nil && ipv6.SubnetPoolID == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("cidr"), "must specify either the CIDR or the subnet pool of the IPv6 subnet"))
+	}
+	if ipv6.CIDR != nil && ipv6.SubnetPoolID != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("subnetPoolID"), "must not be specified together with a CIDR"))
+	}
+
+	if ipv6.CIDR != nil {
+		cidrPath := fldPath.Child("cidr")
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRParse(cidrvalidation.NewCIDR(*ipv6.CIDR, cidrPath))...)
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(cidrPath, *ipv6.CIDR)...)
+		if prefix, err := netip.ParsePrefix(*ipv6.CIDR); err == nil {
+			if !prefix.Addr().Is6() {
+				allErrs = append(allErrs, field.Invalid(cidrPath, *ipv6.CIDR, "must be an IPv6 CIDR"))
+			} else if prefix.Bits() != 64 {
+				allErrs = append(allErrs, field.Invalid(cidrPath, *ipv6.CIDR, "must be a /64 network to allow stateless address autoconfiguration"))
+			}
+		}
+	}
+
+	if ipv6.SubnetPoolID != nil {
+		if _, err := uuid.Parse(*ipv6.SubnetPoolID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnetPoolID"), *ipv6.SubnetPoolID, "if subnet pool ID is provided it must be a valid OpenStack UUID"))
+		}
+	}
+
+	if ipv6.AddressMode != nil && !availableIPv6AddressModes.Has(string(*ipv6.AddressMode)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("addressMode"), *ipv6.AddressMode, sets.List(availableIPv6AddressModes)))
+	}
+
+	return allErrs
+}
+
+var (
+	availableSecurityGroupRuleDirections = sets.New(
+		string(api.SecurityGroupRuleDirectionIngress),
+		string(api.SecurityGroupRuleDirectionEgress),
+	)
+	availableSecurityGroupRuleEtherTypes = sets.New(
+		string(api.SecurityGroupRuleEtherTypeIPv4),
+		string(api.SecurityGroupRuleEtherTypeIPv6),
+	)
+	availableSecurityGroupRuleProtocols = sets.New("tcp", "udp", "icmp", "ipv6-icmp")
+	securityGroupRuleProtocolsWithPorts = sets.New("tcp", "udp")
+)
+
+func validateSecurityGroupRules(rules []api.SecurityGroupRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.New[string]()
+	for i, rule := range rules {
+		rulePath := fldPath.Index(i)
+
+		if !availableSecurityGroupRuleDirections.Has(string(rule.Direction)) {
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("direction"), rule.Direction, sets.List(availableSecurityGroupRuleDirections)))
+		}
+		if rule.EtherType != nil && !availableSecurityGroupRuleEtherTypes.Has(string(*rule.EtherType)) {
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("etherType"), *rule.EtherType, sets.List(availableSecurityGroupRuleEtherTypes)))
+		}
+
+		if rule.Protocol != nil && !availableSecurityGroupRuleProtocols.Has(*rule.Protocol) {
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("protocol"), *rule.Protocol, sets.List(availableSecurityGroupRuleProtocols)))
+		}
+		if rule.PortRangeMin != nil || rule.PortRangeMax != nil {
+			if rule.Protocol == nil || !securityGroupRuleProtocolsWithPorts.Has(*rule.Protocol) {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("portRangeMin"), "port ranges are only supported for the tcp and udp protocols"))
+			} else if rule.PortRangeMin == nil {
+				allErrs = append(allErrs, field.Required(rulePath.Child("portRangeMin"), "must specify the lower bound of the port range"))
+			} else {
+				allErrs = append(allErrs, validatePort(*rule.PortRangeMin, rulePath.Child("portRangeMin"))...)
+				if rule.PortRangeMax != nil {
+					allErrs = append(allErrs, validatePort(*rule.PortRangeMax, rulePath.Child("portRangeMax"))...)
+					if *rule.PortRangeMax < *rule.PortRangeMin {
+						allErrs = append(allErrs, field.Invalid(rulePath.Child("portRangeMax"), *rule.PortRangeMax, "must not be less than the lower bound of the port range"))
+					}
+				}
+			}
+		}
+
+		if rule.RemoteCIDR != nil && rule.RemoteGroupID != nil {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("remoteGroupID"), "must not be specified together with a remote CIDR"))
+		}
+		if rule.RemoteCIDR != nil {
+			cidrPath := rulePath.Child("remoteCIDR")
+			allErrs = append(allErrs, cidrvalidation.ValidateCIDRParse(cidrvalidation.NewCIDR(*rule.RemoteCIDR, cidrPath))...)
+			allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(cidrPath, *rule.RemoteCIDR)...)
+			if prefix, err := netip.ParsePrefix(*rule.RemoteCIDR); err == nil && rule.EtherType != nil &&
+				prefix.Addr().Is6() != (*rule.EtherType == api.SecurityGroupRuleEtherTypeIPv6) {
+				allErrs = append(allErrs, field.Invalid(cidrPath, *rule.RemoteCIDR, "must match the ether type of the rule"))
+			}
+		}
+		if rule.RemoteGroupID != nil {
+			if _, err := uuid.Parse(*rule.RemoteGroupID); err != nil {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("remoteGroupID"), *rule.RemoteGroupID, "if remote group ID is provided it must be a valid OpenStack UUID"))
+			}
+		}
+
+		key := securityGroupRuleKey(rule)
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(rulePath, rule))
+		}
+		seen.Insert(key)
+	}
+
+	return allErrs
+}
+
+func validatePort(port int32, fldPath *field.Path) field.ErrorList {
+	if port < 1 || port > 65535 {
+		return field.ErrorList{field.Invalid(fldPath, port, "must be a valid port number between 1 and 65535")}
+	}
+	return nil
+}
+
+func securityGroupRuleKey(rule api.SecurityGroupRule) string {
+	return fmt.Sprintf("%s/%s/%s/%d/%d/%s/%s", rule.Direction, helper.SecurityGroupRuleEtherType(rule), ptr.Deref(rule.Protocol, ""),
+		ptr.Deref(rule.PortRangeMin, 0), ptr.Deref(rule.PortRangeMax, ptr.Deref(rule.PortRangeMin, 0)),
+		ptr.Deref(rule.RemoteCIDR, ""), ptr.Deref(rule.RemoteGroupID, ""))
+}
+
+// ValidateInfrastructureConfigAgainstNetworking validates the InfrastructureConfig against the IP families of the
+// shoot networking. Dual-stack shoots require an IPv6 subnet.
+func ValidateInfrastructureConfigAgainstNetworking(infra *api.InfrastructureConfig, networking *core.Networking, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if slices.Contains(networking.IPFamilies, core.IPFamilyIPv6) && infra.Networks.IPv6 == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("networks", "ipv6"), "must configure an IPv6 subnet for dual-stack networking"))
+	}
+
+	return allErrs
+}
+
 // ValidateInfrastructureConfigUpdate validates a InfrastructureConfig object.
 func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *api.InfrastructureConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -79,6 +319,19 @@ func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *api.Infrastructure
 	// share network changes are allowed, therefore ignore them on comparing
 	newNetworks.ShareNetwork = nil
 	oldNetworks.ShareNetwork = nil
+	// an IPv6 subnet may be added to move existing networks to dual-stack, but not changed or removed afterwards
+	if oldNetworks.IPv6 == nil {
+		newNetworks.IPv6 = nil
+	}
+	// security group rules may be changed at any time
+	newNetworks.SecurityGroupRules, newNetworks.DisableDefaultNodePortRules = nil, false
+	oldNetworks.SecurityGroupRules, oldNetworks.DisableDefaultNodePortRules = nil, false
+	// the routes of the router may be changed at any time, but not the router itself
+	newNetworks.Router, oldNetworks.Router = routerWithoutRoutes(newNetworks.Router), routerWithoutRoutes(oldNetworks.Router)
+	// zones may be added, but existing zones must not be changed or removed
+	if len(newNetworks.Zones) > len(oldNetworks.Zones) {
+		newNetworks.Zones = newNetworks.Zones[:len(oldNetworks.Zones)]
+	}
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newNetworks, oldNetworks, fldPath.Child("networks"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.FloatingPoolName, oldConfig.FloatingPoolName, fldPath.Child("floatingPoolName"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.FloatingPoolSubnetName, oldConfig.FloatingPoolSubnetName, fldPath.Child("floatingPoolSubnetName"))...)
@@ -86,6 +339,35 @@ func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *api.Infrastructure
 	return allErrs
 }
 
+// routerWithoutRoutes returns the router without its routes, or nil if it does not reference an existing router.
+func routerWithoutRoutes(router *api.Router) *api.Router {
+	if router == nil || len(router.ID) == 0 {
+		return nil
+	}
+	return &api.Router{ID: router.ID}
+}
+
+// ValidateInfrastructureConfigAgainstUseFlow validates the InfrastructureConfig against the use-flow annotation of the
+// shoot. Features which are only supported by the flow-based reconciliation cannot be combined with the opt-out of the
+// flow. Existing shoots might still be reconciled by the Terraformer, so they have to opt in explicitly before using
+// such features for the first time.
+func ValidateInfrastructureConfigAgainstUseFlow(oldInfra, infra *api.InfrastructureConfig, annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !helper.RequiresFlow(infra) {
+		return allErrs
+	}
+
+	useFlow := strings.ToLower(annotations[openstack.UseFlowAnnotation])
+	if useFlow == "false" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("uses features which are only supported by the flow-based reconciliation, which is disabled by the annotation %s", openstack.UseFlowAnnotation)))
+	} else if oldInfra != nil && !helper.RequiresFlow(oldInfra) && useFlow != "true" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("uses features which are only supported by the flow-based reconciliation, the shoot must be annotated with %s=true to migrate its infrastructure to it", openstack.UseFlowAnnotation)))
+	}
+
+	return allErrs
+}
+
 // ValidateInfrastructureConfigAgainstCloudProfile validates the given InfrastructureConfig against constraints in the given CloudProfile.
 func ValidateInfrastructureConfigAgainstCloudProfile(oldInfra, infra *api.InfrastructureConfig, domain, shootRegion string, cloudProfileConfig *api.CloudProfileConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -7,6 +7,7 @@ package validation_test
 import (
 	"strings"
 
+	"github.com/gardener/gardener/pkg/apis/core"
 	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -17,6 +18,7 @@ import (
 
 	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	. "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/validation"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 )
 
 var _ = Describe("InfrastructureConfig validation", func() {
@@ -152,6 +154,432 @@ var _ = Describe("InfrastructureConfig validation", func() {
 
 			Expect(errorList).To(BeEmpty())
 		})
+
+		It("should allow an existing subnet of an existing network", func() {
+			infrastructureConfig.Networks.ID = ptr.To(uuid.NewString())
+			infrastructureConfig.Networks.SubnetID = ptr.To(uuid.NewString())
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid an existing subnet without network id or with an invalid subnet id", func() {
+			infrastructureConfig.Networks.SubnetID = ptr.To("thisiswrong")
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.id"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.subnetID"),
+				})),
+			))
+		})
+	})
+
+	Context("Zones", func() {
+		It("should allow zone subnets within the nodes CIDR", func() {
+			infrastructureConfig.Networks.Workers = "10.250.0.0/19"
+			infrastructureConfig.Networks.Zones = []api.Zone{
+				{Name: "zone1", Workers: "10.250.32.0/19"},
+				{Name: "zone2", Workers: "10.250.64.0/19"},
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid zone subnets without name and CIDR", func() {
+			infrastructureConfig.Networks.Zones = []api.Zone{{}}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.zones[0].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks.zones[0].workers"),
+				})),
+			))
+		})
+
+		It("should forbid duplicate zones", func() {
+			infrastructureConfig.Networks.Workers = "10.250.0.0/19"
+			infrastructureConfig.Networks.Zones = []api.Zone{
+				{Name: "zone1", Workers: "10.250.32.0/19"},
+				{Name: "zone1", Workers: "10.250.64.0/19"},
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("networks.zones[1].name"),
+			}))
+		})
+
+		It("should forbid zone subnets outside of the nodes CIDR", func() {
+			infrastructureConfig.Networks.Workers = "10.250.0.0/19"
+			infrastructureConfig.Networks.Zones = []api.Zone{{Name: "zone1", Workers: "10.251.0.0/19"}}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("networks.zones[0].workers"),
+				"Detail": Equal(`must be a subset of "networking.nodes" ("10.250.0.0/16")`),
+			}))
+		})
+
+		It("should forbid overlapping zone subnets", func() {
+			infrastructureConfig.Networks.Workers = "10.250.0.0/19"
+			infrastructureConfig.Networks.Zones = []api.Zone{
+				{Name: "zone1", Workers: "10.250.16.0/20"},
+				{Name: "zone2", Workers: "10.250.32.0/19"},
+				{Name: "zone3", Workers: "10.250.32.0/20"},
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.zones[0].workers"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.zones[2].workers"),
+				})),
+			))
+		})
+	})
+
+	Context("SecurityGroupRules", func() {
+		It("should allow valid rules", func() {
+			infrastructureConfig.Networks.SecurityGroupRules = []api.SecurityGroupRule{
+				{
+					Direction:    api.SecurityGroupRuleDirectionIngress,
+					Protocol:     ptr.To("tcp"),
+					PortRangeMin: ptr.To[int32](30000),
+					PortRangeMax: ptr.To[int32](32767),
+					RemoteCIDR:   ptr.To("192.168.0.0/16"),
+				},
+				{
+					Direction:    api.SecurityGroupRuleDirectionIngress,
+					Protocol:     ptr.To("tcp"),
+					PortRangeMin: ptr.To[int32](9100),
+					RemoteCIDR:   ptr.To("2001:db8::/32"),
+				},
+				{
+					Direction:     api.SecurityGroupRuleDirectionIngress,
+					EtherType:     ptr.To(api.SecurityGroupRuleEtherTypeIPv4),
+					RemoteGroupID: ptr.To(uuid.NewString()),
+				},
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid malformed rules", func() {
+			infrastructureConfig.Networks.SecurityGroupRules = []api.SecurityGroupRule{
+				{
+					Direction: "sideways",
+					EtherType: ptr.To[api.SecurityGroupRuleEtherType]("IPv5"),
+					Protocol:  ptr.To("sctp"),
+				},
+				{
+					Direction:     api.SecurityGroupRuleDirectionEgress,
+					PortRangeMin:  ptr.To[int32](80),
+					RemoteCIDR:    ptr.To("10.0.0.1/8"),
+					RemoteGroupID: ptr.To("foo"),
+				},
+				{
+					Direction:    api.SecurityGroupRuleDirectionIngress,
+					EtherType:    ptr.To(api.SecurityGroupRuleEtherTypeIPv6),
+					Protocol:     ptr.To("udp"),
+					PortRangeMin: ptr.To[int32](1000),
+					PortRangeMax: ptr.To[int32](70000),
+					RemoteCIDR:   ptr.To("10.0.0.0/8"),
+				},
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.securityGroupRules[0].direction"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.securityGroupRules[0].etherType"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.securityGroupRules[0].protocol"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.securityGroupRules[1].portRangeMin"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("networks.securityGroupRules[1].remoteGroupID"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.securityGroupRules[1].remoteCIDR"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.securityGroupRules[1].remoteGroupID"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.securityGroupRules[2].portRangeMax"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.securityGroupRules[2].remoteCIDR"),
+				})),
+			))
+		})
+
+		It("should forbid duplicate rules", func() {
+			rule := api.SecurityGroupRule{
+				Direction:  api.SecurityGroupRuleDirectionIngress,
+				RemoteCIDR: ptr.To("10.0.0.0/8"),
+			}
+			ruleWithEtherType := *rule.DeepCopy()
+			ruleWithEtherType.EtherType = ptr.To(api.SecurityGroupRuleEtherTypeIPv4)
+			infrastructureConfig.Networks.SecurityGroupRules = []api.SecurityGroupRule{rule, ruleWithEtherType}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("networks.securityGroupRules[1]"),
+			}))
+		})
+	})
+
+	Context("Routes", func() {
+		It("should allow routes of a created router via the worker subnets", func() {
+			infrastructureConfig.Networks.Zones = []api.Zone{{Name: "zone1", Workers: "10.251.0.0/19"}}
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{CIDR: ptr.To("2001:db8::/64")}
+			infrastructureConfig.Networks.Router = &api.Router{Routes: []api.Route{
+				{Destination: "192.168.0.0/16", NextHop: "10.250.0.10"},
+				{Destination: "172.16.0.0/12", NextHop: "10.251.0.10"},
+				{Destination: "2001:db8:1::/48", NextHop: "2001:db8::10"},
+			}}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, ptr.To("10.250.0.0/15"), nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should allow IPv6 next hops in an IPv6 subnet allocated from a subnet pool", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{SubnetPoolID: ptr.To(uuid.NewString())}
+			infrastructureConfig.Networks.Router.Routes = []api.Route{{Destination: "2001:db8:1::/48", NextHop: "2001:db8::10"}}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid malformed routes", func() {
+			infrastructureConfig.Networks.Router.Routes = []api.Route{
+				{Destination: "192.168.0.1/16", NextHop: "10.250.0.10"},
+				{Destination: invalidCIDR, NextHop: "gateway"},
+				{Destination: "2001:db8:1::/48", NextHop: "10.250.0.10"},
+				{Destination: "192.168.0.0/16", NextHop: "10.0.0.1"},
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.router.routes[0].destination"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.router.routes[1].destination"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks.router.routes[1].nextHop"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.router.routes[2].nextHop"),
+					"Detail": Equal("must match the IP family of the destination"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("networks.router.routes[3].nextHop"),
+					"Detail": Equal("must be in one of the worker subnets"),
+				})),
+			))
+		})
+
+		It("should forbid duplicate routes", func() {
+			route := api.Route{Destination: "192.168.0.0/16", NextHop: "10.250.0.10"}
+			infrastructureConfig.Networks.Router = &api.Router{Routes: []api.Route{route, route}}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeDuplicate),
+				"Field": Equal("networks.router.routes[1]"),
+			}))
+		})
+	})
+
+	Context("IPv6", func() {
+		It("should allow an IPv6 subnet with a /64 CIDR", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				CIDR:        ptr.To("2001:db8::/64"),
+				AddressMode: ptr.To(api.IPv6AddressModeDHCPv6Stateless),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should allow an IPv6 subnet allocated from a subnet pool", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				SubnetPoolID: ptr.To(uuid.NewString()),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid an IPv6 subnet without CIDR and subnet pool", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("networks.ipv6.cidr"),
+			}))
+		})
+
+		It("should forbid an IPv6 subnet with CIDR and subnet pool", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				CIDR:         ptr.To("2001:db8::/64"),
+				SubnetPoolID: ptr.To(uuid.NewString()),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("networks.ipv6.subnetPoolID"),
+			}))
+		})
+
+		It("should forbid an IPv6 CIDR which is not a /64 network", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				CIDR: ptr.To("2001:db8::/56"),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("networks.ipv6.cidr"),
+				"Detail": Equal("must be a /64 network to allow stateless address autoconfiguration"),
+			}))
+		})
+
+		It("should forbid an IPv4 CIDR for the IPv6 subnet", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				CIDR: ptr.To("10.251.0.0/16"),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("networks.ipv6.cidr"),
+				"Detail": Equal("must be an IPv6 CIDR"),
+			}))
+		})
+
+		It("should forbid an invalid subnet pool id", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				SubnetPoolID: ptr.To("thisiswrong"),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("networks.ipv6.subnetPoolID"),
+			}))
+		})
+
+		It("should forbid an unsupported address mode", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{
+				CIDR:        ptr.To("2001:db8::/64"),
+				AddressMode: ptr.To(api.IPv6AddressMode("dhcpv6-stateful")),
+			}
+
+			errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("networks.ipv6.addressMode"),
+			}))
+		})
+	})
+
+	Describe("#ValidateInfrastructureConfigAgainstNetworking", func() {
+		var networking *core.Networking
+
+		BeforeEach(func() {
+			networking = &core.Networking{
+				Nodes:      &nodes,
+				IPFamilies: []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6},
+			}
+		})
+
+		It("should allow dual-stack networking with an IPv6 subnet", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{CIDR: ptr.To("2001:db8::/64")}
+
+			Expect(ValidateInfrastructureConfigAgainstNetworking(infrastructureConfig, networking, nilPath)).To(BeEmpty())
+		})
+
+		It("should allow IPv4 networking without an IPv6 subnet", func() {
+			networking.IPFamilies = []core.IPFamily{core.IPFamilyIPv4}
+
+			Expect(ValidateInfrastructureConfigAgainstNetworking(infrastructureConfig, networking, nilPath)).To(BeEmpty())
+		})
+
+		It("should forbid dual-stack networking without an IPv6 subnet", func() {
+			errorList := ValidateInfrastructureConfigAgainstNetworking(infrastructureConfig, networking, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":  Equal(field.ErrorTypeRequired),
+				"Field": Equal("networks.ipv6"),
+			}))
+		})
 	})
 
 	Describe("#ValidateInfrastructureConfigUpdate", func() {
@@ -180,6 +608,80 @@ var _ = Describe("InfrastructureConfig validation", func() {
 			Expect(errorList).To(BeEmpty())
 		})
 
+		It("should allow adding an IPv6 subnet", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.IPv6 = &api.IPv6Network{CIDR: ptr.To("2001:db8::/64")}
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid changing the IPv6 subnet", func() {
+			infrastructureConfig.Networks.IPv6 = &api.IPv6Network{CIDR: ptr.To("2001:db8::/64")}
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.IPv6.CIDR = ptr.To("2001:db8:1::/64")
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("networks"),
+			}))))
+		})
+
+		It("should allow adding zones", func() {
+			infrastructureConfig.Networks.Zones = []api.Zone{{Name: "zone1", Workers: "10.251.0.0/19"}}
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Zones = append(newInfrastructureConfig.Networks.Zones, api.Zone{Name: "zone2", Workers: "10.251.32.0/19"})
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should forbid changing zones", func() {
+			infrastructureConfig.Networks.Zones = []api.Zone{{Name: "zone1", Workers: "10.251.0.0/19"}}
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Zones[0].Workers = "10.251.32.0/19"
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("networks"),
+			}))))
+		})
+
+		It("should allow changing the security group rules", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.SecurityGroupRules = []api.SecurityGroupRule{{Direction: api.SecurityGroupRuleDirectionIngress}}
+			newInfrastructureConfig.Networks.DisableDefaultNodePortRules = true
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should allow changing the routes", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Router.Routes = []api.Route{{Destination: "192.168.0.0/16", NextHop: "10.250.0.10"}}
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should allow adding routes of a created router", func() {
+			infrastructureConfig.Networks.Router = nil
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Router = &api.Router{Routes: []api.Route{{Destination: "192.168.0.0/16", NextHop: "10.250.0.10"}}}
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, nilPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
 		It("should forbid changing the floating pool", func() {
 			newInfrastructureConfig := infrastructureConfig.DeepCopy()
 			newInfrastructureConfig.FloatingPoolName = "test"
@@ -205,6 +707,47 @@ var _ = Describe("InfrastructureConfig validation", func() {
 		})
 	})
 
+	Describe("#ValidateInfrastructureConfigAgainstUseFlow", func() {
+		var newInfrastructureConfig *api.InfrastructureConfig
+
+		BeforeEach(func() {
+			newInfrastructureConfig = infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.Networks.Router.Routes = []api.Route{{Destination: "10.180.0.0/16", NextHop: "10.250.0.10"}}
+		})
+
+		It("should allow configurations not requiring the flow", func() {
+			Expect(ValidateInfrastructureConfigAgainstUseFlow(nil, infrastructureConfig, map[string]string{openstack.UseFlowAnnotation: "false"}, nilPath)).To(BeEmpty())
+			Expect(ValidateInfrastructureConfigAgainstUseFlow(infrastructureConfig, infrastructureConfig, nil, nilPath)).To(BeEmpty())
+		})
+
+		It("should allow new shoots to use features requiring the flow", func() {
+			Expect(ValidateInfrastructureConfigAgainstUseFlow(nil, newInfrastructureConfig, nil, nilPath)).To(BeEmpty())
+		})
+
+		It("should forbid features requiring the flow together with the opt-out", func() {
+			errorList := ValidateInfrastructureConfigAgainstUseFlow(nil, newInfrastructureConfig, map[string]string{openstack.UseFlowAnnotation: "False"}, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":   Equal(field.ErrorTypeForbidden),
+				"Detail": ContainSubstring("disabled by the annotation"),
+			}))
+		})
+
+		It("should require existing shoots to opt in before using features requiring the flow", func() {
+			errorList := ValidateInfrastructureConfigAgainstUseFlow(infrastructureConfig, newInfrastructureConfig, nil, nilPath)
+
+			Expect(errorList).To(ConsistOfFields(Fields{
+				"Type":   Equal(field.ErrorTypeForbidden),
+				"Detail": ContainSubstring("must be annotated"),
+			}))
+			Expect(ValidateInfrastructureConfigAgainstUseFlow(infrastructureConfig, newInfrastructureConfig, map[string]string{openstack.UseFlowAnnotation: "true"}, nilPath)).To(BeEmpty())
+		})
+
+		It("should allow shoots already using features requiring the flow to keep them", func() {
+			Expect(ValidateInfrastructureConfigAgainstUseFlow(newInfrastructureConfig, newInfrastructureConfig, nil, nilPath)).To(BeEmpty())
+		})
+	})
+
 	Describe("#ValidateInfrastructureConfigAgainstCloudProfile", func() {
 		var (
 			region             = "europe"
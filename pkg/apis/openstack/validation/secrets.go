@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 )
@@ -18,6 +19,8 @@ const (
 	tenantNameMaxLen = 64
 )
 
+var availableInterfaces = sets.New("public", "internal", "admin")
+
 // ValidateCloudProviderSecret checks whether the given secret contains a valid OpenStack credentials.
 func ValidateCloudProviderSecret(secret *corev1.Secret) error {
 	credentials, err := openstack.ExtractCredentials(secret, false)
@@ -27,14 +30,23 @@ func ValidateCloudProviderSecret(secret *corev1.Secret) error {
 
 	secretKey := fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
 
-	// domainName, tenantName, and userName must not contain leading or trailing whitespace
+	// domainName, tenantName, userName, tokens and the other identifiers must not contain leading or trailing whitespace
 	for key, value := range map[string]string{
 		openstack.DomainName:                  credentials.DomainName,
 		openstack.TenantName:                  credentials.TenantName,
+		openstack.ProjectID:                   credentials.ProjectID,
+		openstack.UserDomainID:                credentials.UserDomainID,
+		openstack.ProjectDomainID:             credentials.ProjectDomainID,
 		openstack.UserName:                    credentials.Username,
 		openstack.ApplicationCredentialID:     credentials.ApplicationCredentialID,
 		openstack.ApplicationCredentialName:   credentials.ApplicationCredentialName,
 		openstack.ApplicationCredentialSecret: credentials.ApplicationCredentialSecret,
+		openstack.Token:                       credentials.Token,
+		openstack.TrustID:                     credentials.TrustID,
+		openstack.IdentityProvider:            credentials.IdentityProvider,
+		openstack.Protocol:                    credentials.Protocol,
+		openstack.AccessToken:                 credentials.AccessToken,
+		openstack.Interface:                   credentials.Interface,
 	} {
 		if strings.TrimSpace(value) != value {
 			return fmt.Errorf("field %q in secret %s must not contain leading or traling whitespace", key, secretKey)
@@ -60,5 +72,17 @@ func ValidateCloudProviderSecret(secret *corev1.Secret) error {
 		}
 	}
 
+	// interface must be one of the interfaces of the endpoints in the service catalog if present
+	if credentials.Interface != "" && !availableInterfaces.Has(credentials.Interface) {
+		return fmt.Errorf("field %q in secret %s must be one of %v when present", openstack.Interface, secretKey, sets.List(availableInterfaces))
+	}
+
+	// endpoint overrides must be absolute URLs
+	for service, endpoint := range credentials.EndpointOverrides {
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("field %q in secret %s must be an absolute URL", openstack.EndpointOverridePrefix+service, secretKey)
+		}
+	}
+
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/validation"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
@@ -289,5 +290,272 @@ var _ = Describe("Secret validation", func() {
 			},
 			BeNil(),
 		),
+
+		Entry("should succeed when a token is given",
+			map[string][]byte{
+				openstack.DomainName: []byte("domain"),
+				openstack.TenantName: []byte("tenant"),
+				openstack.Token:      []byte("token"),
+			},
+			BeNil(),
+		),
+
+		Entry("should return error when the token contains a trailing new line",
+			map[string][]byte{
+				openstack.DomainName: []byte("domain"),
+				openstack.TenantName: []byte("tenant"),
+				openstack.Token:      []byte("token\n"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should return error when both password and token are given",
+			map[string][]byte{
+				openstack.DomainName: []byte("domain"),
+				openstack.TenantName: []byte("tenant"),
+				openstack.UserName:   []byte("user"),
+				openstack.Password:   []byte("password"),
+				openstack.Token:      []byte("token"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should succeed when a trust is used with username and password",
+			map[string][]byte{
+				openstack.DomainName: []byte("domain"),
+				openstack.TenantName: []byte("tenant"),
+				openstack.UserName:   []byte("user"),
+				openstack.Password:   []byte("password"),
+				openstack.TrustID:    []byte("trust-id"),
+			},
+			BeNil(),
+		),
+
+		Entry("should return error when a trust is used with application credentials",
+			map[string][]byte{
+				openstack.DomainName:                  []byte("domain"),
+				openstack.TenantName:                  []byte("tenant"),
+				openstack.ApplicationCredentialID:     []byte("app-id"),
+				openstack.ApplicationCredentialSecret: []byte("app-secret"),
+				openstack.TrustID:                     []byte("trust-id"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should succeed when an OIDC access token is given with an identity provider",
+			map[string][]byte{
+				openstack.DomainName:       []byte("domain"),
+				openstack.TenantName:       []byte("tenant"),
+				openstack.IdentityProvider: []byte("idp"),
+				openstack.AccessToken:      []byte("access-token"),
+			},
+			BeNil(),
+		),
+
+		Entry("should return error when an OIDC access token is given without identity provider",
+			map[string][]byte{
+				openstack.DomainName:  []byte("domain"),
+				openstack.TenantName:  []byte("tenant"),
+				openstack.AccessToken: []byte("access-token"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should succeed when the project and the domains are given by id",
+			map[string][]byte{
+				openstack.ProjectID:    []byte("project-id"),
+				openstack.UserDomainID: []byte("domain-id"),
+				openstack.UserName:     []byte("user"),
+				openstack.Password:     []byte("password"),
+			},
+			BeNil(),
+		),
+
+		Entry("should return error when the project is given by id but the domain of the user is missing",
+			map[string][]byte{
+				openstack.ProjectID: []byte("project-id"),
+				openstack.UserName:  []byte("user"),
+				openstack.Password:  []byte("password"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should return error when the project id contains a trailing space",
+			map[string][]byte{
+				openstack.DomainName: []byte("domain"),
+				openstack.ProjectID:  []byte("project-id "),
+				openstack.UserName:   []byte("user"),
+				openstack.Password:   []byte("password"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should succeed when the interface and endpoint overrides are valid",
+			map[string][]byte{
+				openstack.DomainName:                         []byte("domain"),
+				openstack.TenantName:                         []byte("tenant"),
+				openstack.UserName:                           []byte("user"),
+				openstack.Password:                           []byte("password"),
+				openstack.Interface:                          []byte("internal"),
+				openstack.EndpointOverridePrefix + "network": []byte("https://neutron.example.com:9696"),
+			},
+			BeNil(),
+		),
+
+		Entry("should return error when the interface is unknown",
+			map[string][]byte{
+				openstack.DomainName: []byte("domain"),
+				openstack.TenantName: []byte("tenant"),
+				openstack.UserName:   []byte("user"),
+				openstack.Password:   []byte("password"),
+				openstack.Interface:  []byte("private"),
+			},
+			HaveOccurred(),
+		),
+
+		Entry("should return error when an endpoint override is not an absolute URL",
+			map[string][]byte{
+				openstack.DomainName:                         []byte("domain"),
+				openstack.TenantName:                         []byte("tenant"),
+				openstack.UserName:                           []byte("user"),
+				openstack.Password:                           []byte("password"),
+				openstack.EndpointOverridePrefix + "network": []byte("neutron:9696"),
+			},
+			HaveOccurred(),
+		),
 	)
+
+	Describe("#ValidateCloudProviderSecret for clouds.yaml", func() {
+		const cloudsYAML = `clouds:
+  openstack:
+    auth:
+      auth_url: https://keystone.example.com/v3
+      project_id: project-id
+      project_domain_id: project-domain-id
+      user_domain_name: user-domain
+      username: user
+      password: password
+    region_name: region
+    interface: internal
+    verify: false
+    cacert: /etc/openstack/ca.crt
+  other:
+    auth:
+      auth_url: https://keystone.example.com/v3
+      project_name: tenant
+      domain_name: domain
+      application_credential_id: app-id
+      application_credential_secret: app-secret
+`
+
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				Data: map[string][]byte{
+					openstack.CloudsYAML: []byte(cloudsYAML),
+					openstack.CloudName:  []byte("openstack"),
+					"ca.crt":             []byte("ca-cert"),
+				},
+			}
+		})
+
+		It("should parse the credentials of the cloud", func() {
+			Expect(ValidateCloudProviderSecret(secret)).To(Succeed())
+
+			credentials, err := openstack.ExtractCredentials(secret, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials).To(Equal(&openstack.Credentials{
+				AuthURL:         "https://keystone.example.com/v3",
+				DomainName:      "user-domain",
+				ProjectID:       "project-id",
+				ProjectDomainID: "project-domain-id",
+				Username:        "user",
+				Password:        "password",
+				Region:          "region",
+				Interface:       "internal",
+				Insecure:        true,
+				CACert:          "ca-cert",
+			}))
+		})
+
+		It("should parse the credentials of another cloud", func() {
+			secret.Data[openstack.CloudName] = []byte("other")
+			Expect(ValidateCloudProviderSecret(secret)).To(Succeed())
+
+			credentials, err := openstack.ExtractCredentials(secret, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials.TenantName).To(Equal("tenant"))
+			Expect(credentials.DomainName).To(Equal("domain"))
+			Expect(credentials.ApplicationCredentialID).To(Equal("app-id"))
+		})
+
+		It("should return error when the cloud is not contained", func() {
+			secret.Data[openstack.CloudName] = []byte("missing")
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+
+		It("should return error when the cloud name is missing for multiple clouds", func() {
+			delete(secret.Data, openstack.CloudName)
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+
+		It("should return error when the referenced CA certificate is missing", func() {
+			delete(secret.Data, "ca.crt")
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+
+		It("should return error when the cloud does not contain credentials", func() {
+			secret.Data[openstack.CloudsYAML] = []byte(`clouds:
+  openstack:
+    auth:
+      auth_url: https://keystone.example.com/v3
+      project_id: project-id
+`)
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+
+		It("should return error when the clouds.yaml is invalid", func() {
+			secret.Data[openstack.CloudsYAML] = []byte("clouds: [")
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#ValidateCloudProviderSecret for workload identities", func() {
+		var secret *corev1.Secret
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{openstack.WorkloadIdentityPurposeLabel: openstack.WorkloadIdentityPurposeTokenRequestor},
+				},
+				Data: map[string][]byte{
+					openstack.DomainName:       []byte("domain"),
+					openstack.TenantName:       []byte("tenant"),
+					openstack.IdentityProvider: []byte("gardener"),
+					openstack.Token:            []byte("service-account-token"),
+				},
+			}
+		})
+
+		It("should exchange the token of the workload identity", func() {
+			Expect(ValidateCloudProviderSecret(secret)).To(Succeed())
+
+			credentials, err := openstack.ExtractCredentials(secret, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credentials.Token).To(BeEmpty())
+			Expect(credentials.AccessToken).To(Equal("service-account-token"))
+			Expect(credentials.Protocol).To(Equal(openstack.DefaultProtocol))
+		})
+
+		It("should return error when the identity provider is missing", func() {
+			delete(secret.Data, openstack.IdentityProvider)
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+
+		It("should return error when an access token is given in addition", func() {
+			secret.Data[openstack.AccessToken] = []byte("access-token")
+			Expect(ValidateCloudProviderSecret(secret)).NotTo(Succeed())
+		})
+	})
 })
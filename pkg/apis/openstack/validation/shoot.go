@@ -29,6 +29,10 @@ func ValidateNetworking(networking *core.Networking, fldPath *field.Path) field.
 		allErrs = append(allErrs, field.Required(fldPath.Child("nodes"), "a nodes CIDR must be provided for Openstack shoots"))
 	}
 
+	if core.IsIPv6SingleStack(networking.IPFamilies) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ipFamilies"), networking.IPFamilies, "IPv6 single-stack networking is not supported for Openstack shoots, use dual-stack networking instead"))
+	}
+
 	return allErrs
 }
 
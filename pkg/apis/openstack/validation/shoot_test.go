@@ -51,6 +51,33 @@ var _ = Describe("Shoot validation", func() {
 				})),
 			))
 		})
+
+		It("should return no error for dual-stack networking", func() {
+			networking := &core.Networking{
+				Nodes:      ptr.To("1.2.3.4/5"),
+				IPFamilies: []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6},
+			}
+
+			errorList := ValidateNetworking(networking, networkingPath)
+
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should return an error for IPv6 single-stack networking", func() {
+			networking := &core.Networking{
+				Nodes:      ptr.To("1.2.3.4/5"),
+				IPFamilies: []core.IPFamily{core.IPFamilyIPv6},
+			}
+
+			errorList := ValidateNetworking(networking, networkingPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("spec.networking.ipFamilies"),
+				})),
+			))
+		})
 	})
 	Describe("#validateWorkerConfig", func() {
 		var (
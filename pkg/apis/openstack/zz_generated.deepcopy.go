@@ -292,6 +292,37 @@ func (in *FloatingPoolStatus) DeepCopy() *FloatingPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPv6Network) DeepCopyInto(out *IPv6Network) {
+	*out = *in
+	if in.CIDR != nil {
+		in, out := &in.CIDR, &out.CIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetPoolID != nil {
+		in, out := &in.SubnetPoolID, &out.SubnetPoolID
+		*out = new(string)
+		**out = **in
+	}
+	if in.AddressMode != nil {
+		in, out := &in.AddressMode, &out.AddressMode
+		*out = new(IPv6AddressMode)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPv6Network.
+func (in *IPv6Network) DeepCopy() *IPv6Network {
+	if in == nil {
+		return nil
+	}
+	out := new(IPv6Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InfrastructureConfig) DeepCopyInto(out *InfrastructureConfig) {
 	*out = *in
@@ -534,7 +565,9 @@ func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
 		*out = make([]Subnet, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ShareNetwork != nil {
 		in, out := &in.ShareNetwork, &out.ShareNetwork
@@ -560,18 +593,40 @@ func (in *Networks) DeepCopyInto(out *Networks) {
 	if in.Router != nil {
 		in, out := &in.Router, &out.Router
 		*out = new(Router)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ID != nil {
 		in, out := &in.ID, &out.ID
 		*out = new(string)
 		**out = **in
 	}
+	if in.SubnetID != nil {
+		in, out := &in.SubnetID, &out.SubnetID
+		*out = new(string)
+		**out = **in
+	}
 	if in.ShareNetwork != nil {
 		in, out := &in.ShareNetwork, &out.ShareNetwork
 		*out = new(ShareNetwork)
 		**out = **in
 	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(IPv6Network)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]Zone, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroupRules != nil {
+		in, out := &in.SecurityGroupRules, &out.SecurityGroupRules
+		*out = make([]SecurityGroupRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -622,9 +677,30 @@ func (in *RegionIDMapping) DeepCopy() *RegionIDMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Router) DeepCopyInto(out *Router) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]Route, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -670,6 +746,52 @@ func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+	*out = *in
+	if in.EtherType != nil {
+		in, out := &in.EtherType, &out.EtherType
+		*out = new(SecurityGroupRuleEtherType)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.PortRangeMin != nil {
+		in, out := &in.PortRangeMin, &out.PortRangeMin
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PortRangeMax != nil {
+		in, out := &in.PortRangeMax, &out.PortRangeMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RemoteCIDR != nil {
+		in, out := &in.RemoteCIDR, &out.RemoteCIDR
+		*out = new(string)
+		**out = **in
+	}
+	if in.RemoteGroupID != nil {
+		in, out := &in.RemoteGroupID, &out.RemoteGroupID
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerGroup) DeepCopyInto(out *ServerGroup) {
 	*out = *in
@@ -815,6 +937,11 @@ func (in *StorageClassDefinition) DeepCopy() *StorageClassDefinition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subnet) DeepCopyInto(out *Subnet) {
 	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -904,3 +1031,19 @@ func (in *WorkerStatus) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Zone) DeepCopyInto(out *Zone) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Zone.
+func (in *Zone) DeepCopy() *Zone {
+	if in == nil {
+		return nil
+	}
+	out := new(Zone)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
 	configloader "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config/loader"
+	configvalidation "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config/validation"
 )
 
 // ConfigOptions are command line options that can be set for config.ControllerConfiguration.
@@ -41,6 +42,9 @@ func (c *ConfigOptions) Complete() error {
 	if err != nil {
 		return err
 	}
+	if err := configvalidation.ValidateControllerConfiguration(config).ToAggregate(); err != nil {
+		return fmt.Errorf("invalid controller configuration: %w", err)
+	}
 
 	c.config = &Config{config}
 	return nil
@@ -91,3 +95,24 @@ func (c *Config) ApplyBastionConfig(config *config.BastionConfig) {
 		*config = *c.Config.BastionConfig
 	}
 }
+
+// ApplyApplicationCredentialRotationConfig applies the ApplicationCredentialRotationConfig to the config
+func (c *Config) ApplyApplicationCredentialRotationConfig(config *config.ApplicationCredentialRotationConfig) {
+	if c.Config.ApplicationCredentialRotation != nil {
+		*config = *c.Config.ApplicationCredentialRotation
+	}
+}
+
+// ApplyOrphanedResourcesConfig applies the OrphanedResourcesConfig to the config
+func (c *Config) ApplyOrphanedResourcesConfig(config *config.OrphanedResourcesConfig) {
+	if c.Config.OrphanedResources != nil {
+		*config = *c.Config.OrphanedResources
+	}
+}
+
+// ApplyFlowMigrationConfig applies the FlowMigrationConfig to the config
+func (c *Config) ApplyFlowMigrationConfig(config *config.FlowMigrationConfig) {
+	if c.Config.FlowMigration != nil {
+		*config = *c.Config.FlowMigration
+	}
+}
@@ -19,6 +19,7 @@ import (
 	webhookcmd "github.com/gardener/gardener/extensions/pkg/webhook/cmd"
 	extensioncontrolplanewebhook "github.com/gardener/gardener/extensions/pkg/webhook/controlplane"
 
+	applicationcredentialcontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/applicationcredential"
 	backupbucketcontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/backupbucket"
 	backupentrycontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/backupentry"
 	bastioncontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/bastion"
@@ -26,6 +27,7 @@ import (
 	dnsrecordcontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/dnsrecord"
 	healthcheckcontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/healthcheck"
 	infrastructurecontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure"
+	orphanedresourcescontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/orphanedresources"
 	workercontroller "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/worker"
 	cloudproviderwebhook "github.com/gardener/gardener-extension-provider-openstack/pkg/webhook/cloudprovider"
 	controlplanewebhook "github.com/gardener/gardener-extension-provider-openstack/pkg/webhook/controlplane"
@@ -44,6 +46,8 @@ func ControllerSwitchOptions() *controllercmd.SwitchOptions {
 		controllercmd.Switch(extensionsworkercontroller.ControllerName, workercontroller.AddToManager),
 		controllercmd.Switch(extensionshealthcheckcontroller.ControllerName, healthcheckcontroller.AddToManager),
 		controllercmd.Switch(extensionsheartbeatcontroller.ControllerName, extensionsheartbeatcontroller.AddToManager),
+		controllercmd.Switch(applicationcredentialcontroller.ControllerName, applicationcredentialcontroller.AddToManager),
+		controllercmd.Switch(orphanedresourcescontroller.ControllerName, orphanedresourcescontroller.AddToManager),
 	)
 }
 
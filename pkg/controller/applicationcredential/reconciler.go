@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationcredential
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+const (
+	// DefaultRenewBefore is the default duration before the expiration of an application credential at which it is
+	// rotated.
+	DefaultRenewBefore = 7 * 24 * time.Hour
+
+	// syncPeriod is the interval in which the age of the application credentials is checked.
+	syncPeriod = time.Hour
+	// rolloutCheckInterval is the interval in which the rollout of a new application credential is checked.
+	rolloutCheckInterval = 30 * time.Second
+
+	// EventReasonCreated is the reason of the event emitted when a new application credential was created.
+	EventReasonCreated = "ApplicationCredentialCreated"
+	// EventReasonRotated is the reason of the event emitted when the rotation of an application credential completed.
+	EventReasonRotated = "ApplicationCredentialRotated"
+	// EventReasonRotationFailed is the reason of the events emitted when an application credential cannot be rotated.
+	EventReasonRotationFailed = "ApplicationCredentialRotationFailed"
+)
+
+// RotationStatus is the progress of the rotation of the application credential of a cloudprovider secret. It is
+// recorded as JSON in the openstack.ApplicationCredentialRotationAnnotation of the secret.
+type RotationStatus struct {
+	// CredentialID is the ID of the current application credential.
+	CredentialID string `json:"credentialID"`
+	// Since is the time at which the current application credential was created or first seen by the controller.
+	Since metav1.Time `json:"since"`
+	// PreviousCredentialID is the ID of the replaced application credential. It is set while the components of the
+	// shoot roll out the current application credential and deleted afterwards.
+	PreviousCredentialID string `json:"previousCredentialID,omitempty"`
+}
+
+// Reconciler rotates the application credentials of the cloudprovider secrets in the shoot namespaces.
+type Reconciler struct {
+	// Client is the client of the seed cluster.
+	Client client.Client
+	// GardenClient is the client of the garden cluster which is used to update the secrets of the secret bindings.
+	GardenClient client.Client
+	// Recorder records the progress of the rotation as events of the cloudprovider secrets.
+	Recorder record.EventRecorder
+	// ClientFactory creates the OpenStack clients.
+	ClientFactory openstackclient.FactoryFactory
+	// Config is the configuration of the rotation.
+	Config controllerconfig.ApplicationCredentialRotationConfig
+	// Clock is the clock.
+	Clock clock.Clock
+}
+
+// Reconcile rotates the application credential of a cloudprovider secret if it is due. A new application credential
+// is created and written to the secret of the secret binding in the garden and to the cloudprovider secret. The old
+// application credential is deleted once the control plane components and the machines were reconciled with the
+// new one.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, request.NamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error retrieving object from store: %w", err)
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, r.Client, secret.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("could not get cluster: %w", err)
+	}
+	if cluster.Shoot == nil || cluster.Shoot.Spec.Provider.Type != openstack.Type || cluster.Shoot.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if _, ok := secret.Data[openstack.CloudsYAML]; ok {
+		log.V(1).Info("Skipping secret in clouds.yaml format")
+		return reconcile.Result{}, nil
+	}
+	credentials, err := openstack.ExtractCredentials(secret, false)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not extract credentials from secret: %w", err)
+	}
+	if credentials.ApplicationCredentialID == "" || credentials.ApplicationCredentialSecret == "" {
+		log.V(1).Info("Skipping secret without application credential ID and secret")
+		return reconcile.Result{}, nil
+	}
+
+	status := rotationStatusOf(secret)
+	if status.CredentialID != credentials.ApplicationCredentialID {
+		// the application credential is seen for the first time or was replaced by someone else
+		status = RotationStatus{
+			CredentialID: credentials.ApplicationCredentialID,
+			Since:        metav1.NewTime(r.Clock.Now().UTC()),
+		}
+		if err := r.patchSecret(ctx, secret, nil, status); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	factory, err := r.ClientFactory.NewFactory(credentials)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not create OpenStack client factory: %w", err)
+	}
+	identity, err := factory.Identity()
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not create identity client: %w", err)
+	}
+
+	if status.PreviousCredentialID != "" {
+		return r.completeRotation(ctx, secret, identity, status)
+	}
+	return r.rotateIfDue(ctx, secret, cluster, identity, credentials, status)
+}
+
+// rotateIfDue creates a new application credential if the current one reached the maximum age or is about to expire.
+func (r *Reconciler) rotateIfDue(
+	ctx context.Context,
+	secret *corev1.Secret,
+	cluster *extensionscontroller.Cluster,
+	identity openstackclient.Identity,
+	credentials *openstack.Credentials,
+	status RotationStatus,
+) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	current, err := identity.GetApplicationCredential(ctx, credentials.ApplicationCredentialID)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not get application credential %s: %w", credentials.ApplicationCredentialID, err)
+	}
+
+	now := r.Clock.Now()
+	dueAt := r.dueAt(status, current)
+	if dueAt.IsZero() {
+		return reconcile.Result{RequeueAfter: syncPeriod}, nil
+	}
+	if now.Before(dueAt) {
+		return reconcile.Result{RequeueAfter: min(dueAt.Sub(now), syncPeriod)}, nil
+	}
+
+	if !current.Unrestricted {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, EventReasonRotationFailed,
+			"Application credential %s is restricted and cannot create its successor", current.ID)
+		return reconcile.Result{RequeueAfter: syncPeriod}, nil
+	}
+
+	gardenSecret, err := r.getGardenSecret(ctx, cluster.Shoot)
+	if err != nil {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, EventReasonRotationFailed, "Cannot rotate application credential %s: %s", current.ID, err)
+		return reconcile.Result{RequeueAfter: syncPeriod}, nil
+	}
+	if string(gardenSecret.Data[openstack.ApplicationCredentialID]) != credentials.ApplicationCredentialID {
+		// the secret in the garden was already changed, it is synced to the seed with the next reconciliation of the shoot
+		log.Info("Secret in the garden has a different application credential, waiting for it to be synced")
+		return reconcile.Result{RequeueAfter: syncPeriod}, nil
+	}
+
+	log.Info("Rotating application credential", "applicationCredentialID", current.ID)
+	successor, err := identity.CreateApplicationCredential(ctx, r.createOpts(secret, current, now))
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not create application credential: %w", err)
+	}
+
+	data := map[string][]byte{
+		openstack.ApplicationCredentialID:     []byte(successor.ID),
+		openstack.ApplicationCredentialSecret: []byte(successor.Secret),
+	}
+	if _, ok := secret.Data[openstack.ApplicationCredentialName]; ok {
+		data[openstack.ApplicationCredentialName] = []byte(successor.Name)
+	}
+	if err := r.patchGardenSecret(ctx, gardenSecret, data); err != nil {
+		// the new application credential is not used anywhere yet
+		if deleteErr := identity.DeleteApplicationCredential(ctx, successor.ID); deleteErr != nil {
+			log.Error(deleteErr, "Could not delete unused application credential", "applicationCredentialID", successor.ID)
+		}
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, EventReasonRotationFailed, "Cannot update secret in the garden: %s", err)
+		return reconcile.Result{}, err
+	}
+
+	status = RotationStatus{
+		CredentialID:         successor.ID,
+		Since:                metav1.NewTime(now.UTC()),
+		PreviousCredentialID: current.ID,
+	}
+	if err := r.patchSecret(ctx, secret, data, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.triggerReconciliation(ctx, secret.Namespace); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.Recorder.Eventf(secret, corev1.EventTypeNormal, EventReasonCreated,
+		"Created application credential %s to replace %s, waiting for the shoot components to roll out", successor.ID, current.ID)
+	return reconcile.Result{RequeueAfter: rolloutCheckInterval}, nil
+}
+
+// completeRotation deletes the previous application credential once the new one was rolled out.
+func (r *Reconciler) completeRotation(ctx context.Context, secret *corev1.Secret, identity openstackclient.Identity, status RotationStatus) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rolledOut, reason, err := r.rolledOut(ctx, secret, status.Since.Time)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !rolledOut {
+		log.Info("Waiting for the rollout of the application credential", "reason", reason)
+		return reconcile.Result{RequeueAfter: rolloutCheckInterval}, nil
+	}
+
+	if err := openstackclient.IgnoreNotFoundError(identity.DeleteApplicationCredential(ctx, status.PreviousCredentialID)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not delete application credential %s: %w", status.PreviousCredentialID, err)
+	}
+	previous := status.PreviousCredentialID
+	status.PreviousCredentialID = ""
+	if err := r.patchSecret(ctx, secret, nil, status); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.Recorder.Eventf(secret, corev1.EventTypeNormal, EventReasonRotated,
+		"Deleted application credential %s after the shoot components rolled out %s", previous, status.CredentialID)
+	return reconcile.Result{RequeueAfter: syncPeriod}, nil
+}
+
+// dueAt returns the time at which the application credential has to be rotated. It is zero if the application
+// credential neither has a maximum age nor expires.
+func (r *Reconciler) dueAt(status RotationStatus, credential *applicationcredentials.ApplicationCredential) time.Time {
+	var dueAt time.Time
+	if r.Config.MaxAge != nil {
+		dueAt = status.Since.Add(r.Config.MaxAge.Duration)
+	}
+	if !credential.ExpiresAt.IsZero() {
+		renewBefore := DefaultRenewBefore
+		if r.Config.RenewBefore != nil {
+			renewBefore = r.Config.RenewBefore.Duration
+		}
+		if renewAt := credential.ExpiresAt.Add(-renewBefore); dueAt.IsZero() || renewAt.Before(dueAt) {
+			dueAt = renewAt
+		}
+	}
+	return dueAt
+}
+
+// createOpts returns the options for the successor of the given application credential. It gets the same roles and
+// access rules as the current application credential.
+func (r *Reconciler) createOpts(secret *corev1.Secret, current *applicationcredentials.ApplicationCredential, now time.Time) applicationcredentials.CreateOpts {
+	opts := applicationcredentials.CreateOpts{
+		Name:         fmt.Sprintf("%s-%d", secret.Namespace, now.Unix()),
+		Description:  current.Description,
+		Unrestricted: true,
+		AccessRules:  current.AccessRules,
+	}
+	for _, role := range current.Roles {
+		opts.Roles = append(opts.Roles, applicationcredentials.Role{ID: role.ID})
+	}
+	if r.Config.Lifetime != nil {
+		opts.ExpiresAt = ptr.To(now.Add(r.Config.Lifetime.Duration).UTC())
+	}
+	return opts
+}
+
+// rotationStatusOf returns the rotation status recorded in the annotation of the secret. A missing or invalid
+// annotation results in an empty status.
+func rotationStatusOf(secret *corev1.Secret) RotationStatus {
+	var status RotationStatus
+	if value, ok := secret.Annotations[openstack.ApplicationCredentialRotationAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &status); err != nil {
+			return RotationStatus{}
+		}
+	}
+	return status
+}
+
+// patchSecret sets the given data and the rotation status of the cloudprovider secret.
+func (r *Reconciler) patchSecret(ctx context.Context, secret *corev1.Secret, data map[string][]byte, status RotationStatus) error {
+	value, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFromWithOptions(secret.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, openstack.ApplicationCredentialRotationAnnotation, string(value))
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+	if err := r.Client.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("could not update secret %s: %w", client.ObjectKeyFromObject(secret), err)
+	}
+	return nil
+}
+
+// getGardenSecret returns the secret referenced by the secret binding of the shoot. Secrets used by several shoots
+// are not rotated, as the other shoots would not roll out the new application credential before the old one is
+// deleted.
+func (r *Reconciler) getGardenSecret(ctx context.Context, shoot *gardencorev1beta1.Shoot) (*corev1.Secret, error) {
+	if shoot.Spec.SecretBindingName == nil {
+		return nil, fmt.Errorf("shoot does not reference a secret binding")
+	}
+
+	secretBinding := &gardencorev1beta1.SecretBinding{}
+	if err := r.GardenClient.Get(ctx, client.ObjectKey{Namespace: shoot.Namespace, Name: *shoot.Spec.SecretBindingName}, secretBinding); err != nil {
+		return nil, fmt.Errorf("could not get secret binding: %w", err)
+	}
+
+	shoots := &gardencorev1beta1.ShootList{}
+	if err := r.GardenClient.List(ctx, shoots, client.InNamespace(shoot.Namespace)); err != nil {
+		return nil, fmt.Errorf("could not list shoots: %w", err)
+	}
+	for _, other := range shoots.Items {
+		if other.Name != shoot.Name && ptr.Deref(other.Spec.SecretBindingName, "") == secretBinding.Name {
+			return nil, fmt.Errorf("secret binding %s is also used by shoot %s", secretBinding.Name, other.Name)
+		}
+	}
+
+	namespace := secretBinding.SecretRef.Namespace
+	if namespace == "" {
+		namespace = secretBinding.Namespace
+	}
+	gardenSecret := &corev1.Secret{}
+	if err := r.GardenClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretBinding.SecretRef.Name}, gardenSecret); err != nil {
+		return nil, fmt.Errorf("could not get secret of secret binding %s: %w", secretBinding.Name, err)
+	}
+	return gardenSecret, nil
+}
+
+// patchGardenSecret sets the given data of the secret in the garden.
+func (r *Reconciler) patchGardenSecret(ctx context.Context, secret *corev1.Secret, data map[string][]byte) error {
+	patch := client.MergeFromWithOptions(secret.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	for key, value := range data {
+		secret.Data[key] = value
+	}
+	return r.GardenClient.Patch(ctx, secret, patch)
+}
+
+// triggerReconciliation annotates the control planes and workers in the namespace, so that they are reconciled with
+// the new application credential.
+func (r *Reconciler) triggerReconciliation(ctx context.Context, namespace string) error {
+	controlPlanes := &extensionsv1alpha1.ControlPlaneList{}
+	if err := r.Client.List(ctx, controlPlanes, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("could not list control planes: %w", err)
+	}
+	workers := &extensionsv1alpha1.WorkerList{}
+	if err := r.Client.List(ctx, workers, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("could not list workers: %w", err)
+	}
+
+	var objects []client.Object
+	for i := range controlPlanes.Items {
+		objects = append(objects, &controlPlanes.Items[i])
+	}
+	for i := range workers.Items {
+		objects = append(objects, &workers.Items[i])
+	}
+	for _, obj := range objects {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		kubernetesutils.SetMetaDataAnnotation(obj, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+		if err := r.Client.Patch(ctx, obj, patch); err != nil {
+			return fmt.Errorf("could not annotate %T %s: %w", obj, client.ObjectKeyFromObject(obj), err)
+		}
+	}
+	return nil
+}
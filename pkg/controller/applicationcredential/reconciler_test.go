@@ -0,0 +1,359 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationcredential_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	. "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/applicationcredential"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+const (
+	namespace     = "shoot--foobar--os"
+	projectNS     = "garden-foobar"
+	shootName     = "os"
+	bindingName   = "my-binding"
+	gardenSecName = "my-secret"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctx      context.Context
+		cloud    *fake.Cloud
+		identity openstackclient.Identity
+		clock    *testclock.FakeClock
+		recorder *record.FakeRecorder
+
+		seedClient    client.Client
+		gardenClient  client.Client
+		gardenObjects func() []client.Object
+		reconciler    *Reconciler
+
+		currentID string
+		request   reconcile.Request
+	)
+
+	newCredentialsData := func(id, secret string) map[string][]byte {
+		return map[string][]byte{
+			openstack.AuthURL:                     []byte("https://keystone.example.com/v3"),
+			openstack.DomainName:                  []byte("default"),
+			openstack.TenantName:                  []byte("gardener"),
+			openstack.ApplicationCredentialID:     []byte(id),
+			openstack.ApplicationCredentialSecret: []byte(secret),
+		}
+	}
+
+	getSecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		ExpectWithOffset(1, seedClient.Get(ctx, request.NamespacedName, secret)).To(Succeed())
+		return secret
+	}
+
+	getStatus := func() RotationStatus {
+		var status RotationStatus
+		ExpectWithOffset(1, json.Unmarshal([]byte(getSecret().Annotations[openstack.ApplicationCredentialRotationAnnotation]), &status)).To(Succeed())
+		return status
+	}
+
+	getGardenSecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		ExpectWithOffset(1, gardenClient.Get(ctx, client.ObjectKey{Namespace: projectNS, Name: gardenSecName}, secret)).To(Succeed())
+		return secret
+	}
+
+	setReconciled := func(obj client.Object, lastOperation *gardencorev1beta1.LastOperation) {
+		ExpectWithOffset(1, seedClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		annotations := obj.GetAnnotations()
+		delete(annotations, v1beta1constants.GardenerOperation)
+		obj.SetAnnotations(annotations)
+		switch obj := obj.(type) {
+		case *extensionsv1alpha1.ControlPlane:
+			obj.Status.LastOperation = lastOperation
+		case *extensionsv1alpha1.Worker:
+			obj.Status.LastOperation = lastOperation
+		}
+		ExpectWithOffset(1, seedClient.Patch(ctx, obj, patch)).To(Succeed())
+	}
+
+	succeededAt := func(t time.Time) *gardencorev1beta1.LastOperation {
+		return &gardencorev1beta1.LastOperation{
+			State:          gardencorev1beta1.LastOperationStateSucceeded,
+			LastUpdateTime: metav1.NewTime(t),
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clock = testclock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+		recorder = record.NewFakeRecorder(10)
+
+		cloud = fake.NewCloud()
+		var err error
+		identity, err = cloud.Factory().Identity()
+		Expect(err).NotTo(HaveOccurred())
+		currentID = cloud.AddApplicationCredential(applicationcredentials.ApplicationCredential{
+			Name:         "gardener",
+			Secret:       "current-secret",
+			Unrestricted: true,
+			Roles:        []applicationcredentials.Role{{ID: "member"}},
+			ExpiresAt:    clock.Now().Add(30 * 24 * time.Hour),
+		})
+
+		shoot := &gardencorev1beta1.Shoot{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: projectNS, Name: shootName},
+			Spec: gardencorev1beta1.ShootSpec{
+				SecretBindingName: ptr.To(bindingName),
+				Provider:          gardencorev1beta1.Provider{Type: openstack.Type},
+			},
+		}
+		shootJSON, err := json.Marshal(shoot)
+		Expect(err).NotTo(HaveOccurred())
+
+		seedClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(
+			&extensionsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+				Spec:       extensionsv1alpha1.ClusterSpec{Shoot: runtime.RawExtension{Raw: shootJSON}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider},
+				Data:       newCredentialsData(currentID, "current-secret"),
+			},
+			&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: shootName}},
+			&extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: shootName}},
+		).Build()
+
+		gardenObjects = func() []client.Object {
+			return []client.Object{
+				shoot.DeepCopy(),
+				&gardencorev1beta1.SecretBinding{
+					ObjectMeta: metav1.ObjectMeta{Namespace: projectNS, Name: bindingName},
+					SecretRef:  corev1.SecretReference{Namespace: projectNS, Name: gardenSecName},
+					Provider:   &gardencorev1beta1.SecretBindingProvider{Type: openstack.Type},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: projectNS, Name: gardenSecName},
+					Data:       newCredentialsData(currentID, "current-secret"),
+				},
+			}
+		}
+		gardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithObjects(gardenObjects()...).Build()
+
+		reconciler = &Reconciler{
+			Client:        seedClient,
+			GardenClient:  gardenClient,
+			Recorder:      recorder,
+			ClientFactory: cloud.FactoryFactory(),
+			Config:        controllerconfig.ApplicationCredentialRotationConfig{Enabled: true},
+			Clock:         clock,
+		}
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+	})
+
+	It("should record the application credential and wait until it is due", func() {
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+		status := getStatus()
+		Expect(status.CredentialID).To(Equal(currentID))
+		Expect(status.PreviousCredentialID).To(BeEmpty())
+		Expect(status.Since.Time).To(BeTemporally("==", clock.Now()))
+		Expect(cloud.Calls("CreateApplicationCredential")).To(BeZero())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("should create a new application credential before the current one expires", func() {
+		clock.Step(24 * 24 * time.Hour)
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+
+		status := getStatus()
+		Expect(status.CredentialID).NotTo(Equal(currentID))
+		Expect(status.PreviousCredentialID).To(Equal(currentID))
+		Expect(status.Since.Time).To(BeTemporally("==", clock.Now()))
+
+		successor, err := identity.GetApplicationCredential(ctx, status.CredentialID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(successor.Unrestricted).To(BeTrue())
+		Expect(successor.Roles).To(ConsistOf(applicationcredentials.Role{ID: "member"}))
+
+		secret := getSecret()
+		Expect(secret.Data).To(HaveKeyWithValue(openstack.ApplicationCredentialID, []byte(status.CredentialID)))
+		Expect(secret.Data).To(HaveKeyWithValue(openstack.ApplicationCredentialSecret, []byte("secret-"+status.CredentialID)))
+		Expect(getGardenSecret().Data).To(Equal(secret.Data))
+
+		controlPlane := &extensionsv1alpha1.ControlPlane{}
+		Expect(seedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: shootName}, controlPlane)).To(Succeed())
+		Expect(controlPlane.Annotations).To(HaveKeyWithValue(v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile))
+		worker := &extensionsv1alpha1.Worker{}
+		Expect(seedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: shootName}, worker)).To(Succeed())
+		Expect(worker.Annotations).To(HaveKeyWithValue(v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile))
+
+		_, err = identity.GetApplicationCredential(ctx, currentID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring(EventReasonCreated)))
+	})
+
+	It("should create a new application credential once the maximum age is reached", func() {
+		reconciler.Config.MaxAge = &metav1.Duration{Duration: 24 * time.Hour}
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getStatus().PreviousCredentialID).To(BeEmpty())
+
+		clock.Step(24 * time.Hour)
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getStatus().PreviousCredentialID).To(Equal(currentID))
+	})
+
+	It("should set the expiration of the new application credential", func() {
+		reconciler.Config.Lifetime = &metav1.Duration{Duration: 90 * 24 * time.Hour}
+		clock.Step(24 * 24 * time.Hour)
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		successor, err := identity.GetApplicationCredential(ctx, getStatus().CredentialID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(successor.ExpiresAt).To(Equal(clock.Now().Add(90 * 24 * time.Hour)))
+	})
+
+	It("should delete the old application credential after the rollout", func() {
+		clock.Step(24 * 24 * time.Hour)
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		<-recorder.Events
+
+		By("waiting for the control plane and the worker")
+		clock.Step(time.Minute)
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+		Expect(getStatus().PreviousCredentialID).To(Equal(currentID))
+
+		setReconciled(&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: shootName}}, succeededAt(clock.Now()))
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getStatus().PreviousCredentialID).To(Equal(currentID))
+
+		By("completing the rotation")
+		setReconciled(&extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: shootName}}, succeededAt(clock.Now()))
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(getStatus().PreviousCredentialID).To(BeEmpty())
+
+		_, err = identity.GetApplicationCredential(ctx, currentID)
+		Expect(openstackclient.IsNotFoundError(err)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring(EventReasonRotated)))
+	})
+
+	It("should not complete the rotation with a failed reconciliation", func() {
+		clock.Step(24 * 24 * time.Hour)
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		failed := succeededAt(clock.Now())
+		failed.State = gardencorev1beta1.LastOperationStateError
+		setReconciled(&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: shootName}}, failed)
+		setReconciled(&extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: shootName}}, succeededAt(clock.Now()))
+
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getStatus().PreviousCredentialID).To(Equal(currentID))
+		_, err = identity.GetApplicationCredential(ctx, currentID)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not rotate a restricted application credential", func() {
+		restrictedID := cloud.AddApplicationCredential(applicationcredentials.ApplicationCredential{
+			Secret:    "restricted-secret",
+			ExpiresAt: clock.Now().Add(24 * time.Hour),
+		})
+		secret := getSecret()
+		secret.Data = newCredentialsData(restrictedID, "restricted-secret")
+		Expect(seedClient.Update(ctx, secret)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloud.Calls("CreateApplicationCredential")).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring(EventReasonRotationFailed)))
+	})
+
+	It("should not rotate an application credential shared with other shoots", func() {
+		Expect(gardenClient.Create(ctx, &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Namespace: projectNS, Name: "other"},
+			Spec:       gardencorev1beta1.ShootSpec{SecretBindingName: ptr.To(bindingName)},
+		})).To(Succeed())
+		clock.Step(24 * 24 * time.Hour)
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloud.Calls("CreateApplicationCredential")).To(BeZero())
+		Expect(recorder.Events).To(Receive(ContainSubstring("also used by shoot other")))
+	})
+
+	It("should delete the new application credential if the secret in the garden cannot be updated", func() {
+		reconciler.GardenClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				return fmt.Errorf("forbidden")
+			},
+		}).WithObjects(gardenObjects()...).Build()
+		clock.Step(24 * 24 * time.Hour)
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError("forbidden"))
+		Expect(cloud.Calls("CreateApplicationCredential")).To(Equal(1))
+		Expect(cloud.Calls("DeleteApplicationCredential")).To(Equal(1))
+		Expect(getSecret().Data).To(HaveKeyWithValue(openstack.ApplicationCredentialID, []byte(currentID)))
+	})
+
+	It("should ignore secrets without application credential", func() {
+		secret := getSecret()
+		secret.Data = map[string][]byte{
+			openstack.AuthURL:    []byte("https://keystone.example.com/v3"),
+			openstack.DomainName: []byte("default"),
+			openstack.TenantName: []byte("gardener"),
+			openstack.UserName:   []byte("user"),
+			openstack.Password:   []byte("password"),
+		}
+		Expect(seedClient.Update(ctx, secret)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(getSecret().Annotations).NotTo(HaveKey(openstack.ApplicationCredentialRotationAnnotation))
+	})
+})
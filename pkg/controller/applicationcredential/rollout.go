@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationcredential
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+// rolledOut checks whether the components of the shoot use the credentials of the cloudprovider secret. This is the
+// case if the control planes and workers were successfully reconciled since the given time, which rolls the
+// cloud-controller-manager, the CSI drivers and the machine-controller-manager, and the deployments of the
+// cloud-controller-manager and the CSI controller are healthy. If not, a reason is returned.
+func (r *Reconciler) rolledOut(ctx context.Context, secret *corev1.Secret, since time.Time) (bool, string, error) {
+	controlPlanes := &extensionsv1alpha1.ControlPlaneList{}
+	if err := r.Client.List(ctx, controlPlanes, client.InNamespace(secret.Namespace)); err != nil {
+		return false, "", fmt.Errorf("could not list control planes: %w", err)
+	}
+	for i := range controlPlanes.Items {
+		if !reconciledSince(&controlPlanes.Items[i], since) {
+			return false, fmt.Sprintf("control plane %s was not reconciled yet", controlPlanes.Items[i].Name), nil
+		}
+	}
+
+	workers := &extensionsv1alpha1.WorkerList{}
+	if err := r.Client.List(ctx, workers, client.InNamespace(secret.Namespace)); err != nil {
+		return false, "", fmt.Errorf("could not list workers: %w", err)
+	}
+	for i := range workers.Items {
+		if !reconciledSince(&workers.Items[i], since) {
+			return false, fmt.Sprintf("worker %s was not reconciled yet", workers.Items[i].Name), nil
+		}
+	}
+
+	checksum := utils.ComputeSecretChecksum(secret.Data)
+	for _, name := range []string{openstack.CloudControllerManagerName, openstack.CSIControllerName} {
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: secret.Namespace, Name: name}, deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, "", fmt.Errorf("could not get deployment %s: %w", name, err)
+		}
+		if value, ok := deployment.Spec.Template.Annotations["checksum/secret-"+v1beta1constants.SecretNameCloudProvider]; ok && value != checksum {
+			return false, fmt.Sprintf("deployment %s does not use the current secret yet", name), nil
+		}
+		if err := health.CheckDeployment(deployment); err != nil {
+			return false, fmt.Sprintf("deployment %s is not healthy: %s", name, err), nil
+		}
+	}
+	return true, "", nil
+}
+
+// reconciledSince checks whether the object was successfully reconciled since the given time and no further
+// reconciliation is requested.
+func reconciledSince(obj extensionsv1alpha1.Object, since time.Time) bool {
+	if _, ok := obj.GetAnnotations()[v1beta1constants.GardenerOperation]; ok {
+		return false
+	}
+	lastOperation := obj.GetExtensionStatus().GetLastOperation()
+	return lastOperation != nil &&
+		lastOperation.State == gardencorev1beta1.LastOperationStateSucceeded &&
+		!lastOperation.LastUpdateTime.Time.Before(since)
+}
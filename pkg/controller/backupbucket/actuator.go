@@ -29,8 +29,10 @@ func newActuator(mgr manager.Manager) backupbucket.Actuator {
 	}
 }
 
+// The BackupBuckets belong to the seed and are not bound to a shoot cluster, hence there is no cloud profile whose
+// `requestTimeout` could be honoured and the storage client uses the default request timeout.
 func (a *actuator) Reconcile(ctx context.Context, _ logr.Logger, bb *extensionsv1alpha1.BackupBucket) error {
-	openstackClient, err := openstackclient.NewStorageClientFromSecretRef(ctx, a.client, bb.Spec.SecretRef, bb.Spec.Region)
+	openstackClient, err := openstackclient.NewStorageClientFromSecretRef(ctx, a.client, bb.Spec.SecretRef, bb.Spec.Region, openstackclient.WithController(backupbucket.ControllerName))
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -39,7 +41,7 @@ func (a *actuator) Reconcile(ctx context.Context, _ logr.Logger, bb *extensionsv
 }
 
 func (a *actuator) Delete(ctx context.Context, _ logr.Logger, bb *extensionsv1alpha1.BackupBucket) error {
-	openstackClient, err := openstackclient.NewStorageClientFromSecretRef(ctx, a.client, bb.Spec.SecretRef, bb.Spec.Region)
+	openstackClient, err := openstackclient.NewStorageClientFromSecretRef(ctx, a.client, bb.Spec.SecretRef, bb.Spec.Region, openstackclient.WithController(backupbucket.ControllerName))
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gardener/gardener/extensions/pkg/controller/backupentry"
 	"github.com/gardener/gardener/extensions/pkg/controller/backupentry/genericactuator"
 	"github.com/gardener/gardener/extensions/pkg/util"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
@@ -37,8 +38,10 @@ func (a *actuator) GetETCDSecretData(_ context.Context, _ logr.Logger, be *exten
 	return backupSecretData, nil
 }
 
+// The BackupEntry delegate is not passed the cluster, i.e. a BackupEntry outlives its shoot, hence there is no cloud
+// profile whose `requestTimeout` could be honoured and the storage client uses the default request timeout.
 func (a *actuator) Delete(ctx context.Context, _ logr.Logger, be *extensionsv1alpha1.BackupEntry) error {
-	openstackClient, err := openstackclient.NewStorageClientFromSecretRef(ctx, a.client, be.Spec.SecretRef, be.Spec.Region)
+	openstackClient, err := openstackclient.NewStorageClientFromSecretRef(ctx, a.client, be.Spec.SecretRef, be.Spec.Region, openstackclient.WithController(backupentry.ControllerName))
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -5,6 +5,7 @@
 package bastion
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -46,16 +47,16 @@ func newActuator(mgr manager.Manager, openstackClientFactory openstackclient.Fac
 	}
 }
 
-func getBastionInstance(client openstackclient.Compute, name string) ([]servers.Server, error) {
-	return client.FindServersByName(name)
+func getBastionInstance(ctx context.Context, client openstackclient.Compute, name string) ([]servers.Server, error) {
+	return client.FindServersByName(ctx, name)
 }
 
-func createBastionInstance(client openstackclient.Compute, parameters servers.CreateOpts) (*servers.Server, error) {
-	return client.CreateServer(parameters)
+func createBastionInstance(ctx context.Context, client openstackclient.Compute, parameters servers.CreateOpts) (*servers.Server, error) {
+	return client.CreateServer(ctx, parameters)
 }
 
-func deleteBastionInstance(client openstackclient.Compute, id string) error {
-	return client.DeleteServer(id)
+func deleteBastionInstance(ctx context.Context, client openstackclient.Compute, id string) error {
+	return client.DeleteServer(ctx, id)
 }
 
 // GetIPs return privateip, publicip
@@ -95,51 +96,51 @@ func GetIPs(s *servers.Server, opt *Options) (string, string, error) {
 	return privateIP, publicIp, nil
 }
 
-func createFloatingIP(client openstackclient.Networking, parameters floatingips.CreateOpts) (*floatingips.FloatingIP, error) {
-	return client.CreateFloatingIP(parameters)
+func createFloatingIP(ctx context.Context, client openstackclient.Networking, parameters floatingips.CreateOpts) (*floatingips.FloatingIP, error) {
+	return client.CreateFloatingIP(ctx, parameters)
 }
 
-func deleteFloatingIP(client openstackclient.Networking, id string) error {
-	return client.DeleteFloatingIP(id)
+func deleteFloatingIP(ctx context.Context, client openstackclient.Networking, id string) error {
+	return client.DeleteFloatingIP(ctx, id)
 }
 
-func associateFIPWithInstance(client openstackclient.Compute, id string, parameter computefip.AssociateOpts) error {
-	return client.AssociateFIPWithInstance(id, parameter)
+func associateFIPWithInstance(ctx context.Context, client openstackclient.Compute, id string, parameter computefip.AssociateOpts) error {
+	return client.AssociateFIPWithInstance(ctx, id, parameter)
 }
 
-func findFloatingIDByInstanceID(client openstackclient.Compute, id string) (string, error) {
-	return client.FindFloatingIDByInstanceID(id)
+func findFloatingIDByInstanceID(ctx context.Context, client openstackclient.Compute, id string) (string, error) {
+	return client.FindFloatingIDByInstanceID(ctx, id)
 }
 
-func getFipByName(client openstackclient.Networking, name string) ([]floatingips.FloatingIP, error) {
-	return client.GetFipByName(name)
+func getFipByName(ctx context.Context, client openstackclient.Networking, name string) ([]floatingips.FloatingIP, error) {
+	return client.GetFipByName(ctx, name)
 }
 
-func createSecurityGroup(client openstackclient.Networking, createOpts groups.CreateOpts) (*groups.SecGroup, error) {
-	return client.CreateSecurityGroup(createOpts)
+func createSecurityGroup(ctx context.Context, client openstackclient.Networking, createOpts groups.CreateOpts) (*groups.SecGroup, error) {
+	return client.CreateSecurityGroup(ctx, createOpts)
 }
 
-func deleteSecurityGroup(client openstackclient.Networking, groupid string) error {
-	return client.DeleteSecurityGroup(groupid)
+func deleteSecurityGroup(ctx context.Context, client openstackclient.Networking, groupid string) error {
+	return client.DeleteSecurityGroup(ctx, groupid)
 }
 
-func getSecurityGroups(client openstackclient.Networking, name string) ([]groups.SecGroup, error) {
-	return client.GetSecurityGroupByName(name)
+func getSecurityGroups(ctx context.Context, client openstackclient.Networking, name string) ([]groups.SecGroup, error) {
+	return client.GetSecurityGroupByName(ctx, name)
 }
 
-func createRules(client openstackclient.Networking, createOpts rules.CreateOpts) (*rules.SecGroupRule, error) {
-	return client.CreateRule(createOpts)
+func createRules(ctx context.Context, client openstackclient.Networking, createOpts rules.CreateOpts) (*rules.SecGroupRule, error) {
+	return client.CreateRule(ctx, createOpts)
 }
 
-func listRules(client openstackclient.Networking, secGroupID string) ([]rules.SecGroupRule, error) {
+func listRules(ctx context.Context, client openstackclient.Networking, secGroupID string) ([]rules.SecGroupRule, error) {
 	listOpts := rules.ListOpts{
 		SecGroupID: secGroupID,
 	}
-	return client.ListRules(listOpts)
+	return client.ListRules(ctx, listOpts)
 }
 
-func deleteRule(client openstackclient.Networking, ruleID string) error {
-	return client.DeleteRule(ruleID)
+func deleteRule(ctx context.Context, client openstackclient.Networking, ruleID string) error {
+	return client.DeleteRule(ctx, ruleID)
 }
 
 func bastionConfigCheck(bastionConfig *controllerconfig.BastionConfig) error {
@@ -27,37 +27,22 @@ func (a *actuator) Delete(ctx context.Context, log logr.Logger, bastion *extensi
 		return err
 	}
 
-	credentials, err := openstack.GetCredentials(ctx, a.client, opt.SecretReference, false)
-	if err != nil {
-		return fmt.Errorf("could not get Openstack credentials: %w", err)
-	}
-
-	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials)
-	if err != nil {
-		return util.DetermineError(fmt.Errorf("could not create openstack client factory: %w", err), helper.KnownCodes)
-	}
-
-	computeClient, err := openstackClientFactory.Compute()
-	if err != nil {
-		return util.DetermineError(err, helper.KnownCodes)
-	}
-
-	networkingClient, err := openstackClientFactory.Networking()
+	computeClient, networkingClient, err := a.newClients(ctx, opt, cluster)
 	if err != nil {
-		return util.DetermineError(err, helper.KnownCodes)
+		return err
 	}
 
-	err = removeBastionInstance(log, computeClient, opt)
+	err = removeBastionInstance(ctx, log, computeClient, opt)
 	if err != nil {
 		return util.DetermineError(fmt.Errorf("failed to remove bastion instance: %w", err), helper.KnownCodes)
 	}
 
-	err = removePublicIPAddress(log, networkingClient, opt)
+	err = removePublicIPAddress(ctx, log, networkingClient, opt)
 	if err != nil {
 		return util.DetermineError(fmt.Errorf("failed to remove public ip address: %w", err), helper.KnownCodes)
 	}
 
-	deleted, err := isInstanceDeleted(computeClient, opt)
+	deleted, err := isInstanceDeleted(ctx, computeClient, opt)
 	if err != nil {
 		return util.DetermineError(fmt.Errorf("failed to check for bastion instance: %w", err), helper.KnownCodes)
 	}
@@ -70,15 +55,67 @@ func (a *actuator) Delete(ctx context.Context, log logr.Logger, bastion *extensi
 	}
 
 	// The ssh ingress rule for the bastion in the worker node security group was also deleted once the bastion security group was removed. Therefore, there's no need to manage its deletion.
-	return util.DetermineError(removeSecurityGroup(networkingClient, opt), helper.KnownCodes)
+	return util.DetermineError(removeSecurityGroup(ctx, networkingClient, opt), helper.KnownCodes)
 }
 
-func (a *actuator) ForceDelete(_ context.Context, _ logr.Logger, _ *extensionsv1alpha1.Bastion, _ *controller.Cluster) error {
+// ForceDelete deletes the bastion on a best-effort basis. It neither waits for the deletion of the instance nor fails,
+// so that the Bastion does not block the force-deletion of the shoot. The resources left behind are logged.
+func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, bastion *extensionsv1alpha1.Bastion, cluster *controller.Cluster) error {
+	opt, err := DetermineOptions(bastion, cluster)
+	if err != nil {
+		log.Error(err, "Could not force-delete bastion")
+		return nil
+	}
+
+	computeClient, networkingClient, err := a.newClients(ctx, opt, cluster)
+	if err != nil {
+		log.Error(err, "Could not force-delete bastion")
+		return nil
+	}
+
+	err = errors.Join(
+		removeBastionInstance(ctx, log, computeClient, opt),
+		removePublicIPAddress(ctx, log, networkingClient, opt),
+		// fails as long as the instance is still deleting
+		removeSecurityGroup(ctx, networkingClient, opt),
+	)
+	if err != nil {
+		log.Error(err, "Force-deletion of bastion left resources behind")
+	}
 	return nil
 }
 
-func removeBastionInstance(log logr.Logger, client openstackclient.Compute, opt *Options) error {
-	instances, err := getBastionInstance(client, opt.BastionInstanceName)
+// newClients creates the compute and networking clients for the project of the bastion.
+func (a *actuator) newClients(ctx context.Context, opt *Options, cluster *controller.Cluster) (openstackclient.Compute, openstackclient.Networking, error) {
+	credentials, err := openstack.GetCredentials(ctx, a.client, opt.SecretReference, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get Openstack credentials: %w", err)
+	}
+
+	factoryOptions, err := openstackclient.FactoryOptionsFromCluster(cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials, factoryOptions...)
+	if err != nil {
+		return nil, nil, util.DetermineError(fmt.Errorf("could not create openstack client factory: %w", err), helper.KnownCodes)
+	}
+
+	computeClient, err := openstackClientFactory.Compute()
+	if err != nil {
+		return nil, nil, util.DetermineError(err, helper.KnownCodes)
+	}
+
+	networkingClient, err := openstackClientFactory.Networking()
+	if err != nil {
+		return nil, nil, util.DetermineError(err, helper.KnownCodes)
+	}
+	return computeClient, networkingClient, nil
+}
+
+func removeBastionInstance(ctx context.Context, log logr.Logger, client openstackclient.Compute, opt *Options) error {
+	instances, err := getBastionInstance(ctx, client, opt.BastionInstanceName)
 	if openstackclient.IgnoreNotFoundError(err) != nil {
 		return err
 	}
@@ -87,7 +124,7 @@ func removeBastionInstance(log logr.Logger, client openstackclient.Compute, opt
 		return nil
 	}
 
-	err = deleteBastionInstance(client, instances[0].ID)
+	err = deleteBastionInstance(ctx, client, instances[0].ID)
 	if err != nil {
 		return fmt.Errorf("failed to terminate bastion instance: %w", err)
 	}
@@ -96,8 +133,8 @@ func removeBastionInstance(log logr.Logger, client openstackclient.Compute, opt
 	return nil
 }
 
-func removePublicIPAddress(log logr.Logger, client openstackclient.Networking, opt *Options) error {
-	fips, err := getFipByName(client, opt.BastionInstanceName)
+func removePublicIPAddress(ctx context.Context, log logr.Logger, client openstackclient.Networking, opt *Options) error {
+	fips, err := getFipByName(ctx, client, opt.BastionInstanceName)
 	if err != nil {
 		return err
 	}
@@ -106,7 +143,7 @@ func removePublicIPAddress(log logr.Logger, client openstackclient.Networking, o
 		return nil
 	}
 
-	err = deleteFloatingIP(client, fips[0].ID)
+	err = deleteFloatingIP(ctx, client, fips[0].ID)
 	if err != nil {
 		return fmt.Errorf("failed to terminate bastion Public IP: %w", err)
 	}
@@ -115,8 +152,8 @@ func removePublicIPAddress(log logr.Logger, client openstackclient.Networking, o
 	return nil
 }
 
-func removeSecurityGroup(client openstackclient.Networking, opt *Options) error {
-	bastionSecurityGroups, err := getSecurityGroups(client, opt.SecurityGroup)
+func removeSecurityGroup(ctx context.Context, client openstackclient.Networking, opt *Options) error {
+	bastionSecurityGroups, err := getSecurityGroups(ctx, client, opt.SecurityGroup)
 	if err != nil {
 		return err
 	}
@@ -125,11 +162,11 @@ func removeSecurityGroup(client openstackclient.Networking, opt *Options) error
 		return nil
 	}
 
-	return deleteSecurityGroup(client, bastionSecurityGroups[0].ID)
+	return deleteSecurityGroup(ctx, client, bastionSecurityGroups[0].ID)
 }
 
-func isInstanceDeleted(client openstackclient.Compute, opt *Options) (bool, error) {
-	instances, err := getBastionInstance(client, opt.BastionInstanceName)
+func isInstanceDeleted(ctx context.Context, client openstackclient.Compute, opt *Options) (bool, error) {
+	instances, err := getBastionInstance(ctx, client, opt.BastionInstanceName)
 	if openstackclient.IgnoreNotFoundError(err) != nil {
 		return false, err
 	}
@@ -62,7 +62,12 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, bastion *exte
 		return fmt.Errorf("could not get Openstack credentials: %w", err)
 	}
 
-	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials)
+	factoryOptions, err := openstackclient.FactoryOptionsFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials, factoryOptions...)
 	if err != nil {
 		return util.DetermineError(fmt.Errorf("could not create Openstack client factory: %w", err), helper.KnownCodes)
 	}
@@ -82,22 +87,22 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, bastion *exte
 		return err
 	}
 
-	securityGroup, err := ensureSecurityGroup(log, networkingClient, opt)
+	securityGroup, err := ensureSecurityGroup(ctx, log, networkingClient, opt)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
 
-	err = ensureSecurityGroupRules(log, networkingClient, bastion, opt, infraStatus, securityGroup.ID)
+	err = ensureSecurityGroupRules(ctx, log, networkingClient, bastion, opt, infraStatus, securityGroup.ID)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
 
-	err = ensureShootWorkerSecurityGroupRules(log, networkingClient, opt, infraStatus, securityGroup.ID)
+	err = ensureShootWorkerSecurityGroupRules(ctx, log, networkingClient, opt, infraStatus, securityGroup.ID)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
 
-	instance, err := ensureComputeInstance(log, computeClient, a.bastionConfig, infraStatus, opt)
+	instance, err := ensureComputeInstance(ctx, log, computeClient, a.bastionConfig, infraStatus, opt)
 	if err != nil || instance == nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -112,18 +117,18 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, bastion *exte
 		return fmt.Errorf("could not decode InfrastructureConfig of cluster Profile': %w", err)
 	}
 
-	fipid, err := ensurePublicIPAddress(opt, log, networkingClient, infraStatus)
+	fipid, err := ensurePublicIPAddress(ctx, opt, log, networkingClient, infraStatus)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
 
-	err = ensureAssociateFIPWithInstance(computeClient, instance, fipid)
+	err = ensureAssociateFIPWithInstance(ctx, computeClient, instance, fipid)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
 
 	// refresh instance after public ip attached/created
-	instances, err := getBastionInstance(computeClient, opt.BastionInstanceName)
+	instances, err := getBastionInstance(ctx, computeClient, opt.BastionInstanceName)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -154,13 +159,16 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, bastion *exte
 	return a.client.Status().Patch(ctx, bastion, patch)
 }
 
-func ensurePublicIPAddress(opt *Options, log logr.Logger, client openstackclient.Networking, infraStatus *openstackapi.InfrastructureStatus) (*floatingips.FloatingIP, error) {
-	fips, err := getFipByName(client, opt.BastionInstanceName)
+func ensurePublicIPAddress(ctx context.Context, opt *Options, log logr.Logger, client openstackclient.Networking, infraStatus *openstackapi.InfrastructureStatus) (*floatingips.FloatingIP, error) {
+	fips, err := getFipByName(ctx, client, opt.BastionInstanceName)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(fips) != 0 && fips[0].Status == "ACTIVE" {
+		if err := ensureTags(ctx, client, openstackclient.ResourceTypeFloatingIPs, fips[0].ID, fips[0].Tags, opt); err != nil {
+			return nil, err
+		}
 		return &fips[0], nil
 	}
 
@@ -174,7 +182,7 @@ func ensurePublicIPAddress(opt *Options, log logr.Logger, client openstackclient
 		return nil, errors.New("router must not be empty")
 	}
 
-	router, err := client.GetRouterByID(infraStatus.Networks.Router.ID)
+	router, err := client.GetRouterByID(ctx, infraStatus.Networks.Router.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -193,16 +201,19 @@ func ensurePublicIPAddress(opt *Options, log logr.Logger, client openstackclient
 		SubnetID:          router.GatewayInfo.ExternalFixedIPs[0].SubnetID,
 	}
 
-	fip, err := createFloatingIP(client, createOpts)
+	fip, err := createFloatingIP(ctx, client, createOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get (create) public ip address: %w", err)
 	}
 
+	if err := ensureTags(ctx, client, openstackclient.ResourceTypeFloatingIPs, fip.ID, fip.Tags, opt); err != nil {
+		return nil, err
+	}
 	return fip, nil
 }
 
-func ensureComputeInstance(log logr.Logger, client openstackclient.Compute, bastionConfig *config.BastionConfig, infraStatus *openstackapi.InfrastructureStatus, opt *Options) (*servers.Server, error) {
-	instances, err := getBastionInstance(client, opt.BastionInstanceName)
+func ensureComputeInstance(ctx context.Context, log logr.Logger, client openstackclient.Compute, bastionConfig *config.BastionConfig, infraStatus *openstackapi.InfrastructureStatus, opt *Options) (*servers.Server, error) {
+	instances, err := getBastionInstance(ctx, client, opt.BastionInstanceName)
 	if openstackclient.IgnoreNotFoundError(err) != nil {
 		return nil, err
 	}
@@ -217,7 +228,7 @@ func ensureComputeInstance(log logr.Logger, client openstackclient.Compute, bast
 		return nil, errors.New("network id not found")
 	}
 
-	flavorID, err := client.FindFlavorID(bastionConfig.FlavorRef)
+	flavorID, err := client.FindFlavorID(ctx, bastionConfig.FlavorRef)
 	if err != nil {
 		return nil, err
 	}
@@ -226,13 +237,13 @@ func ensureComputeInstance(log logr.Logger, client openstackclient.Compute, bast
 		return nil, errors.New("flavorID not found")
 	}
 
-	image, err := client.FindImageByID(bastionConfig.ImageRef)
+	image, err := client.FindImageByID(ctx, bastionConfig.ImageRef)
 	if err != nil {
 		return nil, err
 	}
 	// image not found case
 	if image == nil {
-		images, err := client.FindImages(bastionConfig.ImageRef)
+		images, err := client.FindImages(ctx, bastionConfig.ImageRef)
 		if err != nil {
 			return nil, err
 		}
@@ -251,9 +262,10 @@ func ensureComputeInstance(log logr.Logger, client openstackclient.Compute, bast
 		SecurityGroups: []string{opt.SecurityGroup},
 		Networks:       []servers.Network{{UUID: infraStatus.Networks.ID}},
 		UserData:       opt.UserData,
+		Metadata:       openstack.ResourceMetadata(opt.ShootName, opt.SeedName, openstack.TagPurposeBastion),
 	}
 
-	instance, err := createBastionInstance(client, createOpts)
+	instance, err := createBastionInstance(ctx, client, createOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bastion compute instance: %w ", err)
 	}
@@ -312,8 +324,8 @@ func addressToIngress(dnsName *string, ipAddress *string) *corev1.LoadBalancerIn
 	return ingress
 }
 
-func ensureAssociateFIPWithInstance(client openstackclient.Compute, instance *servers.Server, floatingIP *floatingips.FloatingIP) error {
-	fipid, err := findFloatingIDByInstanceID(client, instance.ID)
+func ensureAssociateFIPWithInstance(ctx context.Context, client openstackclient.Compute, instance *servers.Server, floatingIP *floatingips.FloatingIP) error {
+	fipid, err := findFloatingIDByInstanceID(ctx, client, instance.ID)
 	if err != nil {
 		return err
 	}
@@ -330,13 +342,13 @@ func ensureAssociateFIPWithInstance(client openstackclient.Compute, instance *se
 		FloatingIP: floatingIP.FloatingIP,
 	}
 
-	if err := associateFIPWithInstance(client, instance.ID, associateOpts); err != nil {
+	if err := associateFIPWithInstance(ctx, client, instance.ID, associateOpts); err != nil {
 		return fmt.Errorf("failed to associate public ip address %s to instance %s: %w", floatingIP.FloatingIP, instance.Name, err)
 	}
 	return nil
 }
 
-func ensureSecurityGroupRules(log logr.Logger, client openstackclient.Networking, bastion *extensionsv1alpha1.Bastion, opt *Options, infraStatus *openstackapi.InfrastructureStatus, secGroupID string) error {
+func ensureSecurityGroupRules(ctx context.Context, log logr.Logger, client openstackclient.Networking, bastion *extensionsv1alpha1.Bastion, opt *Options, infraStatus *openstackapi.InfrastructureStatus, secGroupID string) error {
 	ingressPermissions, err := ingressPermissions(bastion)
 	if err != nil {
 		return err
@@ -356,7 +368,7 @@ func ensureSecurityGroupRules(log logr.Logger, client openstackclient.Networking
 	}
 	wantedRules = append(wantedRules, EgressAllowSSHToWorker(opt, secGroupID, infraStatus.SecurityGroups[0].ID))
 
-	currentRules, err := listRules(client, secGroupID)
+	currentRules, err := listRules(ctx, client, secGroupID)
 	if err != nil {
 		return fmt.Errorf("failed to list rules: %w", err)
 	}
@@ -364,13 +376,13 @@ func ensureSecurityGroupRules(log logr.Logger, client openstackclient.Networking
 	rulesToAdd, rulesToDelete := rulesSymmetricDifference(wantedRules, currentRules)
 
 	for _, rule := range rulesToAdd {
-		if err := createSecurityGroupRuleIfNotExist(log, client, rule); err != nil {
+		if err := createSecurityGroupRuleIfNotExist(ctx, log, client, rule); err != nil {
 			return fmt.Errorf("failed to add security group rule %s: %w", rule.Description, err)
 		}
 	}
 
 	for _, rule := range rulesToDelete {
-		if err := deleteRule(client, rule.ID); err != nil {
+		if err := deleteRule(ctx, client, rule.ID); err != nil {
 			if openstackclient.IsNotFoundError(err) {
 				continue
 			}
@@ -452,8 +464,8 @@ func ruleEqual(a rules.CreateOpts, b rules.SecGroupRule) bool {
 	return true
 }
 
-func createSecurityGroupRuleIfNotExist(log logr.Logger, client openstackclient.Networking, createOpts rules.CreateOpts) error {
-	if _, err := createRules(client, createOpts); err != nil {
+func createSecurityGroupRuleIfNotExist(ctx context.Context, log logr.Logger, client openstackclient.Networking, createOpts rules.CreateOpts) error {
+	if _, err := createRules(ctx, client, createOpts); err != nil {
 		if _, ok := err.(gophercloud.ErrDefault409); ok {
 			return nil
 		}
@@ -463,17 +475,20 @@ func createSecurityGroupRuleIfNotExist(log logr.Logger, client openstackclient.N
 	return nil
 }
 
-func ensureSecurityGroup(log logr.Logger, client openstackclient.Networking, opt *Options) (groups.SecGroup, error) {
-	securityGroups, err := getSecurityGroups(client, opt.SecurityGroup)
+func ensureSecurityGroup(ctx context.Context, log logr.Logger, client openstackclient.Networking, opt *Options) (groups.SecGroup, error) {
+	securityGroups, err := getSecurityGroups(ctx, client, opt.SecurityGroup)
 	if err != nil {
 		return groups.SecGroup{}, err
 	}
 
 	if len(securityGroups) != 0 {
+		if err := ensureTags(ctx, client, openstackclient.ResourceTypeSecurityGroups, securityGroups[0].ID, securityGroups[0].Tags, opt); err != nil {
+			return groups.SecGroup{}, err
+		}
 		return securityGroups[0], nil
 	}
 
-	result, err := createSecurityGroup(client, groups.CreateOpts{
+	result, err := createSecurityGroup(ctx, client, groups.CreateOpts{
 		Name:        opt.SecurityGroup,
 		Description: opt.SecurityGroup,
 	})
@@ -482,16 +497,31 @@ func ensureSecurityGroup(log logr.Logger, client openstackclient.Networking, opt
 	}
 
 	log.Info("Security Group created", "security group", result.Name)
+	if err := ensureTags(ctx, client, openstackclient.ResourceTypeSecurityGroups, result.ID, result.Tags, opt); err != nil {
+		return groups.SecGroup{}, err
+	}
 	return *result, nil
 }
 
-func ensureShootWorkerSecurityGroupRules(log logr.Logger, client openstackclient.Networking, opt *Options, infraStatus *openstackapi.InfrastructureStatus, secGroupID string) error {
+// ensureTags sets the tags of the extension on a Neutron resource of the bastion, keeping all other tags.
+func ensureTags(ctx context.Context, client openstackclient.Networking, resourceType, id string, current []string, opt *Options) error {
+	tags, modified := openstack.MergeResourceTags(current, openstack.ResourceTags(opt.ShootName, opt.SeedName, openstack.TagPurposeBastion))
+	if !modified {
+		return nil
+	}
+	if _, err := client.ReplaceAllTags(ctx, resourceType, id, tags); err != nil {
+		return fmt.Errorf("failed to update tags of %s %s: %w", resourceType, id, err)
+	}
+	return nil
+}
+
+func ensureShootWorkerSecurityGroupRules(ctx context.Context, log logr.Logger, client openstackclient.Networking, opt *Options, infraStatus *openstackapi.InfrastructureStatus, secGroupID string) error {
 	if len(infraStatus.SecurityGroups) == 0 {
 		return errors.New("shoot security groups not found")
 	}
 
 	allowSSHRule := IngressAllowSSH(opt, rules.EtherType4, infraStatus.SecurityGroups[0].ID, "", secGroupID)
-	if err := createSecurityGroupRuleIfNotExist(log, client, allowSSHRule); err != nil {
+	if err := createSecurityGroupRuleIfNotExist(ctx, log, client, allowSSHRule); err != nil {
 		return fmt.Errorf("failed to add shoot worker security group rule for %s: %w", allowSSHRule.Description, err)
 	}
 	return nil
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bastion
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/go-logr/logr"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/install"
+	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+var _ = Describe("Actuator", func() {
+	var (
+		ctx        context.Context
+		cloud      *fake.Cloud
+		networking openstackclient.Networking
+		compute    openstackclient.Compute
+		c          client.Client
+		a          *actuator
+		cluster    *controller.Cluster
+		bastion    *extensionsv1alpha1.Bastion
+		opt        *Options
+
+		workerSecurityGroupID string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cloud = fake.NewCloud()
+		cloud.AddFlavor("m1.small")
+		cloud.AddImage("gardenlinux")
+		externalNetworkID := cloud.AddExternalNetwork("public")
+		cloud.AddSubnet(externalNetworkID, "public-subnet", "172.24.4.0/24")
+
+		var err error
+		networking, err = cloud.Factory().Networking()
+		Expect(err).NotTo(HaveOccurred())
+		compute, err = cloud.Factory().Compute()
+		Expect(err).NotTo(HaveOccurred())
+
+		// the resources of the shoot are created by the infrastructure
+		cluster = createOpenstackTestCluster()
+		cluster.Shoot.Name = "shoot"
+		cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw = []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","floatingPoolName":"public","networks":{"workers":"10.250.0.0/16"}}`)
+		router, err := networking.CreateRouter(ctx, routers.CreateOpts{Name: cluster.ObjectMeta.Name, GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetworkID}})
+		Expect(err).NotTo(HaveOccurred())
+		network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: cluster.ObjectMeta.Name})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, Name: cluster.ObjectMeta.Name, CIDR: "10.250.0.0/16", IPVersion: 4})
+		Expect(err).NotTo(HaveOccurred())
+		workerSecurityGroup, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: cluster.ObjectMeta.Name})
+		Expect(err).NotTo(HaveOccurred())
+		workerSecurityGroupID = workerSecurityGroup.ID
+
+		infraStatus, err := json.Marshal(&openstackv1alpha1.InfrastructureStatus{
+			TypeMeta: metav1.TypeMeta{APIVersion: openstackv1alpha1.SchemeGroupVersion.String(), Kind: "InfrastructureStatus"},
+			Networks: openstackv1alpha1.NetworkStatus{
+				ID:           network.ID,
+				Router:       openstackv1alpha1.RouterStatus{ID: router.ID},
+				FloatingPool: openstackv1alpha1.FloatingPoolStatus{ID: externalNetworkID},
+			},
+			SecurityGroups: []openstackv1alpha1.SecurityGroup{{Purpose: openstackv1alpha1.PurposeNodes, ID: workerSecurityGroupID, Name: workerSecurityGroup.Name}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		bastion = createTestBastion()
+		bastion.Namespace = cluster.ObjectMeta.Name
+		opt, err = DetermineOptions(bastion, cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		c = fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithStatusSubresource(&extensionsv1alpha1.Bastion{}).
+			WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: cluster.ObjectMeta.Name, Name: v1beta1constants.SecretNameCloudProvider},
+					Data: map[string][]byte{
+						openstack.AuthURL:    []byte("https://keystone.example.com/v3"),
+						openstack.DomainName: []byte("domain"),
+						openstack.TenantName: []byte("tenant"),
+						openstack.UserName:   []byte("user"),
+						openstack.Password:   []byte("password"),
+					},
+				},
+				&extensionsv1alpha1.Worker{
+					ObjectMeta: metav1.ObjectMeta{Namespace: cluster.ObjectMeta.Name, Name: cluster.Shoot.Name},
+					Spec:       extensionsv1alpha1.WorkerSpec{InfrastructureProviderStatus: &runtime.RawExtension{Raw: infraStatus}},
+				},
+				bastion,
+			).
+			Build()
+
+		scheme := runtime.NewScheme()
+		install.Install(scheme)
+		a = &actuator{
+			client:                 c,
+			decoder:                serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+			openstackClientFactory: cloud.FactoryFactory(),
+			bastionConfig:          &controllerconfig.BastionConfig{ImageRef: "gardenlinux", FlavorRef: "m1.small"},
+		}
+	})
+
+	It("should create and delete the bastion", func() {
+		// Neutron reports floating IPs without associated port as DOWN, the bastion waits for the backend to activate it
+		Expect(a.Reconcile(ctx, logr.Discard(), bastion, cluster)).To(MatchError(ContainSubstring("not ready yet")))
+		fips, err := networking.GetFipByName(ctx, opt.BastionInstanceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fips).To(HaveLen(1))
+		cloud.SetFloatingIPStatus(fips[0].ID, "ACTIVE")
+
+		Expect(a.Reconcile(ctx, logr.Discard(), bastion, cluster)).To(Succeed())
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(bastion), bastion)).To(Succeed())
+		Expect(bastion.Status.Ingress).To(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"IP": Equal(fips[0].FloatingIP)})))
+		instances, err := compute.FindServersByName(ctx, opt.BastionInstanceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instances).To(HaveLen(1))
+		fips, err = networking.GetFipByName(ctx, opt.BastionInstanceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fips).To(HaveLen(1))
+		Expect(fips[0].Tags).To(ContainElement(openstack.TagKeyTechnicalID + "=" + opt.ShootName))
+
+		securityGroups, err := networking.GetSecurityGroupByName(ctx, opt.SecurityGroup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(securityGroups).To(HaveLen(1))
+		bastionRules, err := networking.ListRules(ctx, rules.ListOpts{SecGroupID: securityGroups[0].ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bastionRules).To(ConsistOf(
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Direction": Equal("ingress"), "PortRangeMin": Equal(sshPort), "RemoteIPPrefix": Equal("213.69.151.0/24")}),
+			gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Direction": Equal("egress"), "PortRangeMin": Equal(sshPort), "RemoteGroupID": Equal(workerSecurityGroupID)}),
+		))
+		workerRules, err := networking.ListRules(ctx, rules.ListOpts{SecGroupID: workerSecurityGroupID, RemoteGroupID: securityGroups[0].ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workerRules).To(HaveLen(1))
+
+		// a second reconciliation keeps the resources
+		Expect(a.Reconcile(ctx, logr.Discard(), bastion, cluster)).To(Succeed())
+		Expect(cloud.Calls("CreateServer")).To(Equal(1))
+		Expect(cloud.Calls("CreateFloatingIP")).To(Equal(1))
+
+		Expect(a.Delete(ctx, logr.Discard(), bastion, cluster)).To(Succeed())
+
+		instances, err = compute.FindServersByName(ctx, opt.BastionInstanceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instances).To(BeEmpty())
+		fips, err = networking.GetFipByName(ctx, opt.BastionInstanceName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fips).To(BeEmpty())
+		securityGroups, err = networking.GetSecurityGroupByName(ctx, opt.SecurityGroup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(securityGroups).To(BeEmpty())
+		workerRules, err = networking.ListRules(ctx, rules.ListOpts{SecGroupID: workerSecurityGroupID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workerRules).To(HaveLen(2), "only the default egress rules are left")
+	})
+})
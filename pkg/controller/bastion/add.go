@@ -34,8 +34,13 @@ type AddOptions struct {
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) error {
+	openstackClientFactory := openstackclient.NewCachingFactoryFactory(
+		openstackclient.NewFactoryFactory(openstackclient.WithController(bastion.ControllerName)),
+		openstackclient.DefaultFactoryCacheIdleTimeout,
+	)
+
 	return bastion.Add(mgr, bastion.AddArgs{
-		Actuator:          newActuator(mgr, openstackclient.FactoryFactoryFunc(openstackclient.NewOpenstackClientFromCredentials), &opts.BastionConfig),
+		Actuator:          newActuator(mgr, openstackClientFactory, &opts.BastionConfig),
 		ControllerOptions: opts.Controller,
 		Predicates:        bastion.DefaultPredicates(opts.IgnoreOperationAnnotation),
 		Type:              openstack.Type,
@@ -48,6 +48,7 @@ var _ = Describe("Bastion", func() {
 			Expect(err).To(Not(HaveOccurred()))
 
 			Expect(options.ShootName).To(Equal("cluster1"))
+			Expect(options.SeedName).To(Equal("seed1"))
 			Expect(options.BastionInstanceName).To(Equal("cluster1-bastionName1-bastion-1cdc8"))
 			Expect(options.SecretReference).To(Equal(corev1.SecretReference{
 				Namespace: "cluster1",
@@ -223,6 +224,7 @@ func createOpenstackTestCluster() *extensions.Cluster {
 	return &controller.Cluster{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
 		Shoot:      createShootTestStruct(),
+		Seed:       &gardencorev1beta1.Seed{ObjectMeta: metav1.ObjectMeta{Name: "seed1"}},
 		CloudProfile: &gardencorev1beta1.CloudProfile{
 			Spec: gardencorev1beta1.CloudProfileSpec{
 				Regions: []gardencorev1beta1.Region{
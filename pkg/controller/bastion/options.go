@@ -15,6 +15,8 @@ import (
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 )
 
 const (
@@ -30,6 +32,7 @@ type Options struct {
 	BastionInstanceName string
 	Region              string
 	ShootName           string
+	SeedName            string
 	SecretReference     corev1.SecretReference
 	SecurityGroup       string
 	UserData            []byte
@@ -53,6 +56,7 @@ func DetermineOptions(bastion *extensionsv1alpha1.Bastion, cluster *controller.C
 
 	return &Options{
 		ShootName:           clusterName,
+		SeedName:            openstack.SeedNameFromCluster(cluster),
 		BastionInstanceName: baseResourceName,
 		SecretReference:     secretReference,
 		SecurityGroup:       securityGroupName(baseResourceName),
@@ -338,7 +338,19 @@ func (vp *valuesProvider) GetConfigChartValues(
 	if err != nil {
 		return nil, fmt.Errorf("could not determine overlay status: %v", err)
 	}
-	return getConfigChartValues(cpConfig, infraStatus, cloudProfileConfig, overlayEnabled, cp, credentials)
+	values, err := getConfigChartValues(cpConfig, infraStatus, cloudProfileConfig, overlayEnabled, cp, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := helper.FindSubnetByPurpose(infraStatus.Networks.Subnets, api.PurposeNodesIPv6); err == nil {
+		values["dualStack"] = true
+		// sort the addresses of the nodes network first, so that the IPv4 node addresses remain the primary ones
+		if cluster.Shoot.Spec.Networking != nil {
+			utils.SetStringValue(values, "addressSortOrder", cluster.Shoot.Spec.Networking.Nodes)
+		}
+	}
+	return values, nil
 }
 
 func (vp *valuesProvider) getInfrastructureStatus(cp *extensionsv1alpha1.ControlPlane) (*api.InfrastructureStatus, error) {
@@ -560,6 +572,10 @@ func getConfigChartValues(
 	if len(c.CACert) > 0 {
 		values["caCert"] = c.CACert
 	}
+	utils.SetStringValue(values, "tenantID", &c.ProjectID)
+	utils.SetStringValue(values, "userDomainID", &c.UserDomainID)
+	utils.SetStringValue(values, "projectDomainID", &c.ProjectDomainID)
+	utils.SetStringValue(values, "interface", &c.Interface)
 
 	loadBalancerClassesFromCloudProfile := []api.LoadBalancerClass{}
 	if floatingPool, err := helper.FindFloatingPool(cloudProfileConfig.Constraints.FloatingPools, infraStatus.Networks.FloatingPool.Name, cp.Spec.Region, nil); err == nil {
@@ -969,7 +985,7 @@ func (vp *valuesProvider) addCSIManilaValues(
 	if infraStatus.Networks.ShareNetwork != nil {
 		shareNetworkID = infraStatus.Networks.ShareNetwork.ID
 	}
-	values["openstack"] = map[string]interface{}{
+	openstackValues := map[string]interface{}{
 		"availabilityZones":           vp.getAllWorkerPoolsZones(cluster),
 		"shareNetworkID":              shareNetworkID,
 		"shareClient":                 infrastructure.WorkersCIDR(infraConfig),
@@ -985,6 +1001,13 @@ func (vp *valuesProvider) addCSIManilaValues(
 		"tlsInsecure":                 insecure,
 		"caCert":                      caCert,
 	}
+	if credentials != nil {
+		utils.SetStringValue(openstackValues, "projectID", &credentials.ProjectID)
+		utils.SetStringValue(openstackValues, "userDomainID", &credentials.UserDomainID)
+		utils.SetStringValue(openstackValues, "projectDomainID", &credentials.ProjectDomainID)
+		utils.SetStringValue(openstackValues, "interface", &credentials.Interface)
+	}
+	values["openstack"] = openstackValues
 
 	return nil
 }
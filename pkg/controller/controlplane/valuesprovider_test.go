@@ -506,6 +506,35 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(values).To(Equal(expectedValues))
 		})
 
+		It("should return correct config chart values with clouds.yaml credentials", func() {
+			secret2 := *cpSecret
+			secret2.Data = map[string][]byte{
+				"clouds.yaml": []byte(`clouds:
+  openstack:
+    auth:
+      auth_url: ` + authURL + `
+      project_id: project-id
+      project_domain_name: domain-name
+      user_domain_id: user-domain-id
+      username: username
+      password: password
+    interface: internal
+`),
+			}
+
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(&secret2))
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"tenantName":   "",
+				"tenantID":     "project-id",
+				"userDomainID": "user-domain-id",
+				"interface":    "internal",
+			})
+			values, err := vp.GetConfigChartValues(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
 		It("should configure cloud routes when not using overlay", func() {
 			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
 			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
@@ -516,6 +545,39 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(values).To(Equal(expectedValues))
 		})
 
+		It("should configure dual-stack networking if the infrastructure has an IPv6 subnet", func() {
+			c.EXPECT().Get(ctx, cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			dualStackCP := cp.DeepCopy()
+			dualStackCP.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+				Raw: encode(&api.InfrastructureStatus{
+					Networks: api.NetworkStatus{
+						Name:         technicalID,
+						FloatingPool: api.FloatingPoolStatus{ID: "floating-network-id"},
+						Router:       api.RouterStatus{ID: "routerID"},
+						Subnets: []api.Subnet{
+							{ID: "subnet-acbd1234", Purpose: api.PurposeNodes},
+							{ID: "subnet-ipv6", Purpose: api.PurposeNodesIPv6},
+						},
+					},
+				}),
+			}
+			dualStackCluster := &extensionscontroller.Cluster{
+				ObjectMeta:   cluster.ObjectMeta,
+				CloudProfile: cluster.CloudProfile,
+				Seed:         cluster.Seed,
+				Shoot:        cluster.Shoot.DeepCopy(),
+			}
+			dualStackCluster.Shoot.Spec.Networking.Nodes = ptr.To("10.200.0.0/19")
+
+			expectedValues := utils.MergeMaps(configChartValues, map[string]interface{}{
+				"dualStack":        true,
+				"addressSortOrder": "10.200.0.0/19",
+			})
+			values, err := vp.GetConfigChartValues(ctx, dualStackCP, dualStackCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
 		It("should return correct config chart values with KeyStone CA Cert", func() {
 			secret2 := cpSecret.DeepCopy()
 			caCert := "custom-cert"
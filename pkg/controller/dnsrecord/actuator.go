@@ -46,13 +46,17 @@ func NewActuator(mgr manager.Manager, openstackClientFactory openstackclient.Fac
 }
 
 // Reconcile reconciles the DNSRecord.
-func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, dns *extensionsv1alpha1.DNSRecord, _ *extensionscontroller.Cluster) error {
+func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, dns *extensionsv1alpha1.DNSRecord, cluster *extensionscontroller.Cluster) error {
 	// Create Openstack DNS client
 	credentials, err := openstack.GetCredentials(ctx, a.client, dns.Spec.SecretRef, true)
 	if err != nil {
 		return fmt.Errorf("could not get Openstack credentials: %w", err)
 	}
-	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials)
+	factoryOptions, err := openstackclient.FactoryOptionsFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials, factoryOptions...)
 	if err != nil {
 		return util.DetermineError(fmt.Errorf("could not create Openstack client factory: %w", err), helper.KnownCodes)
 	}
@@ -84,13 +88,17 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, dns *extensio
 }
 
 // Delete deletes the DNSRecord.
-func (a *actuator) Delete(ctx context.Context, log logr.Logger, dns *extensionsv1alpha1.DNSRecord, _ *extensionscontroller.Cluster) error {
+func (a *actuator) Delete(ctx context.Context, log logr.Logger, dns *extensionsv1alpha1.DNSRecord, cluster *extensionscontroller.Cluster) error {
 	// Create Openstack DNS client
 	credentials, err := openstack.GetCredentials(ctx, a.client, dns.Spec.SecretRef, true)
 	if err != nil {
 		return fmt.Errorf("could not get Openstack credentials: %+v", err)
 	}
-	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials)
+	factoryOptions, err := openstackclient.FactoryOptionsFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	openstackClientFactory, err := a.openstackClientFactory.NewFactory(credentials, factoryOptions...)
 	if err != nil {
 		return util.DetermineError(fmt.Errorf("could not create Openstack client factory: %+v", err), helper.KnownCodes)
 	}
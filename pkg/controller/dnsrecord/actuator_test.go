@@ -6,8 +6,11 @@ package dnsrecord_test
 
 import (
 	"context"
+	"time"
 
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/dnsrecord"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	mockclient "github.com/gardener/gardener/third_party/mock/controller-runtime/client"
@@ -18,12 +21,14 @@ import (
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	. "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/dnsrecord"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
 	mockopenstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/mocks"
 )
 
@@ -154,6 +159,42 @@ var _ = Describe("Actuator", func() {
 			err := a.Reconcile(ctx, logger, dns, nil)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("should use the request timeout of the cloud profile", func() {
+			dns.Spec.Zone = ptr.To(zone)
+			cluster := &extensionscontroller.Cluster{
+				CloudProfile: &gardencorev1beta1.CloudProfile{
+					Spec: gardencorev1beta1.CloudProfileSpec{
+						ProviderConfig: &runtime.RawExtension{
+							Raw: []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","requestTimeout":"42s"}`),
+						},
+					},
+				},
+			}
+
+			c.EXPECT().Get(ctx, kutil.Key(namespace, name), gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret, _ ...client.GetOption) error {
+					*obj = *secret
+					return nil
+				},
+			)
+			openstackClientFactoryFactory.EXPECT().NewFactory(credentials, gomock.Any()).DoAndReturn(
+				func(_ *openstack.Credentials, options ...openstackclient.FactoryOption) (openstackclient.Factory, error) {
+					opts := &openstackclient.FactoryOptions{}
+					for _, option := range options {
+						option(opts)
+					}
+					Expect(opts.RequestTimeout).To(Equal(42 * time.Second))
+					return openstackClientFactory, nil
+				},
+			)
+			openstackClientFactory.EXPECT().DNS().Return(dnsClient, nil)
+			dnsClient.EXPECT().CreateOrUpdateRecordSet(ctx, zone, dnsName, string(extensionsv1alpha1.DNSRecordTypeA), []string{address}, 120).Return(nil)
+			sw.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&extensionsv1alpha1.DNSRecord{}), gomock.Any()).Return(nil)
+
+			err := a.Reconcile(ctx, logger, dns, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Describe("#Delete", func() {
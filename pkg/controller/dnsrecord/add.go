@@ -31,8 +31,13 @@ type AddOptions struct {
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
+	openstackClientFactory := openstackclient.NewCachingFactoryFactory(
+		openstackclient.NewFactoryFactory(openstackclient.WithController(dnsrecord.ControllerName)),
+		openstackclient.DefaultFactoryCacheIdleTimeout,
+	)
+
 	return dnsrecord.Add(ctx, mgr, dnsrecord.AddArgs{
-		Actuator:          NewActuator(mgr, openstackclient.FactoryFactoryFunc(openstackclient.NewOpenstackClientFromCredentials)),
+		Actuator:          NewActuator(mgr, openstackClientFactory),
 		ControllerOptions: opts.Controller,
 		Predicates:        dnsrecord.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
 		Type:              openstack.DNSType,
@@ -9,34 +9,50 @@ import (
 
 	"github.com/gardener/gardener/extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener/extensions/pkg/terraformer"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
 	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
 	infrainternal "github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
 )
 
 const (
-	// AnnotationKeyUseFlow is the annotation key used to enable reconciliation with flow instead of terraformer.
-	AnnotationKeyUseFlow = "openstack.provider.extensions.gardener.cloud/use-flow"
+	// AnnotationKeyUseFlow is the annotation key used to enable reconciliation with flow instead of terraformer. With the
+	// value "false", the Infrastructure or Shoot opts out of the flow migration policy of the seed.
+	AnnotationKeyUseFlow = openstack.UseFlowAnnotation
+	// AnnotationKeyPlan is the annotation key used to run the flow in plan mode instead of reconciling the infrastructure.
+	// The value is the name of the planned flow, i.e. "reconcile" or "delete".
+	AnnotationKeyPlan = "openstack.provider.extensions.gardener.cloud/plan"
+
+	// ConditionTypeLeftoverResources is the type of the condition reporting the resources left behind by the
+	// force-deletion of the Infrastructure.
+	ConditionTypeLeftoverResources gardencorev1beta1.ConditionType = "LeftoverResources"
 )
 
 type actuator struct {
 	client                     client.Client
 	restConfig                 *rest.Config
+	openstackClientFactory     openstackclient.FactoryFactory
 	disableProjectedTokenMount bool
+	flowMigration              config.FlowMigrationConfig
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled Infrastructure resources.
-func NewActuator(mgr manager.Manager, disableProjectedTokenMount bool) infrastructure.Actuator {
+func NewActuator(mgr manager.Manager, openstackClientFactory openstackclient.FactoryFactory, disableProjectedTokenMount bool, flowMigration config.FlowMigrationConfig) infrastructure.Actuator {
 	return &actuator{
 		disableProjectedTokenMount: disableProjectedTokenMount,
 		client:                     mgr.GetClient(),
 		restConfig:                 mgr.GetConfig(),
+		openstackClientFactory:     openstackClientFactory,
+		flowMigration:              flowMigration,
 	}
 }
 
@@ -7,14 +7,19 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/terraformer"
 	"github.com/gardener/gardener/extensions/pkg/util"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/flow"
 	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
@@ -35,11 +40,69 @@ func (a *actuator) Delete(ctx context.Context, log logr.Logger, infra *extension
 	} else {
 		err = a.deleteWithTerraformer(ctx, log, infra, cluster)
 	}
-	return util.DetermineError(err, helper.KnownCodes)
+	if err != nil {
+		return util.DetermineError(err, helper.KnownCodes)
+	}
+	if err := a.deleteFlowHistory(ctx, infra); err != nil {
+		return err
+	}
+	shootsPerBackend.forget(client.ObjectKeyFromObject(infra))
+	return nil
 }
 
-func (a *actuator) ForceDelete(_ context.Context, _ logr.Logger, _ *extensionsv1alpha1.Infrastructure, _ *extensionscontroller.Cluster) error {
-	return nil
+// ForceDelete deletes the infrastructure on a best-effort basis, when the shoot is force-deleted. The deletion does not
+// stop at the first failure, and the resources left behind are reported in the status of the Infrastructure instead of
+// blocking its deletion.
+func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) error {
+	log.Info("forceDeleteWithFlow")
+
+	state, err := a.getStateFromInfraStatus(ctx, infra)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		// the resources created by the Terraformer are looked up by their names
+		if state, err = a.migrateFromTerraformerState(ctx, log, infra); err != nil {
+			return err
+		}
+	}
+
+	flowContext, err := a.createFlowContext(ctx, log, infra, cluster, state)
+	if err != nil {
+		// e.g. the credentials are invalid, so that nothing can be deleted
+		return a.reportLeftovers(ctx, log, infra, []string{fmt.Sprintf("failed to create flow context: %s", err)})
+	}
+	leftovers, err := flowContext.ForceDelete(ctx)
+	observeFlowRun(infra, FlowOperationForceDelete, flowContext.LastRun())
+	if err != nil {
+		_ = flowContext.PersistState(ctx, true)
+		return err
+	}
+	if err := flowContext.PersistState(ctx, true); err != nil {
+		return err
+	}
+	if err := a.deleteFlowHistory(ctx, infra); err != nil {
+		return err
+	}
+	shootsPerBackend.forget(client.ObjectKeyFromObject(infra))
+	return a.reportLeftovers(ctx, log, infra, leftovers)
+}
+
+// reportLeftovers sets the condition about the resources left behind by the force-deletion.
+func (a *actuator) reportLeftovers(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, leftovers []string) error {
+	condition := v1beta1helper.GetOrInitConditionWithClock(clock.RealClock{}, infra.Status.Conditions, ConditionTypeLeftoverResources)
+	if len(leftovers) == 0 {
+		condition = v1beta1helper.UpdatedConditionWithClock(clock.RealClock{}, condition, gardencorev1beta1.ConditionFalse,
+			"NoLeftoverResources", "All resources have been deleted.")
+	} else {
+		log.Info("Force-deletion left resources behind", "leftovers", leftovers)
+		condition = v1beta1helper.UpdatedConditionWithClock(clock.RealClock{}, condition, gardencorev1beta1.ConditionTrue,
+			"LeftoverResources", "The force-deletion left resources behind: "+strings.Join(leftovers, "; "))
+	}
+
+	patch := client.MergeFrom(infra.DeepCopy())
+	infra.Status.Conditions = v1beta1helper.MergeConditions(infra.Status.Conditions, condition)
+	return a.client.Status().Patch(ctx, infra, patch)
 }
 
 func (a *actuator) deleteWithFlow(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure,
@@ -51,9 +114,12 @@ func (a *actuator) deleteWithFlow(ctx context.Context, log logr.Logger, infra *e
 		return err
 	}
 	if err = flowContext.Delete(ctx); err != nil {
+		// the history is only kept for failed deletions, as it is deleted together with the Infrastructure
+		a.recordFlowRun(ctx, log, infra, FlowOperationDelete, flowContext.LastRun())
 		_ = flowContext.PersistState(ctx, true)
 		return err
 	}
+	observeFlowRun(infra, FlowOperationDelete, flowContext.LastRun())
 	return flowContext.PersistState(ctx, true)
 }
 
@@ -92,7 +158,11 @@ func (a *actuator) deleteWithTerraformer(ctx context.Context, log logr.Logger, i
 		return util.DetermineError(err, helper.KnownCodes)
 	}
 
-	openstackClient, err := openstackclient.NewOpenstackClientFromCredentials(credentials)
+	factoryOptions, err := openstackclient.FactoryOptionsFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	openstackClient, err := a.openstackClientFactory.NewFactory(credentials, factoryOptions...)
 	if err != nil {
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -12,8 +12,10 @@ import (
 	"github.com/gardener/gardener/extensions/pkg/util"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
 )
@@ -24,9 +26,32 @@ func (a *actuator) Migrate(ctx context.Context, log logr.Logger, infra *extensio
 		return err
 	}
 	if flowState != nil {
-		return nil // nothing to do if already using new flow without Terraformer
+		err = a.migrateWithFlow(ctx, log, infra, flowState)
+	} else {
+		err = a.migrateWithTerraformer(ctx, log, infra, cluster)
 	}
-	return a.migrateWithTerraformer(ctx, log, infra, cluster)
+	if err != nil {
+		return err
+	}
+	// the infrastructure is reconciled by the destination seed from now on
+	shootsPerBackend.forget(client.ObjectKeyFromObject(infra))
+	return nil
+}
+
+// migrateWithFlow prepares the state of the flow for the control plane migration. The IDs of all resources are kept in
+// the state, which is copied by gardener to the destination seed, where the whiteboard is rebuilt from it on restore.
+// No resources are changed in the infrastructure.
+func (a *actuator) migrateWithFlow(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, state *infraflow.PersistentState) error {
+	log.Info("migrateWithFlow")
+
+	if state.MigratedFromTerraform() && !state.TerraformCleanedUp() {
+		// the Terraformer resources in the shoot namespace are not migrated, so that they need to be removed here
+		if err := a.cleanupTerraformerResources(ctx, log, infra); err != nil {
+			return util.DetermineError(fmt.Errorf("cleaning up terraformer resources failed: %w", err), helper.KnownCodes)
+		}
+		state.SetTerraformCleanedUp()
+	}
+	return a.updateStatusState(ctx, infra, state)
 }
 
 func (a *actuator) migrateWithTerraformer(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, _ *extensionscontroller.Cluster) error {
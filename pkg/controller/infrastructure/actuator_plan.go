@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+const (
+	// PlanConfigMapSuffix is the suffix of the name of the config map containing the report of the plan mode.
+	PlanConfigMapSuffix = "-infra-plan"
+	// PlanConfigMapDataKey is the key of the JSON report in the config map of the plan mode.
+	PlanConfigMapDataKey = "plan.json"
+
+	// requeueAfterPlan is the interval in which the plan is refreshed as long as the plan annotation is present.
+	requeueAfterPlan = 5 * time.Minute
+)
+
+// planWithFlow runs the given flow in plan mode and publishes the report in a config map next to the Infrastructure.
+// The infrastructure itself is not reconciled as long as the plan annotation is present. Hence, a RequeueAfterError is
+// returned after the plan was published, so that the last operation does not report a successful reconciliation.
+func (a *actuator) planWithFlow(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure,
+	cluster *extensionscontroller.Cluster, flowName string) error {
+
+	log.Info("planWithFlow", "flow", flowName)
+
+	if flowName != infraflow.PlanFlowReconcile && flowName != infraflow.PlanFlowDelete {
+		return fmt.Errorf("unknown flow %q in annotation %s, expected %q or %q", flowName, AnnotationKeyPlan,
+			infraflow.PlanFlowReconcile, infraflow.PlanFlowDelete)
+	}
+
+	oldState, err := a.getStateFromInfraStatus(ctx, infra)
+	if err != nil {
+		return err
+	}
+	var oldFlatState shared.FlatMap
+	if oldState != nil {
+		oldFlatState = oldState.ToFlatMap()
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	config, err := helper.InfrastructureConfigFromInfrastructure(infra)
+	if err != nil {
+		return err
+	}
+	clientFactory, err := a.newClientFactory(ctx, infra, cloudProfileConfig)
+	if err != nil {
+		return err
+	}
+
+	flowContext, err := infraflow.NewPlanningFlowContext(log, clientFactory, infra, config, cloudProfileConfig,
+		openstack.SeedNameFromCluster(cluster), oldFlatState)
+	if err != nil {
+		return err
+	}
+	var plan *infraflow.Plan
+	if flowName == infraflow.PlanFlowDelete {
+		plan, err = flowContext.PlanDelete(ctx)
+	} else {
+		plan, err = flowContext.PlanReconcile(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("planning the %s flow failed: %w", flowName, err)
+	}
+
+	report, err := plan.ToJSON()
+	if err != nil {
+		return err
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infra.Namespace,
+			Name:      infra.Name + PlanConfigMapSuffix,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.client, configMap, func() error {
+		configMap.Data = map[string]string{PlanConfigMapDataKey: string(report)}
+		return controllerutil.SetControllerReference(infra, configMap, a.client.Scheme())
+	}); err != nil {
+		return fmt.Errorf("publishing the plan failed: %w", err)
+	}
+
+	log.Info("published plan, skipping reconciliation", "configMap", configMap.Name, "changes", len(plan.Changes))
+	return &reconcilerutils.RequeueAfterError{
+		RequeueAfter: requeueAfterPlan,
+		Cause: fmt.Errorf("infrastructure is not reconciled while annotation %s is present, the plan of the %s flow was published in config map %s",
+			AnnotationKeyPlan, flowName, configMap.Name),
+	}
+}
@@ -7,16 +7,22 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/terraformer"
 	"github.com/gardener/gardener/extensions/pkg/util"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
 	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
@@ -28,6 +34,9 @@ import (
 )
 
 func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) error {
+	if flowName, ok := infra.Annotations[AnnotationKeyPlan]; ok {
+		return a.planWithFlow(ctx, log, infra, cluster, flowName)
+	}
 	flowState, err := a.getStateFromInfraStatus(ctx, infra)
 	if err != nil {
 		return err
@@ -42,14 +51,14 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, infra *extens
 		}
 		return a.reconcileWithFlow(ctx, log, infra, cluster, flowState)
 	}
+	if infraConfig, err := helper.InfrastructureConfigFromInfrastructure(infra); err == nil && helper.RequiresFlow(infraConfig) {
+		// the Terraformer would silently ignore the features, the infrastructure is only migrated to the flow on request
+		return v1beta1helper.NewErrorWithCodes(fmt.Errorf("the infrastructure config uses features which are only supported by the flow-based reconciliation, "+
+			"annotate the shoot with %s=true to migrate the infrastructure to it", AnnotationKeyUseFlow), gardencorev1beta1.ErrorConfigurationProblem)
+	}
 	return a.reconcileWithTerraformer(ctx, log, infra, cluster, terraformer.StateConfigMapInitializerFunc(terraformer.CreateState))
 }
 
-func (a *actuator) shouldUseFlow(infrastructure *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) bool {
-	return (infrastructure.Annotations != nil && strings.EqualFold(infrastructure.Annotations[AnnotationKeyUseFlow], "true")) ||
-		(cluster.Shoot != nil && cluster.Shoot.Annotations != nil && strings.EqualFold(cluster.Shoot.Annotations[AnnotationKeyUseFlow], "true"))
-}
-
 func (a *actuator) getStateFromInfraStatus(_ context.Context, infrastructure *extensionsv1alpha1.Infrastructure) (*infraflow.PersistentState, error) {
 	if infrastructure.Status.State != nil {
 		return infraflow.NewPersistentStateFromJSON(infrastructure.Status.State.Raw)
@@ -60,8 +69,21 @@ func (a *actuator) getStateFromInfraStatus(_ context.Context, infrastructure *ex
 func (a *actuator) migrateFromTerraformerState(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure) (*infraflow.PersistentState, error) {
 	log.Info("starting terraform state migration")
 
-	// just explore the infrastructure objects by starting with an empty state
+	tfState, err := a.loadTerraformState(ctx, infra)
+	if err != nil {
+		return nil, fmt.Errorf("loading terraform state failed: %w", err)
+	}
+
+	// without a terraform state, just explore the infrastructure objects by starting with an empty state
 	state := infraflow.NewPersistentState()
+	if tfState != nil {
+		var report *infraflow.MigrationReport
+		state, report = infraflow.NewPersistentStateFromTerraformState(tfState)
+		log.Info("imported terraform state", "mapped", report.Mapped)
+		if len(report.Unmapped) > 0 {
+			log.Info("WARNING: resources of the terraform state could not be mapped and are not managed by the flow", "unmapped", report.Unmapped)
+		}
+	}
 
 	if err := a.updateStatusState(ctx, infra, state); err != nil {
 		return nil, fmt.Errorf("updating status state failed: %w", err)
@@ -71,16 +93,45 @@ func (a *actuator) migrateFromTerraformerState(ctx context.Context, log logr.Log
 	return state, nil
 }
 
+// loadTerraformState reads the state of the Terraformer from its ConfigMap. If the ConfigMap does not exist, e.g. on the
+// destination seed of a control plane migration, the state is read from the status of the Infrastructure instead. It
+// returns nil if there is no state.
+func (a *actuator) loadTerraformState(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) (*shared.TerraformState, error) {
+	configMap := &corev1.ConfigMap{}
+	err := a.client.Get(ctx, client.ObjectKey{Namespace: infra.Namespace, Name: infra.Name + "." + infrastructure.TerraformerPurpose + terraformer.StateSuffix}, configMap)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if err == nil && configMap.Data[terraformer.StateKey] != "" {
+		return shared.LoadTerraformStateFromConfigMapData(configMap.Data)
+	}
+
+	if infra.Status.State == nil || len(infra.Status.State.Raw) == 0 {
+		return nil, nil
+	}
+	rawState, err := terraformer.UnmarshalRawState(infra.Status.State)
+	if err != nil {
+		return nil, err
+	}
+	if rawState.Data == "" {
+		return nil, nil
+	}
+	return shared.UnmarshalTerraformStateFromTerraformer(rawState)
+}
+
 func (a *actuator) reconcileWithFlow(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure,
 	cluster *extensionscontroller.Cluster, oldState *infraflow.PersistentState) error {
 
 	log.Info("reconcileWithFlow")
+	shootsPerBackend.record(client.ObjectKeyFromObject(infra), backendFlow)
 
 	flowContext, err := a.createFlowContext(ctx, log, infra, cluster, oldState)
 	if err != nil {
 		return err
 	}
-	if err = flowContext.Reconcile(ctx); err != nil {
+	err = flowContext.Reconcile(ctx)
+	a.recordFlowRun(ctx, log, infra, FlowOperationReconcile, flowContext.LastRun())
+	if err != nil {
 		_ = flowContext.PersistState(ctx, true)
 		return util.DetermineError(err, helper.KnownCodes)
 	}
@@ -111,12 +162,7 @@ func (a *actuator) createFlowContext(ctx context.Context, log logr.Logger,
 	if err != nil {
 		return nil, err
 	}
-
-	credentials, err := openstack.GetCredentials(ctx, a.client, infra.Spec.SecretRef, false)
-	if err != nil {
-		return nil, fmt.Errorf("could not get Openstack credentials: %w", err)
-	}
-	clientFactory, err := openstackclient.NewOpenstackClientFromCredentials(credentials)
+	clientFactory, err := a.newClientFactory(ctx, infra, cloudProfileConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -136,13 +182,18 @@ func (a *actuator) createFlowContext(ctx context.Context, log logr.Logger,
 
 	var oldFlatState shared.FlatMap
 	if oldState != nil {
-		if valid, err := oldState.HasValidVersion(); !valid {
-			return nil, err
-		}
 		oldFlatState = oldState.ToFlatMap()
 	}
 
-	return infraflow.NewFlowContext(log, clientFactory, infra, config, cloudProfileConfig, oldFlatState, persistor)
+	return infraflow.NewFlowContext(log, clientFactory, infra, config, cloudProfileConfig, openstack.SeedNameFromCluster(cluster), oldFlatState, persistor)
+}
+
+func (a *actuator) newClientFactory(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, cloudProfileConfig *api.CloudProfileConfig) (openstackclient.Factory, error) {
+	credentials, err := openstack.GetCredentials(ctx, a.client, infra.Spec.SecretRef, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Openstack credentials: %w", err)
+	}
+	return a.openstackClientFactory.NewFactory(credentials, openstackclient.WithRequestTimeout(cloudProfileConfig.RequestTimeout))
 }
 
 func (a *actuator) updateStatusState(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, state *infraflow.PersistentState) error {
@@ -177,6 +228,8 @@ func (a *actuator) cleanupTerraformerResources(ctx context.Context, log logr.Log
 }
 
 func (a *actuator) reconcileWithTerraformer(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster, stateInitializer terraformer.StateConfigMapInitializer) error {
+	shootsPerBackend.record(client.ObjectKeyFromObject(infra), backendTerraformer)
+
 	config, err := helper.InfrastructureConfigFromInfrastructure(infra)
 	if err != nil {
 		return err
@@ -241,6 +294,13 @@ func computeProviderStatusFromFlowState(state *infraflow.PersistentState) (*open
 			},
 		}
 	}
+	status.Networks.Subnets = append(status.Networks.Subnets, zoneSubnetsFromFlowState(state)...)
+	if subnetID := shared.ValidValue(state.Data[infraflow.IdentifierSubnetIPv6]); subnetID != "" {
+		status.Networks.Subnets = append(status.Networks.Subnets, openstackv1alpha1.Subnet{
+			Purpose: openstackv1alpha1.PurposeNodesIPv6,
+			ID:      subnetID,
+		})
+	}
 
 	secGroupID := shared.ValidValue(state.Data[infraflow.IdentifierSecGroup])
 	if secGroupID != "" {
@@ -257,3 +317,29 @@ func computeProviderStatusFromFlowState(state *infraflow.PersistentState) (*open
 
 	return status, nil
 }
+
+// zoneSubnetsFromFlowState returns the zone-scoped subnets stored in the flow state sorted by zone name.
+func zoneSubnetsFromFlowState(state *infraflow.PersistentState) []openstackv1alpha1.Subnet {
+	var (
+		prefix = infraflow.ChildIdZones + shared.Separator
+		suffix = shared.Separator + infraflow.IdentifierSubnet
+		result []openstackv1alpha1.Subnet
+	)
+	for key, value := range state.Data {
+		if !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, suffix) {
+			continue
+		}
+		zone := strings.TrimSuffix(strings.TrimPrefix(key, prefix), suffix)
+		if id := shared.ValidValue(value); id != "" && !strings.Contains(zone, shared.Separator) {
+			result = append(result, openstackv1alpha1.Subnet{
+				Purpose: openstackv1alpha1.PurposeNodes,
+				ID:      id,
+				Zone:    ptr.To(zone),
+			})
+		}
+	}
+	slices.SortFunc(result, func(a, b openstackv1alpha1.Subnet) int {
+		return strings.Compare(*a.Zone, *b.Zone)
+	})
+	return result
+}
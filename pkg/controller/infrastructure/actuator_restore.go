@@ -6,6 +6,7 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/terraformer"
@@ -14,6 +15,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
 )
 
 // Restore implements infrastructure.Actuator.
@@ -23,7 +25,7 @@ func (a *actuator) Restore(ctx context.Context, log logr.Logger, infra *extensio
 		return err
 	}
 	if flowState != nil {
-		return a.reconcileWithFlow(ctx, log, infra, cluster, flowState)
+		return a.restoreWithFlow(ctx, log, infra, cluster, flowState)
 	}
 	if a.shouldUseFlow(infra, cluster) {
 		flowState, err = a.migrateFromTerraformerState(ctx, log, infra)
@@ -35,6 +37,18 @@ func (a *actuator) Restore(ctx context.Context, log logr.Logger, infra *extensio
 	return a.restoreWithTerraformer(ctx, log, infra, cluster)
 }
 
+// restoreWithFlow reconciles the infrastructure on the destination seed of a control plane migration. The whiteboard is
+// rebuilt from the state restored by gardener, so that the existing resources are found by their IDs.
+func (a *actuator) restoreWithFlow(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster, state *infraflow.PersistentState) error {
+	log.Info("restoreWithFlow")
+
+	// the provider status is not restored by gardener, but the state contains all its values
+	if err := a.updateStatusState(ctx, infra, state); err != nil {
+		return fmt.Errorf("updating status state failed: %w", err)
+	}
+	return a.reconcileWithFlow(ctx, log, infra, cluster, state)
+}
+
 func (a *actuator) restoreWithTerraformer(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
 	terraformState, err := terraformer.UnmarshalRawState(infra.Status.State)
 	if err != nil {
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/gardener/gardener/extensions/pkg/controller/infrastructure"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	mockmanager "github.com/gardener/gardener/third_party/mock/controller-runtime/manager"
+	"github.com/go-logr/logr"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	apisopenstack "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
+	. "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+var _ = Describe("Actuator", func() {
+	const (
+		sourceSeedName      = "source-seed"
+		destinationSeedName = "destination-seed"
+	)
+
+	var (
+		ctx        context.Context
+		ctrl       *gomock.Controller
+		cloud      *fake.Cloud
+		networking openstackclient.Networking
+		cluster    *extensionscontroller.Cluster
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		cloud = fake.NewCloud()
+		externalNetworkID := cloud.AddExternalNetwork(floatingPoolName)
+		cloud.AddSubnet(externalNetworkID, floatingPoolName+"-subnet", "172.24.4.0/24")
+
+		var err error
+		networking, err = cloud.Factory().Networking()
+		Expect(err).NotTo(HaveOccurred())
+
+		cloudProfileConfig, err := json.Marshal(&openstackv1alpha1.CloudProfileConfig{
+			TypeMeta: metav1.TypeMeta{APIVersion: openstackv1alpha1.SchemeGroupVersion.String(), Kind: "CloudProfileConfig"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		cluster = &extensionscontroller.Cluster{
+			CloudProfile: &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig}},
+			},
+			Seed: &gardencorev1beta1.Seed{ObjectMeta: metav1.ObjectMeta{Name: sourceSeedName}},
+		}
+	})
+
+	// newSeed returns the client and the actuator of a seed, on which the given Infrastructure exists.
+	newSeed := func(infra *extensionsv1alpha1.Infrastructure) (client.Client, infrastructure.Actuator) {
+		c := fakeclient.NewClientBuilder().
+			WithScheme(kubernetes.SeedScheme).
+			WithStatusSubresource(&extensionsv1alpha1.Infrastructure{}).
+			WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cloudprovider"},
+					Data: map[string][]byte{
+						openstack.AuthURL:    []byte(authURL),
+						openstack.DomainName: []byte(domainName),
+						openstack.TenantName: []byte(tenantName),
+						openstack.UserName:   []byte(userName),
+						openstack.Password:   []byte(password),
+					},
+				},
+				infra,
+			).
+			Build()
+
+		mgr := mockmanager.NewMockManager(ctrl)
+		mgr.EXPECT().GetClient().Return(c)
+		mgr.EXPECT().GetConfig().Return(nil)
+		return c, NewActuator(mgr, cloud.FactoryFactory(), false, config.FlowMigrationConfig{})
+	}
+
+	newInfrastructure := func() *extensionsv1alpha1.Infrastructure {
+		config, err := json.Marshal(&openstackv1alpha1.InfrastructureConfig{
+			TypeMeta:         metav1.TypeMeta{APIVersion: openstackv1alpha1.SchemeGroupVersion.String(), Kind: "InfrastructureConfig"},
+			FloatingPoolName: floatingPoolName,
+			Networks:         openstackv1alpha1.Networks{Workers: "10.250.0.0/16"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return &extensionsv1alpha1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Annotations: map[string]string{AnnotationKeyUseFlow: "true"},
+			},
+			Spec: extensionsv1alpha1.InfrastructureSpec{
+				DefaultSpec:  extensionsv1alpha1.DefaultSpec{ProviderConfig: &runtime.RawExtension{Raw: config}},
+				Region:       "eu-1",
+				SecretRef:    corev1.SecretReference{Namespace: namespace, Name: "cloudprovider"},
+				SSHPublicKey: []byte("ssh-rsa AAAA"),
+			},
+		}
+	}
+
+	getInfrastructure := func(c client.Client) *extensionsv1alpha1.Infrastructure {
+		infra := &extensionsv1alpha1.Infrastructure{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, infra)).To(Succeed())
+		return infra
+	}
+
+	getState := func(infra *extensionsv1alpha1.Infrastructure) *infraflow.PersistentState {
+		Expect(infra.Status.State).NotTo(BeNil())
+		state, err := infraflow.NewPersistentStateFromJSON(infra.Status.State.Raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).NotTo(BeNil())
+		return state
+	}
+
+	Describe("#Reconcile and #Delete", func() {
+		getFlowHistory := func(c client.Client) (*corev1.ConfigMap, error) {
+			configMap := &corev1.ConfigMap{}
+			err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name + FlowHistoryConfigMapSuffix}, configMap)
+			return configMap, err
+		}
+
+		It("should keep the history of the latest flow run", func() {
+			c, actuator := newSeed(newInfrastructure())
+			Expect(actuator.Reconcile(ctx, logr.Discard(), getInfrastructure(c), cluster)).To(Succeed())
+
+			configMap, err := getFlowHistory(c)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configMap.OwnerReferences).To(ConsistOf(HaveField("Name", name)))
+			run := &shared.FlowRun{}
+			Expect(json.Unmarshal([]byte(configMap.Data[FlowOperationReconcile]), run)).To(Succeed())
+			Expect(run.Tasks).To(ContainElement(And(
+				HaveField("Name", "ensure router interface"),
+				HaveField("Outcome", shared.TaskOutcomeSucceeded),
+			)))
+
+			Expect(actuator.Delete(ctx, logr.Discard(), getInfrastructure(c), cluster)).To(Succeed())
+
+			_, err = getFlowHistory(c)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should refuse to reconcile features requiring the flow with the Terraformer", func() {
+			infra := newInfrastructure()
+			infra.Annotations[AnnotationKeyUseFlow] = "false"
+			infra.Spec.ProviderConfig.Raw = []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","floatingPoolName":"` + floatingPoolName + `","networks":{"workers":"10.250.0.0/16","subnetID":"subnet"}}`)
+			c, actuator := newSeed(infra)
+
+			err := actuator.Reconcile(ctx, logr.Discard(), getInfrastructure(c), cluster)
+			Expect(err).To(MatchError(ContainSubstring(AnnotationKeyUseFlow + "=true")))
+			Expect(v1beta1helper.ExtractErrorCodes(err)).To(ConsistOf(gardencorev1beta1.ErrorConfigurationProblem))
+			Expect(getInfrastructure(c).Status.State).To(BeNil())
+		})
+
+		It("should publish the plan without reporting a successful reconciliation", func() {
+			infra := newInfrastructure()
+			infra.Annotations[AnnotationKeyPlan] = infraflow.PlanFlowReconcile
+			c, actuator := newSeed(infra)
+
+			err := actuator.Reconcile(ctx, logr.Discard(), getInfrastructure(c), cluster)
+			requeueErr := &reconcilerutils.RequeueAfterError{}
+			Expect(errors.As(err, &requeueErr)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring(name + PlanConfigMapSuffix)))
+
+			configMap := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name + PlanConfigMapSuffix}, configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveKey(PlanConfigMapDataKey))
+			Expect(getInfrastructure(c).Status.State).To(BeNil())
+			Expect(cloud.Calls("CreateNetwork")).To(BeZero())
+		})
+	})
+
+	Describe("#Migrate and #Restore", func() {
+		var (
+			sourceClient   client.Client
+			sourceActuator infrastructure.Actuator
+			migratedState  *infraflow.PersistentState
+		)
+
+		BeforeEach(func() {
+			sourceClient, sourceActuator = newSeed(newInfrastructure())
+			Expect(sourceActuator.Reconcile(ctx, logr.Discard(), getInfrastructure(sourceClient), cluster)).To(Succeed())
+
+			Expect(sourceActuator.Migrate(ctx, logr.Discard(), getInfrastructure(sourceClient), cluster)).To(Succeed())
+			migratedState = getState(getInfrastructure(sourceClient))
+		})
+
+		It("should keep the state of the flow on migration", func() {
+			Expect(migratedState.Data).To(HaveKeyWithValue(infraflow.IdentifierRouter, Not(BeEmpty())))
+			Expect(migratedState.Data).To(HaveKeyWithValue(infraflow.IdentifierNetwork, Not(BeEmpty())))
+			Expect(migratedState.Data).To(HaveKeyWithValue(infraflow.IdentifierSubnet, Not(BeEmpty())))
+			Expect(migratedState.Data).To(HaveKeyWithValue(infraflow.IdentifierSecGroup, Not(BeEmpty())))
+			Expect(migratedState.Data).To(HaveKeyWithValue(infraflow.NameKeyPair, namespace))
+			for _, operation := range []string{"DeleteRouter", "DeleteNetwork", "DeleteSubnet", "DeleteSecurityGroup", "DeleteKeyPair"} {
+				Expect(cloud.Calls(operation)).To(BeZero(), operation)
+			}
+		})
+
+		It("should restore the infrastructure on the destination seed with the migrated IDs", func() {
+			// the network is modified in between, so that it can only be found by its ID
+			_, err := networking.UpdateNetwork(ctx, migratedState.Data[infraflow.IdentifierNetwork], networks.UpdateOpts{Name: ptr.To("renamed")})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeNetworks, migratedState.Data[infraflow.IdentifierNetwork], []string{"foo"})
+			Expect(err).NotTo(HaveOccurred())
+
+			// gardener restores only the state on the destination seed
+			infra := newInfrastructure()
+			infra.Status.State = &runtime.RawExtension{Raw: getInfrastructure(sourceClient).Status.State.Raw}
+			destinationClient, destinationActuator := newSeed(infra)
+			cluster.Seed.Name = destinationSeedName
+
+			Expect(destinationActuator.Restore(ctx, logr.Discard(), getInfrastructure(destinationClient), cluster)).To(Succeed())
+
+			restored := getInfrastructure(destinationClient)
+			restoredState := getState(restored)
+			for _, key := range []string{infraflow.IdentifierRouter, infraflow.IdentifierNetwork, infraflow.IdentifierSubnet, infraflow.IdentifierSecGroup} {
+				Expect(restoredState.Data).To(HaveKeyWithValue(key, migratedState.Data[key]), key)
+			}
+			for _, operation := range []string{"CreateRouter", "CreateNetwork", "CreateSubnet", "CreateSecurityGroup", "CreateKeyPair", "AddRouterInterface"} {
+				Expect(cloud.Calls(operation)).To(Equal(1), operation)
+			}
+
+			Expect(restored.Status.ProviderStatus).NotTo(BeNil())
+			status := &openstackv1alpha1.InfrastructureStatus{}
+			Expect(json.Unmarshal(restored.Status.ProviderStatus.Raw, status)).To(Succeed())
+			Expect(status.Networks.ID).To(Equal(migratedState.Data[infraflow.IdentifierNetwork]))
+			Expect(status.Networks.Router.ID).To(Equal(migratedState.Data[infraflow.IdentifierRouter]))
+
+			networkList, err := networking.ListNetwork(ctx, networks.ListOpts{ID: migratedState.Data[infraflow.IdentifierNetwork]})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkList).To(HaveLen(1))
+			Expect(networkList[0].Name).To(Equal(namespace))
+			Expect(networkList[0].Tags).To(ContainElement("gardener-seed=" + destinationSeedName))
+		})
+
+		It("should refuse to restore a state of an unsupported version", func() {
+			migratedState.APIVersion = apisopenstack.GroupName + "/v1alpha0"
+			raw, err := json.Marshal(migratedState)
+			Expect(err).NotTo(HaveOccurred())
+
+			infra := newInfrastructure()
+			infra.Status.State = &runtime.RawExtension{Raw: raw}
+			destinationClient, destinationActuator := newSeed(infra)
+
+			Expect(destinationActuator.Restore(ctx, logr.Discard(), getInfrastructure(destinationClient), cluster)).To(MatchError(ContainSubstring("unsupported state version v1alpha0")))
+			Expect(cloud.Calls("CreateNetwork")).To(Equal(1))
+		})
+	})
+})
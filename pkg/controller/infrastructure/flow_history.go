@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+)
+
+const (
+	// FlowHistoryConfigMapSuffix is the suffix of the name of the config map containing the latest runs of the flows.
+	FlowHistoryConfigMapSuffix = "-infra-flow-history"
+
+	// FlowOperationReconcile is the operation of the reconcile flow. It is the label of its metrics and the key of its
+	// latest run in the history.
+	FlowOperationReconcile = "reconcile"
+	// FlowOperationDelete is the operation of the delete flow. It is the label of its metrics and the key of its latest
+	// failed run in the history.
+	FlowOperationDelete = "delete"
+	// FlowOperationForceDelete is the operation of the force-delete flow. It is the label of its metrics.
+	FlowOperationForceDelete = "force-delete"
+)
+
+// recordFlowRun observes the durations of the tasks of the given run, and stores the run as the latest one of its
+// operation in the config map next to the Infrastructure. The history is informational, so that failures to store it
+// are only logged.
+func (a *actuator) recordFlowRun(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, operation string, run *shared.FlowRun) {
+	if run == nil {
+		return
+	}
+	observeFlowRun(infra, operation, run)
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		log.Error(err, "marshalling flow run failed")
+		return
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infra.Namespace,
+			Name:      infra.Name + FlowHistoryConfigMapSuffix,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.client, configMap, func() error {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[operation] = string(data)
+		return controllerutil.SetControllerReference(infra, configMap, a.client.Scheme())
+	}); err != nil {
+		log.Error(err, "storing flow run failed", "configMap", configMap.Name)
+	}
+}
+
+// deleteFlowHistory deletes the config map with the latest runs of the flows.
+func (a *actuator) deleteFlowHistory(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infra.Namespace,
+			Name:      infra.Name + FlowHistoryConfigMapSuffix,
+		},
+	}
+	return client.IgnoreNotFound(a.client.Delete(ctx, configMap))
+}
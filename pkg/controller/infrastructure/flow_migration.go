@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"hash/fnv"
+	"strings"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+)
+
+// shouldUseFlow decides whether an infrastructure without flow state is reconciled with the flow instead of the
+// Terraformer. The annotation of the Infrastructure or of the Shoot takes precedence, so that single shoots can opt in
+// and out. Otherwise, new infrastructures whose configuration requires the flow use it, and the others are selected by
+// the flow migration policy of the seed. Infrastructures managed by the Terraformer are never migrated just because
+// their configuration requires the flow.
+func (a *actuator) shouldUseFlow(infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) bool {
+	if useFlow, ok := useFlowFromAnnotations(infra, cluster); ok {
+		return useFlow
+	}
+	if infraConfig, err := helper.InfrastructureConfigFromInfrastructure(infra); err == nil && helper.RequiresFlow(infraConfig) && infra.Status.State == nil {
+		return true
+	}
+	return selectedByFlowMigration(a.flowMigration, infra, cluster)
+}
+
+// useFlowFromAnnotations returns the value of the AnnotationKeyUseFlow annotation of the Infrastructure, or of the Shoot
+// if the Infrastructure is not annotated. The second return value is false if neither is annotated with a boolean.
+func useFlowFromAnnotations(infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) (bool, bool) {
+	annotations := []map[string]string{infra.Annotations}
+	if cluster.Shoot != nil {
+		annotations = append(annotations, cluster.Shoot.Annotations)
+	}
+	for _, a := range annotations {
+		switch strings.ToLower(a[AnnotationKeyUseFlow]) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// selectedByFlowMigration returns true if the flow migration policy selects the infrastructure for the flow.
+func selectedByFlowMigration(flowMigration config.FlowMigrationConfig, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) bool {
+	switch flowMigration.Policy {
+	case config.FlowMigrationPolicyAll:
+		return true
+	case config.FlowMigrationPolicyNewShoots:
+		// the Terraformer stores its state in the status after the first successful reconciliation
+		return infra.Status.State == nil
+	case config.FlowMigrationPolicyPercentage:
+		if cluster.Shoot == nil || flowMigration.Percentage == nil {
+			return false
+		}
+		// the hash of the UID is stable, so that the shoots selected for a percentage stay selected when it is raised
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(cluster.Shoot.UID))
+		return int64(hash.Sum32()%100) < int64(*flowMigration.Percentage)
+	default:
+		return false
+	}
+}
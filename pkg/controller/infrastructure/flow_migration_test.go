@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+)
+
+var _ = Describe("FlowMigration", func() {
+	var (
+		a       *actuator
+		infra   *extensionsv1alpha1.Infrastructure
+		cluster *extensionscontroller.Cluster
+	)
+
+	BeforeEach(func() {
+		a = &actuator{}
+		infra = &extensionsv1alpha1.Infrastructure{
+			Spec: extensionsv1alpha1.InfrastructureSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{ProviderConfig: &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","floatingPoolName":"public","networks":{"workers":"10.250.0.0/16"}}`),
+				}},
+			},
+		}
+		cluster = &extensionscontroller.Cluster{
+			Shoot: &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{UID: "a5a5b2a2-0f33-4b8e-9a5b-8d6c2b1f0e11"}},
+		}
+	})
+
+	Describe("#shouldUseFlow", func() {
+		It("should not use the flow by default", func() {
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse())
+		})
+
+		It("should use the flow for all infrastructures", func() {
+			a.flowMigration.Policy = config.FlowMigrationPolicyAll
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+		})
+
+		It("should only use the flow for new infrastructures", func() {
+			a.flowMigration.Policy = config.FlowMigrationPolicyNewShoots
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+
+			infra.Status.State = &runtime.RawExtension{Raw: []byte(`{"data":"","encoding":"none"}`)}
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse())
+		})
+
+		It("should use the flow for the percentage of shoots", func() {
+			a.flowMigration.Policy = config.FlowMigrationPolicyPercentage
+
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse())
+			a.flowMigration.Percentage = ptr.To[int32](0)
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse())
+			a.flowMigration.Percentage = ptr.To[int32](100)
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+
+			a.flowMigration.Percentage = ptr.To[int32](30)
+			selected := 0
+			for i := 0; i < 1000; i++ {
+				cluster.Shoot.UID = types.UID(fmt.Sprintf("uid-%d", i))
+				useFlow := a.shouldUseFlow(infra, cluster)
+				Expect(a.shouldUseFlow(infra, cluster)).To(Equal(useFlow), "the selection is stable")
+				if useFlow {
+					selected++
+				}
+			}
+			Expect(selected).To(BeNumerically("~", 300, 50))
+		})
+
+		It("should let the annotations take precedence over the policy", func() {
+			a.flowMigration.Policy = config.FlowMigrationPolicyAll
+			cluster.Shoot.Annotations = map[string]string{AnnotationKeyUseFlow: "false"}
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse())
+
+			infra.Annotations = map[string]string{AnnotationKeyUseFlow: "true"}
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+
+			a.flowMigration.Policy = config.FlowMigrationPolicyNever
+			infra.Annotations = nil
+			cluster.Shoot.Annotations = map[string]string{AnnotationKeyUseFlow: "True"}
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+		})
+
+		It("should use the flow for new infrastructures with configurations requiring it", func() {
+			infra.Spec.ProviderConfig.Raw = []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","floatingPoolName":"public","networks":{"workers":"10.250.0.0/16","subnetID":"subnet"}}`)
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+
+			infra.Status.State = &runtime.RawExtension{Raw: []byte(`{"data":"","encoding":"none"}`)}
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse(), "infrastructures managed by the Terraformer are only migrated on request")
+
+			cluster.Shoot.Annotations = map[string]string{AnnotationKeyUseFlow: "true"}
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeTrue())
+		})
+
+		It("should let the opt-out take precedence over configurations requiring the flow", func() {
+			infra.Annotations = map[string]string{AnnotationKeyUseFlow: "false"}
+			infra.Spec.ProviderConfig.Raw = []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"InfrastructureConfig","floatingPoolName":"public","networks":{"workers":"10.250.0.0/16","subnetID":"subnet"}}`)
+			Expect(a.shouldUseFlow(infra, cluster)).To(BeFalse())
+		})
+	})
+
+	Describe("#backendRecorder", func() {
+		It("should count the infrastructures per backend", func() {
+			gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test"}, []string{"backend"})
+			recorder := &backendRecorder{gauge: gauge, backends: map[client.ObjectKey]string{}}
+
+			recorder.record(client.ObjectKey{Namespace: "shoot--foo--bar", Name: "bar"}, backendTerraformer)
+			recorder.record(client.ObjectKey{Namespace: "shoot--foo--baz", Name: "baz"}, backendTerraformer)
+			Expect(testutil.ToFloat64(gauge.WithLabelValues(backendFlow))).To(BeZero())
+			Expect(testutil.ToFloat64(gauge.WithLabelValues(backendTerraformer))).To(Equal(float64(2)))
+
+			recorder.record(client.ObjectKey{Namespace: "shoot--foo--bar", Name: "bar"}, backendFlow)
+			Expect(testutil.ToFloat64(gauge.WithLabelValues(backendFlow))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(gauge.WithLabelValues(backendTerraformer))).To(Equal(float64(1)))
+
+			recorder.forget(client.ObjectKey{Namespace: "shoot--foo--baz", Name: "baz"})
+			Expect(testutil.ToFloat64(gauge.WithLabelValues(backendFlow))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(gauge.WithLabelValues(backendTerraformer))).To(BeZero())
+		})
+	})
+})
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,40 +19,50 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
 
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
 )
 
 // NetworkingAccess provides methods for managing routers and networks
 type NetworkingAccess interface {
 	// Routers
-	CreateRouter(desired *Router) (*Router, error)
-	GetRouterByID(id string) (*Router, error)
-	GetRouterByName(name string) ([]*Router, error)
-	UpdateRouter(desired, current *Router) (modified bool, err error)
-	LookupFloatingPoolSubnetIDs(networkID, floatingPoolSubnetNameRegex string) ([]string, error)
+	CreateRouter(ctx context.Context, desired *Router) (*Router, error)
+	GetRouterByID(ctx context.Context, id string) (*Router, error)
+	GetRouterByName(ctx context.Context, name string) ([]*Router, error)
+	GetRouterByTags(ctx context.Context, tags []string) ([]*Router, error)
+	UpdateRouter(ctx context.Context, desired, current *Router) (modified bool, err error)
+	LookupFloatingPoolSubnetIDs(ctx context.Context, networkID, floatingPoolSubnetNameRegex string) ([]string, error)
 	AddRouterInterfaceAndWait(ctx context.Context, routerID, subnetID string) error
-	GetRouterInterfacePortID(routerID, subnetID string) (portID *string, err error)
+	GetRouterInterfacePortID(ctx context.Context, routerID, subnetID string) (portID *string, err error)
+	GetRouterIDsOfSubnet(ctx context.Context, subnetID string) ([]string, error)
 	RemoveRouterInterfaceAndWait(ctx context.Context, routerID, subnetID, portID string) error
 
 	// Networks
-	CreateNetwork(desired *Network) (*Network, error)
-	GetNetworkByID(id string) (*Network, error)
-	GetNetworkByName(name string) ([]*Network, error)
-	UpdateNetwork(desired, current *Network) (modified bool, err error)
+	CreateNetwork(ctx context.Context, desired *Network) (*Network, error)
+	GetNetworkByID(ctx context.Context, id string) (*Network, error)
+	GetNetworkByName(ctx context.Context, name string) ([]*Network, error)
+	GetNetworkByTags(ctx context.Context, tags []string) ([]*Network, error)
+	UpdateNetwork(ctx context.Context, desired, current *Network) (modified bool, err error)
 
 	// Subnets
-	CreateSubnet(desired *subnets.Subnet) (*subnets.Subnet, error)
-	GetSubnetByID(id string) (*subnets.Subnet, error)
-	GetSubnetByName(networkID, name string) ([]*subnets.Subnet, error)
-	UpdateSubnet(desired, current *subnets.Subnet) (modified bool, err error)
+	CreateSubnet(ctx context.Context, desired *subnets.Subnet) (*subnets.Subnet, error)
+	GetSubnetByID(ctx context.Context, id string) (*subnets.Subnet, error)
+	GetSubnetByName(ctx context.Context, networkID, name string) ([]*subnets.Subnet, error)
+	GetSubnetByTags(ctx context.Context, networkID string, tags []string) ([]*subnets.Subnet, error)
+	UpdateSubnet(ctx context.Context, desired, current *subnets.Subnet) (modified bool, err error)
 
 	// SecurityGroups
-	CreateSecurityGroup(desired *groups.SecGroup) (*groups.SecGroup, error)
-	GetSecurityGroupByID(id string) (*groups.SecGroup, error)
-	GetSecurityGroupByName(name string) ([]*groups.SecGroup, error)
-	UpdateSecurityGroupRules(group *groups.SecGroup, desiredRules []rules.SecGroupRule, allowDelete func(rule *rules.SecGroupRule) bool) (modified bool, err error)
+	CreateSecurityGroup(ctx context.Context, desired *groups.SecGroup) (*groups.SecGroup, error)
+	GetSecurityGroupByID(ctx context.Context, id string) (*groups.SecGroup, error)
+	GetSecurityGroupByName(ctx context.Context, name string) ([]*groups.SecGroup, error)
+	GetSecurityGroupByTags(ctx context.Context, tags []string) ([]*groups.SecGroup, error)
+	UpdateSecurityGroupRules(ctx context.Context, group *groups.SecGroup, desiredRules []rules.SecGroupRule, allowDelete func(rule *rules.SecGroupRule) bool) (modified bool, err error)
+
+	// Tags
+	UpdateTags(ctx context.Context, resourceType, id string, current, desired []string) (modified bool, err error)
 }
 
 // Router is a simplified router resource
@@ -63,6 +75,7 @@ type Router struct {
 
 	Status           string                    // only output
 	ExternalFixedIPs []routers.ExternalFixedIP // only output
+	Tags             []string                  // only output
 }
 
 // Network is a simplified network resource
@@ -72,6 +85,7 @@ type Network struct {
 	AdminStateUp bool
 
 	Status string
+	Tags   []string
 }
 
 const (
@@ -97,13 +111,13 @@ func NewNetworkingAccess(networking client.Networking, log logr.Logger) (Network
 // CreateRouter creates a router.
 // If the input router object specifies external subnet ids, the router is created in the
 // first available subnet.
-func (a *networkingAccess) CreateRouter(desired *Router) (router *Router, err error) {
+func (a *networkingAccess) CreateRouter(ctx context.Context, desired *Router) (router *Router, err error) {
 	if len(desired.ExternalSubnetIDs) == 0 {
-		return a.tryCreateRouter(desired, nil)
+		return a.tryCreateRouter(ctx, desired, nil)
 	}
 	// create router in first available subnet
 	for _, subnetID := range desired.ExternalSubnetIDs {
-		router, err = a.tryCreateRouter(desired, &subnetID)
+		router, err = a.tryCreateRouter(ctx, desired, &subnetID)
 		if err != nil && !retryOnError(a.log, err) {
 			return
 		}
@@ -114,7 +128,7 @@ func (a *networkingAccess) CreateRouter(desired *Router) (router *Router, err er
 	return
 }
 
-func (a *networkingAccess) tryCreateRouter(desired *Router, subnetID *string) (*Router, error) {
+func (a *networkingAccess) tryCreateRouter(ctx context.Context, desired *Router, subnetID *string) (*Router, error) {
 	options := routers.CreateOpts{
 		Name: desired.Name,
 		GatewayInfo: &routers.GatewayInfo{
@@ -125,7 +139,7 @@ func (a *networkingAccess) tryCreateRouter(desired *Router, subnetID *string) (*
 	if subnetID != nil {
 		options.GatewayInfo.ExternalFixedIPs = []routers.ExternalFixedIP{{SubnetID: *subnetID}}
 	}
-	raw, err := a.networking.CreateRouter(options)
+	raw, err := a.networking.CreateRouter(ctx, options)
 	if err != nil {
 		return nil, err
 	}
@@ -133,8 +147,8 @@ func (a *networkingAccess) tryCreateRouter(desired *Router, subnetID *string) (*
 }
 
 // GetRouterByID retrieves router by identifier
-func (a *networkingAccess) GetRouterByID(id string) (*Router, error) {
-	routers, err := a.networking.ListRouters(routers.ListOpts{ID: id})
+func (a *networkingAccess) GetRouterByID(ctx context.Context, id string) (*Router, error) {
+	routers, err := a.networking.ListRouters(ctx, routers.ListOpts{ID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -145,8 +159,21 @@ func (a *networkingAccess) GetRouterByID(id string) (*Router, error) {
 }
 
 // GetRouterByName retrieves routers by name
-func (a *networkingAccess) GetRouterByName(name string) ([]*Router, error) {
-	routers, err := a.networking.ListRouters(routers.ListOpts{Name: name})
+func (a *networkingAccess) GetRouterByName(ctx context.Context, name string) ([]*Router, error) {
+	routers, err := a.networking.ListRouters(ctx, routers.ListOpts{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var result []*Router
+	for _, raw := range routers {
+		result = append(result, a.toRouter(&raw))
+	}
+	return result, nil
+}
+
+// GetRouterByTags retrieves routers having all given tags
+func (a *networkingAccess) GetRouterByTags(ctx context.Context, tags []string) ([]*Router, error) {
+	routers, err := a.networking.ListRouters(ctx, routers.ListOpts{Tags: strings.Join(tags, ",")})
 	if err != nil {
 		return nil, err
 	}
@@ -165,12 +192,13 @@ func (a *networkingAccess) toRouter(raw *routers.Router) *Router {
 		EnableSNAT:        raw.GatewayInfo.EnableSNAT,
 		Status:            raw.Status,
 		ExternalFixedIPs:  raw.GatewayInfo.ExternalFixedIPs,
+		Tags:              raw.Tags,
 	}
 	return router
 }
 
 // UpdateRouter updates the router if important fields have changed
-func (a *networkingAccess) UpdateRouter(desired, current *Router) (modified bool, err error) {
+func (a *networkingAccess) UpdateRouter(ctx context.Context, desired, current *Router) (modified bool, err error) {
 	updateOpts := routers.UpdateOpts{}
 	if desired.Name != current.Name {
 		modified = true
@@ -186,14 +214,14 @@ func (a *networkingAccess) UpdateRouter(desired, current *Router) (modified bool
 		}
 	}
 	if modified {
-		_, err = a.networking.UpdateRouter(current.ID, updateOpts)
+		_, err = a.networking.UpdateRouter(ctx, current.ID, updateOpts)
 	}
 	return
 }
 
 // AddRouterInterfaceAndWait adds router interface and waits up to
 func (a *networkingAccess) AddRouterInterfaceAndWait(ctx context.Context, routerID, subnetID string) error {
-	info, err := a.networking.AddRouterInterface(routerID, routers.AddInterfaceOpts{SubnetID: subnetID})
+	info, err := a.networking.AddRouterInterface(ctx, routerID, routers.AddInterfaceOpts{SubnetID: subnetID})
 	if err != nil {
 		return err
 	}
@@ -202,7 +230,7 @@ func (a *networkingAccess) AddRouterInterfaceAndWait(ctx context.Context, router
 			return ctx.Err()
 		}
 		time.Sleep(1 * time.Second)
-		port, err := a.networking.GetPort(info.PortID)
+		port, err := a.networking.GetPort(ctx, info.PortID)
 		if err != nil {
 			return err
 		}
@@ -218,8 +246,8 @@ func (a *networkingAccess) AddRouterInterfaceAndWait(ctx context.Context, router
 	}
 }
 
-func (a *networkingAccess) GetRouterInterfacePortID(routerID, subnetID string) (portID *string, err error) {
-	port, err := a.networking.GetRouterInterfacePort(routerID, subnetID)
+func (a *networkingAccess) GetRouterInterfacePortID(ctx context.Context, routerID, subnetID string) (portID *string, err error) {
+	port, err := a.networking.GetRouterInterfacePort(ctx, routerID, subnetID)
 	if err != nil {
 		return
 	}
@@ -230,13 +258,29 @@ func (a *networkingAccess) GetRouterInterfacePortID(routerID, subnetID string) (
 	return
 }
 
+// GetRouterIDsOfSubnet returns the ids of all routers with an interface in the given subnet.
+func (a *networkingAccess) GetRouterIDsOfSubnet(ctx context.Context, subnetID string) ([]string, error) {
+	list, err := a.networking.ListPorts(ctx, ports.ListOpts{
+		DeviceOwner: "network:router_interface",
+		FixedIPs:    []ports.FixedIPOpts{{SubnetID: subnetID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var routerIDs []string
+	for _, port := range list {
+		routerIDs = append(routerIDs, port.DeviceID)
+	}
+	return routerIDs, nil
+}
+
 // RemoveRouterInterfaceAndWait removes the router interface. Either subnetID or portID must be specified
 func (a *networkingAccess) RemoveRouterInterfaceAndWait(ctx context.Context, routerID, subnetID, portID string) error {
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
-		_, err := a.networking.RemoveRouterInterface(routerID, routers.RemoveInterfaceOpts{SubnetID: subnetID, PortID: portID})
+		_, err := a.networking.RemoveRouterInterface(ctx, routerID, routers.RemoveInterfaceOpts{SubnetID: subnetID, PortID: portID})
 		if err != nil {
 			if _, ok := err.(gophercloud.ErrDefault404); ok {
 				return nil
@@ -253,8 +297,8 @@ func (a *networkingAccess) RemoveRouterInterfaceAndWait(ctx context.Context, rou
 }
 
 // LookupFloatingPoolSubnetIDs returns a list of subnet ids matching the given regex of the subnet name
-func (a *networkingAccess) LookupFloatingPoolSubnetIDs(networkID, floatingPoolSubnetNameRegex string) ([]string, error) {
-	allSubnets, err := a.networking.ListSubnets(subnets.ListOpts{
+func (a *networkingAccess) LookupFloatingPoolSubnetIDs(ctx context.Context, networkID, floatingPoolSubnetNameRegex string) ([]string, error) {
+	allSubnets, err := a.networking.ListSubnets(ctx, subnets.ListOpts{
 		NetworkID: networkID,
 	})
 	if err != nil {
@@ -289,8 +333,8 @@ func (a *networkingAccess) LookupFloatingPoolSubnetIDs(networkID, floatingPoolSu
 }
 
 // CreateNetwork creates a private network
-func (a *networkingAccess) CreateNetwork(desired *Network) (*Network, error) {
-	raw, err := a.networking.CreateNetwork(networks.CreateOpts{
+func (a *networkingAccess) CreateNetwork(ctx context.Context, desired *Network) (*Network, error) {
+	raw, err := a.networking.CreateNetwork(ctx, networks.CreateOpts{
 		AdminStateUp: &desired.AdminStateUp,
 		Name:         desired.Name,
 	})
@@ -301,8 +345,8 @@ func (a *networkingAccess) CreateNetwork(desired *Network) (*Network, error) {
 }
 
 // GetNetworkByID retrieves a network by identifer
-func (a *networkingAccess) GetNetworkByID(id string) (*Network, error) {
-	networks, err := a.networking.ListNetwork(networks.ListOpts{ID: id})
+func (a *networkingAccess) GetNetworkByID(ctx context.Context, id string) (*Network, error) {
+	networks, err := a.networking.ListNetwork(ctx, networks.ListOpts{ID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -313,8 +357,21 @@ func (a *networkingAccess) GetNetworkByID(id string) (*Network, error) {
 }
 
 // GetNetworkByName retrieves networks by name
-func (a *networkingAccess) GetNetworkByName(name string) ([]*Network, error) {
-	networks, err := a.networking.GetNetworkByName(name)
+func (a *networkingAccess) GetNetworkByName(ctx context.Context, name string) ([]*Network, error) {
+	networks, err := a.networking.GetNetworkByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Network
+	for _, raw := range networks {
+		result = append(result, a.toNetwork(&raw))
+	}
+	return result, nil
+}
+
+// GetNetworkByTags retrieves networks having all given tags
+func (a *networkingAccess) GetNetworkByTags(ctx context.Context, tags []string) ([]*Network, error) {
+	networks, err := a.networking.ListNetwork(ctx, networks.ListOpts{Tags: strings.Join(tags, ",")})
 	if err != nil {
 		return nil, err
 	}
@@ -326,7 +383,7 @@ func (a *networkingAccess) GetNetworkByName(name string) ([]*Network, error) {
 }
 
 // UpdateNetwork updates a network
-func (a *networkingAccess) UpdateNetwork(desired, current *Network) (modified bool, err error) {
+func (a *networkingAccess) UpdateNetwork(ctx context.Context, desired, current *Network) (modified bool, err error) {
 	updateOpts := networks.UpdateOpts{}
 	if desired.Name != current.Name {
 		modified = true
@@ -337,7 +394,7 @@ func (a *networkingAccess) UpdateNetwork(desired, current *Network) (modified bo
 		updateOpts.AdminStateUp = &desired.AdminStateUp
 	}
 	if modified {
-		_, err = a.networking.UpdateNetwork(current.ID, updateOpts)
+		_, err = a.networking.UpdateNetwork(ctx, current.ID, updateOpts)
 	}
 	return
 }
@@ -348,16 +405,20 @@ func (a *networkingAccess) toNetwork(raw *networks.Network) *Network {
 		Name:         raw.Name,
 		AdminStateUp: raw.AdminStateUp,
 		Status:       raw.Status,
+		Tags:         raw.Tags,
 	}
 }
 
-func (a *networkingAccess) CreateSubnet(desired *subnets.Subnet) (*subnets.Subnet, error) {
-	raw, err := a.networking.CreateSubnet(subnets.CreateOpts{
-		NetworkID:      desired.NetworkID,
-		CIDR:           desired.CIDR,
-		Name:           desired.Name,
-		IPVersion:      gophercloud.IPVersion(desired.IPVersion),
-		DNSNameservers: desired.DNSNameservers,
+func (a *networkingAccess) CreateSubnet(ctx context.Context, desired *subnets.Subnet) (*subnets.Subnet, error) {
+	raw, err := a.networking.CreateSubnet(ctx, subnets.CreateOpts{
+		NetworkID:       desired.NetworkID,
+		CIDR:            desired.CIDR,
+		Name:            desired.Name,
+		IPVersion:       gophercloud.IPVersion(desired.IPVersion),
+		DNSNameservers:  desired.DNSNameservers,
+		SubnetPoolID:    desired.SubnetPoolID,
+		IPv6AddressMode: desired.IPv6AddressMode,
+		IPv6RAMode:      desired.IPv6RAMode,
 	})
 	if err != nil {
 		return nil, err
@@ -365,8 +426,8 @@ func (a *networkingAccess) CreateSubnet(desired *subnets.Subnet) (*subnets.Subne
 	return raw, nil
 }
 
-func (a *networkingAccess) GetSubnetByID(id string) (*subnets.Subnet, error) {
-	list, err := a.networking.ListSubnets(subnets.ListOpts{ID: id})
+func (a *networkingAccess) GetSubnetByID(ctx context.Context, id string) (*subnets.Subnet, error) {
+	list, err := a.networking.ListSubnets(ctx, subnets.ListOpts{ID: id})
 	if err != nil {
 		return nil, err
 	}
@@ -376,8 +437,8 @@ func (a *networkingAccess) GetSubnetByID(id string) (*subnets.Subnet, error) {
 	return &list[0], nil
 }
 
-func (a *networkingAccess) GetSubnetByName(networkID, name string) ([]*subnets.Subnet, error) {
-	list, err := a.networking.ListSubnets(subnets.ListOpts{NetworkID: networkID, Name: name})
+func (a *networkingAccess) GetSubnetByName(ctx context.Context, networkID, name string) ([]*subnets.Subnet, error) {
+	list, err := a.networking.ListSubnets(ctx, subnets.ListOpts{NetworkID: networkID, Name: name})
 	if err != nil {
 		return nil, err
 	}
@@ -389,37 +450,50 @@ func (a *networkingAccess) GetSubnetByName(networkID, name string) ([]*subnets.S
 	return result, nil
 }
 
-func (a *networkingAccess) UpdateSubnet(desired, current *subnets.Subnet) (modified bool, err error) {
+func (a *networkingAccess) GetSubnetByTags(ctx context.Context, networkID string, tags []string) ([]*subnets.Subnet, error) {
+	list, err := a.networking.ListSubnets(ctx, subnets.ListOpts{NetworkID: networkID, Tags: strings.Join(tags, ",")})
+	if err != nil {
+		return nil, err
+	}
+	var result []*subnets.Subnet
+	for _, raw := range list {
+		tmp := raw
+		result = append(result, &tmp)
+	}
+	return result, nil
+}
+
+func (a *networkingAccess) UpdateSubnet(ctx context.Context, desired, current *subnets.Subnet) (modified bool, err error) {
 	updateOpts := subnets.UpdateOpts{}
 	if desired.Name != current.Name {
 		modified = true
 		updateOpts.Name = &desired.Name
 	}
-	if !reflect.DeepEqual(desired.DNSNameservers, current.DNSNameservers) {
+	if !slices.Equal(desired.DNSNameservers, current.DNSNameservers) {
 		modified = true
 		updateOpts.DNSNameservers = &desired.DNSNameservers
 	}
 	if modified {
-		_, err = a.networking.UpdateSubnet(current.ID, updateOpts)
+		_, err = a.networking.UpdateSubnet(ctx, current.ID, updateOpts)
 	}
 	return
 }
 
-func (a *networkingAccess) CreateSecurityGroup(desired *groups.SecGroup) (*groups.SecGroup, error) {
+func (a *networkingAccess) CreateSecurityGroup(ctx context.Context, desired *groups.SecGroup) (*groups.SecGroup, error) {
 	opts := groups.CreateOpts{
 		Name:        desired.Name,
 		Description: desired.Description,
 	}
-	return a.networking.CreateSecurityGroup(opts)
+	return a.networking.CreateSecurityGroup(ctx, opts)
 }
 
-func (a *networkingAccess) GetSecurityGroupByID(id string) (*groups.SecGroup, error) {
-	sg, err := a.networking.GetSecurityGroup(id)
+func (a *networkingAccess) GetSecurityGroupByID(ctx context.Context, id string) (*groups.SecGroup, error) {
+	sg, err := a.networking.GetSecurityGroup(ctx, id)
 	return sg, client.IgnoreNotFoundError(err)
 }
 
-func (a *networkingAccess) GetSecurityGroupByName(name string) ([]*groups.SecGroup, error) {
-	list, err := a.networking.ListSecurityGroup(groups.ListOpts{Name: name})
+func (a *networkingAccess) GetSecurityGroupByName(ctx context.Context, name string) ([]*groups.SecGroup, error) {
+	list, err := a.networking.ListSecurityGroup(ctx, groups.ListOpts{Name: name})
 	if err != nil {
 		return nil, err
 	}
@@ -431,7 +505,23 @@ func (a *networkingAccess) GetSecurityGroupByName(name string) ([]*groups.SecGro
 	return result, nil
 }
 
+func (a *networkingAccess) GetSecurityGroupByTags(ctx context.Context, tags []string) ([]*groups.SecGroup, error) {
+	list, err := a.networking.ListSecurityGroup(ctx, groups.ListOpts{Tags: strings.Join(tags, ",")})
+	if err != nil {
+		return nil, err
+	}
+	var result []*groups.SecGroup
+	for _, raw := range list {
+		tmp := raw
+		result = append(result, &tmp)
+	}
+	return result, nil
+}
+
+// UpdateSecurityGroupRules creates the missing desired rules and deletes the existing rules which are not desired if
+// allowed by allowDelete. The ids of the desired rules are set to the ids of the matching or created rules.
 func (a *networkingAccess) UpdateSecurityGroupRules(
+	ctx context.Context,
 	group *groups.SecGroup,
 	desiredRules []rules.SecGroupRule,
 	allowDelete func(rule *rules.SecGroupRule) bool,
@@ -450,7 +540,7 @@ func (a *networkingAccess) UpdateSecurityGroupRules(
 		rule := &group.Rules[i]
 		if desiredRule, _ := a.findMatchingRule(rule, desiredRules); desiredRule == nil {
 			if allowDelete == nil || allowDelete(rule) {
-				if err = a.networking.DeleteRule(rule.ID); err != nil {
+				if err = a.networking.DeleteRule(ctx, rule.ID); err != nil {
 					err = fmt.Errorf("Error deleting rule for security group %s: %s", rule.ID, err)
 					return
 				}
@@ -478,10 +568,12 @@ func (a *networkingAccess) UpdateSecurityGroupRules(
 			RemoteIPPrefix: rule.RemoteIPPrefix,
 			ProjectID:      rule.ProjectID,
 		}
-		if _, err = a.networking.CreateRule(createOpts); err != nil {
+		var created *rules.SecGroupRule
+		if created, err = a.networking.CreateRule(ctx, createOpts); err != nil {
 			err = fmt.Errorf("Error creating rule %d for security group: %s", i, err)
 			return
 		}
+		rule.ID = created.ID
 		modified = true
 	}
 	return
@@ -508,3 +600,13 @@ func (a *networkingAccess) findMatchingRule(rule *rules.SecGroupRule, desiredRul
 	}
 	return nil, false
 }
+
+// UpdateTags replaces the tags of the extension on the Neutron resource of the given type with the desired ones,
+// keeping all other tags.
+func (a *networkingAccess) UpdateTags(ctx context.Context, resourceType, id string, current, desired []string) (modified bool, err error) {
+	tags, modified := openstack.MergeResourceTags(current, desired)
+	if modified {
+		_, err = a.networking.ReplaceAllTags(ctx, resourceType, id, tags)
+	}
+	return
+}
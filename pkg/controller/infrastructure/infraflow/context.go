@@ -24,10 +24,14 @@ const (
 	IdentifierNetwork = "Network"
 	// IdentifierSubnet is the key for the subnet id
 	IdentifierSubnet = "Subnet"
+	// IdentifierSubnetIPv6 is the key for the IPv6 subnet id
+	IdentifierSubnetIPv6 = "SubnetIPv6"
 	// IdentifierFloatingNetwork is the key for the floating network id
 	IdentifierFloatingNetwork = "FloatingNetwork"
 	// IdentifierSecGroup is the key for the security group id
 	IdentifierSecGroup = "SecurityGroup"
+	// IdentifierSecGroupRules is the key for the comma-separated ids of the security group rules managed by the flow
+	IdentifierSecGroupRules = "SecurityGroupRules"
 	// IdentifierShareNetwork is the key for the share network id
 	IdentifierShareNetwork = "ShareNetwork"
 
@@ -46,6 +50,14 @@ const (
 
 	// RouterIP is the key for the router IP address
 	RouterIP = "RouterIP"
+	// RouterRoutes is the key for the comma-separated routes of the router managed by the flow, each formatted as
+	// `<destination>=<next hop>`
+	RouterRoutes = "RouterRoutes"
+	// CIDRSubnetIPv6 is the key for the CIDR of the IPv6 subnet
+	CIDRSubnetIPv6 = "SubnetIPv6CIDR"
+
+	// ChildIdZones is the key for the child whiteboard of the zone-scoped resources
+	ChildIdZones = "Zones"
 
 	// ObjectSecGroup is the key for the cached security group
 	ObjectSecGroup = "SecurityGroup"
@@ -61,6 +73,7 @@ type FlowContext struct {
 	shared.BasicFlowContext
 	state              shared.Whiteboard
 	namespace          string
+	seedName           string
 	infraSpec          extensionsv1alpha1.InfrastructureSpec
 	config             *openstackapi.InfrastructureConfig
 	cloudProfileConfig *openstackapi.CloudProfileConfig
@@ -69,12 +82,13 @@ type FlowContext struct {
 	sharedFilesystem   osclient.SharedFilesystem
 	access             access.NetworkingAccess
 	compute            osclient.Compute
+	planRecorder       *planRecorder
 }
 
 // NewFlowContext creates a new FlowContext object
 func NewFlowContext(log logr.Logger, clientFactory osclient.Factory,
 	infra *extensionsv1alpha1.Infrastructure, config *openstackapi.InfrastructureConfig,
-	cloudProfileConfig *openstackapi.CloudProfileConfig,
+	cloudProfileConfig *openstackapi.CloudProfileConfig, seedName string,
 	oldState shared.FlatMap, persistor shared.FlowStatePersistor) (*FlowContext, error) {
 
 	whiteboard := shared.NewWhiteboard()
@@ -107,6 +121,7 @@ func NewFlowContext(log logr.Logger, clientFactory osclient.Factory,
 		BasicFlowContext:   *shared.NewBasicFlowContext(log, whiteboard, persistor),
 		state:              whiteboard,
 		namespace:          infra.Namespace,
+		seedName:           seedName,
 		infraSpec:          infra.Spec,
 		config:             config,
 		cloudProfileConfig: cloudProfileConfig,
@@ -6,13 +6,16 @@ package infraflow
 
 import (
 	"context"
+	"slices"
 
 	"github.com/gardener/gardener/pkg/utils/flow"
-	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
 	"k8s.io/utils/ptr"
 
+	openstackapi "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
 	. "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 )
 
 // Delete creates and runs the flow to delete the AWS infrastructure.
@@ -21,9 +24,7 @@ func (c *FlowContext) Delete(ctx context.Context) error {
 		// nothing to do, e.g. if cluster was created with wrong credentials
 		return nil
 	}
-	g := c.buildDeleteGraph()
-	f := g.Compile()
-	if err := f.Run(ctx, flow.Opts{Log: c.Log}); err != nil {
+	if err := c.RunGraph(ctx, c.buildDeleteGraph()); err != nil {
 		return flow.Causes(err)
 	}
 	return nil
@@ -33,7 +34,8 @@ func (c *FlowContext) buildDeleteGraph() *flow.Graph {
 	g := flow.NewGraph("Openstack infrastructure destruction")
 
 	needToDeleteNetwork := c.config.Networks.ID == nil
-	needToDeleteRouter := c.config.Networks.Router == nil
+	needToDeleteRouter := !helper.UsesExistingRouter(c.config)
+	needToDeleteSubnet := !needToDeleteNetwork && c.config.Networks.SubnetID == nil
 
 	_ = c.AddTask(g, "delete ssh key pair",
 		c.deleteSSHKeyPair,
@@ -47,15 +49,28 @@ func (c *FlowContext) buildDeleteGraph() *flow.Graph {
 	recoverSubnetID := c.AddTask(g, "recover subnet ID",
 		c.recoverSubnetID,
 		Timeout(defaultTimeout))
+	recoverSubnetIPv6ID := c.AddTask(g, "recover IPv6 subnet ID",
+		c.recoverSubnetIPv6ID,
+		DoIf(c.config.Networks.IPv6 != nil), Timeout(defaultTimeout))
+	deleteRouterRoutes := c.AddTask(g, "delete router routes",
+		c.deleteRouterRoutes,
+		Timeout(defaultTimeout), Dependencies(recoverRouterID))
 	k8sRoutes := c.AddTask(g, "delete kubernetes routes",
 		func(ctx context.Context) error {
 			routerID := c.state.Get(IdentifierRouter)
 			if routerID == nil {
 				return nil
 			}
-			return infrastructure.CleanupKubernetesRoutes(ctx, c.networking, *routerID, infrastructure.WorkersCIDR(c.config))
+			workers := []string{infrastructure.WorkersCIDR(c.config)}
+			for _, zone := range c.config.Networks.Zones {
+				workers = append(workers, zone.Workers)
+			}
+			if cidr := c.state.Get(CIDRSubnetIPv6); cidr != nil {
+				workers = append(workers, *cidr)
+			}
+			return infrastructure.CleanupKubernetesRoutes(ctx, c.networking, *routerID, workers...)
 		},
-		Timeout(defaultTimeout),
+		Timeout(defaultTimeout), Dependencies(recoverSubnetIPv6ID, deleteRouterRoutes),
 	)
 	k8sLoadBalancers := c.AddTask(g, "delete kubernetes loadbalancers",
 		func(ctx context.Context) error {
@@ -74,44 +89,95 @@ func (c *FlowContext) buildDeleteGraph() *flow.Graph {
 	deleteRouterInterface := c.AddTask(g, "delete router interface",
 		c.deleteRouterInterface,
 		Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverSubnetID, k8sRoutes))
-	// subnet deletion only needed if network is given by spec
+	deleteRouterInterfaceIPv6 := c.AddTask(g, "delete IPv6 router interface",
+		c.deleteRouterInterfaceIPv6,
+		DoIf(c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverSubnetIPv6ID, k8sRoutes))
+	deleteRouterInterfaces := []flow.TaskIDer{deleteRouterInterface, deleteRouterInterfaceIPv6}
+	for _, zone := range c.config.Networks.Zones {
+		recoverZoneSubnetID := c.AddTask(g, "recover subnet ID of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.recoverZoneSubnetID(ctx, zone)
+			},
+			Kind("recover subnet ID of zone"), Timeout(defaultTimeout))
+		deleteZoneRouterInterface := c.AddTask(g, "delete router interface of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.deleteZoneRouterInterface(ctx, zone)
+			},
+			Kind("delete router interface of zone"), Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverZoneSubnetID, k8sRoutes))
+		_ = c.AddTask(g, "delete subnet of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.deleteZoneSubnet(ctx, zone)
+			},
+			Kind("delete subnet of zone"), DoIf(!needToDeleteNetwork), Timeout(defaultTimeout), Dependencies(deleteZoneRouterInterface))
+		deleteRouterInterfaces = append(deleteRouterInterfaces, deleteZoneRouterInterface)
+	}
+
+	// subnet deletion only needed if network is given by spec and the subnet is not adopted
 	_ = c.AddTask(g, "delete subnet",
 		c.deleteSubnet,
-		DoIf(!needToDeleteNetwork), Timeout(defaultTimeout), Dependencies(deleteRouterInterface, k8sLoadBalancers))
+		DoIf(needToDeleteSubnet), Timeout(defaultTimeout), Dependencies(deleteRouterInterface, k8sLoadBalancers))
+	_ = c.AddTask(g, "delete IPv6 subnet",
+		c.deleteSubnetIPv6,
+		DoIf(!needToDeleteNetwork && c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(deleteRouterInterfaceIPv6))
 	_ = c.AddTask(g, "delete network",
 		c.deleteNetwork,
-		DoIf(needToDeleteNetwork), Timeout(defaultTimeout), Dependencies(deleteRouterInterface))
+		DoIf(needToDeleteNetwork), Timeout(defaultTimeout), Dependencies(deleteRouterInterfaces...))
 	_ = c.AddTask(g, "delete router",
 		c.deleteRouter,
-		DoIf(needToDeleteRouter), Timeout(defaultTimeout), Dependencies(deleteRouterInterface))
+		DoIf(needToDeleteRouter), Timeout(defaultTimeout), Dependencies(deleteRouterInterfaces...))
 
 	return g
 }
 
 func (c *FlowContext) deleteRouter(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
-	current, err := c.findExistingRouter()
+	current, err := c.findExistingRouter(ctx)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		log.Info("deleting...", "router", current.ID)
-		if err := c.networking.DeleteRouter(current.ID); err != nil {
+		if err := c.networking.DeleteRouter(ctx, current.ID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// deleteRouterRoutes removes the routes configured in the InfrastructureConfig or managed by the flow from the router,
+// but keeps all other routes, e.g. if an existing router is used.
+func (c *FlowContext) deleteRouterRoutes(ctx context.Context) error {
+	log := c.LogFromContext(ctx)
+
+	routes := routesFromState(c.state.Get(RouterRoutes))
+	for _, route := range c.configuredRoutes() {
+		if !slices.Contains(routes, route) {
+			routes = append(routes, route)
+		}
+	}
+	routerID := c.state.Get(IdentifierRouter)
+	if len(routes) == 0 || routerID == nil {
+		return nil
+	}
+
+	if modified, err := c.updateRouterRoutes(ctx, *routerID, routes, nil); err != nil {
+		return err
+	} else if modified {
+		log.Info("deleted routes of router", "router", *routerID)
+	}
+	c.state.Set(RouterRoutes, "")
+	return nil
+}
+
 func (c *FlowContext) deleteNetwork(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
-	current, err := c.findExistingNetwork()
+	current, err := c.findExistingNetwork(ctx)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		log.Info("deleting...", "network", current.ID)
-		if err := c.networking.DeleteNetwork(current.ID); err != nil {
+		if err := c.networking.DeleteNetwork(ctx, current.ID); err != nil {
 			return err
 		}
 	}
@@ -120,22 +186,39 @@ func (c *FlowContext) deleteNetwork(ctx context.Context) error {
 }
 
 func (c *FlowContext) deleteSubnet(ctx context.Context) error {
+	return c.deleteSubnetFor(ctx, c.state.Get(IdentifierSubnet), c.namespace, openstack.TagPurposeNodes)
+}
+
+func (c *FlowContext) deleteSubnetIPv6(ctx context.Context) error {
+	return c.deleteSubnetFor(ctx, c.state.Get(IdentifierSubnetIPv6), c.subnetNameIPv6(), openstack.TagPurposeNodesIPv6)
+}
+
+func (c *FlowContext) deleteZoneSubnet(ctx context.Context, zone openstackapi.Zone) error {
+	zoneState := c.state.GetChild(ChildIdZones).GetChild(zone.Name)
+	if err := c.deleteSubnetFor(ctx, zoneState.Get(IdentifierSubnet), c.zoneSubnetName(zone.Name), openstack.TagPurposeZone(zone.Name)); err != nil {
+		return err
+	}
+	zoneState.Set(IdentifierSubnet, "")
+	return nil
+}
+
+func (c *FlowContext) deleteSubnetFor(ctx context.Context, id *string, name, purpose string) error {
 	log := c.LogFromContext(ctx)
-	current, err := c.findExistingSubnet()
+	current, err := c.findExistingSubnetFor(ctx, id, name, purpose)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		log.Info("deleting...", "subnet", current.ID)
-		if err := c.networking.DeleteSubnet(current.ID); err != nil {
+		if err := c.networking.DeleteSubnet(ctx, current.ID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *FlowContext) recoverRouterID(_ context.Context) error {
-	if c.config.Networks.Router != nil {
+func (c *FlowContext) recoverRouterID(ctx context.Context) error {
+	if helper.UsesExistingRouter(c.config) {
 		c.state.Set(IdentifierRouter, c.config.Networks.Router.ID)
 		return nil
 	}
@@ -143,7 +226,7 @@ func (c *FlowContext) recoverRouterID(_ context.Context) error {
 	if routerID != nil {
 		return nil
 	}
-	router, err := c.findExistingRouter()
+	router, err := c.findExistingRouter(ctx)
 	if err != nil {
 		return err
 	}
@@ -153,12 +236,16 @@ func (c *FlowContext) recoverRouterID(_ context.Context) error {
 	return nil
 }
 
-func (c *FlowContext) recoverSubnetID(_ context.Context) error {
+func (c *FlowContext) recoverSubnetID(ctx context.Context) error {
+	if c.config.Networks.SubnetID != nil {
+		c.state.Set(IdentifierSubnet, *c.config.Networks.SubnetID)
+		return nil
+	}
 	if c.state.Get(IdentifierSubnet) != nil {
 		return nil
 	}
 
-	subnet, err := c.findExistingSubnet()
+	subnet, err := c.findExistingSubnet(ctx)
 	if err != nil {
 		return err
 	}
@@ -168,17 +255,62 @@ func (c *FlowContext) recoverSubnetID(_ context.Context) error {
 	return nil
 }
 
+func (c *FlowContext) recoverSubnetIPv6ID(ctx context.Context) error {
+	if c.state.Get(IdentifierSubnetIPv6) != nil {
+		return nil
+	}
+
+	subnet, err := c.findExistingSubnetFor(ctx, nil, c.subnetNameIPv6(), openstack.TagPurposeNodesIPv6)
+	if err != nil {
+		return err
+	}
+	if subnet != nil {
+		c.state.Set(IdentifierSubnetIPv6, subnet.ID)
+		c.state.Set(CIDRSubnetIPv6, subnet.CIDR)
+	}
+	return nil
+}
+
+func (c *FlowContext) recoverZoneSubnetID(ctx context.Context, zone openstackapi.Zone) error {
+	zoneState := c.state.GetChild(ChildIdZones).GetChild(zone.Name)
+	if zoneState.Get(IdentifierSubnet) != nil {
+		return nil
+	}
+
+	subnet, err := c.findExistingSubnetFor(ctx, nil, c.zoneSubnetName(zone.Name), openstack.TagPurposeZone(zone.Name))
+	if err != nil {
+		return err
+	}
+	if subnet != nil {
+		zoneState.Set(IdentifierSubnet, subnet.ID)
+	}
+	return nil
+}
+
+// deleteRouterInterface deletes the interface of the subnet on the router of the shoot. The interfaces of an adopted
+// subnet on other routers are kept.
 func (c *FlowContext) deleteRouterInterface(ctx context.Context) error {
+	return c.deleteRouterInterfaceForSubnet(ctx, c.state.Get(IdentifierSubnet))
+}
+
+func (c *FlowContext) deleteRouterInterfaceIPv6(ctx context.Context) error {
+	return c.deleteRouterInterfaceForSubnet(ctx, c.state.Get(IdentifierSubnetIPv6))
+}
+
+func (c *FlowContext) deleteZoneRouterInterface(ctx context.Context, zone openstackapi.Zone) error {
+	return c.deleteRouterInterfaceForSubnet(ctx, c.state.GetChild(ChildIdZones).GetChild(zone.Name).Get(IdentifierSubnet))
+}
+
+func (c *FlowContext) deleteRouterInterfaceForSubnet(ctx context.Context, subnetID *string) error {
 	routerID := c.state.Get(IdentifierRouter)
 	if routerID == nil {
 		return nil
 	}
-	subnetID := c.state.Get(IdentifierSubnet)
 	if subnetID == nil {
 		return nil
 	}
 
-	portID, err := c.access.GetRouterInterfacePortID(*routerID, *subnetID)
+	portID, err := c.access.GetRouterInterfacePortID(ctx, *routerID, *subnetID)
 	if err != nil {
 		return err
 	}
@@ -197,30 +329,31 @@ func (c *FlowContext) deleteRouterInterface(ctx context.Context) error {
 
 func (c *FlowContext) deleteSecGroup(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
-	current, err := findExisting(c.state.Get(IdentifierSecGroup), c.namespace, c.access.GetSecurityGroupByID, c.access.GetSecurityGroupByName)
+	current, err := c.findExistingSecGroup(ctx)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		log.Info("deleting...", "securityGroup", current.ID)
-		if err := c.networking.DeleteSecurityGroup(current.ID); err != nil {
+		if err := c.networking.DeleteSecurityGroup(ctx, current.ID); err != nil {
 			return err
 		}
 	}
 	c.state.Set(NameSecGroup, "")
+	c.state.Set(IdentifierSecGroupRules, "")
 	c.state.SetObject(ObjectSecGroup, nil)
 	return nil
 }
 
 func (c *FlowContext) deleteSSHKeyPair(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
-	current, err := c.compute.GetKeyPair(c.namespace)
+	current, err := c.compute.GetKeyPair(ctx, c.namespace)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		log.Info("deleting...")
-		if err := c.compute.DeleteKeyPair(current.Name); err != nil {
+		if err := c.compute.DeleteKeyPair(ctx, current.Name); err != nil {
 			return err
 		}
 	}
@@ -231,26 +364,13 @@ func (c *FlowContext) deleteShareNetwork(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
 	networkID := ptr.Deref(c.state.Get(IdentifierNetwork), "")
 	subnetID := ptr.Deref(c.state.Get(IdentifierSubnet), "")
-	current, err := findExisting(c.state.Get(IdentifierShareNetwork),
-		c.namespace,
-		c.sharedFilesystem.GetShareNetwork,
-		func(_ string) ([]*sharenetworks.ShareNetwork, error) {
-			list, err := c.sharedFilesystem.ListShareNetworks(sharenetworks.ListOpts{
-				AllTenants:      false,
-				NeutronNetID:    networkID,
-				NeutronSubnetID: subnetID,
-			})
-			if err != nil {
-				return nil, err
-			}
-			return sliceToPtr(list), nil
-		})
+	current, err := c.findExistingShareNetwork(ctx, networkID, subnetID)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		log.Info("deleting...", "shareNetwork", current.ID)
-		if err := c.sharedFilesystem.DeleteShareNetwork(current.ID); err != nil {
+		if err := c.sharedFilesystem.DeleteShareNetwork(ctx, current.ID); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,829 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	computefip "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	openstackapi "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+var _ = Describe("FlowContext", func() {
+	const (
+		namespace = "shoot--foo--bar"
+		seedName  = "seed"
+	)
+
+	var (
+		ctx        context.Context
+		cloud      *fake.Cloud
+		networking openstackclient.Networking
+		infra      *extensionsv1alpha1.Infrastructure
+		config     *openstackapi.InfrastructureConfig
+		state      shared.FlatMap
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cloud = fake.NewCloud()
+		externalNetworkID := cloud.AddExternalNetwork("public")
+		cloud.AddSubnet(externalNetworkID, "public-subnet", "172.24.4.0/24")
+
+		var err error
+		networking, err = cloud.Factory().Networking()
+		Expect(err).NotTo(HaveOccurred())
+
+		infra = &extensionsv1alpha1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "infrastructure"},
+			Spec: extensionsv1alpha1.InfrastructureSpec{
+				Region:       "eu-1",
+				SSHPublicKey: []byte("ssh-rsa AAAA"),
+			},
+		}
+		config = &openstackapi.InfrastructureConfig{
+			FloatingPoolName: "public",
+			Networks:         openstackapi.Networks{Workers: "10.250.0.0/16"},
+		}
+		state = nil
+	})
+
+	newFlowContext := func() *infraflow.FlowContext {
+		flowContext, err := infraflow.NewFlowContext(logr.Discard(), cloud.Factory(), infra, config, &openstackapi.CloudProfileConfig{}, seedName, state,
+			func(_ context.Context, flatMap shared.FlatMap) error {
+				state = flatMap
+				return nil
+			})
+		Expect(err).NotTo(HaveOccurred())
+		return flowContext
+	}
+
+	// reconcileInfrastructure and deleteInfrastructure persist the state at the end like the actuator does.
+	reconcileInfrastructure := func() error {
+		flowContext := newFlowContext()
+		err := flowContext.Reconcile(ctx)
+		Expect(flowContext.PersistState(ctx, true)).To(Succeed())
+		return err
+	}
+	deleteInfrastructure := func() error {
+		flowContext := newFlowContext()
+		err := flowContext.Delete(ctx)
+		Expect(flowContext.PersistState(ctx, true)).To(Succeed())
+		return err
+	}
+
+	It("should reconcile and delete the infrastructure", func() {
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		Expect(state).To(HaveKeyWithValue(infraflow.IdentifierRouter, Not(BeEmpty())))
+		Expect(state).To(HaveKeyWithValue(infraflow.RouterIP, "172.24.4.2"))
+		Expect(state).To(HaveKeyWithValue(infraflow.NameKeyPair, namespace))
+		subnetID := state[infraflow.IdentifierSubnet]
+		port, err := networking.GetRouterInterfacePort(ctx, state[infraflow.IdentifierRouter], subnetID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(port).NotTo(BeNil())
+		group, err := networking.GetSecurityGroup(ctx, state[infraflow.IdentifierSecGroup])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.Rules).To(HaveLen(5))
+
+		By("reconciling again without creating resources")
+		Expect(reconcileInfrastructure()).To(Succeed())
+		for _, operation := range []string{"CreateRouter", "CreateNetwork", "CreateSubnet", "CreateSecurityGroup", "CreateKeyPair", "AddRouterInterface"} {
+			Expect(cloud.Calls(operation)).To(Equal(1), operation)
+		}
+
+		By("deleting the infrastructure and orphaned load balancers")
+		cloud.AddLoadBalancer(loadbalancers.LoadBalancer{Name: "kube_service_" + namespace + "_default_nginx", VipSubnetID: subnetID})
+		Expect(deleteInfrastructure()).To(Succeed())
+
+		routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerList).To(BeEmpty())
+		networkList, err := networking.GetNetworkByName(ctx, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(networkList).To(BeEmpty())
+		groupList, err := networking.ListSecurityGroup(ctx, groups.ListOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(groupList).To(BeEmpty())
+	})
+
+	It("should reconcile and delete an IPv6 subnet", func() {
+		config.Networks.IPv6 = &openstackapi.IPv6Network{SubnetPoolID: ptr.To(cloud.AddSubnetPool("ipv6", "2001:db8::/56", 64))}
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		Expect(state).To(HaveKeyWithValue(infraflow.CIDRSubnetIPv6, "2001:db8::/64"))
+		subnetList, err := networking.ListSubnets(ctx, subnets.ListOpts{ID: state[infraflow.IdentifierSubnetIPv6]})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subnetList).To(HaveLen(1))
+		subnet := subnetList[0]
+		Expect(subnet.NetworkID).To(Equal(state[infraflow.IdentifierNetwork]))
+		Expect(subnet.IPVersion).To(Equal(6))
+		Expect(subnet.IPv6AddressMode).To(Equal("slaac"))
+		Expect(subnet.IPv6RAMode).To(Equal("slaac"))
+		port, err := networking.GetRouterInterfacePort(ctx, state[infraflow.IdentifierRouter], subnet.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(port).NotTo(BeNil())
+		group, err := networking.GetSecurityGroup(ctx, state[infraflow.IdentifierSecGroup])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.Rules).To(HaveLen(8))
+		Expect(group.Rules).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"EtherType":      Equal("IPv6"),
+			"Protocol":       Equal("tcp"),
+			"RemoteIPPrefix": Equal("::/0"),
+		})))
+
+		By("reconciling again without creating resources")
+		Expect(reconcileInfrastructure()).To(Succeed())
+		Expect(cloud.Calls("CreateSubnet")).To(Equal(2))
+		Expect(cloud.Calls("AddRouterInterface")).To(Equal(2))
+
+		By("deleting the infrastructure")
+		Expect(deleteInfrastructure()).To(Succeed())
+
+		routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerList).To(BeEmpty())
+		networkList, err := networking.GetNetworkByName(ctx, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(networkList).To(BeEmpty())
+	})
+
+	It("should reconcile and delete the subnets of the zones", func() {
+		config.Networks.Zones = []openstackapi.Zone{
+			{Name: "eu-1a", Workers: "10.251.0.0/19"},
+			{Name: "eu-1b", Workers: "10.251.32.0/19"},
+		}
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		for _, zone := range config.Networks.Zones {
+			key := infraflow.ChildIdZones + shared.Separator + zone.Name + shared.Separator + infraflow.IdentifierSubnet
+			Expect(state).To(HaveKeyWithValue(key, Not(BeEmpty())), zone.Name)
+			subnetList, err := networking.ListSubnets(ctx, subnets.ListOpts{ID: state[key]})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnetList).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":      Equal(namespace + "-" + zone.Name),
+				"NetworkID": Equal(state[infraflow.IdentifierNetwork]),
+				"CIDR":      Equal(zone.Workers),
+			})))
+			port, err := networking.GetRouterInterfacePort(ctx, state[infraflow.IdentifierRouter], state[key])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port).NotTo(BeNil())
+		}
+
+		By("reconciling again without creating resources")
+		Expect(reconcileInfrastructure()).To(Succeed())
+		Expect(cloud.Calls("CreateSubnet")).To(Equal(3))
+		Expect(cloud.Calls("AddRouterInterface")).To(Equal(3))
+
+		By("deleting the infrastructure")
+		Expect(deleteInfrastructure()).To(Succeed())
+
+		routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerList).To(BeEmpty())
+		networkList, err := networking.GetNetworkByName(ctx, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(networkList).To(BeEmpty())
+	})
+
+	It("should reconcile the user-defined security group rules", func() {
+		config.Networks.SecurityGroupRules = []openstackapi.SecurityGroupRule{
+			{
+				Direction:    openstackapi.SecurityGroupRuleDirectionIngress,
+				Protocol:     ptr.To("tcp"),
+				PortRangeMin: ptr.To[int32](30000),
+				PortRangeMax: ptr.To[int32](32767),
+				RemoteCIDR:   ptr.To("192.168.0.0/16"),
+			},
+			{
+				Direction:    openstackapi.SecurityGroupRuleDirectionIngress,
+				Protocol:     ptr.To("tcp"),
+				PortRangeMin: ptr.To[int32](9100),
+				RemoteCIDR:   ptr.To("10.0.0.0/8"),
+			},
+		}
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		group, err := networking.GetSecurityGroup(ctx, state[infraflow.IdentifierSecGroup])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.Rules).To(HaveLen(7))
+		Expect(group.Rules).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"Protocol":       Equal("tcp"),
+			"PortRangeMin":   Equal(9100),
+			"PortRangeMax":   Equal(9100),
+			"RemoteIPPrefix": Equal("10.0.0.0/8"),
+		})))
+
+		By("removing a rule and the default NodePort rules")
+		_, err = networking.CreateRule(ctx, rules.CreateOpts{
+			SecGroupID:     group.ID,
+			Direction:      rules.DirIngress,
+			EtherType:      rules.EtherType4,
+			Protocol:       rules.ProtocolTCP,
+			PortRangeMin:   22,
+			PortRangeMax:   22,
+			RemoteIPPrefix: "172.16.0.0/12",
+			Description:    "added manually",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		config.Networks.SecurityGroupRules = config.Networks.SecurityGroupRules[:1]
+		config.Networks.DisableDefaultNodePortRules = true
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		group, err = networking.GetSecurityGroup(ctx, state[infraflow.IdentifierSecGroup])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.Rules).To(HaveLen(5))
+		Expect(group.Rules).NotTo(ContainElement(HaveField("RemoteIPPrefix", "0.0.0.0/0")))
+		Expect(group.Rules).To(ContainElement(HaveField("Description", "added manually")))
+		Expect(group.Rules).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"PortRangeMin":   Equal(30000),
+			"RemoteIPPrefix": Equal("192.168.0.0/16"),
+		})))
+	})
+
+	It("should adopt the security group rules of existing shoots and prune managed rules", func() {
+		group, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: namespace})
+		Expect(err).NotTo(HaveOccurred())
+		createRule := func(port int, remoteIPPrefix, description string) *rules.SecGroupRule {
+			rule, err := networking.CreateRule(ctx, rules.CreateOpts{
+				SecGroupID:     group.ID,
+				Direction:      rules.DirIngress,
+				EtherType:      rules.EtherType4,
+				Protocol:       rules.ProtocolTCP,
+				PortRangeMin:   port,
+				PortRangeMax:   port,
+				RemoteIPPrefix: remoteIPPrefix,
+				Description:    description,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return rule
+		}
+		monitoringRule := createRule(9100, "10.0.0.0/8", "monitoring")
+		foreignRule := createRule(22, "172.16.0.0/12", "added manually")
+		config.Networks.SecurityGroupRules = []openstackapi.SecurityGroupRule{{
+			Direction:    openstackapi.SecurityGroupRuleDirectionIngress,
+			Protocol:     ptr.To("tcp"),
+			PortRangeMin: ptr.To[int32](9100),
+			RemoteCIDR:   ptr.To("10.0.0.0/8"),
+		}}
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		Expect(state).To(HaveKeyWithValue(infraflow.IdentifierSecGroup, group.ID))
+		managedRuleIDs := strings.Split(state[infraflow.IdentifierSecGroupRules], ",")
+		Expect(managedRuleIDs).To(HaveLen(6))
+		Expect(managedRuleIDs).To(ContainElement(monitoringRule.ID))
+		Expect(managedRuleIDs).NotTo(ContainElement(foreignRule.ID))
+		group, err = networking.GetSecurityGroup(ctx, group.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.Rules).To(HaveLen(7))
+		Expect(group.Rules).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"PortRangeMin": Equal(30000),
+			"Description":  HavePrefix("[gardener] "),
+		})))
+
+		By("removing the user-defined rule")
+		config.Networks.SecurityGroupRules = nil
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+
+		Expect(strings.Split(state[infraflow.IdentifierSecGroupRules], ",")).To(HaveLen(5))
+		group, err = networking.GetSecurityGroup(ctx, group.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group.Rules).To(HaveLen(6))
+		Expect(group.Rules).NotTo(ContainElement(HaveField("ID", monitoringRule.ID)))
+		Expect(group.Rules).To(ContainElement(HaveField("ID", foreignRule.ID)))
+	})
+
+	Context("routes", func() {
+		var (
+			vpnRoute        = routers.Route{DestinationCIDR: "192.168.0.0/16", NextHop: "10.250.0.10"}
+			datacenterRoute = routers.Route{DestinationCIDR: "172.16.0.0/12", NextHop: "10.250.0.11"}
+			podRoute        = routers.Route{DestinationCIDR: "100.96.0.0/24", NextHop: "10.250.0.5"}
+		)
+
+		getRoutes := func(routerID string) []routers.Route {
+			router, err := networking.GetRouterByID(ctx, routerID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(router).NotTo(BeNil())
+			return router.Routes
+		}
+		// addPodRoute adds a route like the cloud-controller-manager does for the pod network of a node
+		addPodRoute := func(routerID string) {
+			_, err := networking.UpdateRoutesForRouter(ctx, append(getRoutes(routerID), podRoute), routerID)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should merge the configured routes with the routes of the cloud-controller-manager", func() {
+			config.Networks.Router = &openstackapi.Router{Routes: []openstackapi.Route{
+				{Destination: vpnRoute.DestinationCIDR, NextHop: vpnRoute.NextHop},
+				{Destination: datacenterRoute.DestinationCIDR, NextHop: datacenterRoute.NextHop},
+			}}
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			routerID := state[infraflow.IdentifierRouter]
+			Expect(cloud.Calls("CreateRouter")).To(Equal(1))
+			Expect(getRoutes(routerID)).To(ConsistOf(vpnRoute, datacenterRoute))
+			Expect(state).To(HaveKeyWithValue(infraflow.RouterRoutes, "172.16.0.0/12=10.250.0.11,192.168.0.0/16=10.250.0.10"))
+
+			By("keeping the routes of the cloud-controller-manager")
+			addPodRoute(routerID)
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(cloud.Calls("UpdateRoutesForRouter")).To(Equal(2))
+			Expect(getRoutes(routerID)).To(ConsistOf(vpnRoute, datacenterRoute, podRoute))
+
+			By("removing a configured route")
+			config.Networks.Router.Routes = config.Networks.Router.Routes[:1]
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(getRoutes(routerID)).To(ConsistOf(vpnRoute, podRoute))
+			Expect(state).To(HaveKeyWithValue(infraflow.RouterRoutes, "192.168.0.0/16=10.250.0.10"))
+
+			By("removing all configured routes")
+			config.Networks.Router = nil
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(getRoutes(routerID)).To(ConsistOf(podRoute))
+			Expect(state).NotTo(HaveKey(infraflow.RouterRoutes))
+		})
+
+		It("should only delete the configured routes of an existing router", func() {
+			externalNetwork, err := networking.GetExternalNetworkByName(ctx, "public")
+			Expect(err).NotTo(HaveOccurred())
+			router, err := networking.CreateRouter(ctx, routers.CreateOpts{
+				Name:        "existing",
+				GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetwork.ID},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			foreignRoute := routers.Route{DestinationCIDR: "10.0.0.0/8", NextHop: "172.24.4.10"}
+			_, err = networking.UpdateRoutesForRouter(ctx, []routers.Route{foreignRoute}, router.ID)
+			Expect(err).NotTo(HaveOccurred())
+			config.Networks.Router = &openstackapi.Router{
+				ID:     router.ID,
+				Routes: []openstackapi.Route{{Destination: vpnRoute.DestinationCIDR, NextHop: vpnRoute.NextHop}},
+			}
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(state).To(HaveKeyWithValue(infraflow.IdentifierRouter, router.ID))
+			Expect(getRoutes(router.ID)).To(ConsistOf(foreignRoute, vpnRoute))
+
+			By("deleting the infrastructure")
+			addPodRoute(router.ID)
+
+			Expect(deleteInfrastructure()).To(Succeed())
+
+			Expect(getRoutes(router.ID)).To(ConsistOf(foreignRoute))
+			Expect(state).NotTo(HaveKey(infraflow.RouterRoutes))
+		})
+	})
+
+	Context("tags", func() {
+		resourceTags := []string{"gardener-purpose=nodes", "gardener-seed=" + seedName, "gardener-shoot=" + namespace}
+
+		It("should tag the created resources and find them by tag", func() {
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			routerList, err := networking.ListRouters(ctx, routers.ListOpts{ID: state[infraflow.IdentifierRouter]})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Tags": ConsistOf(resourceTags)})))
+			networkList, err := networking.ListNetwork(ctx, networks.ListOpts{ID: state[infraflow.IdentifierNetwork]})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkList).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Tags": ConsistOf(resourceTags)})))
+			subnetList, err := networking.ListSubnets(ctx, subnets.ListOpts{ID: state[infraflow.IdentifierSubnet]})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnetList).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Tags": ConsistOf(resourceTags)})))
+			group, err := networking.GetSecurityGroup(ctx, state[infraflow.IdentifierSecGroup])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Tags).To(ConsistOf(resourceTags))
+
+			By("reconciling a renamed network without state")
+			_, err = networking.UpdateNetwork(ctx, state[infraflow.IdentifierNetwork], networks.UpdateOpts{Name: ptr.To("renamed")})
+			Expect(err).NotTo(HaveOccurred())
+			state = nil
+			Expect(reconcileInfrastructure()).To(Succeed())
+			Expect(state).To(HaveKeyWithValue(infraflow.IdentifierNetwork, networkList[0].ID))
+			for _, operation := range []string{"CreateRouter", "CreateNetwork", "CreateSubnet", "CreateSecurityGroup"} {
+				Expect(cloud.Calls(operation)).To(Equal(1), operation)
+			}
+		})
+
+		It("should tag the existing resources of a shoot", func() {
+			network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: namespace})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeNetworks, network.ID, []string{"user-tag", "gardener-seed=old-seed"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(state).To(HaveKeyWithValue(infraflow.IdentifierNetwork, network.ID))
+			networkList, err := networking.ListNetwork(ctx, networks.ListOpts{ID: network.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkList).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Tags": ConsistOf(append([]string{"user-tag"}, resourceTags...)),
+			})))
+		})
+
+		It("should not adopt a resource of another shoot with the same name", func() {
+			network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: namespace})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeNetworks, network.ID, []string{"gardener-purpose=nodes", "gardener-shoot=shoot--foo--other"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(state).To(HaveKeyWithValue(infraflow.IdentifierNetwork, Not(Equal(network.ID))))
+			Expect(cloud.Calls("CreateNetwork")).To(Equal(2))
+		})
+
+		Context("share network", func() {
+			var sharedFilesystem openstackclient.SharedFilesystem
+
+			BeforeEach(func() {
+				var err error
+				sharedFilesystem, err = cloud.Factory().SharedFilesystem()
+				Expect(err).NotTo(HaveOccurred())
+				config.Networks.ShareNetwork = &openstackapi.ShareNetwork{Enabled: true}
+			})
+
+			It("should keep the tags in the description of the share network and find it by them", func() {
+				Expect(reconcileInfrastructure()).To(Succeed())
+
+				shareNetwork, err := sharedFilesystem.GetShareNetwork(ctx, state[infraflow.IdentifierShareNetwork])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(strings.Fields(shareNetwork.Description)).To(ConsistOf(resourceTags))
+
+				By("reconciling again without the id in the state")
+				delete(state, infraflow.IdentifierShareNetwork)
+				Expect(reconcileInfrastructure()).To(Succeed())
+				Expect(state).To(HaveKeyWithValue(infraflow.IdentifierShareNetwork, shareNetwork.ID))
+				Expect(cloud.Calls("CreateShareNetwork")).To(Equal(1))
+				Expect(cloud.Calls("UpdateShareNetwork")).To(BeZero())
+
+				By("deleting the infrastructure without the id in the state")
+				delete(state, infraflow.IdentifierShareNetwork)
+				Expect(deleteInfrastructure()).To(Succeed())
+				shareNetwork, err = sharedFilesystem.GetShareNetwork(ctx, shareNetwork.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(shareNetwork).To(BeNil())
+			})
+
+			It("should tag an existing share network and keep foreign share networks", func() {
+				Expect(reconcileInfrastructure()).To(Succeed())
+				shareNetwork, err := sharedFilesystem.UpdateShareNetwork(ctx, state[infraflow.IdentifierShareNetwork], sharenetworks.UpdateOpts{Description: ptr.To("created by the Terraformer")})
+				Expect(err).NotTo(HaveOccurred())
+				foreignShareNetwork, err := sharedFilesystem.CreateShareNetwork(ctx, sharenetworks.CreateOpts{
+					Name:            "foreign",
+					NeutronNetID:    state[infraflow.IdentifierNetwork],
+					NeutronSubnetID: state[infraflow.IdentifierSubnet],
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(reconcileInfrastructure()).To(Succeed())
+
+				shareNetwork, err = sharedFilesystem.GetShareNetwork(ctx, shareNetwork.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(shareNetwork.Description).To(HavePrefix("created by the Terraformer "))
+				Expect(strings.Fields(shareNetwork.Description)).To(ContainElements(resourceTags))
+
+				By("disabling the share network")
+				config.Networks.ShareNetwork.Enabled = false
+				Expect(reconcileInfrastructure()).To(Succeed())
+
+				list, err := sharedFilesystem.ListShareNetworks(ctx, sharenetworks.ListOpts{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(list).To(ConsistOf(HaveField("ID", foreignShareNetwork.ID)))
+				Expect(state).NotTo(HaveKey(infraflow.IdentifierShareNetwork))
+			})
+		})
+	})
+
+	Context("existing subnet", func() {
+		var (
+			network *networks.Network
+			subnet  *subnets.Subnet
+		)
+
+		BeforeEach(func() {
+			var err error
+			network, err = networking.CreateNetwork(ctx, networks.CreateOpts{Name: "existing"})
+			Expect(err).NotTo(HaveOccurred())
+			subnet, err = networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, Name: "existing", CIDR: "10.250.0.0/16", IPVersion: 4})
+			Expect(err).NotTo(HaveOccurred())
+
+			config.Networks.ID = ptr.To(network.ID)
+			config.Networks.SubnetID = ptr.To(subnet.ID)
+		})
+
+		It("should adopt a routed subnet and leave it untouched on deletion", func() {
+			router, err := networking.CreateRouter(ctx, routers.CreateOpts{Name: "existing"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.AddRouterInterface(ctx, router.ID, routers.AddInterfaceOpts{SubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			Expect(state).To(HaveKeyWithValue(infraflow.IdentifierSubnet, subnet.ID))
+			Expect(cloud.Calls("CreateSubnet")).To(Equal(1))
+			Expect(cloud.Calls("UpdateSubnet")).To(BeZero())
+			port, err := networking.GetRouterInterfacePort(ctx, state[infraflow.IdentifierRouter], subnet.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port).To(BeNil())
+
+			By("reconciling again")
+			Expect(reconcileInfrastructure()).To(Succeed())
+			Expect(cloud.Calls("AddRouterInterface")).To(Equal(1), "only the interface of the existing router")
+
+			By("deleting the infrastructure")
+			Expect(deleteInfrastructure()).To(Succeed())
+
+			subnetList, err := networking.ListSubnets(ctx, subnets.ListOpts{ID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnetList).To(HaveLen(1))
+			port, err = networking.GetRouterInterfacePort(ctx, router.ID, subnet.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port).NotTo(BeNil())
+			routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(ConsistOf(HaveField("ID", router.ID)))
+		})
+
+		It("should attach an unrouted subnet to the router and detach it on deletion", func() {
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			port, err := networking.GetRouterInterfacePort(ctx, state[infraflow.IdentifierRouter], subnet.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port).NotTo(BeNil())
+
+			By("reconciling again with the subnet attached to the router of the shoot")
+			Expect(reconcileInfrastructure()).To(Succeed())
+			Expect(cloud.Calls("AddRouterInterface")).To(Equal(1))
+
+			By("deleting the infrastructure")
+			Expect(deleteInfrastructure()).To(Succeed())
+
+			subnetList, err := networking.ListSubnets(ctx, subnets.ListOpts{ID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnetList).To(HaveLen(1))
+			routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(BeEmpty())
+		})
+
+		It("should detach a subnet attached to the existing router of the shoot on deletion", func() {
+			externalNetwork, err := networking.GetExternalNetworkByName(ctx, "public")
+			Expect(err).NotTo(HaveOccurred())
+			router, err := networking.CreateRouter(ctx, routers.CreateOpts{
+				Name:        "existing",
+				GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetwork.ID},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.AddRouterInterface(ctx, router.ID, routers.AddInterfaceOpts{SubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+			config.Networks.Router = &openstackapi.Router{ID: router.ID}
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+			Expect(cloud.Calls("AddRouterInterface")).To(Equal(1))
+
+			By("deleting the infrastructure")
+			Expect(deleteInfrastructure()).To(Succeed())
+
+			port, err := networking.GetRouterInterfacePort(ctx, router.ID, subnet.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port).To(BeNil())
+		})
+
+		It("should adopt a subnet whose CIDR has a different notation", func() {
+			config.Networks.Workers = "10.250.0.1/16"
+
+			Expect(reconcileInfrastructure()).To(Succeed())
+			Expect(state).To(HaveKeyWithValue(infraflow.IdentifierSubnet, subnet.ID))
+		})
+
+		It("should refuse to adopt a subnet with a different CIDR", func() {
+			config.Networks.Workers = "10.251.0.0/16"
+
+			Expect(reconcileInfrastructure()).To(MatchError(ContainSubstring("does not match the workers CIDR")))
+		})
+	})
+
+	Context("plan mode", func() {
+		plannedChange := func(action infraflow.PlanAction, resource string) OmegaMatcher {
+			return MatchFields(IgnoreExtras, Fields{
+				"Action":   Equal(action),
+				"Resource": Equal(resource),
+			})
+		}
+
+		newPlanningFlowContext := func() *infraflow.FlowContext {
+			flowContext, err := infraflow.NewPlanningFlowContext(logr.Discard(), cloud.Factory(), infra, config, &openstackapi.CloudProfileConfig{}, seedName, state)
+			Expect(err).NotTo(HaveOccurred())
+			return flowContext
+		}
+
+		It("should plan a new infrastructure without creating anything", func() {
+			plan, err := newPlanningFlowContext().PlanReconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Flow).To(Equal(infraflow.PlanFlowReconcile))
+			Expect(plan.Changes).To(ContainElements(
+				plannedChange(infraflow.PlanActionCreate, "router"),
+				plannedChange(infraflow.PlanActionCreate, "network"),
+				plannedChange(infraflow.PlanActionCreate, "routerInterface"),
+				plannedChange(infraflow.PlanActionCreate, "securityGroup"),
+				plannedChange(infraflow.PlanActionCreate, "keyPair"),
+			))
+			Expect(plan.Changes).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Action":   Equal(infraflow.PlanActionCreate),
+				"Resource": Equal("subnet"),
+				"Name":     Equal(namespace),
+				"Details":  Equal("10.250.0.0/16"),
+			})))
+			for _, operation := range []string{"CreateRouter", "CreateNetwork", "CreateSubnet", "CreateSecurityGroup", "CreateRule", "CreateKeyPair", "AddRouterInterface"} {
+				Expect(cloud.Calls(operation)).To(BeZero(), operation)
+			}
+			Expect(state).To(BeNil())
+		})
+
+		It("should only plan the changes of an existing infrastructure", func() {
+			Expect(reconcileInfrastructure()).To(Succeed())
+			reconciledState := state
+
+			plan, err := newPlanningFlowContext().PlanReconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Changes).To(BeEmpty())
+
+			config.Networks.SecurityGroupRules = []openstackapi.SecurityGroupRule{
+				{
+					Direction:    openstackapi.SecurityGroupRuleDirectionIngress,
+					Protocol:     ptr.To("tcp"),
+					PortRangeMin: ptr.To[int32](9100),
+					RemoteCIDR:   ptr.To("10.0.0.0/8"),
+				},
+			}
+			plan, err = newPlanningFlowContext().PlanReconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Changes).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Action":   Equal(infraflow.PlanActionCreate),
+				"Resource": Equal("securityGroupRule"),
+				"Details":  Equal("ingress IPv4 tcp 9100-9100 from 10.0.0.0/8"),
+			})))
+			// the default egress rules are created together with the security group
+			Expect(cloud.Calls("CreateRule")).To(Equal(3))
+			Expect(state).To(Equal(reconciledState))
+		})
+
+		It("should plan the deletion without deleting anything", func() {
+			Expect(reconcileInfrastructure()).To(Succeed())
+
+			plan, err := newPlanningFlowContext().PlanDelete(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.Flow).To(Equal(infraflow.PlanFlowDelete))
+			Expect(plan.Changes).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Action":   Equal(infraflow.PlanActionDelete),
+					"Resource": Equal("router"),
+					"ID":       Equal(state[infraflow.IdentifierRouter]),
+				}),
+				plannedChange(infraflow.PlanActionDelete, "routerInterface"),
+				plannedChange(infraflow.PlanActionDelete, "network"),
+				plannedChange(infraflow.PlanActionDelete, "securityGroup"),
+				plannedChange(infraflow.PlanActionDelete, "keyPair"),
+			))
+			for _, operation := range []string{"DeleteRouter", "DeleteNetwork", "DeleteSubnet", "DeleteSecurityGroup", "DeleteKeyPair", "RemoveRouterInterface"} {
+				Expect(cloud.Calls(operation)).To(BeZero(), operation)
+			}
+			routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(HaveLen(1))
+		})
+	})
+
+	Context("force deletion", func() {
+		forceDeleteInfrastructure := func() []string {
+			flowContext := newFlowContext()
+			leftovers, err := flowContext.ForceDelete(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowContext.PersistState(ctx, true)).To(Succeed())
+			return leftovers
+		}
+
+		It("should delete the resources blocking the deletion of the network", func() {
+			Expect(reconcileInfrastructure()).To(Succeed())
+			networkID := state[infraflow.IdentifierNetwork]
+			compute, err := cloud.Factory().Compute()
+			Expect(err).NotTo(HaveOccurred())
+			server, err := compute.CreateServer(ctx, servers.CreateOpts{
+				Name:      namespace + "-worker",
+				FlavorRef: cloud.AddFlavor("small"),
+				ImageRef:  cloud.AddImage("image"),
+				Networks:  []servers.Network{{UUID: networkID}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			external, err := networking.GetExternalNetworkByName(ctx, "public")
+			Expect(err).NotTo(HaveOccurred())
+			fip, err := networking.CreateFloatingIP(ctx, floatingips.CreateOpts{FloatingNetworkID: external.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(compute.AssociateFIPWithInstance(ctx, server.ID, computefip.AssociateOpts{FloatingIP: fip.FloatingIP})).To(Succeed())
+			cloud.AddLoadBalancer(loadbalancers.LoadBalancer{Name: "kube_service_" + namespace + "_default_nginx",
+				VipSubnetID: state[infraflow.IdentifierSubnet], ProvisioningStatus: "ERROR"})
+
+			Expect(forceDeleteInfrastructure()).To(BeEmpty())
+
+			Expect(cloud.Calls("DeleteLoadbalancer")).To(Equal(1))
+			fipList, err := networking.ListFip(ctx, floatingips.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fipList).To(BeEmpty())
+			portList, err := networking.ListPorts(ctx, ports.ListOpts{NetworkID: networkID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(portList).To(BeEmpty())
+			networkList, err := networking.GetNetworkByName(ctx, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkList).To(BeEmpty())
+			routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(BeEmpty())
+			keyPair, err := compute.GetKeyPair(ctx, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair).To(BeNil())
+		})
+
+		It("should continue after failures and report the resources left behind", func() {
+			Expect(reconcileInfrastructure()).To(Succeed())
+			cloud.InjectFault("RemoveRouterInterface", fake.Fault{Err: errors.New("internal error")})
+
+			Expect(forceDeleteInfrastructure()).To(ConsistOf(
+				HavePrefix("failed to delete router interface: internal error"),
+				HavePrefix("failed to delete network: "),
+				HavePrefix("failed to delete router: "),
+			))
+
+			groupList, err := networking.ListSecurityGroup(ctx, groups.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groupList).To(BeEmpty())
+			routerList, err := networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(HaveLen(1))
+
+			By("force-deleting the resources left behind once the failure is gone")
+			cloud.ClearFaults()
+			Expect(forceDeleteInfrastructure()).To(BeEmpty())
+			routerList, err = networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerList).To(BeEmpty())
+		})
+
+		It("should not report anything if the infrastructure does not exist", func() {
+			Expect(forceDeleteInfrastructure()).To(BeEmpty())
+		})
+	})
+
+	It("should continue the reconciliation after a failure", func() {
+		cloud.InjectFault("CreateNetwork", fake.Fault{Err: fake.QuotaExceededError("network"), Times: 1})
+
+		Expect(reconcileInfrastructure()).To(MatchError(ContainSubstring("Quota exceeded")))
+		Expect(state).To(HaveKeyWithValue(infraflow.IdentifierRouter, Not(BeEmpty())))
+
+		Expect(reconcileInfrastructure()).To(Succeed())
+		Expect(cloud.Calls("CreateRouter")).To(Equal(1))
+		networkList, err := networking.ListNetwork(ctx, networks.ListOpts{Name: namespace})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(networkList).To(HaveLen(1))
+	})
+})
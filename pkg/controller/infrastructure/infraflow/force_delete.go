@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gardener/gardener/pkg/utils/flow"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	. "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
+	osclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+// ForceDelete creates and runs a best-effort flow to delete the OpenStack infrastructure if the shoot is
+// force-deleted. In contrast to Delete, a failed task does not stop the flow, and the resources which are usually
+// cleaned up by other components, i.e. the loadbalancers, ports and floating IPs in the shoot network, are deleted
+// as well. It returns the errors of the failed tasks, which describe the resources left behind.
+func (c *FlowContext) ForceDelete(ctx context.Context) ([]string, error) {
+	leftovers := &leftovers{}
+	if err := c.RunGraph(ctx, c.buildForceDeleteGraph(leftovers)); err != nil {
+		return nil, flow.Causes(err)
+	}
+	return leftovers.list(), nil
+}
+
+// leftovers collects the errors of the failed tasks of the force-delete flow.
+type leftovers struct {
+	lock     sync.Mutex
+	messages []string
+}
+
+func (l *leftovers) add(message string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.messages = append(l.messages, message)
+}
+
+func (l *leftovers) list() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	result := slices.Clone(l.messages)
+	slices.Sort(result)
+	return result
+}
+
+func (c *FlowContext) buildForceDeleteGraph(leftovers *leftovers) *flow.Graph {
+	g := flow.NewGraph("Openstack infrastructure force-deletion")
+
+	needToDeleteNetwork := c.config.Networks.ID == nil
+	needToDeleteRouter := !helper.UsesExistingRouter(c.config)
+	needToDeleteSubnet := !needToDeleteNetwork && c.config.Networks.SubnetID == nil
+
+	// addTask adds a task whose error is collected instead of failing the flow, so that the dependent tasks are
+	// still executed.
+	addTask := func(name string, fn flow.TaskFn, options ...TaskOption) flow.TaskIDer {
+		return c.AddTask(g, name, func(ctx context.Context) error {
+			if err := fn(ctx); err != nil {
+				c.LogFromContext(ctx).Error(err, "ignoring failed task of force-deletion")
+				leftovers.add(fmt.Sprintf("failed to %s: %s", name, err))
+			}
+			return nil
+		}, options...)
+	}
+
+	// ifNetworkExists skips the lookup of the subnets if the network does not exist (anymore)
+	ifNetworkExists := func(fn flow.TaskFn) flow.TaskFn {
+		return func(ctx context.Context) error {
+			if c.config.Networks.ID == nil && c.state.Get(IdentifierNetwork) == nil {
+				return nil
+			}
+			return fn(ctx)
+		}
+	}
+
+	_ = addTask("delete ssh key pair",
+		c.deleteSSHKeyPair,
+		Timeout(defaultTimeout))
+	recoverRouterID := addTask("recover router ID",
+		c.recoverRouterID,
+		Timeout(defaultTimeout))
+	recoverNetworkID := addTask("recover network ID",
+		func(ctx context.Context) error {
+			_, err := c.getNetworkID(ctx)
+			return err
+		},
+		Timeout(defaultTimeout))
+	recoverSubnetID := addTask("recover subnet ID",
+		ifNetworkExists(c.recoverSubnetID),
+		Timeout(defaultTimeout), Dependencies(recoverNetworkID))
+	recoverSubnetIPv6ID := addTask("recover IPv6 subnet ID",
+		ifNetworkExists(c.recoverSubnetIPv6ID),
+		DoIf(c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(recoverNetworkID))
+	var recoverZoneSubnetIDs []flow.TaskIDer
+	for _, zone := range c.config.Networks.Zones {
+		recoverZoneSubnetIDs = append(recoverZoneSubnetIDs, addTask("recover subnet ID of zone "+zone.Name,
+			ifNetworkExists(func(ctx context.Context) error {
+				return c.recoverZoneSubnetID(ctx, zone)
+			}),
+			Timeout(defaultTimeout), Dependencies(recoverNetworkID)))
+	}
+
+	routerRoutes := addTask("delete router routes",
+		c.deleteRouterRoutes,
+		Timeout(defaultTimeout), Dependencies(recoverRouterID))
+	k8sRoutes := addTask("delete kubernetes routes",
+		func(ctx context.Context) error {
+			routerID := c.state.Get(IdentifierRouter)
+			if routerID == nil {
+				return nil
+			}
+			workers := []string{infrastructure.WorkersCIDR(c.config)}
+			for _, zone := range c.config.Networks.Zones {
+				workers = append(workers, zone.Workers)
+			}
+			if cidr := c.state.Get(CIDRSubnetIPv6); cidr != nil {
+				workers = append(workers, *cidr)
+			}
+			return infrastructure.CleanupKubernetesRoutes(ctx, c.networking, *routerID, workers...)
+		},
+		Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverSubnetIPv6ID, routerRoutes),
+	)
+	// the floating IPs are deleted first, as they are disassociated when the ports of the loadbalancers are deleted
+	floatingIPs := addTask("delete floating IPs",
+		c.deleteShootFloatingIPs,
+		Timeout(defaultTimeout), Dependencies(recoverSubnetID, recoverSubnetIPv6ID), Dependencies(recoverZoneSubnetIDs...))
+	k8sLoadBalancers := addTask("delete kubernetes loadbalancers",
+		func(ctx context.Context) error {
+			var errs []error
+			for _, subnetID := range c.shootSubnetIDs() {
+				errs = append(errs, infrastructure.DeleteKubernetesLoadbalancers(ctx, c.LogFromContext(ctx), c.loadbalancing, subnetID, c.namespace))
+			}
+			return errors.Join(errs...)
+		},
+		Timeout(defaultTimeout), Dependencies(floatingIPs))
+	deletePorts := addTask("delete ports",
+		c.deleteShootPorts,
+		Timeout(defaultTimeout), Dependencies(k8sLoadBalancers))
+
+	_ = addTask("delete security group",
+		c.deleteSecGroup,
+		Timeout(defaultTimeout), Dependencies(deletePorts))
+	_ = addTask("delete share network",
+		c.deleteShareNetwork,
+		Timeout(defaultTimeout), Dependencies(recoverSubnetID))
+	deleteRouterInterface := addTask("delete router interface",
+		c.deleteRouterInterface,
+		Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverSubnetID, k8sRoutes, floatingIPs))
+	deleteRouterInterfaceIPv6 := addTask("delete IPv6 router interface",
+		c.deleteRouterInterfaceIPv6,
+		DoIf(c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverSubnetIPv6ID, k8sRoutes, floatingIPs))
+	deleteRouterInterfaces := []flow.TaskIDer{deleteRouterInterface, deleteRouterInterfaceIPv6}
+	for i, zone := range c.config.Networks.Zones {
+		deleteZoneRouterInterface := addTask("delete router interface of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.deleteZoneRouterInterface(ctx, zone)
+			},
+			Timeout(defaultTimeout), Dependencies(recoverRouterID, recoverZoneSubnetIDs[i], k8sRoutes, floatingIPs))
+		_ = addTask("delete subnet of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.deleteZoneSubnet(ctx, zone)
+			},
+			DoIf(!needToDeleteNetwork), Timeout(defaultTimeout), Dependencies(deleteZoneRouterInterface, deletePorts))
+		deleteRouterInterfaces = append(deleteRouterInterfaces, deleteZoneRouterInterface)
+	}
+
+	_ = addTask("delete subnet",
+		c.deleteSubnet,
+		DoIf(needToDeleteSubnet), Timeout(defaultTimeout), Dependencies(deleteRouterInterface, deletePorts))
+	_ = addTask("delete IPv6 subnet",
+		c.deleteSubnetIPv6,
+		DoIf(!needToDeleteNetwork && c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(deleteRouterInterfaceIPv6, deletePorts))
+	_ = addTask("delete network",
+		c.deleteNetwork,
+		DoIf(needToDeleteNetwork), Timeout(defaultTimeout), Dependencies(deleteRouterInterfaces...), Dependencies(deletePorts))
+	_ = addTask("delete router",
+		c.deleteRouter,
+		DoIf(needToDeleteRouter), Timeout(defaultTimeout), Dependencies(deleteRouterInterfaces...))
+
+	return g
+}
+
+// shootSubnetIDs returns the IDs of the known subnets of the shoot, i.e. the subnet of the nodes, the IPv6 subnet and
+// the subnets of the zones.
+func (c *FlowContext) shootSubnetIDs() []string {
+	var ids []string
+	for _, id := range []*string{c.state.Get(IdentifierSubnet), c.state.Get(IdentifierSubnetIPv6)} {
+		if id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	zones := c.state.GetChild(ChildIdZones)
+	for _, zone := range zones.GetChildrenKeys() {
+		if id := zones.GetChild(zone).Get(IdentifierSubnet); id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	return ids
+}
+
+// listShootPorts returns the ports which are deleted with the infrastructure. These are all ports in the network if it
+// is managed by the flow, or otherwise the ports in the subnets which are managed by the flow. The interfaces of the
+// routers and other ports owned by Neutron itself are skipped.
+func (c *FlowContext) listShootPorts(ctx context.Context) ([]ports.Port, error) {
+	var listOpts []ports.ListOpts
+	if c.config.Networks.ID == nil {
+		networkID := c.state.Get(IdentifierNetwork)
+		if networkID == nil {
+			return nil, nil
+		}
+		listOpts = append(listOpts, ports.ListOpts{NetworkID: *networkID})
+	} else {
+		for _, subnetID := range c.shootSubnetIDs() {
+			if subnetID == ptr.Deref(c.config.Networks.SubnetID, "") {
+				// the ports of an adopted subnet are left untouched
+				continue
+			}
+			listOpts = append(listOpts, ports.ListOpts{FixedIPs: []ports.FixedIPOpts{{SubnetID: subnetID}}})
+		}
+	}
+
+	var result []ports.Port
+	for _, opts := range listOpts {
+		list, err := c.networking.ListPorts(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, port := range list {
+			if strings.HasPrefix(port.DeviceOwner, "network:") ||
+				slices.ContainsFunc(result, func(p ports.Port) bool { return p.ID == port.ID }) {
+				continue
+			}
+			result = append(result, port)
+		}
+	}
+	return result, nil
+}
+
+func (c *FlowContext) deleteShootFloatingIPs(ctx context.Context) error {
+	log := c.LogFromContext(ctx)
+	portList, err := c.listShootPorts(ctx)
+	if err != nil {
+		return err
+	}
+	if len(portList) == 0 {
+		return nil
+	}
+	fips, err := c.networking.ListFip(ctx, floatingips.ListOpts{})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, fip := range fips {
+		if fip.PortID == "" || !slices.ContainsFunc(portList, func(port ports.Port) bool { return port.ID == fip.PortID }) {
+			continue
+		}
+		log.Info("deleting...", "floatingIP", fip.ID)
+		if err := c.networking.DeleteFloatingIP(ctx, fip.ID); osclient.IgnoreNotFoundError(err) != nil {
+			errs = append(errs, fmt.Errorf("floating IP %s: %w", fip.FloatingIP, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *FlowContext) deleteShootPorts(ctx context.Context) error {
+	log := c.LogFromContext(ctx)
+	portList, err := c.listShootPorts(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, port := range portList {
+		log.Info("deleting...", "port", port.ID, "deviceOwner", port.DeviceOwner)
+		if err := c.networking.DeletePort(ctx, port.ID); osclient.IgnoreNotFoundError(err) != nil {
+			errs = append(errs, fmt.Errorf("port %s: %w", port.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/internal/infrastructure"
+)
+
+// tfResourceMapping maps an attribute of a resource managed by the Terraformer to a key of the whiteboard.
+type tfResourceMapping struct {
+	tfType    string
+	name      string
+	attribute string
+	key       string
+}
+
+var (
+	// tfResourceMappings are the mappings of the resources of the Terraformer configuration of the extension.
+	tfResourceMappings = []tfResourceMapping{
+		{tfType: "openstack_networking_router_v2", name: "router", attribute: shared.AttributeKeyId, key: IdentifierRouter},
+		{tfType: "openstack_networking_network_v2", name: "cluster", attribute: shared.AttributeKeyId, key: IdentifierNetwork},
+		{tfType: "openstack_networking_network_v2", name: "cluster", attribute: shared.AttributeKeyName, key: NameNetwork},
+		{tfType: "openstack_networking_subnet_v2", name: "cluster", attribute: shared.AttributeKeyId, key: IdentifierSubnet},
+		{tfType: "openstack_networking_secgroup_v2", name: "cluster", attribute: shared.AttributeKeyId, key: IdentifierSecGroup},
+		{tfType: "openstack_networking_secgroup_v2", name: "cluster", attribute: shared.AttributeKeyName, key: NameSecGroup},
+		{tfType: "openstack_sharedfilesystem_sharenetwork_v2", name: "cluster", attribute: shared.AttributeKeyId, key: IdentifierShareNetwork},
+		{tfType: "openstack_sharedfilesystem_sharenetwork_v2", name: "cluster", attribute: shared.AttributeKeyName, key: NameShareNetwork},
+		{tfType: "openstack_compute_keypair_v2", name: "ssh_key", attribute: shared.AttributeKeyName, key: NameKeyPair},
+	}
+	// tfOutputMappings map the outputs of the Terraformer configuration, which are no resources, to keys of the whiteboard.
+	tfOutputMappings = map[string]string{
+		infrastructure.TerraformOutputKeyRouterIP:          RouterIP,
+		infrastructure.TerraformOutputKeyFloatingNetworkID: IdentifierFloatingNetwork,
+	}
+)
+
+const (
+	// tfTypeSecGroupRule is the type of the security group rules managed by the Terraformer.
+	tfTypeSecGroupRule = "openstack_networking_secgroup_rule_v2"
+	// tfTypeRouterInterface is the type of the router interface managed by the Terraformer. It is found by the flow
+	// via the router and the subnet.
+	tfTypeRouterInterface = "openstack_networking_router_interface_v2"
+	// tfTypeNullResource is the type of the resource used by the Terraformer configuration for the outputs.
+	tfTypeNullResource = "null_resource"
+)
+
+// MigrationReport describes the result of the import of a Terraform state into a PersistentState.
+type MigrationReport struct {
+	// Mapped contains the addresses of the resources, which are mapped to the state.
+	Mapped []string
+	// Unmapped contains the addresses of the managed resources, which could not be mapped to the state. They are not
+	// managed by the flow, and need to be checked manually.
+	Unmapped []string
+}
+
+// NewPersistentStateFromTerraformState creates a new PersistentState with the IDs of the resources managed by the
+// Terraformer, so that the flow finds them even if they were renamed in the meantime. The resources which could not
+// be mapped are reported.
+func NewPersistentStateFromTerraformState(tfState *shared.TerraformState) (*PersistentState, *MigrationReport) {
+	state := NewPersistentState()
+	report := &MigrationReport{}
+
+	var ruleIDs []string
+	for _, resource := range tfState.Resources {
+		if resource.Mode != shared.ModeManaged || resource.Type == tfTypeNullResource {
+			continue
+		}
+		address := resource.Type + "." + resource.Name
+		if !importTerraformResource(state, resource, &ruleIDs) {
+			report.Unmapped = append(report.Unmapped, address)
+			continue
+		}
+		report.Mapped = append(report.Mapped, address)
+	}
+	if len(ruleIDs) > 0 {
+		slices.Sort(ruleIDs)
+		state.Data[IdentifierSecGroupRules] = strings.Join(ruleIDs, ",")
+	}
+	for output, key := range tfOutputMappings {
+		if value := tfState.Outputs[output].Value; value != "" {
+			state.Data[key] = value
+		}
+	}
+	slices.Sort(report.Mapped)
+	slices.Sort(report.Unmapped)
+
+	state.SetMigratedFromTerraform()
+	return state, report
+}
+
+// importTerraformResource sets the keys of the state for the given resource and returns false if the resource is unknown
+// or has not exactly one instance with an ID.
+func importTerraformResource(state *PersistentState, resource shared.TFResource, ruleIDs *[]string) bool {
+	if len(resource.Instances) != 1 {
+		return false
+	}
+	attributes := resource.Instances[0].Attributes
+	id, ok := shared.AttributeAsString(attributes, shared.AttributeKeyId)
+	if !ok || id == "" {
+		return false
+	}
+
+	switch resource.Type {
+	case tfTypeSecGroupRule:
+		*ruleIDs = append(*ruleIDs, id)
+		return true
+	case tfTypeRouterInterface:
+		return true
+	}
+
+	found := false
+	for _, mapping := range tfResourceMappings {
+		if mapping.tfType != resource.Type || mapping.name != resource.Name {
+			continue
+		}
+		found = true
+		if value, ok := shared.AttributeAsString(attributes, mapping.attribute); ok && value != "" {
+			state.Data[mapping.key] = value
+		}
+	}
+	return found
+}
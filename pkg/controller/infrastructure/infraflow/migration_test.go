@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow_test
+
+import (
+	"context"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	openstackapi "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+var _ = Describe("Migration", func() {
+	Describe("#NewPersistentStateFromTerraformState", func() {
+		It("should map the resources of the terraform state", func() {
+			tfState, err := shared.UnmarshalTerraformState([]byte(openstackTFState))
+			Expect(err).NotTo(HaveOccurred())
+
+			state, report := infraflow.NewPersistentStateFromTerraformState(tfState)
+
+			Expect(state.HasValidVersion()).To(BeTrue())
+			Expect(state.MigratedFromTerraform()).To(BeTrue())
+			Expect(state.TerraformCleanedUp()).To(BeFalse())
+			Expect(state.Data).To(Equal(map[string]string{
+				infraflow.IdentifierRouter:            "router-id",
+				infraflow.RouterIP:                    "172.24.4.2",
+				infraflow.IdentifierFloatingNetwork:   "fip-network-id",
+				infraflow.IdentifierNetwork:           "network-id",
+				infraflow.NameNetwork:                 "shoot--foo--bar-renamed",
+				infraflow.IdentifierSubnet:            "subnet-id",
+				infraflow.IdentifierSecGroup:          "secgroup-id",
+				infraflow.NameSecGroup:                "shoot--foo--bar",
+				infraflow.IdentifierSecGroupRules:     "rule-egress-id,rule-self-id",
+				infraflow.IdentifierShareNetwork:      "share-network-id",
+				infraflow.NameShareNetwork:            "shoot--foo--bar",
+				infraflow.NameKeyPair:                 "shoot--foo--bar",
+				infraflow.MarkerMigratedFromTerraform: "true",
+			}))
+			Expect(report.Mapped).To(ConsistOf(
+				"openstack_compute_keypair_v2.ssh_key",
+				"openstack_networking_network_v2.cluster",
+				"openstack_networking_router_interface_v2.router_nodes",
+				"openstack_networking_router_v2.router",
+				"openstack_networking_secgroup_rule_v2.cluster_egress",
+				"openstack_networking_secgroup_rule_v2.cluster_self",
+				"openstack_networking_secgroup_v2.cluster",
+				"openstack_networking_subnet_v2.cluster",
+				"openstack_sharedfilesystem_sharenetwork_v2.cluster",
+			))
+			Expect(report.Unmapped).To(ConsistOf(
+				"openstack_networking_floatingip_v2.manual",
+				"openstack_networking_secgroup_rule_v2.cluster_tcp_all",
+			))
+		})
+
+		It("should map nothing for an empty terraform state", func() {
+			state, report := infraflow.NewPersistentStateFromTerraformState(&shared.TerraformState{})
+
+			Expect(state.Data).To(Equal(map[string]string{infraflow.MarkerMigratedFromTerraform: "true"}))
+			Expect(report.Mapped).To(BeEmpty())
+			Expect(report.Unmapped).To(BeEmpty())
+		})
+	})
+
+	It("should reconcile the infrastructure with the imported IDs", func() {
+		const namespace = "shoot--foo--bar"
+
+		ctx := context.Background()
+		cloud := fake.NewCloud()
+		externalNetworkID := cloud.AddExternalNetwork("public")
+		cloud.AddSubnet(externalNetworkID, "public-subnet", "172.24.4.0/24")
+		networking, err := cloud.Factory().Networking()
+		Expect(err).NotTo(HaveOccurred())
+
+		infra := &extensionsv1alpha1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "infrastructure"},
+			Spec:       extensionsv1alpha1.InfrastructureSpec{Region: "eu-1", SSHPublicKey: []byte("ssh-rsa AAAA")},
+		}
+		config := &openstackapi.InfrastructureConfig{
+			FloatingPoolName: "public",
+			Networks:         openstackapi.Networks{Workers: "10.250.0.0/16"},
+		}
+		reconcile := func(state shared.FlatMap) shared.FlatMap {
+			flowContext, err := infraflow.NewFlowContext(logr.Discard(), cloud.Factory(), infra, config, &openstackapi.CloudProfileConfig{}, "seed", state,
+				func(_ context.Context, flatMap shared.FlatMap) error {
+					state = flatMap
+					return nil
+				})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowContext.Reconcile(ctx)).To(Succeed())
+			Expect(flowContext.PersistState(ctx, true)).To(Succeed())
+			return state
+		}
+
+		// the resources are created by a reconciliation, whose state is replaced by the imported one
+		existing := reconcile(nil)
+		networkID := existing[infraflow.IdentifierNetwork]
+		_, err = networking.UpdateNetwork(ctx, networkID, networks.UpdateOpts{Name: ptr.To("renamed")})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeNetworks, networkID, []string{"foo"})
+		Expect(err).NotTo(HaveOccurred())
+
+		tfState := &shared.TerraformState{
+			Resources: []shared.TFResource{
+				tfResource("openstack_networking_router_v2", "router", existing[infraflow.IdentifierRouter], namespace),
+				tfResource("openstack_networking_network_v2", "cluster", networkID, "renamed"),
+				tfResource("openstack_networking_subnet_v2", "cluster", existing[infraflow.IdentifierSubnet], namespace),
+				tfResource("openstack_networking_secgroup_v2", "cluster", existing[infraflow.IdentifierSecGroup], namespace),
+				tfResource("openstack_compute_keypair_v2", "ssh_key", namespace, namespace),
+			},
+		}
+		state, report := infraflow.NewPersistentStateFromTerraformState(tfState)
+		Expect(report.Unmapped).To(BeEmpty())
+
+		reconciled := reconcile(state.ToFlatMap())
+
+		Expect(reconciled).To(HaveKeyWithValue(infraflow.IdentifierNetwork, networkID))
+		for _, operation := range []string{"CreateRouter", "CreateNetwork", "CreateSubnet", "CreateSecurityGroup", "CreateKeyPair"} {
+			Expect(cloud.Calls(operation)).To(Equal(1), operation)
+		}
+		networkList, err := networking.ListNetwork(ctx, networks.ListOpts{ID: networkID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(networkList).To(HaveLen(1))
+		Expect(networkList[0].Name).To(Equal(namespace))
+	})
+})
+
+func tfResource(tfType, name, id, resourceName string) shared.TFResource {
+	return shared.TFResource{
+		Mode: shared.ModeManaged,
+		Type: tfType,
+		Name: name,
+		Instances: []shared.TFInstance{{
+			Attributes: map[string]interface{}{shared.AttributeKeyId: id, shared.AttributeKeyName: resourceName},
+		}},
+	}
+}
+
+const openstackTFState = `{
+  "version": 4,
+  "terraform_version": "1.5.7",
+  "serial": 12,
+  "lineage": "9d6b0d5a-3c0c-4b5e-8f5e-3b1c6b0a7e4f",
+  "outputs": {
+    "router_id": {"value": "router-id", "type": "string"},
+    "router_ip": {"value": "172.24.4.2", "type": "string"},
+    "network_id": {"value": "network-id", "type": "string"},
+    "floating_network_id": {"value": "fip-network-id", "type": "string"}
+  },
+  "resources": [
+    {
+      "mode": "data",
+      "type": "openstack_networking_network_v2",
+      "name": "fip",
+      "instances": [{"attributes": {"id": "fip-network-id", "name": "public"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_router_v2",
+      "name": "router",
+      "instances": [{"attributes": {"id": "router-id", "name": "shoot--foo--bar"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_network_v2",
+      "name": "cluster",
+      "instances": [{"attributes": {"id": "network-id", "name": "shoot--foo--bar-renamed"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_subnet_v2",
+      "name": "cluster",
+      "instances": [{"attributes": {"id": "subnet-id", "name": "shoot--foo--bar"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_router_interface_v2",
+      "name": "router_nodes",
+      "instances": [{"attributes": {"id": "router-interface-id", "router_id": "router-id", "subnet_id": "subnet-id"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_secgroup_v2",
+      "name": "cluster",
+      "instances": [{"attributes": {"id": "secgroup-id", "name": "shoot--foo--bar"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_secgroup_rule_v2",
+      "name": "cluster_self",
+      "instances": [{"attributes": {"id": "rule-self-id"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_secgroup_rule_v2",
+      "name": "cluster_egress",
+      "instances": [{"attributes": {"id": "rule-egress-id"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_secgroup_rule_v2",
+      "name": "cluster_tcp_all",
+      "instances": [{"attributes": {"id": "rule-tcp-1-id"}}, {"attributes": {"id": "rule-tcp-2-id"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_sharedfilesystem_sharenetwork_v2",
+      "name": "cluster",
+      "instances": [{"attributes": {"id": "share-network-id", "name": "shoot--foo--bar"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_compute_keypair_v2",
+      "name": "ssh_key",
+      "instances": [{"attributes": {"id": "shoot--foo--bar", "name": "shoot--foo--bar"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "openstack_networking_floatingip_v2",
+      "name": "manual",
+      "instances": [{"attributes": {"id": "fip-id"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "null_resource",
+      "name": "outputs",
+      "instances": [{"attributes": {"id": "123"}}]
+    }
+  ]
+}`
@@ -44,6 +44,8 @@ func NewPersistentState() *PersistentState {
 
 // NewPersistentStateFromJSON unmarshals PersistentState from JSON or YAML.
 // Returns nil if input contains no kind field with value "FlowState".
+// States of older versions are migrated to the current version, so that the returned state always has a valid version.
+// An error is returned for unsupported versions.
 func NewPersistentStateFromJSON(raw []byte) (*PersistentState, error) {
 	// first check if state is from flow or Terraformer
 	marker := &metav1.TypeMeta{}
@@ -62,13 +64,18 @@ func NewPersistentStateFromJSON(raw []byte) (*PersistentState, error) {
 	if err := json.Unmarshal(raw, state); err != nil {
 		return nil, err
 	}
-	if valid, err := state.HasValidVersion(); !valid {
-		return nil, err
-	}
-
 	if state.Data == nil {
 		state.Data = map[string]string{}
 	}
+
+	// states written by older versions of the extension are upgraded to the current layout of the data
+	version := strings.TrimPrefix(state.APIVersion, openstack.GroupName+"/")
+	data, err := migrateStateData(stateMigrations, version, state.Data)
+	if err != nil {
+		return nil, err
+	}
+	state.APIVersion = PersistentStateAPIVersion
+	state.Data = data
 	return state, nil
 }
 
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/version"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+)
+
+// stateMigration upgrades the data of a PersistentState from the preceding version of the registry to its version.
+type stateMigration struct {
+	// version is the version of the PersistentState after the migration.
+	version string
+	// migrate changes the layout of the data in place.
+	migrate func(data shared.FlatMap)
+}
+
+// stateMigrations is the registry of the versions of the PersistentState in ascending order. The first version has no
+// migration, and the last one must be the PersistentStateVersion. Changes of the layout of the data, e.g. renamed keys or
+// keys moved to a child, are added as a new version with a migration from the preceding version. New keys which are
+// simply missing in older states do not require a new version.
+var stateMigrations = []stateMigration{
+	{version: "v1alpha1"},
+}
+
+// migrateStateData upgrades the data of the given version with the migrations of the registry to its last version.
+// States of unknown versions are refused, in particular the ones written by a newer version of the extension, which
+// could be corrupted by the flow of this version.
+func migrateStateData(migrations []stateMigration, stateVersion string, data shared.FlatMap) (shared.FlatMap, error) {
+	latest := migrations[len(migrations)-1].version
+	index := slices.IndexFunc(migrations, func(m stateMigration) bool { return m.version == stateVersion })
+	if index < 0 {
+		if version.CompareKubeAwareVersionStrings(stateVersion, latest) > 0 {
+			return nil, fmt.Errorf("state version %s was written by a newer version of the extension, the latest supported version is %s", stateVersion, latest)
+		}
+		return nil, fmt.Errorf("unsupported state version %s", stateVersion)
+	}
+
+	data = copyMap(data)
+	for _, m := range migrations[index+1:] {
+		m.migrate(data)
+	}
+	return data, nil
+}
+
+// renameKeys returns a migration renaming the given keys of the data.
+func renameKeys(renames map[string]string) func(data shared.FlatMap) {
+	return func(data shared.FlatMap) {
+		for oldKey, newKey := range renames {
+			if value, ok := data[oldKey]; ok {
+				delete(data, oldKey)
+				data[newKey] = value
+			}
+		}
+	}
+}
+
+// splitToChild returns a migration moving the given keys of the data to a child whiteboard, e.g. to group the keys of
+// a resource which gets more than one instance. The keys are mapped to their names in the child.
+func splitToChild(child string, keys map[string]string) func(data shared.FlatMap) {
+	renames := make(map[string]string, len(keys))
+	for oldKey, newKey := range keys {
+		renames[oldKey] = child + shared.Separator + newKey
+	}
+	return renameKeys(renames)
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+)
+
+var _ = Describe("PersistentStateMigration", func() {
+	// testMigrations is a registry with changes of the layout, which the current registry does not have yet.
+	testMigrations := []stateMigration{
+		{version: "v1alpha1"},
+		{version: "v1alpha2", migrate: renameKeys(map[string]string{NameKeyPair: "KeyPairName"})},
+		{version: "v1alpha3", migrate: splitToChild("IPv6", map[string]string{IdentifierSubnetIPv6: "Subnet", CIDRSubnetIPv6: "CIDR"})},
+	}
+
+	readGoldenState := func(file string) *PersistentState {
+		raw, err := os.ReadFile(filepath.Join("testdata", file))
+		Expect(err).NotTo(HaveOccurred())
+		state := &PersistentState{}
+		Expect(json.Unmarshal(raw, state)).To(Succeed())
+		return state
+	}
+
+	It("should end the registry with the current version", func() {
+		Expect(stateMigrations[0].migrate).To(BeNil())
+		Expect(stateMigrations[len(stateMigrations)-1].version).To(Equal(PersistentStateVersion))
+	})
+
+	It("should load a state of the current version unchanged", func() {
+		raw, err := os.ReadFile(filepath.Join("testdata", "state-v1alpha1.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		state, err := NewPersistentStateFromJSON(raw)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(readGoldenState("state-v1alpha1.json")))
+	})
+
+	It("should refuse to load a state written by a newer version of the extension", func() {
+		_, err := NewPersistentStateFromJSON([]byte(`{"apiVersion":"` + openstack.GroupName + `/v1alpha2","kind":"FlowState","data":{}}`))
+		Expect(err).To(MatchError(ContainSubstring("state version v1alpha2 was written by a newer version of the extension")))
+	})
+
+	DescribeTable("#migrateStateData",
+		func(input, expected string) {
+			state := readGoldenState(input)
+			data, err := migrateStateData(testMigrations, strings.TrimPrefix(state.APIVersion, openstack.GroupName+"/"), state.Data)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(readGoldenState(expected).ToFlatMap()))
+			Expect(state.Data).To(Equal(readGoldenState(input).Data), "the input is not modified")
+		},
+		Entry("should rename the keys and split the child of a v1alpha1 state", "state-v1alpha1.json", "state-v1alpha3.json"),
+		Entry("should split the child of a v1alpha2 state", "state-v1alpha2.json", "state-v1alpha3.json"),
+		Entry("should keep a state of the latest version", "state-v1alpha3.json", "state-v1alpha3.json"),
+	)
+
+	DescribeTable("#migrateStateData with unsupported versions",
+		func(stateVersion, expectedError string) {
+			_, err := migrateStateData(testMigrations, stateVersion, readGoldenState("state-v1alpha1.json").Data)
+			Expect(err).To(MatchError(expectedError))
+		},
+		Entry("should refuse a newer alpha version", "v1alpha4", "state version v1alpha4 was written by a newer version of the extension, the latest supported version is v1alpha3"),
+		Entry("should refuse a newer beta version", "v1beta1", "state version v1beta1 was written by a newer version of the extension, the latest supported version is v1alpha3"),
+		Entry("should refuse an unknown older version", "v1alpha0", "unsupported state version v1alpha0"),
+		Entry("should refuse an invalid version", "foo", "unsupported state version foo"),
+	)
+})
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/flow"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	openstackapi "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	osclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+// PlanAction is the kind of change the flow intends to make to a resource.
+type PlanAction string
+
+const (
+	// PlanActionCreate is the action for resources which would be created.
+	PlanActionCreate PlanAction = "create"
+	// PlanActionUpdate is the action for resources which would be updated.
+	PlanActionUpdate PlanAction = "update"
+	// PlanActionDelete is the action for resources which would be deleted.
+	PlanActionDelete PlanAction = "delete"
+
+	// PlanFlowReconcile is the name of the planned reconciliation flow.
+	PlanFlowReconcile = "reconcile"
+	// PlanFlowDelete is the name of the planned deletion flow.
+	PlanFlowDelete = "delete"
+
+	// plannedIDPrefix is the prefix of the ids of resources which only exist in the plan.
+	plannedIDPrefix = "planned-"
+)
+
+// PlannedChange is a change of a single cloud resource recorded in plan mode.
+type PlannedChange struct {
+	// Action is the intended action.
+	Action PlanAction `json:"action"`
+	// Resource is the kind of the resource, e.g. network or securityGroupRule.
+	Resource string `json:"resource"`
+	// ID is the id of an existing resource or a placeholder for a resource which would be created.
+	ID string `json:"id,omitempty"`
+	// Name is the name of the resource if known.
+	Name string `json:"name,omitempty"`
+	// Details describes the change, e.g. the updated fields.
+	Details string `json:"details,omitempty"`
+}
+
+// Plan is the report of a flow run in plan mode.
+type Plan struct {
+	// Flow is the name of the planned flow.
+	Flow string `json:"flow"`
+	// Changes are the recorded changes sorted by resource kind and action.
+	Changes []PlannedChange `json:"changes"`
+}
+
+// ToJSON returns the plan as indented JSON.
+func (p *Plan) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// NewPlanningFlowContext creates a FlowContext in plan mode. All read operations are executed against the cloud, but
+// creates, updates and deletes are only recorded and answered with placeholder objects. The state is never persisted.
+func NewPlanningFlowContext(log logr.Logger, clientFactory osclient.Factory,
+	infra *extensionsv1alpha1.Infrastructure, config *openstackapi.InfrastructureConfig,
+	cloudProfileConfig *openstackapi.CloudProfileConfig, seedName string, oldState shared.FlatMap) (*FlowContext, error) {
+
+	recorder := &planRecorder{}
+	c, err := NewFlowContext(log, &planningFactory{Factory: clientFactory, recorder: recorder}, infra, config, cloudProfileConfig, seedName, oldState, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.planRecorder = recorder
+	return c, nil
+}
+
+// PlanReconcile runs the reconciliation flow in plan mode and returns the intended changes.
+func (c *FlowContext) PlanReconcile(ctx context.Context) (*Plan, error) {
+	if c.planRecorder == nil {
+		return nil, fmt.Errorf("flow context is not in plan mode")
+	}
+	if err := c.runPlan(ctx, c.buildReconcileGraph()); err != nil {
+		return nil, err
+	}
+	return c.planRecorder.plan(PlanFlowReconcile), nil
+}
+
+// PlanDelete runs the deletion flow in plan mode and returns the intended changes.
+func (c *FlowContext) PlanDelete(ctx context.Context) (*Plan, error) {
+	if c.planRecorder == nil {
+		return nil, fmt.Errorf("flow context is not in plan mode")
+	}
+	if !c.state.IsEmpty() {
+		if err := c.runPlan(ctx, c.buildDeleteGraph()); err != nil {
+			return nil, err
+		}
+	}
+	return c.planRecorder.plan(PlanFlowDelete), nil
+}
+
+func (c *FlowContext) runPlan(ctx context.Context, g *flow.Graph) error {
+	f := g.Compile()
+	if err := f.Run(ctx, flow.Opts{Log: c.Log}); err != nil {
+		return flow.Causes(err)
+	}
+	return nil
+}
+
+// planRecorder collects the changes of the planning clients. It is safe for concurrent use by the flow tasks.
+type planRecorder struct {
+	lock    sync.Mutex
+	changes []PlannedChange
+	counter int
+	deleted sets.Set[string]
+}
+
+// record adds the change and returns a placeholder id for resources which would be created.
+func (r *planRecorder) record(change PlannedChange) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if change.Action == PlanActionCreate && change.ID == "" {
+		r.counter++
+		change.ID = fmt.Sprintf("%s%s-%d", plannedIDPrefix, strings.ToLower(change.Resource), r.counter)
+	}
+	if change.Action == PlanActionDelete && change.ID != "" {
+		if r.deleted == nil {
+			r.deleted = sets.New[string]()
+		}
+		r.deleted.Insert(change.ID)
+	}
+	r.changes = append(r.changes, change)
+	return change.ID
+}
+
+// isDeleted returns true if the deletion of the resource with the given id has been recorded.
+func (r *planRecorder) isDeleted(id string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.deleted.Has(id)
+}
+
+func (r *planRecorder) plan(flowName string) *Plan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	changes := slices.Clone(r.changes)
+	// the tasks run concurrently, so sort by all fields to get a stable report
+	slices.SortFunc(changes, func(a, b PlannedChange) int {
+		return cmp.Or(
+			strings.Compare(a.Resource, b.Resource),
+			strings.Compare(string(a.Action), string(b.Action)),
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.Details, b.Details),
+			strings.Compare(a.ID, b.ID),
+		)
+	})
+	return &Plan{Flow: flowName, Changes: changes}
+}
+
+func isPlannedID(ids ...string) bool {
+	return slices.ContainsFunc(ids, func(id string) bool {
+		return strings.HasPrefix(id, plannedIDPrefix)
+	})
+}
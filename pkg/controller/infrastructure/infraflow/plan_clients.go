@@ -0,0 +1,422 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	computefip "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+
+	osclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+// planningFactory creates clients which pass read operations to the clients of the wrapped factory and record all
+// mutating operations instead of executing them.
+type planningFactory struct {
+	osclient.Factory
+	recorder *planRecorder
+}
+
+func (f *planningFactory) Networking(options ...osclient.Option) (osclient.Networking, error) {
+	networking, err := f.Factory.Networking(options...)
+	if err != nil {
+		return nil, err
+	}
+	return &planningNetworking{Networking: networking, recorder: f.recorder}, nil
+}
+
+func (f *planningFactory) Compute(options ...osclient.Option) (osclient.Compute, error) {
+	compute, err := f.Factory.Compute(options...)
+	if err != nil {
+		return nil, err
+	}
+	return &planningCompute{Compute: compute, recorder: f.recorder}, nil
+}
+
+func (f *planningFactory) Loadbalancing(options ...osclient.Option) (osclient.Loadbalancing, error) {
+	loadbalancing, err := f.Factory.Loadbalancing(options...)
+	if err != nil {
+		return nil, err
+	}
+	return &planningLoadbalancing{Loadbalancing: loadbalancing, recorder: f.recorder}, nil
+}
+
+func (f *planningFactory) SharedFilesystem(options ...osclient.Option) (osclient.SharedFilesystem, error) {
+	sharedFilesystem, err := f.Factory.SharedFilesystem(options...)
+	if err != nil {
+		return nil, err
+	}
+	return &planningSharedFilesystem{SharedFilesystem: sharedFilesystem, recorder: f.recorder}, nil
+}
+
+type planningNetworking struct {
+	osclient.Networking
+	recorder *planRecorder
+}
+
+var _ osclient.Networking = &planningNetworking{}
+
+func (n *planningNetworking) CreateNetwork(_ context.Context, opts networks.CreateOpts) (*networks.Network, error) {
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "network", Name: opts.Name})
+	return &networks.Network{ID: id, Name: opts.Name, AdminStateUp: true, Status: "ACTIVE"}, nil
+}
+
+func (n *planningNetworking) ListNetwork(ctx context.Context, listOpts networks.ListOpts) ([]networks.Network, error) {
+	if isPlannedID(listOpts.ID) {
+		return nil, nil
+	}
+	return n.Networking.ListNetwork(ctx, listOpts)
+}
+
+func (n *planningNetworking) UpdateNetwork(_ context.Context, networkID string, _ networks.UpdateOpts) (*networks.Network, error) {
+	n.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: "network", ID: networkID, Details: "name or admin state"})
+	return &networks.Network{ID: networkID}, nil
+}
+
+func (n *planningNetworking) DeleteNetwork(_ context.Context, networkID string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "network", ID: networkID})
+	return nil
+}
+
+func (n *planningNetworking) CreateFloatingIP(_ context.Context, createOpts floatingips.CreateOpts) (*floatingips.FloatingIP, error) {
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "floatingIP", Details: "network " + createOpts.FloatingNetworkID})
+	return &floatingips.FloatingIP{ID: id, FloatingNetworkID: createOpts.FloatingNetworkID, Description: createOpts.Description}, nil
+}
+
+func (n *planningNetworking) DeleteFloatingIP(_ context.Context, id string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "floatingIP", ID: id})
+	return nil
+}
+
+func (n *planningNetworking) CreateSecurityGroup(_ context.Context, opts groups.CreateOpts) (*groups.SecGroup, error) {
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "securityGroup", Name: opts.Name})
+	group := &groups.SecGroup{ID: id, Name: opts.Name, Description: opts.Description}
+	// like Neutron, assume the default egress rules are created together with the group
+	for _, etherType := range []rules.RuleEtherType{rules.EtherType4, rules.EtherType6} {
+		group.Rules = append(group.Rules, rules.SecGroupRule{
+			Direction:  string(rules.DirEgress),
+			EtherType:  string(etherType),
+			SecGroupID: id,
+		})
+	}
+	return group, nil
+}
+
+func (n *planningNetworking) DeleteSecurityGroup(_ context.Context, groupID string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "securityGroup", ID: groupID})
+	return nil
+}
+
+func (n *planningNetworking) GetSecurityGroup(ctx context.Context, groupID string) (*groups.SecGroup, error) {
+	if isPlannedID(groupID) {
+		return nil, gophercloud.ErrDefault404{}
+	}
+	return n.Networking.GetSecurityGroup(ctx, groupID)
+}
+
+func (n *planningNetworking) CreateRule(_ context.Context, createOpts rules.CreateOpts) (*rules.SecGroupRule, error) {
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "securityGroupRule", Details: describeRule(createOpts)})
+	return &rules.SecGroupRule{
+		ID:             id,
+		Direction:      string(createOpts.Direction),
+		Description:    createOpts.Description,
+		EtherType:      string(createOpts.EtherType),
+		SecGroupID:     createOpts.SecGroupID,
+		PortRangeMin:   createOpts.PortRangeMin,
+		PortRangeMax:   createOpts.PortRangeMax,
+		Protocol:       string(createOpts.Protocol),
+		RemoteGroupID:  createOpts.RemoteGroupID,
+		RemoteIPPrefix: createOpts.RemoteIPPrefix,
+		ProjectID:      createOpts.ProjectID,
+	}, nil
+}
+
+func (n *planningNetworking) DeleteRule(_ context.Context, ruleID string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "securityGroupRule", ID: ruleID})
+	return nil
+}
+
+func (n *planningNetworking) ListRouters(ctx context.Context, listOpts routers.ListOpts) ([]routers.Router, error) {
+	if isPlannedID(listOpts.ID) {
+		return nil, nil
+	}
+	return n.Networking.ListRouters(ctx, listOpts)
+}
+
+func (n *planningNetworking) GetRouterByID(ctx context.Context, id string) (*routers.Router, error) {
+	if isPlannedID(id) {
+		return nil, nil
+	}
+	return n.Networking.GetRouterByID(ctx, id)
+}
+
+func (n *planningNetworking) UpdateRoutesForRouter(_ context.Context, routes []routers.Route, routerID string) (*routers.Router, error) {
+	n.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: "router", ID: routerID, Details: fmt.Sprintf("%d remaining routes", len(routes))})
+	return &routers.Router{ID: routerID, Routes: routes}, nil
+}
+
+func (n *planningNetworking) UpdateRouter(_ context.Context, routerID string, updateOpts routers.UpdateOpts) (*routers.Router, error) {
+	var fields []string
+	if updateOpts.Name != "" {
+		fields = append(fields, "name")
+	}
+	if updateOpts.GatewayInfo != nil {
+		fields = append(fields, "external gateway")
+	}
+	if updateOpts.Routes != nil {
+		fields = append(fields, "routes")
+	}
+	n.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: "router", ID: routerID, Name: updateOpts.Name, Details: strings.Join(fields, ", ")})
+	return &routers.Router{ID: routerID, Name: updateOpts.Name}, nil
+}
+
+func (n *planningNetworking) CreateRouter(_ context.Context, createOpts routers.CreateOpts) (*routers.Router, error) {
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "router", Name: createOpts.Name})
+	router := &routers.Router{ID: id, Name: createOpts.Name, Status: "ACTIVE"}
+	if createOpts.GatewayInfo != nil {
+		router.GatewayInfo = *createOpts.GatewayInfo
+	}
+	if len(router.GatewayInfo.ExternalFixedIPs) == 0 {
+		// the address is only known after the creation
+		router.GatewayInfo.ExternalFixedIPs = []routers.ExternalFixedIP{{}}
+	}
+	return router, nil
+}
+
+func (n *planningNetworking) DeleteRouter(_ context.Context, routerID string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "router", ID: routerID})
+	return nil
+}
+
+func (n *planningNetworking) AddRouterInterface(_ context.Context, routerID string, addOpts routers.AddInterfaceOpts) (*routers.InterfaceInfo, error) {
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "routerInterface", Details: fmt.Sprintf("router %s, subnet %s", routerID, addOpts.SubnetID)})
+	return &routers.InterfaceInfo{ID: routerID, SubnetID: addOpts.SubnetID, PortID: id}, nil
+}
+
+func (n *planningNetworking) RemoveRouterInterface(_ context.Context, routerID string, removeOpts routers.RemoveInterfaceOpts) (*routers.InterfaceInfo, error) {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "routerInterface", ID: removeOpts.PortID, Details: fmt.Sprintf("router %s, subnet %s", routerID, removeOpts.SubnetID)})
+	return &routers.InterfaceInfo{ID: routerID, SubnetID: removeOpts.SubnetID, PortID: removeOpts.PortID}, nil
+}
+
+func (n *planningNetworking) CreateSubnet(_ context.Context, createOpts subnets.CreateOpts) (*subnets.Subnet, error) {
+	details := createOpts.CIDR
+	if details == "" && createOpts.SubnetPoolID != "" {
+		details = "from subnet pool " + createOpts.SubnetPoolID
+	}
+	id := n.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "subnet", Name: createOpts.Name, Details: details})
+	return &subnets.Subnet{
+		ID:             id,
+		NetworkID:      createOpts.NetworkID,
+		Name:           createOpts.Name,
+		IPVersion:      int(createOpts.IPVersion),
+		CIDR:           createOpts.CIDR,
+		DNSNameservers: createOpts.DNSNameservers,
+		SubnetPoolID:   createOpts.SubnetPoolID,
+	}, nil
+}
+
+func (n *planningNetworking) ListSubnets(ctx context.Context, listOpts subnets.ListOpts) ([]subnets.Subnet, error) {
+	if isPlannedID(listOpts.ID, listOpts.NetworkID) {
+		return nil, nil
+	}
+	return n.Networking.ListSubnets(ctx, listOpts)
+}
+
+func (n *planningNetworking) UpdateSubnet(_ context.Context, subnetID string, updateOpts subnets.UpdateOpts) (*subnets.Subnet, error) {
+	var fields []string
+	if updateOpts.Name != nil {
+		fields = append(fields, "name")
+	}
+	if updateOpts.DNSNameservers != nil {
+		fields = append(fields, "DNS nameservers")
+	}
+	n.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: "subnet", ID: subnetID, Details: strings.Join(fields, ", ")})
+	return &subnets.Subnet{ID: subnetID}, nil
+}
+
+func (n *planningNetworking) DeleteSubnet(_ context.Context, subnetID string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "subnet", ID: subnetID})
+	return nil
+}
+
+func (n *planningNetworking) GetPort(ctx context.Context, portID string) (*ports.Port, error) {
+	if isPlannedID(portID) {
+		return &ports.Port{ID: portID, Status: "ACTIVE"}, nil
+	}
+	return n.Networking.GetPort(ctx, portID)
+}
+
+func (n *planningNetworking) DeletePort(_ context.Context, portID string) error {
+	n.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "port", ID: portID})
+	return nil
+}
+
+func (n *planningNetworking) GetRouterInterfacePort(ctx context.Context, routerID, subnetID string) (*ports.Port, error) {
+	if isPlannedID(routerID, subnetID) {
+		return nil, nil
+	}
+	return n.Networking.GetRouterInterfacePort(ctx, routerID, subnetID)
+}
+
+func (n *planningNetworking) ReplaceAllTags(_ context.Context, resourceType, resourceID string, tags []string) ([]string, error) {
+	if !isPlannedID(resourceID) {
+		n.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: planResourceOfType(resourceType), ID: resourceID, Details: "tags"})
+	}
+	return tags, nil
+}
+
+type planningCompute struct {
+	osclient.Compute
+	recorder *planRecorder
+}
+
+var _ osclient.Compute = &planningCompute{}
+
+func (c *planningCompute) CreateServerGroup(_ context.Context, name, policy string) (*servergroups.ServerGroup, error) {
+	id := c.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "serverGroup", Name: name, Details: "policy " + policy})
+	return &servergroups.ServerGroup{ID: id, Name: name, Policies: []string{policy}}, nil
+}
+
+func (c *planningCompute) DeleteServerGroup(_ context.Context, id string) error {
+	c.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "serverGroup", ID: id})
+	return nil
+}
+
+func (c *planningCompute) CreateServer(_ context.Context, createOpts servers.CreateOpts) (*servers.Server, error) {
+	id := c.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "server", Name: createOpts.Name})
+	return &servers.Server{ID: id, Name: createOpts.Name}, nil
+}
+
+func (c *planningCompute) DeleteServer(_ context.Context, id string) error {
+	c.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "server", ID: id})
+	return nil
+}
+
+func (c *planningCompute) AssociateFIPWithInstance(_ context.Context, serverID string, associateOpts computefip.AssociateOpts) error {
+	c.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: "server", ID: serverID, Details: "associate floating IP " + associateOpts.FloatingIP})
+	return nil
+}
+
+func (c *planningCompute) CreateKeyPair(_ context.Context, name, publicKey string) (*keypairs.KeyPair, error) {
+	c.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "keyPair", ID: name, Name: name})
+	return &keypairs.KeyPair{Name: name, PublicKey: publicKey}, nil
+}
+
+func (c *planningCompute) DeleteKeyPair(_ context.Context, name string) error {
+	c.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "keyPair", ID: name, Name: name})
+	return nil
+}
+
+type planningLoadbalancing struct {
+	osclient.Loadbalancing
+	recorder *planRecorder
+}
+
+var _ osclient.Loadbalancing = &planningLoadbalancing{}
+
+func (l *planningLoadbalancing) GetLoadbalancer(ctx context.Context, id string) (*loadbalancers.LoadBalancer, error) {
+	if l.recorder.isDeleted(id) {
+		// pretend the deletion has completed
+		return nil, nil
+	}
+	return l.Loadbalancing.GetLoadbalancer(ctx, id)
+}
+
+func (l *planningLoadbalancing) DeleteLoadbalancer(_ context.Context, id string, _ loadbalancers.DeleteOpts) error {
+	l.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "loadBalancer", ID: id})
+	return nil
+}
+
+type planningSharedFilesystem struct {
+	osclient.SharedFilesystem
+	recorder *planRecorder
+}
+
+var _ osclient.SharedFilesystem = &planningSharedFilesystem{}
+
+func (s *planningSharedFilesystem) GetShareNetwork(ctx context.Context, id string) (*sharenetworks.ShareNetwork, error) {
+	if isPlannedID(id) {
+		return nil, nil
+	}
+	return s.SharedFilesystem.GetShareNetwork(ctx, id)
+}
+
+func (s *planningSharedFilesystem) CreateShareNetwork(_ context.Context, createOpts sharenetworks.CreateOpts) (*sharenetworks.ShareNetwork, error) {
+	id := s.recorder.record(PlannedChange{Action: PlanActionCreate, Resource: "shareNetwork", Name: createOpts.Name})
+	return &sharenetworks.ShareNetwork{
+		ID:              id,
+		Name:            createOpts.Name,
+		NeutronNetID:    createOpts.NeutronNetID,
+		NeutronSubnetID: createOpts.NeutronSubnetID,
+	}, nil
+}
+
+func (s *planningSharedFilesystem) ListShareNetworks(ctx context.Context, listOpts sharenetworks.ListOpts) ([]sharenetworks.ShareNetwork, error) {
+	if isPlannedID(listOpts.NeutronNetID, listOpts.NeutronSubnetID) {
+		return nil, nil
+	}
+	return s.SharedFilesystem.ListShareNetworks(ctx, listOpts)
+}
+
+func (s *planningSharedFilesystem) UpdateShareNetwork(_ context.Context, id string, _ sharenetworks.UpdateOpts) (*sharenetworks.ShareNetwork, error) {
+	if !isPlannedID(id) {
+		s.recorder.record(PlannedChange{Action: PlanActionUpdate, Resource: "shareNetwork", ID: id, Details: "tags"})
+	}
+	return &sharenetworks.ShareNetwork{ID: id}, nil
+}
+
+func (s *planningSharedFilesystem) DeleteShareNetwork(_ context.Context, id string) error {
+	s.recorder.record(PlannedChange{Action: PlanActionDelete, Resource: "shareNetwork", ID: id})
+	return nil
+}
+
+// planResourceOfType returns the resource kind used in the plan for the given Neutron resource type.
+func planResourceOfType(resourceType string) string {
+	switch resourceType {
+	case osclient.ResourceTypeNetworks:
+		return "network"
+	case osclient.ResourceTypeSubnets:
+		return "subnet"
+	case osclient.ResourceTypeRouters:
+		return "router"
+	case osclient.ResourceTypeSecurityGroups:
+		return "securityGroup"
+	case osclient.ResourceTypeFloatingIPs:
+		return "floatingIP"
+	}
+	return resourceType
+}
+
+func describeRule(opts rules.CreateOpts) string {
+	parts := []string{string(opts.Direction), string(opts.EtherType)}
+	if opts.Protocol != "" {
+		parts = append(parts, string(opts.Protocol))
+	}
+	if opts.PortRangeMin != 0 || opts.PortRangeMax != 0 {
+		parts = append(parts, fmt.Sprintf("%d-%d", opts.PortRangeMin, opts.PortRangeMax))
+	}
+	if opts.RemoteIPPrefix != "" {
+		parts = append(parts, "from "+opts.RemoteIPPrefix)
+	}
+	if opts.RemoteGroupID != "" {
+		parts = append(parts, "from group "+opts.RemoteGroupID)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infraflow
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	osclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+var _ = Describe("PlanningClients", func() {
+	mutatingPrefixes := []string{"Create", "Update", "Delete", "Add", "Remove", "Replace", "Associate", "Disassociate"}
+
+	isMutating := func(method string) bool {
+		for _, prefix := range mutatingPrefixes {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// callMutatingMethods calls all mutating methods of the given interface on the planning client with zero arguments.
+	// The wrapped client of the planning client must be nil, so that a method promoted from it panics instead of
+	// reaching the cloud.
+	callMutatingMethods := func(iface reflect.Type, client any) []string {
+		var called []string
+		value := reflect.ValueOf(client)
+		for i := 0; i < iface.NumMethod(); i++ {
+			method := iface.Method(i)
+			if !isMutating(method.Name) {
+				continue
+			}
+			called = append(called, method.Name)
+
+			args := make([]reflect.Value, method.Type.NumIn())
+			for j := range args {
+				args[j] = reflect.Zero(method.Type.In(j))
+			}
+			args[0] = reflect.ValueOf(context.Background())
+			Expect(func() {
+				value.MethodByName(method.Name).Call(args)
+			}).NotTo(Panic(), fmt.Sprintf("%s.%s is not overridden by the planning client", iface.Name(), method.Name))
+		}
+		return called
+	}
+
+	DescribeTable("should not pass any mutating operation to the wrapped client",
+		func(iface reflect.Type, client any) {
+			Expect(callMutatingMethods(iface, client)).NotTo(BeEmpty())
+		},
+		Entry("Networking", reflect.TypeOf((*osclient.Networking)(nil)).Elem(), &planningNetworking{recorder: &planRecorder{}}),
+		Entry("Compute", reflect.TypeOf((*osclient.Compute)(nil)).Elem(), &planningCompute{recorder: &planRecorder{}}),
+		Entry("Loadbalancing", reflect.TypeOf((*osclient.Loadbalancing)(nil)).Elem(), &planningLoadbalancing{recorder: &planRecorder{}}),
+		Entry("SharedFilesystem", reflect.TypeOf((*osclient.SharedFilesystem)(nil)).Elem(), &planningSharedFilesystem{recorder: &planRecorder{}}),
+	)
+})
@@ -7,18 +7,26 @@ package infraflow
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gardener/gardener/pkg/utils/flow"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
 	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
+	openstackapi "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/access"
 	. "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	osclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
 )
 
 const (
@@ -28,9 +36,7 @@ const (
 
 // Reconcile creates and runs the flow to reconcile the AWS infrastructure.
 func (c *FlowContext) Reconcile(ctx context.Context) error {
-	g := c.buildReconcileGraph()
-	f := g.Compile()
-	if err := f.Run(ctx, flow.Opts{Log: c.Log}); err != nil {
+	if err := c.RunGraph(ctx, c.buildReconcileGraph()); err != nil {
 		return flow.Causes(err)
 	}
 	return nil
@@ -55,9 +61,39 @@ func (c *FlowContext) buildReconcileGraph() *flow.Graph {
 		c.ensureSubnet,
 		Timeout(defaultTimeout), Dependencies(ensureNetwork))
 
-	_ = c.AddTask(g, "ensure router interface",
+	ensureRouterInterface := c.AddTask(g, "ensure router interface",
 		c.ensureRouterInterface,
 		Timeout(defaultTimeout), Dependencies(ensureRouter, ensureSubnet))
+	ensureRouterInterfaces := []flow.TaskIDer{ensureRouterInterface}
+
+	for _, zone := range c.config.Networks.Zones {
+		ensureZoneSubnet := c.AddTask(g, "ensure subnet of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.ensureZoneSubnet(ctx, zone)
+			},
+			Kind("ensure subnet of zone"), Timeout(defaultTimeout), Dependencies(ensureNetwork))
+
+		ensureZoneRouterInterface := c.AddTask(g, "ensure router interface of zone "+zone.Name,
+			func(ctx context.Context) error {
+				return c.ensureZoneRouterInterface(ctx, zone)
+			},
+			Kind("ensure router interface of zone"), Timeout(defaultTimeout), Dependencies(ensureRouter, ensureZoneSubnet))
+		ensureRouterInterfaces = append(ensureRouterInterfaces, ensureZoneRouterInterface)
+	}
+
+	ensureSubnetIPv6 := c.AddTask(g, "ensure IPv6 subnet",
+		c.ensureSubnetIPv6,
+		DoIf(c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(ensureNetwork))
+
+	ensureRouterInterfaceIPv6 := c.AddTask(g, "ensure IPv6 router interface",
+		c.ensureRouterInterfaceIPv6,
+		DoIf(c.config.Networks.IPv6 != nil), Timeout(defaultTimeout), Dependencies(ensureRouter, ensureSubnetIPv6))
+	ensureRouterInterfaces = append(ensureRouterInterfaces, ensureRouterInterfaceIPv6)
+
+	// the next hops of the routes must be in a subnet attached to the router
+	_ = c.AddTask(g, "ensure router routes",
+		c.ensureRouterRoutes,
+		Timeout(defaultTimeout), Dependencies(ensureRouterInterfaces...))
 
 	ensureSecGroup := c.AddTask(g, "ensure security group",
 		c.ensureSecGroup,
@@ -79,8 +115,8 @@ func (c *FlowContext) buildReconcileGraph() *flow.Graph {
 	return g
 }
 
-func (c *FlowContext) ensureExternalNetwork(_ context.Context) error {
-	externalNetwork, err := c.networking.GetExternalNetworkByName(c.config.FloatingPoolName)
+func (c *FlowContext) ensureExternalNetwork(ctx context.Context) error {
+	externalNetwork, err := c.networking.GetExternalNetworkByName(ctx, c.config.FloatingPoolName)
 	if err != nil {
 		return err
 	}
@@ -98,14 +134,14 @@ func (c *FlowContext) ensureRouter(ctx context.Context) error {
 		return fmt.Errorf("missing external network ID")
 	}
 
-	if c.config.Networks.Router != nil {
+	if helper.UsesExistingRouter(c.config) {
 		return c.ensureConfiguredRouter(ctx)
 	}
 	return c.ensureNewRouter(ctx, *externalNetworkID)
 }
 
-func (c *FlowContext) ensureConfiguredRouter(_ context.Context) error {
-	router, err := c.access.GetRouterByID(c.config.Networks.Router.ID)
+func (c *FlowContext) ensureConfiguredRouter(ctx context.Context) error {
+	router, err := c.access.GetRouterByID(ctx, c.config.Networks.Router.ID)
 	if err != nil {
 		c.state.Set(IdentifierRouter, "")
 		return err
@@ -123,39 +159,66 @@ func (c *FlowContext) ensureNewRouter(ctx context.Context, externalNetworkID str
 		ExternalNetworkID: externalNetworkID,
 		EnableSNAT:        c.cloudProfileConfig.UseSNAT,
 	}
-	current, err := c.findExistingRouter()
+	current, err := c.findExistingRouter(ctx)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		c.state.Set(IdentifierRouter, current.ID)
 		c.state.Set(RouterIP, current.ExternalFixedIPs[0].IPAddress)
-		_, err := c.access.UpdateRouter(desired, current)
-		return err
+		if _, err := c.access.UpdateRouter(ctx, desired, current); err != nil {
+			return err
+		}
+		return c.ensureTags(ctx, osclient.ResourceTypeRouters, current.ID, current.Tags, openstack.TagPurposeNodes)
 	}
 
 	floatingPoolSubnetName := c.findFloatingPoolSubnetName()
 	c.state.SetPtr(NameFloatingPoolSubnet, floatingPoolSubnetName)
 	if floatingPoolSubnetName != nil {
 		log.Info("looking up floating pool subnets...")
-		desired.ExternalSubnetIDs, err = c.access.LookupFloatingPoolSubnetIDs(externalNetworkID, *floatingPoolSubnetName)
+		desired.ExternalSubnetIDs, err = c.access.LookupFloatingPoolSubnetIDs(ctx, externalNetworkID, *floatingPoolSubnetName)
 		if err != nil {
 			return err
 		}
 	}
 	log.Info("creating...")
-	created, err := c.access.CreateRouter(desired)
+	created, err := c.access.CreateRouter(ctx, desired)
 	if err != nil {
 		return err
 	}
 	c.state.Set(IdentifierRouter, created.ID)
 	c.state.Set(RouterIP, created.ExternalFixedIPs[0].IPAddress)
 
-	return nil
+	return c.ensureTags(ctx, osclient.ResourceTypeRouters, created.ID, created.Tags, openstack.TagPurposeNodes)
+}
+
+func (c *FlowContext) findExistingRouter(ctx context.Context) (*access.Router, error) {
+	finder := byTagsOrName(c.lookupTags(openstack.TagPurposeNodes), c.access.GetRouterByTags, c.access.GetRouterByName,
+		func(router *access.Router) []string { return router.Tags })
+	return findExisting(ctx, c.state.Get(IdentifierRouter), c.namespace, c.access.GetRouterByID, finder)
+}
+
+// resourceTags returns the tags of the resources with the given purpose managed by the flow.
+func (c *FlowContext) resourceTags(purpose string) []string {
+	return openstack.ResourceTags(c.namespace, c.seedName, purpose)
+}
+
+// lookupTags returns the tags to look up the resource with the given purpose managed by the flow.
+func (c *FlowContext) lookupTags(purpose string) []string {
+	return openstack.ResourceLookupTags(c.namespace, purpose)
 }
 
-func (c *FlowContext) findExistingRouter() (*access.Router, error) {
-	return findExisting(c.state.Get(IdentifierRouter), c.namespace, c.access.GetRouterByID, c.access.GetRouterByName)
+// ensureTags sets the tags of the extension on a resource managed by the flow. Resources created before the
+// introduction of tags or by the Terraformer are tagged on their next reconciliation this way.
+func (c *FlowContext) ensureTags(ctx context.Context, resourceType, id string, current []string, purpose string) error {
+	modified, err := c.access.UpdateTags(ctx, resourceType, id, current, c.resourceTags(purpose))
+	if err != nil {
+		return fmt.Errorf("updating tags of %s %s failed: %w", resourceType, id, err)
+	}
+	if modified {
+		c.LogFromContext(ctx).Info("updated tags", "resourceType", resourceType, "id", id)
+	}
+	return nil
 }
 
 func (c *FlowContext) findFloatingPoolSubnetName() *string {
@@ -171,6 +234,93 @@ func (c *FlowContext) findFloatingPoolSubnetName() *string {
 	return nil
 }
 
+// ensureRouterRoutes updates the routes of the router configured in the InfrastructureConfig. Only the routes managed
+// by the flow, which are stored in the state, are removed if they are no longer configured. All other routes of the
+// router, e.g. the routes to the pod networks of the nodes managed by the cloud-controller-manager, are kept.
+func (c *FlowContext) ensureRouterRoutes(ctx context.Context) error {
+	log := c.LogFromContext(ctx)
+
+	desired := c.configuredRoutes()
+	managed := routesFromState(c.state.Get(RouterRoutes))
+	if len(desired) == 0 && len(managed) == 0 {
+		return nil
+	}
+	routerID := c.state.Get(IdentifierRouter)
+	if routerID == nil {
+		return fmt.Errorf("missing router ID")
+	}
+
+	if modified, err := c.updateRouterRoutes(ctx, *routerID, managed, desired); err != nil {
+		return err
+	} else if modified {
+		log.Info("updated routes of router", "router", *routerID, "routes", routesToState(desired))
+	}
+	c.state.Set(RouterRoutes, routesToState(desired))
+	return nil
+}
+
+func (c *FlowContext) configuredRoutes() []routers.Route {
+	if c.config.Networks.Router == nil {
+		return nil
+	}
+	routes := make([]routers.Route, 0, len(c.config.Networks.Router.Routes))
+	for _, route := range c.config.Networks.Router.Routes {
+		routes = append(routes, routers.Route{DestinationCIDR: route.Destination, NextHop: route.NextHop})
+	}
+	return routes
+}
+
+// updateRouterRoutes removes the obsolete routes from the router and adds the desired ones, while keeping all other
+// routes. The routes are replaced as a whole, so that the router is read immediately before and only updated if needed,
+// to minimize the risk of overwriting routes added concurrently by the cloud-controller-manager.
+func (c *FlowContext) updateRouterRoutes(ctx context.Context, routerID string, obsolete, desired []routers.Route) (bool, error) {
+	router, err := c.networking.GetRouterByID(ctx, routerID)
+	if err != nil {
+		return false, err
+	}
+	var current []routers.Route
+	if router != nil {
+		current = router.Routes
+	}
+
+	routes := slices.DeleteFunc(slices.Clone(current), func(route routers.Route) bool {
+		return slices.Contains(obsolete, route) && !slices.Contains(desired, route)
+	})
+	for _, route := range desired {
+		if !slices.Contains(routes, route) {
+			routes = append(routes, route)
+		}
+	}
+	if slices.Equal(routes, current) {
+		return false, nil
+	}
+
+	if _, err := c.networking.UpdateRoutesForRouter(ctx, routes, routerID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// routesToState formats the routes as sorted, comma-separated list of `<destination>=<next hop>` entries.
+func routesToState(routes []routers.Route) string {
+	entries := make([]string, 0, len(routes))
+	for _, route := range routes {
+		entries = append(entries, route.DestinationCIDR+"="+route.NextHop)
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, ",")
+}
+
+func routesFromState(value *string) []routers.Route {
+	var routes []routers.Route
+	for _, entry := range strings.Split(ptr.Deref(value, ""), ",") {
+		if destination, nextHop, ok := strings.Cut(entry, "="); ok {
+			routes = append(routes, routers.Route{DestinationCIDR: destination, NextHop: nextHop})
+		}
+	}
+	return routes
+}
+
 func (c *FlowContext) ensureNetwork(ctx context.Context) error {
 	if c.config.Networks.ID != nil {
 		return c.ensureConfiguredNetwork(ctx)
@@ -178,8 +328,8 @@ func (c *FlowContext) ensureNetwork(ctx context.Context) error {
 	return c.ensureNewNetwork(ctx)
 }
 
-func (c *FlowContext) ensureConfiguredNetwork(_ context.Context) error {
-	network, err := c.access.GetNetworkByID(*c.config.Networks.ID)
+func (c *FlowContext) ensureConfiguredNetwork(ctx context.Context) error {
+	network, err := c.access.GetNetworkByID(ctx, *c.config.Networks.ID)
 	if err != nil {
 		c.state.Set(IdentifierNetwork, "")
 		c.state.Set(NameNetwork, "")
@@ -197,34 +347,36 @@ func (c *FlowContext) ensureNewNetwork(ctx context.Context) error {
 		Name:         c.namespace,
 		AdminStateUp: true,
 	}
-	current, err := c.findExistingNetwork()
+	current, err := c.findExistingNetwork(ctx)
 	if err != nil {
 		return err
 	}
 	if current != nil {
 		c.state.Set(IdentifierNetwork, current.ID)
 		c.state.Set(NameNetwork, current.Name)
-		if _, err := c.access.UpdateNetwork(desired, current); err != nil {
-			return err
-		}
-	} else {
-		log.Info("creating...")
-		created, err := c.access.CreateNetwork(desired)
-		if err != nil {
+		if _, err := c.access.UpdateNetwork(ctx, desired, current); err != nil {
 			return err
 		}
-		c.state.Set(IdentifierNetwork, created.ID)
-		c.state.Set(NameNetwork, created.Name)
+		return c.ensureTags(ctx, osclient.ResourceTypeNetworks, current.ID, current.Tags, openstack.TagPurposeNodes)
 	}
 
-	return nil
+	log.Info("creating...")
+	created, err := c.access.CreateNetwork(ctx, desired)
+	if err != nil {
+		return err
+	}
+	c.state.Set(IdentifierNetwork, created.ID)
+	c.state.Set(NameNetwork, created.Name)
+	return c.ensureTags(ctx, osclient.ResourceTypeNetworks, created.ID, created.Tags, openstack.TagPurposeNodes)
 }
 
-func (c *FlowContext) findExistingNetwork() (*access.Network, error) {
-	return findExisting(c.state.Get(IdentifierNetwork), c.namespace, c.access.GetNetworkByID, c.access.GetNetworkByName)
+func (c *FlowContext) findExistingNetwork(ctx context.Context) (*access.Network, error) {
+	finder := byTagsOrName(c.lookupTags(openstack.TagPurposeNodes), c.access.GetNetworkByTags, c.access.GetNetworkByName,
+		func(network *access.Network) []string { return network.Tags })
+	return findExisting(ctx, c.state.Get(IdentifierNetwork), c.namespace, c.access.GetNetworkByID, finder)
 }
 
-func (c *FlowContext) getNetworkID() (*string, error) {
+func (c *FlowContext) getNetworkID(ctx context.Context) (*string, error) {
 	if c.config.Networks.ID != nil {
 		return c.config.Networks.ID, nil
 	}
@@ -232,7 +384,7 @@ func (c *FlowContext) getNetworkID() (*string, error) {
 	if networkID != nil {
 		return networkID, nil
 	}
-	network, err := c.findExistingNetwork()
+	network, err := c.findExistingNetwork(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -244,53 +396,155 @@ func (c *FlowContext) getNetworkID() (*string, error) {
 }
 
 func (c *FlowContext) ensureSubnet(ctx context.Context) error {
-	log := c.LogFromContext(ctx)
-
-	networkID := *c.state.Get(IdentifierNetwork)
 	workersCIDR := c.config.Networks.Workers
 	// Backwards compatibility - remove this code in a future version.
 	if workersCIDR == "" {
 		workersCIDR = c.config.Networks.Worker
 	}
+	if c.config.Networks.SubnetID != nil {
+		return c.adoptSubnet(ctx, *c.config.Networks.SubnetID, workersCIDR)
+	}
 	desired := &subnets.Subnet{
 		Name:           c.namespace,
-		NetworkID:      networkID,
+		NetworkID:      *c.state.Get(IdentifierNetwork),
 		CIDR:           workersCIDR,
 		IPVersion:      4,
 		DNSNameservers: c.cloudProfileConfig.DNSServers,
 	}
-	current, err := c.findExistingSubnet()
+	return c.ensureSubnetInState(ctx, c.state, desired, openstack.TagPurposeNodes)
+}
+
+// adoptSubnet checks that the existing subnet belongs to the network and has the workers CIDR. It is never modified.
+func (c *FlowContext) adoptSubnet(ctx context.Context, subnetID, workersCIDR string) error {
+	networkID := *c.state.Get(IdentifierNetwork)
+	subnet, err := c.access.GetSubnetByID(ctx, subnetID)
+	if err != nil {
+		return err
+	}
+	if subnet == nil {
+		return fmt.Errorf("subnet %s not found", subnetID)
+	}
+	if subnet.NetworkID != networkID {
+		return fmt.Errorf("subnet %s does not belong to network %s", subnetID, networkID)
+	}
+	if !sameCIDR(subnet.CIDR, workersCIDR) {
+		return fmt.Errorf("CIDR %s of subnet %s does not match the workers CIDR %s", subnet.CIDR, subnetID, workersCIDR)
+	}
+	c.state.Set(IdentifierSubnet, subnet.ID)
+	return nil
+}
+
+// sameCIDR returns true if both CIDRs denote the same network, regardless of their notation, e.g. host bits or IPv6
+// zero compression.
+func sameCIDR(a, b string) bool {
+	prefixA, errA := netip.ParsePrefix(a)
+	prefixB, errB := netip.ParsePrefix(b)
+	return errA == nil && errB == nil && prefixA.Masked() == prefixB.Masked()
+}
+
+// ensureSubnetInState creates or updates the desired subnet with the given purpose and stores its id in the given state.
+func (c *FlowContext) ensureSubnetInState(ctx context.Context, state Whiteboard, desired *subnets.Subnet, purpose string) error {
+	log := c.LogFromContext(ctx)
+
+	current, err := c.findExistingSubnetFor(ctx, state.Get(IdentifierSubnet), desired.Name, purpose)
 	if err != nil {
 		return err
 	}
 	if current != nil {
-		c.state.Set(IdentifierSubnet, current.ID)
-		if _, err := c.access.UpdateSubnet(desired, current); err != nil {
-			return err
-		}
-	} else {
-		log.Info("creating...")
-		created, err := c.access.CreateSubnet(desired)
-		if err != nil {
+		state.Set(IdentifierSubnet, current.ID)
+		if _, err := c.access.UpdateSubnet(ctx, desired, current); err != nil {
 			return err
 		}
-		c.state.Set(IdentifierSubnet, created.ID)
+		return c.ensureTags(ctx, osclient.ResourceTypeSubnets, current.ID, current.Tags, purpose)
 	}
-	return nil
+
+	log.Info("creating...")
+	created, err := c.access.CreateSubnet(ctx, desired)
+	if err != nil {
+		return err
+	}
+	state.Set(IdentifierSubnet, created.ID)
+	return c.ensureTags(ctx, osclient.ResourceTypeSubnets, created.ID, created.Tags, purpose)
+}
+
+func (c *FlowContext) findExistingSubnet(ctx context.Context) (*subnets.Subnet, error) {
+	return c.findExistingSubnetFor(ctx, c.state.Get(IdentifierSubnet), c.namespace, openstack.TagPurposeNodes)
 }
 
-func (c *FlowContext) findExistingSubnet() (*subnets.Subnet, error) {
-	networkID, err := c.getNetworkID()
+// findExistingSubnetFor finds the subnet with the given purpose by id, tags or name.
+func (c *FlowContext) findExistingSubnetFor(ctx context.Context, id *string, name, purpose string) (*subnets.Subnet, error) {
+	networkID, err := c.getNetworkID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	if networkID == nil {
 		return nil, fmt.Errorf("network not found")
 	}
-	getByName := func(name string) ([]*subnets.Subnet, error) {
-		return c.access.GetSubnetByName(*networkID, name)
+	getByName := func(ctx context.Context, name string) ([]*subnets.Subnet, error) {
+		return c.access.GetSubnetByName(ctx, *networkID, name)
+	}
+	getByTags := func(ctx context.Context, tags []string) ([]*subnets.Subnet, error) {
+		return c.access.GetSubnetByTags(ctx, *networkID, tags)
+	}
+	finder := byTagsOrName(c.lookupTags(purpose), getByTags, getByName,
+		func(subnet *subnets.Subnet) []string { return subnet.Tags })
+	return findExisting(ctx, id, name, c.access.GetSubnetByID, finder)
+}
+
+func (c *FlowContext) subnetNameIPv6() string {
+	return c.namespace + "-ipv6"
+}
+
+func (c *FlowContext) ensureSubnetIPv6(ctx context.Context) error {
+	log := c.LogFromContext(ctx)
+
+	ipv6 := c.config.Networks.IPv6
+	addressMode := string(ptr.Deref(ipv6.AddressMode, openstackapi.IPv6AddressModeSLAAC))
+	desired := &subnets.Subnet{
+		Name:            c.subnetNameIPv6(),
+		NetworkID:       *c.state.Get(IdentifierNetwork),
+		CIDR:            ptr.Deref(ipv6.CIDR, ""),
+		SubnetPoolID:    ptr.Deref(ipv6.SubnetPoolID, ""),
+		IPVersion:       6,
+		IPv6AddressMode: addressMode,
+		IPv6RAMode:      addressMode,
+	}
+	current, err := c.findExistingSubnetFor(ctx, c.state.Get(IdentifierSubnetIPv6), desired.Name, openstack.TagPurposeNodesIPv6)
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		c.state.Set(IdentifierSubnetIPv6, current.ID)
+		c.state.Set(CIDRSubnetIPv6, current.CIDR)
+		if _, err := c.access.UpdateSubnet(ctx, desired, current); err != nil {
+			return err
+		}
+		return c.ensureTags(ctx, osclient.ResourceTypeSubnets, current.ID, current.Tags, openstack.TagPurposeNodesIPv6)
+	}
+
+	log.Info("creating...")
+	created, err := c.access.CreateSubnet(ctx, desired)
+	if err != nil {
+		return err
+	}
+	c.state.Set(IdentifierSubnetIPv6, created.ID)
+	c.state.Set(CIDRSubnetIPv6, created.CIDR)
+	return c.ensureTags(ctx, osclient.ResourceTypeSubnets, created.ID, created.Tags, openstack.TagPurposeNodesIPv6)
+}
+
+func (c *FlowContext) zoneSubnetName(zoneName string) string {
+	return c.namespace + "-" + zoneName
+}
+
+func (c *FlowContext) ensureZoneSubnet(ctx context.Context, zone openstackapi.Zone) error {
+	desired := &subnets.Subnet{
+		Name:           c.zoneSubnetName(zone.Name),
+		NetworkID:      *c.state.Get(IdentifierNetwork),
+		CIDR:           zone.Workers,
+		IPVersion:      4,
+		DNSNameservers: c.cloudProfileConfig.DNSServers,
 	}
-	return findExisting(c.state.Get(IdentifierSubnet), c.namespace, c.access.GetSubnetByID, getByName)
+	return c.ensureSubnetInState(ctx, c.state.GetChild(ChildIdZones).GetChild(zone.Name), desired, openstack.TagPurposeZone(zone.Name))
 }
 
 type notFoundError struct {
@@ -311,17 +565,59 @@ func ignoreNotFound(err error) error {
 }
 
 func (c *FlowContext) ensureRouterInterface(ctx context.Context) error {
+	if c.config.Networks.SubnetID != nil {
+		routed, err := c.isSubnetRoutedExternally(ctx)
+		if err != nil || routed {
+			return err
+		}
+	}
+	return c.ensureRouterInterfaceForSubnet(ctx, c.state.Get(IdentifierSubnet))
+}
+
+// isSubnetRoutedExternally checks if the adopted subnet is attached to another router than the one of the shoot. In
+// this case no router interface is created for it. An interface on the router of the shoot is managed like the one of
+// a created subnet, so the check is repeated on every reconciliation instead of being stored in the state.
+func (c *FlowContext) isSubnetRoutedExternally(ctx context.Context) (bool, error) {
+	routerID := c.state.Get(IdentifierRouter)
+	if routerID == nil {
+		return false, fmt.Errorf("internal error: missing routerID")
+	}
+	subnetID := c.state.Get(IdentifierSubnet)
+	if subnetID == nil {
+		return false, fmt.Errorf("internal error: missing subnetID")
+	}
+	routerIDs, err := c.access.GetRouterIDsOfSubnet(ctx, *subnetID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range routerIDs {
+		if id != *routerID {
+			c.LogFromContext(ctx).Info("subnet is already routed by another router, skipping router interface", "router", id)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *FlowContext) ensureRouterInterfaceIPv6(ctx context.Context) error {
+	return c.ensureRouterInterfaceForSubnet(ctx, c.state.Get(IdentifierSubnetIPv6))
+}
+
+func (c *FlowContext) ensureZoneRouterInterface(ctx context.Context, zone openstackapi.Zone) error {
+	return c.ensureRouterInterfaceForSubnet(ctx, c.state.GetChild(ChildIdZones).GetChild(zone.Name).Get(IdentifierSubnet))
+}
+
+func (c *FlowContext) ensureRouterInterfaceForSubnet(ctx context.Context, subnetID *string) error {
 	log := c.LogFromContext(ctx)
 
 	routerID := c.state.Get(IdentifierRouter)
 	if routerID == nil {
 		return fmt.Errorf("internal error: missing routerID")
 	}
-	subnetID := c.state.Get(IdentifierSubnet)
 	if subnetID == nil {
 		return fmt.Errorf("internal error: missing subnetID")
 	}
-	portID, err := c.access.GetRouterInterfacePortID(*routerID, *subnetID)
+	portID, err := c.access.GetRouterInterfacePortID(ctx, *routerID, *subnetID)
 	if err != nil {
 		return err
 	}
@@ -339,7 +635,7 @@ func (c *FlowContext) ensureSecGroup(ctx context.Context) error {
 		Name:        c.namespace,
 		Description: "Cluster Nodes",
 	}
-	current, err := findExisting(c.state.Get(IdentifierSecGroup), c.namespace, c.access.GetSecurityGroupByID, c.access.GetSecurityGroupByName)
+	current, err := c.findExistingSecGroup(ctx)
 	if err != nil {
 		return err
 	}
@@ -347,18 +643,24 @@ func (c *FlowContext) ensureSecGroup(ctx context.Context) error {
 		c.state.Set(IdentifierSecGroup, current.ID)
 		c.state.Set(NameSecGroup, current.Name)
 		c.state.SetObject(ObjectSecGroup, current)
-		return nil
+		return c.ensureTags(ctx, osclient.ResourceTypeSecurityGroups, current.ID, current.Tags, openstack.TagPurposeNodes)
 	}
 
 	log.Info("creating...")
-	created, err := c.access.CreateSecurityGroup(desired)
+	created, err := c.access.CreateSecurityGroup(ctx, desired)
 	if err != nil {
 		return err
 	}
 	c.state.Set(IdentifierSecGroup, created.ID)
 	c.state.Set(NameSecGroup, created.Name)
 	c.state.SetObject(ObjectSecGroup, created)
-	return nil
+	return c.ensureTags(ctx, osclient.ResourceTypeSecurityGroups, created.ID, created.Tags, openstack.TagPurposeNodes)
+}
+
+func (c *FlowContext) findExistingSecGroup(ctx context.Context) (*groups.SecGroup, error) {
+	finder := byTagsOrName(c.lookupTags(openstack.TagPurposeNodes), c.access.GetSecurityGroupByTags, c.access.GetSecurityGroupByName,
+		func(group *groups.SecGroup) []string { return group.Tags })
+	return findExisting(ctx, c.state.Get(IdentifierSecGroup), c.namespace, c.access.GetSecurityGroupByID, finder)
 }
 
 func (c *FlowContext) ensureSecGroupRules(ctx context.Context) error {
@@ -373,6 +675,43 @@ func (c *FlowContext) ensureSecGroupRules(ctx context.Context) error {
 		return fmt.Errorf("internal error: casting to SecGroup failed")
 	}
 
+	// Do NOT delete foreign rules to keep permissive behaviour as with terraform. Managed rules are identified by the ids
+	// stored in the state or by their description. Rules of shoots created before the ids were stored are adopted if
+	// they match a desired rule.
+	managedRuleIDs := sets.New(strings.Split(ptr.Deref(c.state.Get(IdentifierSecGroupRules), ""), ",")...)
+	desiredRules := c.desiredSecGroupRules()
+	if modified, err := c.access.UpdateSecurityGroupRules(ctx, group, desiredRules, func(rule *rules.SecGroupRule) bool {
+		return managedRuleIDs.Has(rule.ID) || isManagedSecGroupRule(rule) ||
+			(c.config.Networks.DisableDefaultNodePortRules && isDefaultNodePortRule(rule))
+	}); err != nil {
+		return err
+	} else if modified {
+		log.Info("updated rules")
+	}
+
+	ids := make([]string, 0, len(desiredRules))
+	for _, rule := range desiredRules {
+		ids = append(ids, rule.ID)
+	}
+	slices.Sort(ids)
+	c.state.Set(IdentifierSecGroupRules, strings.Join(ids, ","))
+	return nil
+}
+
+const (
+	// managedRuleMarker is the prefix of the description of the security group rules managed by the extension.
+	managedRuleMarker = "[gardener] "
+	// descriptionUserDefinedRule is the description of the security group rules defined in the InfrastructureConfig.
+	descriptionUserDefinedRule = "user-defined rule of the InfrastructureConfig"
+)
+
+// isManagedSecGroupRule returns true if the description of the rule marks it as managed by the extension. User-defined
+// rules created before the marker was introduced are recognized by their description, too.
+func isManagedSecGroupRule(rule *rules.SecGroupRule) bool {
+	return strings.HasPrefix(rule.Description, managedRuleMarker) || rule.Description == descriptionUserDefinedRule
+}
+
+func (c *FlowContext) desiredSecGroupRules() []rules.SecGroupRule {
 	desiredRules := []rules.SecGroupRule{
 		{
 			Direction:     string(rules.DirIngress),
@@ -390,43 +729,80 @@ func (c *FlowContext) ensureSecGroupRules(ctx context.Context) error {
 			EtherType:   string(rules.EtherType6),
 			Description: "IPv6: allow all outgoing traffic",
 		},
-		{
-			Direction:      string(rules.DirIngress),
-			EtherType:      string(rules.EtherType4),
-			Protocol:       string(rules.ProtocolTCP),
-			PortRangeMin:   30000,
-			PortRangeMax:   32767,
-			RemoteIPPrefix: "0.0.0.0/0",
-			Description:    "IPv4: allow all incoming tcp traffic with port range 30000-32767",
-		},
-		{
+	}
+	if !c.config.Networks.DisableDefaultNodePortRules {
+		desiredRules = append(desiredRules, defaultNodePortRules(rules.EtherType4, "0.0.0.0/0")...)
+	}
+	if c.config.Networks.IPv6 != nil {
+		desiredRules = append(desiredRules, rules.SecGroupRule{
+			Direction:     string(rules.DirIngress),
+			EtherType:     string(rules.EtherType6),
+			RemoteGroupID: access.SecurityGroupIDSelf,
+			Description:   "IPv6: allow all incoming traffic within the same security group",
+		})
+		if !c.config.Networks.DisableDefaultNodePortRules {
+			desiredRules = append(desiredRules, defaultNodePortRules(rules.EtherType6, "::/0")...)
+		}
+	}
+
+	for _, userRule := range c.config.Networks.SecurityGroupRules {
+		rule := rules.SecGroupRule{
+			Direction:      string(userRule.Direction),
+			EtherType:      string(helper.SecurityGroupRuleEtherType(userRule)),
+			Protocol:       ptr.Deref(userRule.Protocol, ""),
+			PortRangeMin:   int(ptr.Deref(userRule.PortRangeMin, 0)),
+			PortRangeMax:   int(ptr.Deref(userRule.PortRangeMax, ptr.Deref(userRule.PortRangeMin, 0))),
+			RemoteIPPrefix: ptr.Deref(userRule.RemoteCIDR, ""),
+			RemoteGroupID:  ptr.Deref(userRule.RemoteGroupID, ""),
+			Description:    descriptionUserDefinedRule,
+		}
+		// rules equal to a default rule would be rejected as duplicates
+		if !slices.ContainsFunc(desiredRules, func(desired rules.SecGroupRule) bool { return sameSecGroupRule(&desired, &rule) }) {
+			desiredRules = append(desiredRules, rule)
+		}
+	}
+
+	for i := range desiredRules {
+		desiredRules[i].Description = managedRuleMarker + desiredRules[i].Description
+	}
+	return desiredRules
+}
+
+func defaultNodePortRules(etherType rules.RuleEtherType, remoteIPPrefix string) []rules.SecGroupRule {
+	var result []rules.SecGroupRule
+	for _, protocol := range []rules.RuleProtocol{rules.ProtocolTCP, rules.ProtocolUDP} {
+		result = append(result, rules.SecGroupRule{
 			Direction:      string(rules.DirIngress),
-			EtherType:      string(rules.EtherType4),
-			Protocol:       string(rules.ProtocolUDP),
+			EtherType:      string(etherType),
+			Protocol:       string(protocol),
 			PortRangeMin:   30000,
 			PortRangeMax:   32767,
-			RemoteIPPrefix: "0.0.0.0/0",
-			Description:    "IPv4: allow all incoming udp traffic with port range 30000-32767",
-		},
+			RemoteIPPrefix: remoteIPPrefix,
+			Description:    fmt.Sprintf("%s: allow all incoming %s traffic with port range 30000-32767", etherType, protocol),
+		})
 	}
+	return result
+}
 
-	if modified, err := c.access.UpdateSecurityGroupRules(group, desiredRules, func(_ *rules.SecGroupRule) bool {
-		// Do NOT delete unknown rules to keep permissive behaviour as with terraform.
-		// As we don't store the role ids in the state, this function needs to be adjusted
-		// if values in existing rules are changed to identify them for update by replacement.
-		return false
-	}); err != nil {
-		return err
-	} else if modified {
-		log.Info("updated rules")
-	}
-	return nil
+func isDefaultNodePortRule(rule *rules.SecGroupRule) bool {
+	return slices.ContainsFunc(append(defaultNodePortRules(rules.EtherType4, "0.0.0.0/0"), defaultNodePortRules(rules.EtherType6, "::/0")...),
+		func(nodePortRule rules.SecGroupRule) bool { return sameSecGroupRule(&nodePortRule, rule) })
+}
+
+func sameSecGroupRule(a, b *rules.SecGroupRule) bool {
+	return a.Direction == b.Direction &&
+		a.EtherType == b.EtherType &&
+		a.Protocol == b.Protocol &&
+		a.PortRangeMin == b.PortRangeMin &&
+		a.PortRangeMax == b.PortRangeMax &&
+		a.RemoteIPPrefix == b.RemoteIPPrefix &&
+		a.RemoteGroupID == b.RemoteGroupID
 }
 
 func (c *FlowContext) ensureSSHKeyPair(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
 
-	keyPair, err := c.compute.GetKeyPair(c.namespace)
+	keyPair, err := c.compute.GetKeyPair(ctx, c.namespace)
 	if err != nil {
 		return err
 	}
@@ -436,7 +812,7 @@ func (c *FlowContext) ensureSSHKeyPair(ctx context.Context) error {
 			return nil
 		}
 		log.Info("replacing SSH key pair")
-		if err := c.compute.DeleteKeyPair(c.namespace); err != nil {
+		if err := c.compute.DeleteKeyPair(ctx, c.namespace); err != nil {
 			return err
 		}
 		keyPair = nil
@@ -445,7 +821,7 @@ func (c *FlowContext) ensureSSHKeyPair(ctx context.Context) error {
 	if keyPair == nil {
 		c.state.Set(NameKeyPair, "")
 		log.Info("creating SSH key pair")
-		if keyPair, err = c.compute.CreateKeyPair(c.namespace, string(c.infraSpec.SSHPublicKey)); err != nil {
+		if keyPair, err = c.compute.CreateKeyPair(ctx, c.namespace, string(c.infraSpec.SSHPublicKey)); err != nil {
 			return err
 		}
 	}
@@ -461,21 +837,7 @@ func (c *FlowContext) ensureShareNetwork(ctx context.Context) error {
 	log := c.LogFromContext(ctx)
 	networkID := ptr.Deref(c.state.Get(IdentifierNetwork), "")
 	subnetID := ptr.Deref(c.state.Get(IdentifierSubnet), "")
-	current, err := findExisting(c.state.Get(IdentifierShareNetwork),
-		c.namespace,
-		c.sharedFilesystem.GetShareNetwork,
-		func(name string) ([]*sharenetworks.ShareNetwork, error) {
-			list, err := c.sharedFilesystem.ListShareNetworks(sharenetworks.ListOpts{
-				Name:            name,
-				NeutronNetID:    networkID,
-				NeutronSubnetID: subnetID,
-			})
-			if err != nil {
-				return nil, err
-			}
-			return sliceToPtr(list), nil
-		})
-
+	current, err := c.findExistingShareNetwork(ctx, networkID, subnetID)
 	if err != nil {
 		return err
 	}
@@ -483,14 +845,15 @@ func (c *FlowContext) ensureShareNetwork(ctx context.Context) error {
 	if current != nil {
 		c.state.Set(IdentifierShareNetwork, current.ID)
 		c.state.Set(NameShareNetwork, current.Name)
-		return nil
+		return c.ensureShareNetworkTags(ctx, current)
 	}
 
 	log.Info("creating...")
-	created, err := c.sharedFilesystem.CreateShareNetwork(sharenetworks.CreateOpts{
+	created, err := c.sharedFilesystem.CreateShareNetwork(ctx, sharenetworks.CreateOpts{
 		NeutronNetID:    networkID,
 		NeutronSubnetID: subnetID,
 		Name:            c.namespace,
+		Description:     shareNetworkDescription("", c.resourceTags(openstack.TagPurposeNodes)),
 	})
 	if err != nil {
 		return err
@@ -499,3 +862,63 @@ func (c *FlowContext) ensureShareNetwork(ctx context.Context) error {
 	c.state.Set(NameShareNetwork, created.Name)
 	return nil
 }
+
+// findExistingShareNetwork finds the share network of the shoot by id, tags or by name in the given network and subnet.
+func (c *FlowContext) findExistingShareNetwork(ctx context.Context, networkID, subnetID string) (*sharenetworks.ShareNetwork, error) {
+	finder := byTagsOrName(c.lookupTags(openstack.TagPurposeNodes), c.getShareNetworksByTags,
+		func(ctx context.Context, name string) ([]*sharenetworks.ShareNetwork, error) {
+			list, err := c.sharedFilesystem.ListShareNetworks(ctx, sharenetworks.ListOpts{
+				Name:            name,
+				NeutronNetID:    networkID,
+				NeutronSubnetID: subnetID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return sliceToPtr(list), nil
+		},
+		shareNetworkTags)
+	return findExisting(ctx, c.state.Get(IdentifierShareNetwork), c.namespace, c.sharedFilesystem.GetShareNetwork, finder)
+}
+
+// getShareNetworksByTags returns the share networks carrying all given tags. Manila neither supports tags for share
+// networks nor filtering them by parts of their description, so they are filtered here.
+func (c *FlowContext) getShareNetworksByTags(ctx context.Context, tags []string) ([]*sharenetworks.ShareNetwork, error) {
+	list, err := c.sharedFilesystem.ListShareNetworks(ctx, sharenetworks.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(sliceToPtr(list), func(shareNetwork *sharenetworks.ShareNetwork) bool {
+		current := shareNetworkTags(shareNetwork)
+		return slices.ContainsFunc(tags, func(tag string) bool { return !slices.Contains(current, tag) })
+	}), nil
+}
+
+// ensureShareNetworkTags sets the tags of the extension in the description of a share network, like ensureTags does
+// for the Neutron resources.
+func (c *FlowContext) ensureShareNetworkTags(ctx context.Context, current *sharenetworks.ShareNetwork) error {
+	description := shareNetworkDescription(current.Description, c.resourceTags(openstack.TagPurposeNodes))
+	if description == current.Description {
+		return nil
+	}
+	if _, err := c.sharedFilesystem.UpdateShareNetwork(ctx, current.ID, sharenetworks.UpdateOpts{Description: &description}); err != nil {
+		return fmt.Errorf("updating tags of share network %s failed: %w", current.ID, err)
+	}
+	c.LogFromContext(ctx).Info("updated tags", "shareNetwork", current.ID)
+	return nil
+}
+
+// shareNetworkTags returns the tags of a share network. As share networks have no tags, the extension keeps them as
+// words of the description.
+func shareNetworkTags(shareNetwork *sharenetworks.ShareNetwork) []string {
+	return strings.Fields(shareNetwork.Description)
+}
+
+// shareNetworkDescription replaces the tags of the extension in the given description of a share network with the
+// desired ones and keeps all other words.
+func shareNetworkDescription(current string, desired []string) string {
+	words := slices.DeleteFunc(strings.Fields(current), func(word string) bool {
+		return len(openstack.ResourceTagValues([]string{word})) > 0
+	})
+	return strings.Join(append(words, desired...), " ")
+}
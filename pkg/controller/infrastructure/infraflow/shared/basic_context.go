@@ -7,11 +7,13 @@ package shared
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/gardener/gardener/pkg/utils/flow"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -21,6 +23,7 @@ type TaskOption struct {
 	Dependencies []flow.TaskIDer
 	Timeout      time.Duration
 	DoIf         *bool
+	Kind         string
 }
 
 // Dependencies creates a TaskOption for dependencies
@@ -38,6 +41,12 @@ func DoIf(condition bool) TaskOption {
 	return TaskOption{DoIf: ptr.To(condition)}
 }
 
+// Kind creates a TaskOption for the kind of a task, which is the same for all instances of a task, e.g. for each zone.
+// It defaults to the name of the task.
+func Kind(kind string) TaskOption {
+	return TaskOption{Kind: kind}
+}
+
 // FlowStatePersistor persists the flat map to the provider status
 type FlowStatePersistor func(ctx context.Context, flatMap FlatMap) error
 
@@ -51,6 +60,10 @@ type BasicFlowContext struct {
 	lastPersistedGeneration int64
 	lastPersistedAt         time.Time
 	PersistInterval         time.Duration
+
+	runLock    sync.Mutex
+	currentRun *FlowRun
+	lastRun    *FlowRun
 }
 
 // StateExporter knows how to export the internal state to a flat string map.
@@ -107,7 +120,7 @@ func (c *BasicFlowContext) LogFromContext(ctx context.Context) logr.Logger {
 
 // AddTask adds a wrapped task for the given task function and options.
 func (c *BasicFlowContext) AddTask(g *flow.Graph, name string, fn flow.TaskFn, options ...TaskOption) flow.TaskIDer {
-	allOptions := TaskOption{}
+	allOptions := TaskOption{Kind: name}
 	for _, opt := range options {
 		if len(opt.Dependencies) > 0 {
 			allOptions.Dependencies = append(allOptions.Dependencies, opt.Dependencies...)
@@ -123,6 +136,9 @@ func (c *BasicFlowContext) AddTask(g *flow.Graph, name string, fn flow.TaskFn, o
 			condition = condition && *opt.DoIf
 			allOptions.DoIf = ptr.To(condition)
 		}
+		if opt.Kind != "" {
+			allOptions.Kind = opt.Kind
+		}
 	}
 
 	tunedFn := fn
@@ -131,7 +147,7 @@ func (c *BasicFlowContext) AddTask(g *flow.Graph, name string, fn flow.TaskFn, o
 	}
 	task := flow.Task{
 		Name:   name,
-		Fn:     c.wrapTaskFn(g.Name(), name, tunedFn),
+		Fn:     c.wrapTaskFn(g.Name(), name, allOptions.Kind, tunedFn),
 		SkipIf: allOptions.DoIf != nil && !*allOptions.DoIf,
 	}
 
@@ -142,10 +158,12 @@ func (c *BasicFlowContext) AddTask(g *flow.Graph, name string, fn flow.TaskFn, o
 	return g.Add(task)
 }
 
-func (c *BasicFlowContext) wrapTaskFn(flowName, taskName string, fn flow.TaskFn) flow.TaskFn {
+func (c *BasicFlowContext) wrapTaskFn(flowName, taskName, taskKind string, fn flow.TaskFn) flow.TaskFn {
 	return func(ctx context.Context) error {
 		taskCtx := logf.IntoContext(ctx, c.Log.WithValues("flow", flowName, "task", taskName))
+		start := time.Now()
 		err := fn(taskCtx)
+		c.recordTask(taskName, taskKind, start, err)
 		if err != nil {
 			// don't wrap error with '%w', as otherwise the error context get lost
 			err = fmt.Errorf("failed to %s: %s", taskName, err)
@@ -160,3 +178,102 @@ func (c *BasicFlowContext) wrapTaskFn(flowName, taskName string, fn flow.TaskFn)
 		return err
 	}
 }
+
+// TaskOutcome is the outcome of a task of a flow.
+type TaskOutcome string
+
+const (
+	// TaskOutcomeSucceeded is the outcome of a task which returned no error.
+	TaskOutcomeSucceeded TaskOutcome = "Succeeded"
+	// TaskOutcomeFailed is the outcome of a task which returned an error, including timeouts.
+	TaskOutcomeFailed TaskOutcome = "Failed"
+)
+
+// maxTaskErrorLength is the maximum length of the error of a task kept in a FlowRun.
+const maxTaskErrorLength = 512
+
+// TaskRecord describes the execution of a task of a flow.
+type TaskRecord struct {
+	// Name is the name of the task.
+	Name string `json:"name"`
+	// Kind is the kind of the task, which does not contain instance specific parts of the name like zone names. It
+	// is only kept in memory, e.g. as label of metrics.
+	Kind string `json:"-"`
+	// Start is the time the task was started.
+	Start metav1.Time `json:"start"`
+	// Duration is the time the task took.
+	Duration metav1.Duration `json:"duration"`
+	// Outcome is the outcome of the task.
+	Outcome TaskOutcome `json:"outcome"`
+	// Error is the (truncated) error of a failed task.
+	Error string `json:"error,omitempty"`
+}
+
+// FlowRun describes a run of a flow with the tasks which were executed. Tasks skipped by a condition or because of a
+// failed dependency are not contained.
+type FlowRun struct {
+	// Flow is the name of the flow graph.
+	Flow string `json:"flow"`
+	// Start is the time the flow was started.
+	Start metav1.Time `json:"start"`
+	// Duration is the time the flow took.
+	Duration metav1.Duration `json:"duration"`
+	// Tasks are the executed tasks ordered by their start.
+	Tasks []TaskRecord `json:"tasks"`
+}
+
+// RunGraph compiles and runs the given graph, and records the execution of its tasks, see `LastRun`.
+func (c *BasicFlowContext) RunGraph(ctx context.Context, g *flow.Graph) error {
+	c.runLock.Lock()
+	c.currentRun = &FlowRun{Flow: g.Name(), Start: metav1.Now()}
+	c.runLock.Unlock()
+
+	err := g.Compile().Run(ctx, flow.Opts{Log: c.Log})
+
+	c.runLock.Lock()
+	defer c.runLock.Unlock()
+	c.currentRun.Duration = metav1.Duration{Duration: time.Since(c.currentRun.Start.Time)}
+	c.lastRun, c.currentRun = c.currentRun, nil
+	return err
+}
+
+// LastRun returns a copy of the last run completed by `RunGraph` or nil if there was none.
+func (c *BasicFlowContext) LastRun() *FlowRun {
+	c.runLock.Lock()
+	defer c.runLock.Unlock()
+
+	if c.lastRun == nil {
+		return nil
+	}
+	run := *c.lastRun
+	run.Tasks = slices.Clone(c.lastRun.Tasks)
+	slices.SortStableFunc(run.Tasks, func(a, b TaskRecord) int {
+		return a.Start.Time.Compare(b.Start.Time)
+	})
+	return &run
+}
+
+func (c *BasicFlowContext) recordTask(name, kind string, start time.Time, err error) {
+	c.runLock.Lock()
+	defer c.runLock.Unlock()
+
+	if c.currentRun == nil {
+		// the graph is not run by `RunGraph`, e.g. in plan mode
+		return
+	}
+	record := TaskRecord{
+		Name:     name,
+		Kind:     kind,
+		Start:    metav1.NewTime(start),
+		Duration: metav1.Duration{Duration: time.Since(start)},
+		Outcome:  TaskOutcomeSucceeded,
+	}
+	if err != nil {
+		record.Outcome = TaskOutcomeFailed
+		record.Error = err.Error()
+		if len(record.Error) > maxTaskErrorLength {
+			record.Error = record.Error[:maxTaskErrorLength] + "..."
+		}
+	}
+	c.currentRun.Tasks = append(c.currentRun.Tasks, record)
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gardener/gardener/pkg/utils/flow"
@@ -152,4 +153,58 @@ var _ = Describe("BasicFlowContext", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	It("should record the run of the tasks", func() {
+		var (
+			ctx = context.Background()
+			c   = newTestFlowContext(logr.Discard(), shared.NewWhiteboard(), nil)
+			g   = flow.NewGraph("test")
+		)
+
+		task1 := c.AddTask(g, "task1", func(_ context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		task2 := c.AddTask(g, "task2", func(_ context.Context) error {
+			return fmt.Errorf("%s", strings.Repeat("x", 1000))
+		}, shared.Kind("task"), shared.Dependencies(task1))
+		_ = c.AddTask(g, "task3", func(_ context.Context) error {
+			return nil
+		}, shared.Dependencies(task2))
+		_ = c.AddTask(g, "task4", func(_ context.Context) error {
+			return nil
+		}, shared.DoIf(false))
+
+		Expect(c.LastRun()).To(BeNil())
+		Expect(c.RunGraph(ctx, g)).NotTo(Succeed())
+
+		run := c.LastRun()
+		Expect(run.Flow).To(Equal("test"))
+		Expect(run.Duration.Duration).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(run.Tasks).To(HaveLen(2))
+		Expect(run.Tasks[0].Name).To(Equal("task1"))
+		Expect(run.Tasks[0].Kind).To(Equal("task1"))
+		Expect(run.Tasks[0].Outcome).To(Equal(shared.TaskOutcomeSucceeded))
+		Expect(run.Tasks[0].Duration.Duration).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(run.Tasks[0].Error).To(BeEmpty())
+		Expect(run.Tasks[1].Name).To(Equal("task2"))
+		Expect(run.Tasks[1].Kind).To(Equal("task"))
+		Expect(run.Tasks[1].Outcome).To(Equal(shared.TaskOutcomeFailed))
+		Expect(run.Tasks[1].Error).To(Equal(strings.Repeat("x", 512) + "..."))
+
+		By("not recording graphs which are not run by the flow context", func() {
+			Expect(g.Compile().Run(ctx, flow.Opts{})).NotTo(Succeed())
+			Expect(c.LastRun()).To(Equal(run))
+		})
+
+		By("replacing the last run", func() {
+			g := flow.NewGraph("test2")
+			_ = c.AddTask(g, "task", func(_ context.Context) error {
+				return nil
+			})
+			Expect(c.RunGraph(ctx, g)).To(Succeed())
+			Expect(c.LastRun().Flow).To(Equal("test2"))
+			Expect(c.LastRun().Tasks).To(ConsistOf(HaveField("Name", "task")))
+		})
+	})
 })
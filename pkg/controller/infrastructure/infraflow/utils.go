@@ -5,20 +5,24 @@
 package infraflow
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	"go.uber.org/atomic"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 )
 
-func findExisting[T any](id *string, name string,
-	getter func(id string) (*T, error),
-	finder func(name string) ([]*T, error),
+func findExisting[T any](ctx context.Context, id *string, name string,
+	getter func(ctx context.Context, id string) (*T, error),
+	finder func(ctx context.Context, name string) ([]*T, error),
 	selector ...func(item *T) bool) (*T, error) {
 
 	if id != nil {
-		found, err := getter(*id)
+		found, err := getter(ctx, *id)
 		if err != nil {
 			return nil, err
 		}
@@ -27,7 +31,7 @@ func findExisting[T any](id *string, name string,
 		}
 	}
 
-	found, err := finder(name)
+	found, err := finder(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +49,31 @@ func findExisting[T any](id *string, name string,
 	return found[0], nil
 }
 
+// byTagsOrName returns a finder for findExisting which looks up resources by the given tags first. Only if none is found,
+// the resources are looked up by name. Resources found by name are skipped if they are tagged for a shoot, as they
+// belong to another shoot or purpose then.
+func byTagsOrName[T any](tags []string,
+	byTags func(ctx context.Context, tags []string) ([]*T, error),
+	byName func(ctx context.Context, name string) ([]*T, error),
+	tagsOf func(item *T) []string) func(ctx context.Context, name string) ([]*T, error) {
+
+	return func(ctx context.Context, name string) ([]*T, error) {
+		if len(tags) > 0 {
+			found, err := byTags(ctx, tags)
+			if err != nil || len(found) > 0 {
+				return found, err
+			}
+		}
+		found, err := byName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return slices.DeleteFunc(found, func(item *T) bool {
+			return openstack.IsShootResource(tagsOf(item))
+		}), nil
+	}
+}
+
 type waiter struct {
 	log           logr.Logger
 	start         time.Time
@@ -106,9 +135,9 @@ func copyMap(src map[string]string) map[string]string {
 }
 
 func sliceToPtr[T any](slice []T) []*T {
-	res := make([]*T, len(slice))
-	for _, t := range slice {
-		res = append(res, &t)
+	res := make([]*T, 0, len(slice))
+	for i := range slice {
+		res = append(res, &slice[i])
 	}
 	return res
 }
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"sync"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow/shared"
+)
+
+const (
+	backendFlow        = "flow"
+	backendTerraformer = "terraformer"
+)
+
+var (
+	infrastructureBackends = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "openstack",
+		Name:      "infrastructure_shoots",
+		Help:      "Number of shoots whose infrastructure is reconciled by the backend.",
+	}, []string{"backend"})
+
+	flowTaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "openstack",
+		Name:      "infrastructure_flow_task_duration_seconds",
+		Help:      "Duration of the tasks of the infrastructure flows.",
+		Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"operation", "task", "outcome", "region"})
+
+	// shootsPerBackend tracks the backend of the infrastructures handled by this extension.
+	shootsPerBackend = &backendRecorder{gauge: infrastructureBackends, backends: map[client.ObjectKey]string{}}
+)
+
+func init() {
+	metrics.Registry.MustRegister(infrastructureBackends, flowTaskDuration)
+}
+
+// observeFlowRun records the durations of the tasks of a run of the flow for the given operation. The tasks are
+// labeled with their kind instead of their name, as the names of tasks per zone contain the zone.
+func observeFlowRun(infra *extensionsv1alpha1.Infrastructure, operation string, run *shared.FlowRun) {
+	if run == nil {
+		return
+	}
+	for _, task := range run.Tasks {
+		flowTaskDuration.WithLabelValues(operation, task.Kind, string(task.Outcome), infra.Spec.Region).Observe(task.Duration.Seconds())
+	}
+}
+
+// backendRecorder counts the infrastructures per backend. Infrastructures are counted from their last reconciliation
+// until they are deleted or migrated to another seed.
+type backendRecorder struct {
+	lock     sync.Mutex
+	gauge    *prometheus.GaugeVec
+	backends map[client.ObjectKey]string
+}
+
+func (r *backendRecorder) record(key client.ObjectKey, backend string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.backends[key] = backend
+	r.update()
+}
+
+func (r *backendRecorder) forget(key client.ObjectKey) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.backends, key)
+	r.update()
+}
+
+func (r *backendRecorder) update() {
+	counts := map[string]int{}
+	for _, backend := range r.backends {
+		counts[backend]++
+	}
+	for _, backend := range []string{backendFlow, backendTerraformer} {
+		r.gauge.WithLabelValues(backend).Set(float64(counts[backend]))
+	}
+}
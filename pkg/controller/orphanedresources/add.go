@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphanedresources
+
+import (
+	"context"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+// ControllerName is the name of the controller.
+const ControllerName = "orphanedresources"
+
+var (
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are Options to apply when adding the orphaned resources controller to the manager.
+type AddOptions struct {
+	// Controller are the controller.Options.
+	Controller controller.Options
+	// Config contains the configuration of the detection of orphaned resources.
+	Config controllerconfig.OrphanedResourcesConfig
+}
+
+// AddToManagerWithOptions adds a controller with the given Options to the given manager. The controller is only added
+// if the detection of orphaned resources is enabled in the configuration.
+func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) error {
+	if !opts.Config.Enabled {
+		return nil
+	}
+
+	r := &Reconciler{
+		Client:   mgr.GetClient(),
+		Recorder: mgr.GetEventRecorderFor(ControllerName + "-controller"),
+		ClientFactory: openstackclient.NewCachingFactoryFactory(
+			openstackclient.NewFactoryFactory(openstackclient.WithController(ControllerName)),
+			openstackclient.DefaultFactoryCacheIdleTimeout,
+		),
+		Config: opts.Config,
+		Clock:  clock.RealClock{},
+	}
+
+	return builder.
+		ControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == v1beta1constants.SecretNameCloudProvider
+		}))).
+		WithOptions(opts.Controller).
+		Complete(r)
+}
+
+// AddToManager adds a controller with the default Options.
+func AddToManager(_ context.Context, mgr manager.Manager) error {
+	return AddToManagerWithOptions(mgr, DefaultAddOptions)
+}
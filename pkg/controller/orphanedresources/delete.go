@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphanedresources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+// deleteOrphans deletes the orphaned resources which may be deleted and were found at least the grace period ago.
+// Resources which cannot be deleted yet are retried with the next scan. It returns the remaining orphaned resources.
+func (s *scan) deleteOrphans(ctx context.Context, orphans []resource) []resource {
+	log := logf.FromContext(ctx)
+
+	gracePeriod := DefaultGracePeriod
+	if s.Config.GracePeriod != nil {
+		gracePeriod = s.Config.GracePeriod.Duration
+	}
+	now := s.Clock.Now()
+
+	var remaining []resource
+	for _, kind := range kinds {
+		for _, res := range orphans {
+			if res.kind != kind {
+				continue
+			}
+			if !s.mayDelete(res) || now.Before(s.state.firstSeen[res.key()].Add(gracePeriod)) {
+				remaining = append(remaining, res)
+				continue
+			}
+
+			log.Info("Deleting orphaned resource", "kind", res.kind, "name", res.name, "id", res.id)
+			if err := openstackclient.IgnoreNotFoundError(s.delete(ctx, res)); err != nil {
+				log.Error(err, "Could not delete orphaned resource", "kind", res.kind, "name", res.name, "id", res.id)
+				s.Recorder.Eventf(s.secret, corev1.EventTypeWarning, EventReasonDeletionFailed,
+					"Cannot delete orphaned %s %s (%s): %s", res.kind, res.name, res.id, err)
+				remaining = append(remaining, res)
+				continue
+			}
+			deletedOrphanedResources.WithLabelValues(s.project, s.region, res.kind).Inc()
+			s.Recorder.Eventf(s.secret, corev1.EventTypeNormal, EventReasonDeleted,
+				"Deleted orphaned %s %s (%s) in project %s and region %s", res.kind, res.name, res.id, s.project, s.region)
+			delete(s.state.firstSeen, res.key())
+		}
+	}
+	return remaining
+}
+
+// mayDelete returns true if the given resource is tagged as managed by this seed. Resources which are only recognized
+// by their names may only be deleted if it is enabled explicitly.
+func (s *scan) mayDelete(res resource) bool {
+	if s.Config.DeleteUntagged {
+		return true
+	}
+	return res.tagged && res.seedName != "" && res.seedName == s.seedName
+}
+
+// delete deletes the given resource.
+func (s *scan) delete(ctx context.Context, res resource) error {
+	switch res.kind {
+	case kindLoadBalancer:
+		return s.loadbalancing.DeleteLoadbalancer(ctx, res.id, loadbalancers.DeleteOpts{Cascade: true})
+	case kindServer:
+		return s.compute.DeleteServer(ctx, res.id)
+	case kindFloatingIP:
+		return s.networking.DeleteFloatingIP(ctx, res.id)
+	case kindNetwork:
+		return s.deleteNetwork(ctx, res.id)
+	case kindRouter:
+		return s.networking.DeleteRouter(ctx, res.id)
+	case kindSecurityGroup:
+		return s.networking.DeleteSecurityGroup(ctx, res.id)
+	case kindKeyPair:
+		return s.compute.DeleteKeyPair(ctx, res.name)
+	case kindServerGroup:
+		return s.compute.DeleteServerGroup(ctx, res.id)
+	}
+	return fmt.Errorf("unknown kind %s", res.kind)
+}
+
+// deleteNetwork detaches the subnets of the network from their routers and deletes the network together with its
+// subnets.
+func (s *scan) deleteNetwork(ctx context.Context, id string) error {
+	subnetList, err := s.networking.ListSubnets(ctx, subnets.ListOpts{NetworkID: id})
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnetList {
+		port, err := s.networking.GetRouterInterfacePort(ctx, "", subnet.ID)
+		if err != nil {
+			return err
+		}
+		if port == nil {
+			continue
+		}
+		if _, err := s.networking.RemoveRouterInterface(ctx, port.DeviceID, routers.RemoveInterfaceOpts{SubnetID: subnet.ID}); openstackclient.IgnoreNotFoundError(err) != nil {
+			return fmt.Errorf("could not remove subnet %s from router %s: %w", subnet.ID, port.DeviceID, err)
+		}
+	}
+	return s.networking.DeleteNetwork(ctx, id)
+}
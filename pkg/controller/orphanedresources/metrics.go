@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphanedresources
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	metricLabels = []string{"project", "region", "kind"}
+
+	orphanedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "openstack",
+		Name:      "orphaned_resources",
+		Help:      "Number of orphaned resources found by the last scan of an OpenStack project.",
+	}, metricLabels)
+
+	deletedOrphanedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "openstack",
+		Name:      "orphaned_resources_deleted_total",
+		Help:      "Total number of orphaned resources deleted in an OpenStack project.",
+	}, metricLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphanedResources, deletedOrphanedResources)
+}
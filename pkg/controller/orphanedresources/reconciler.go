@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphanedresources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+const (
+	// DefaultSyncPeriod is the default interval in which each OpenStack project is scanned.
+	DefaultSyncPeriod = time.Hour
+	// DefaultGracePeriod is the default duration for which a resource has to be orphaned before it is deleted.
+	DefaultGracePeriod = 24 * time.Hour
+
+	// EventReasonFound is the reason of the event emitted when an orphaned resource is found for the first time.
+	EventReasonFound = "OrphanedResourceFound"
+	// EventReasonDeleted is the reason of the event emitted when an orphaned resource was deleted.
+	EventReasonDeleted = "OrphanedResourceDeleted"
+	// EventReasonDeletionFailed is the reason of the event emitted when an orphaned resource cannot be deleted.
+	EventReasonDeletionFailed = "OrphanedResourceDeletionFailed"
+)
+
+// Reconciler detects resources in the OpenStack projects of the shoots which were created by the extension but are no
+// longer owned by any Infrastructure, Worker or Bastion in the seed. The projects are found via the cloudprovider
+// secrets in the shoot namespaces, and each project is scanned at most once per sync period.
+type Reconciler struct {
+	// Client is the client of the seed cluster.
+	Client client.Client
+	// Recorder records the orphaned resources as events of the cloudprovider secrets.
+	Recorder record.EventRecorder
+	// ClientFactory creates the OpenStack clients.
+	ClientFactory openstackclient.FactoryFactory
+	// Config is the configuration of the detection of orphaned resources.
+	Config controllerconfig.OrphanedResourcesConfig
+	// Clock is the clock.
+	Clock clock.Clock
+
+	lock     sync.Mutex
+	projects map[string]*projectState
+}
+
+// projectState is the state of the scans of a single OpenStack project and region.
+type projectState struct {
+	// lastScan is the time of the last scan. It is set when a scan starts, so that concurrent reconciliations of
+	// secrets of the same project do not scan it again.
+	lastScan time.Time
+	// firstSeen contains the time at which each orphaned resource was found first by its kind and id.
+	firstSeen map[string]time.Time
+}
+
+// Reconcile scans the OpenStack project of a cloudprovider secret for orphaned resources if the last scan is older
+// than the sync period.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, request.NamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error retrieving object from store: %w", err)
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, r.Client, secret.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("could not get cluster: %w", err)
+	}
+	if cluster.Shoot == nil || cluster.Shoot.Spec.Provider.Type != openstack.Type || cluster.Shoot.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	credentials, err := openstack.ExtractCredentials(secret, false)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not extract credentials from secret: %w", err)
+	}
+	region := cluster.Shoot.Spec.Region
+	project := projectName(credentials)
+
+	syncPeriod := DefaultSyncPeriod
+	if r.Config.SyncPeriod != nil {
+		syncPeriod = r.Config.SyncPeriod.Duration
+	}
+	state, wait := r.startScan(strings.Join([]string{credentials.AuthURL, project, region}, "|"), syncPeriod)
+	if wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	factory, err := r.ClientFactory.NewFactory(credentials)
+	if err != nil {
+		r.resetScan(state)
+		return reconcile.Result{}, fmt.Errorf("could not create OpenStack client factory: %w", err)
+	}
+	s := &scan{
+		Reconciler: r,
+		secret:     secret,
+		project:    project,
+		region:     region,
+		seedName:   openstack.SeedNameFromCluster(cluster),
+		state:      state,
+	}
+	log.Info("Scanning OpenStack project for orphaned resources", "project", project, "region", region)
+	if err := s.run(ctx, factory); err != nil {
+		// scan the project again with the next reconciliation
+		r.resetScan(state)
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: syncPeriod}, nil
+}
+
+// startScan returns the state of the given project if it is due for a scan and records the start of the scan.
+// Otherwise, it returns the duration until the next scan.
+func (r *Reconciler) startScan(key string, syncPeriod time.Duration) (*projectState, time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.projects == nil {
+		r.projects = map[string]*projectState{}
+	}
+	state, ok := r.projects[key]
+	if !ok {
+		state = &projectState{firstSeen: map[string]time.Time{}}
+		r.projects[key] = state
+	}
+	now := r.Clock.Now()
+	if next := state.lastScan.Add(syncPeriod); !state.lastScan.IsZero() && now.Before(next) {
+		return nil, next.Sub(now)
+	}
+	state.lastScan = now
+	return state, 0
+}
+
+// resetScan resets the time of the last scan of a project, so that it is scanned again with the next reconciliation.
+func (r *Reconciler) resetScan(state *projectState) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	state.lastScan = time.Time{}
+}
+
+// projectName returns the name of the OpenStack project of the given credentials as used in the metrics.
+func projectName(credentials *openstack.Credentials) string {
+	if credentials.ProjectID != "" {
+		return credentials.ProjectID
+	}
+	return credentials.DomainName + "/" + credentials.TenantName
+}
@@ -0,0 +1,342 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphanedresources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+const (
+	liveNamespace = "shoot--foobar--live"
+	goneNamespace = "shoot--foobar--gone"
+	seedName      = "seed1"
+	region        = "eu-1"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctx        context.Context
+		cloud      *fake.Cloud
+		networking openstackclient.Networking
+		compute    openstackclient.Compute
+		clock      *testclock.FakeClock
+		recorder   *record.FakeRecorder
+
+		seedClient client.Client
+		reconciler *Reconciler
+		request    reconcile.Request
+		project    string
+
+		projects int
+
+		goneRouterID, goneNetworkID, bastionGroupID, otherSeedGroupID string
+	)
+
+	tags := func(technicalID, seed, purpose string) []string {
+		return openstack.ResourceTags(technicalID, seed, purpose)
+	}
+
+	orphans := func(kind string) float64 {
+		return testutil.ToFloat64(orphanedResources.WithLabelValues(project, region, kind))
+	}
+
+	deleted := func(kind string) float64 {
+		return testutil.ToFloat64(deletedOrphanedResources.WithLabelValues(project, region, kind))
+	}
+
+	events := func() []string {
+		var result []string
+		for len(recorder.Events) > 0 {
+			result = append(result, <-recorder.Events)
+		}
+		return result
+	}
+
+	routerExists := func(id string) bool {
+		router, err := networking.GetRouterByID(ctx, id)
+		ExpectWithOffset(1, openstackclient.IgnoreNotFoundError(err)).To(Succeed())
+		return router != nil
+	}
+
+	securityGroupExists := func(id string) bool {
+		group, err := networking.GetSecurityGroup(ctx, id)
+		ExpectWithOffset(1, openstackclient.IgnoreNotFoundError(err)).To(Succeed())
+		return group != nil
+	}
+
+	keyPairExists := func(name string) bool {
+		keyPair, err := compute.GetKeyPair(ctx, name)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return keyPair != nil
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clock = testclock.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+		recorder = record.NewFakeRecorder(20)
+		// the metrics are global, so every test uses its own project
+		projects++
+		tenantName := fmt.Sprintf("gardener-%d", projects)
+		project = "default/" + tenantName
+
+		cloud = fake.NewCloud()
+		var err error
+		networking, err = cloud.Factory().Networking()
+		Expect(err).NotTo(HaveOccurred())
+		compute, err = cloud.Factory().Compute()
+		Expect(err).NotTo(HaveOccurred())
+
+		// resources of the live shoot
+		liveNetwork, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: liveNamespace})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeNetworks, liveNetwork.ID, tags(liveNamespace, seedName, openstack.TagPurposeNodes))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = compute.CreateKeyPair(ctx, liveNamespace, "ssh-rsa AAAA")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = compute.CreateServerGroup(ctx, liveNamespace+"-pool-abcde", "soft-anti-affinity")
+		Expect(err).NotTo(HaveOccurred())
+		bastionGroup, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: liveNamespace + "-bastion-12345-sg"})
+		Expect(err).NotTo(HaveOccurred())
+		bastionGroupID = bastionGroup.ID
+		_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeSecurityGroups, bastionGroupID, tags(liveNamespace, seedName, openstack.TagPurposeBastion))
+		Expect(err).NotTo(HaveOccurred())
+
+		// resources of the deleted shoot
+		goneRouter, err := networking.CreateRouter(ctx, routers.CreateOpts{Name: goneNamespace})
+		Expect(err).NotTo(HaveOccurred())
+		goneRouterID = goneRouter.ID
+		_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeRouters, goneRouterID, tags(goneNamespace, seedName, openstack.TagPurposeNodes))
+		Expect(err).NotTo(HaveOccurred())
+		goneNetwork, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: goneNamespace})
+		Expect(err).NotTo(HaveOccurred())
+		goneNetworkID = goneNetwork.ID
+		_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeNetworks, goneNetworkID, tags(goneNamespace, seedName, openstack.TagPurposeNodes))
+		Expect(err).NotTo(HaveOccurred())
+		goneSubnet, err := networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: goneNetworkID, Name: goneNamespace, CIDR: "10.250.0.0/16", IPVersion: 4})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = networking.AddRouterInterface(ctx, goneRouterID, routers.AddInterfaceOpts{SubnetID: goneSubnet.ID})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = compute.CreateKeyPair(ctx, goneNamespace, "ssh-rsa AAAA")
+		Expect(err).NotTo(HaveOccurred())
+		cloud.AddLoadBalancer(loadbalancers.LoadBalancer{Name: "kube_service_" + goneNamespace + "_default_nginx"})
+
+		// resources of another seed and of the user
+		otherSeedGroup, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: "shoot--foobar--other"})
+		Expect(err).NotTo(HaveOccurred())
+		otherSeedGroupID = otherSeedGroup.ID
+		_, err = networking.ReplaceAllTags(ctx, openstackclient.ResourceTypeSecurityGroups, otherSeedGroupID, tags("shoot--foobar--other", "seed2", openstack.TagPurposeNodes))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = networking.CreateNetwork(ctx, networks.CreateOpts{Name: "private"})
+		Expect(err).NotTo(HaveOccurred())
+
+		shoot := &gardencorev1beta1.Shoot{
+			TypeMeta: metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+			Spec: gardencorev1beta1.ShootSpec{
+				Region:   region,
+				Provider: gardencorev1beta1.Provider{Type: openstack.Type},
+			},
+		}
+		shootJSON, err := json.Marshal(shoot)
+		Expect(err).NotTo(HaveOccurred())
+		seed := &gardencorev1beta1.Seed{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Seed"},
+			ObjectMeta: metav1.ObjectMeta{Name: seedName},
+		}
+		seedJSON, err := json.Marshal(seed)
+		Expect(err).NotTo(HaveOccurred())
+
+		seedClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(
+			&extensionsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: liveNamespace},
+				Spec: extensionsv1alpha1.ClusterSpec{
+					Shoot: runtime.RawExtension{Raw: shootJSON},
+					Seed:  runtime.RawExtension{Raw: seedJSON},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: liveNamespace, Name: v1beta1constants.SecretNameCloudProvider},
+				Data: map[string][]byte{
+					openstack.AuthURL:    []byte("https://keystone.example.com/v3"),
+					openstack.DomainName: []byte("default"),
+					openstack.TenantName: []byte(tenantName),
+					openstack.UserName:   []byte("user"),
+					openstack.Password:   []byte("secret"),
+				},
+			},
+			&extensionsv1alpha1.Infrastructure{ObjectMeta: metav1.ObjectMeta{Namespace: liveNamespace, Name: "live"}},
+			&extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Namespace: liveNamespace, Name: "live"}},
+		).Build()
+
+		reconciler = &Reconciler{
+			Client:        seedClient,
+			Recorder:      recorder,
+			ClientFactory: cloud.FactoryFactory(),
+			Config:        controllerconfig.OrphanedResourcesConfig{Enabled: true},
+			Clock:         clock,
+		}
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Namespace: liveNamespace, Name: v1beta1constants.SecretNameCloudProvider}}
+	})
+
+	It("should report the orphaned resources without deleting them", func() {
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+		Expect(orphans(kindRouter)).To(Equal(float64(1)))
+		Expect(orphans(kindNetwork)).To(Equal(float64(1)))
+		Expect(orphans(kindSecurityGroup)).To(Equal(float64(1)))
+		Expect(orphans(kindKeyPair)).To(Equal(float64(1)))
+		Expect(orphans(kindLoadBalancer)).To(Equal(float64(1)))
+		Expect(orphans(kindServerGroup)).To(BeZero())
+		Expect(events()).To(ConsistOf(
+			ContainSubstring("OrphanedResourceFound Found orphaned router "+goneNamespace),
+			ContainSubstring("OrphanedResourceFound Found orphaned network "+goneNamespace),
+			ContainSubstring("OrphanedResourceFound Found orphaned securityGroup "+liveNamespace+"-bastion-12345-sg"),
+			ContainSubstring("OrphanedResourceFound Found orphaned keyPair "+goneNamespace),
+			ContainSubstring("OrphanedResourceFound Found orphaned loadBalancer kube_service_"+goneNamespace),
+		))
+
+		clock.Step(48 * time.Hour)
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events()).To(BeEmpty())
+		Expect(routerExists(goneRouterID)).To(BeTrue())
+		Expect(cloud.Calls("DeleteRouter")).To(BeZero())
+	})
+
+	It("should delete the tagged orphaned resources after the grace period", func() {
+		reconciler.Config.Delete = true
+		reconciler.Config.GracePeriod = &metav1.Duration{Duration: 2 * time.Hour}
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerExists(goneRouterID)).To(BeTrue())
+		events()
+
+		clock.Step(time.Hour)
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerExists(goneRouterID)).To(BeTrue())
+
+		clock.Step(time.Hour)
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerExists(goneRouterID)).To(BeFalse())
+		Expect(securityGroupExists(bastionGroupID)).To(BeFalse())
+		list, err := networking.ListNetwork(ctx, networks.ListOpts{ID: goneNetworkID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list).To(BeEmpty())
+
+		By("keeping the untagged orphaned resources and the ones of other seeds")
+		Expect(keyPairExists(goneNamespace)).To(BeTrue())
+		Expect(cloud.Calls("DeleteLoadbalancer")).To(BeZero())
+		Expect(securityGroupExists(otherSeedGroupID)).To(BeTrue())
+
+		Expect(orphans(kindRouter)).To(BeZero())
+		Expect(orphans(kindKeyPair)).To(Equal(float64(1)))
+		Expect(deleted(kindRouter)).To(Equal(float64(1)))
+		Expect(deleted(kindNetwork)).To(Equal(float64(1)))
+		Expect(deleted(kindSecurityGroup)).To(Equal(float64(1)))
+		Expect(events()).To(ConsistOf(
+			ContainSubstring("OrphanedResourceDeleted Deleted orphaned network "+goneNamespace),
+			ContainSubstring("OrphanedResourceDeleted Deleted orphaned router "+goneNamespace),
+			ContainSubstring("OrphanedResourceDeleted Deleted orphaned securityGroup "+liveNamespace+"-bastion-12345-sg"),
+		))
+	})
+
+	It("should delete the untagged orphaned resources if enabled", func() {
+		reconciler.Config.Delete = true
+		reconciler.Config.DeleteUntagged = true
+		reconciler.Config.GracePeriod = &metav1.Duration{}
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keyPairExists(goneNamespace)).To(BeFalse())
+		Expect(keyPairExists(liveNamespace)).To(BeTrue())
+		Expect(cloud.Calls("DeleteLoadbalancer")).To(Equal(1))
+		Expect(securityGroupExists(otherSeedGroupID)).To(BeTrue())
+		serverGroups, err := compute.ListServerGroups(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serverGroups).To(HaveLen(1))
+		Expect(deleted(kindLoadBalancer)).To(Equal(float64(1)))
+	})
+
+	It("should retry resources which cannot be deleted with the next scan", func() {
+		reconciler.Config.Delete = true
+		reconciler.Config.GracePeriod = &metav1.Duration{}
+		cloud.InjectFault("DeleteRouter", fake.Fault{Err: fmt.Errorf("router is busy"), Times: 1})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerExists(goneRouterID)).To(BeTrue())
+		Expect(orphans(kindRouter)).To(Equal(float64(1)))
+		Expect(events()).To(ContainElement(ContainSubstring("OrphanedResourceDeletionFailed Cannot delete orphaned router " + goneNamespace)))
+
+		clock.Step(time.Hour)
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routerExists(goneRouterID)).To(BeFalse())
+		Expect(orphans(kindRouter)).To(BeZero())
+	})
+
+	It("should scan each project at most once per sync period", func() {
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(cloud.Calls("ListRouters")).To(Equal(1))
+
+		clock.Step(20 * time.Minute)
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(40 * time.Minute))
+		Expect(cloud.Calls("ListRouters")).To(Equal(1))
+
+		clock.Step(40 * time.Minute)
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloud.Calls("ListRouters")).To(Equal(2))
+	})
+
+	It("should scan the project again after a failed scan", func() {
+		cloud.InjectFault("ListNetwork", fake.Fault{Err: fmt.Errorf("service unavailable"), Times: 1})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).To(MatchError(ContainSubstring("service unavailable")))
+
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloud.Calls("ListNetwork")).To(Equal(2))
+		Expect(orphans(kindNetwork)).To(Equal(float64(1)))
+	})
+})
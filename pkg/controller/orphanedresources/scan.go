@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package orphanedresources
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+const (
+	kindLoadBalancer  = "loadBalancer"
+	kindServer        = "server"
+	kindFloatingIP    = "floatingIP"
+	kindNetwork       = "network"
+	kindRouter        = "router"
+	kindSecurityGroup = "securityGroup"
+	kindKeyPair       = "keyPair"
+	kindServerGroup   = "serverGroup"
+
+	ownerInfrastructure = "Infrastructure"
+	ownerWorker         = "Worker"
+	ownerBastion        = "Bastion"
+
+	// loadBalancerPrefix is the prefix of the names of the load balancers of services created by the cloud controller
+	// manager. It is followed by the technical id of the shoot and an underscore.
+	loadBalancerPrefix = "kube_service_"
+	// bastionInfix is contained in the names of all resources of bastions.
+	bastionInfix = "-bastion-"
+)
+
+// kinds contains the kinds of resources in the order in which they are deleted.
+var kinds = []string{
+	kindLoadBalancer,
+	kindServer,
+	kindFloatingIP,
+	kindNetwork,
+	kindRouter,
+	kindSecurityGroup,
+	kindKeyPair,
+	kindServerGroup,
+}
+
+// resource is a resource in an OpenStack project which might have been created by the extension.
+type resource struct {
+	kind string
+	id   string
+	name string
+	// technicalID is the technical id of the shoot from the tags or the name of the resource. It is empty if it cannot
+	// be derived from the name.
+	technicalID string
+	// tagged is true if the resource carries the tags of the extension.
+	tagged   bool
+	seedName string
+	purpose  string
+}
+
+func (r resource) key() string {
+	return r.kind + "/" + r.id
+}
+
+// owners contains the namespaces of the objects in the seed which own resources in the OpenStack projects.
+type owners struct {
+	// namespaces contains the namespaces of all shoots sorted by descending length.
+	namespaces []string
+	// objects contains the namespaces with an object of the given kind.
+	objects map[string]sets.Set[string]
+}
+
+// scan is a single scan of an OpenStack project.
+type scan struct {
+	*Reconciler
+
+	secret   *corev1.Secret
+	project  string
+	region   string
+	seedName string
+	state    *projectState
+
+	networking    openstackclient.Networking
+	compute       openstackclient.Compute
+	loadbalancing openstackclient.Loadbalancing
+}
+
+// run lists the candidates in the project and the owners in the seed, reports the orphaned resources and deletes them
+// if enabled.
+func (s *scan) run(ctx context.Context, factory openstackclient.Factory) error {
+	var err error
+	if s.networking, err = factory.Networking(openstackclient.WithRegion(s.region)); err != nil {
+		return fmt.Errorf("could not create networking client: %w", err)
+	}
+	if s.compute, err = factory.Compute(openstackclient.WithRegion(s.region)); err != nil {
+		return fmt.Errorf("could not create compute client: %w", err)
+	}
+	if s.loadbalancing, err = factory.Loadbalancing(openstackclient.WithRegion(s.region)); err != nil {
+		return fmt.Errorf("could not create loadbalancing client: %w", err)
+	}
+
+	owners, err := s.listOwners(ctx)
+	if err != nil {
+		return err
+	}
+	candidates, err := s.listCandidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.Clock.Now()
+	firstSeen := map[string]time.Time{}
+	var orphans []resource
+	for _, res := range candidates {
+		if !owners.isOrphan(res) {
+			continue
+		}
+		orphans = append(orphans, res)
+		since, ok := s.state.firstSeen[res.key()]
+		if !ok {
+			since = now
+			s.Recorder.Eventf(s.secret, corev1.EventTypeWarning, EventReasonFound,
+				"Found orphaned %s %s (%s) in project %s and region %s", res.kind, res.name, res.id, s.project, s.region)
+		}
+		firstSeen[res.key()] = since
+	}
+	// forget resources which are gone or owned again
+	s.state.firstSeen = firstSeen
+
+	if s.Config.Delete {
+		orphans = s.deleteOrphans(ctx, orphans)
+	}
+
+	for _, kind := range kinds {
+		count := 0
+		for _, res := range orphans {
+			if res.kind == kind {
+				count++
+			}
+		}
+		orphanedResources.WithLabelValues(s.project, s.region, kind).Set(float64(count))
+	}
+	return nil
+}
+
+// listOwners lists the shoots and the objects owning resources in the OpenStack projects in the seed.
+func (s *scan) listOwners(ctx context.Context) (*owners, error) {
+	namespaces := sets.New[string]()
+	clusters := &extensionsv1alpha1.ClusterList{}
+	if err := s.Client.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("could not list clusters: %w", err)
+	}
+	for _, cluster := range clusters.Items {
+		namespaces.Insert(cluster.Name)
+	}
+
+	o := &owners{objects: map[string]sets.Set[string]{}}
+	for kind, list := range map[string]client.ObjectList{
+		ownerInfrastructure: &extensionsv1alpha1.InfrastructureList{},
+		ownerWorker:         &extensionsv1alpha1.WorkerList{},
+		ownerBastion:        &extensionsv1alpha1.BastionList{},
+	} {
+		if err := s.Client.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("could not list %s objects: %w", kind, err)
+		}
+		o.objects[kind] = sets.New[string]()
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			o.objects[kind].Insert(obj.(client.Object).GetNamespace())
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		namespaces.Insert(o.objects[kind].UnsortedList()...)
+	}
+
+	o.namespaces = sets.List(namespaces)
+	slices.SortStableFunc(o.namespaces, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+	return o, nil
+}
+
+// isOrphan returns true if no object in the seed owns the given resource.
+func (o *owners) isOrphan(res resource) bool {
+	technicalID := res.technicalID
+	if technicalID == "" {
+		// the namespace of the shoot is the longest one which is a prefix of the name
+		for _, namespace := range o.namespaces {
+			if res.name == namespace || strings.HasPrefix(res.name, namespace+"-") {
+				technicalID = namespace
+				break
+			}
+		}
+		if technicalID == "" {
+			return true
+		}
+	}
+	return !o.objects[ownerKind(res, technicalID)].Has(technicalID)
+}
+
+// ownerKind returns the kind of the object owning the given resource.
+func ownerKind(res resource, technicalID string) string {
+	switch {
+	case res.kind == kindServerGroup:
+		return ownerWorker
+	case res.kind == kindServer:
+		// only bastion servers are candidates, the servers of the machines are managed by the machine-controller-manager
+		return ownerBastion
+	case res.tagged:
+		if res.purpose == openstack.TagPurposeBastion {
+			return ownerBastion
+		}
+		return ownerInfrastructure
+	case (res.kind == kindFloatingIP || res.kind == kindSecurityGroup) &&
+		strings.Contains(strings.TrimPrefix(res.name, technicalID), bastionInfix):
+		return ownerBastion
+	default:
+		return ownerInfrastructure
+	}
+}
+
+// listCandidates lists the resources in the project which are tagged by the extension or named like the resources of
+// shoots. Tagged resources of other seeds are skipped.
+func (s *scan) listCandidates(ctx context.Context) ([]resource, error) {
+	var candidates []resource
+	add := func(kind, id, name string, tags map[string]string, filter func(resource) bool) {
+		res := resource{
+			kind:        kind,
+			id:          id,
+			name:        name,
+			technicalID: tags[openstack.TagKeyTechnicalID],
+			seedName:    tags[openstack.TagKeySeed],
+			purpose:     tags[openstack.TagKeyPurpose],
+		}
+		if res.technicalID != "" {
+			res.tagged = true
+			// the resources of other seeds sharing the project are left to them
+			if res.seedName != "" && res.seedName != s.seedName {
+				return
+			}
+		} else if !strings.HasPrefix(name, v1beta1constants.TechnicalIDPrefix) {
+			return
+		}
+		if filter == nil || filter(res) {
+			candidates = append(candidates, res)
+		}
+	}
+	isBastion := func(res resource) bool {
+		if res.tagged {
+			return res.purpose == openstack.TagPurposeBastion
+		}
+		return strings.Contains(res.name, bastionInfix)
+	}
+
+	routerList, err := s.networking.ListRouters(ctx, routers.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list routers: %w", err)
+	}
+	for _, router := range routerList {
+		add(kindRouter, router.ID, router.Name, openstack.ResourceTagValues(router.Tags), nil)
+	}
+
+	networkList, err := s.networking.ListNetwork(ctx, networks.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list networks: %w", err)
+	}
+	for _, network := range networkList {
+		add(kindNetwork, network.ID, network.Name, openstack.ResourceTagValues(network.Tags), nil)
+	}
+
+	securityGroups, err := s.networking.ListSecurityGroup(ctx, groups.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list security groups: %w", err)
+	}
+	for _, group := range securityGroups {
+		add(kindSecurityGroup, group.ID, group.Name, openstack.ResourceTagValues(group.Tags), nil)
+	}
+
+	fips, err := s.networking.ListFip(ctx, floatingips.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list floating IPs: %w", err)
+	}
+	for _, fip := range fips {
+		// the floating IPs of bastions are named by their descriptions
+		add(kindFloatingIP, fip.ID, fip.Description, openstack.ResourceTagValues(fip.Tags), isBastion)
+	}
+
+	serverList, err := s.compute.ListServers(ctx, servers.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list servers: %w", err)
+	}
+	for _, server := range serverList {
+		add(kindServer, server.ID, server.Name, server.Metadata, isBastion)
+	}
+
+	keyPairs, err := s.compute.ListKeyPairs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list key pairs: %w", err)
+	}
+	for _, keyPair := range keyPairs {
+		add(kindKeyPair, keyPair.Name, keyPair.Name, nil, nil)
+	}
+
+	serverGroups, err := s.compute.ListServerGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list server groups: %w", err)
+	}
+	for _, serverGroup := range serverGroups {
+		add(kindServerGroup, serverGroup.ID, serverGroup.Name, nil, nil)
+	}
+
+	loadBalancers, err := s.loadbalancing.ListLoadbalancers(ctx, loadbalancers.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list load balancers: %w", err)
+	}
+	for _, lb := range loadBalancers {
+		technicalID, _, ok := strings.Cut(strings.TrimPrefix(lb.Name, loadBalancerPrefix), "_")
+		if strings.HasPrefix(lb.Name, loadBalancerPrefix) && ok && strings.HasPrefix(technicalID, v1beta1constants.TechnicalIDPrefix) &&
+			lb.ProvisioningStatus != "PENDING_DELETE" {
+			candidates = append(candidates, resource{kind: kindLoadBalancer, id: lb.ID, name: lb.Name, technicalID: technicalID})
+		}
+	}
+
+	return candidates, nil
+}
@@ -7,6 +7,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
@@ -25,14 +26,16 @@ import (
 
 	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
 )
 
 type delegateFactory struct {
-	seedClient   client.Client
-	restConfig   *rest.Config
-	scheme       *runtime.Scheme
-	gardenReader client.Reader
+	seedClient             client.Client
+	restConfig             *rest.Config
+	scheme                 *runtime.Scheme
+	gardenReader           client.Reader
+	openstackClientFactory openstackclient.FactoryFactory
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled WorkerPoolConfigs.
@@ -42,6 +45,10 @@ func NewActuator(mgr manager.Manager, gardenCluster cluster.Cluster) worker.Actu
 			seedClient: mgr.GetClient(),
 			restConfig: mgr.GetConfig(),
 			scheme:     mgr.GetScheme(),
+			openstackClientFactory: openstackclient.NewCachingFactoryFactory(
+				openstackclient.NewFactoryFactory(openstackclient.WithController(worker.ControllerName)),
+				openstackclient.DefaultFactoryCacheIdleTimeout,
+			),
 		}
 	)
 
@@ -81,7 +88,15 @@ func (d *delegateFactory) WorkerDelegate(ctx context.Context, worker *extensions
 		return nil, err
 	}
 
-	openstackClient, err := openstackclient.NewOpenStackClientFromSecretRef(ctx, d.seedClient, worker.Spec.SecretRef, &keyStoneURL)
+	credentials, err := openstack.GetCredentials(ctx, d.seedClient, worker.Spec.SecretRef, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Openstack credentials: %w", err)
+	}
+	if len(strings.TrimSpace(credentials.AuthURL)) == 0 {
+		credentials.AuthURL = keyStoneURL
+	}
+
+	openstackClient, err := d.openstackClientFactory.NewFactory(credentials, openstackclient.WithRequestTimeout(cloudProfileConfig.RequestTimeout))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create openstack seedClient: %w", err)
 	}
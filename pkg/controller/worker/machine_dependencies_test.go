@@ -27,6 +27,8 @@ import (
 	api "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack"
 	apiv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/worker"
+	osclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/mocks"
 )
 
@@ -126,10 +128,10 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().CreateServerGroup(prefixMatch(serverGroupPrefix(clusterName, pool1)), policy).Return(&servergroups.ServerGroup{
+				computeClient.EXPECT().CreateServerGroup(gomock.Any(), prefixMatch(serverGroupPrefix(clusterName, pool1)), policy).Return(&servergroups.ServerGroup{
 					ID: serverGroupID1,
 				}, nil)
-				computeClient.EXPECT().CreateServerGroup(prefixMatch(serverGroupPrefix(clusterName, pool2)), policy).Return(&servergroups.ServerGroup{
+				computeClient.EXPECT().CreateServerGroup(gomock.Any(), prefixMatch(serverGroupPrefix(clusterName, pool2)), policy).Return(&servergroups.ServerGroup{
 					ID: serverGroupID2,
 				}, nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
@@ -171,7 +173,7 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().CreateServerGroup(prefixMatch(serverGroupPrefix(clusterName, poolName)), policy).Return(&servergroups.ServerGroup{
+				computeClient.EXPECT().CreateServerGroup(gomock.Any(), prefixMatch(serverGroupPrefix(clusterName, poolName)), policy).Return(&servergroups.ServerGroup{
 					ID: "id",
 				}, nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
@@ -188,11 +190,11 @@ var _ = Describe("#MachineDependencies", func() {
 				))
 
 				w.Spec.Pools[0] = *(newWorkerPoolWithPolicy("pool", &newPolicy))
-				computeClient.EXPECT().GetServerGroup("id").Return(&servergroups.ServerGroup{
+				computeClient.EXPECT().GetServerGroup(gomock.Any(), "id").Return(&servergroups.ServerGroup{
 					ID:       "id",
 					Policies: []string{"foo"},
 				}, nil)
-				computeClient.EXPECT().CreateServerGroup(prefixMatch(serverGroupPrefix(clusterName, poolName)), newPolicy).Return(&servergroups.ServerGroup{
+				computeClient.EXPECT().CreateServerGroup(gomock.Any(), prefixMatch(serverGroupPrefix(clusterName, poolName)), newPolicy).Return(&servergroups.ServerGroup{
 					ID: "new-id",
 				}, nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
@@ -243,13 +245,13 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().ListServerGroups().Return([]servergroups.ServerGroup{
+				computeClient.EXPECT().ListServerGroups(gomock.Any()).Return([]servergroups.ServerGroup{
 					{
 						ID:   serverGroupID,
 						Name: serverGroupName,
 					},
 				}, nil)
-				computeClient.EXPECT().DeleteServerGroup(serverGroupID).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), serverGroupID).Return(nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
 
 				err := workerDelegate.PostReconcileHook(ctx)
@@ -298,7 +300,7 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().ListServerGroups().Return([]servergroups.ServerGroup{
+				computeClient.EXPECT().ListServerGroups(gomock.Any()).Return([]servergroups.ServerGroup{
 					{
 						ID:   serverGroupID,
 						Name: serverGroupName,
@@ -308,7 +310,7 @@ var _ = Describe("#MachineDependencies", func() {
 						Name: oldServerGroupName,
 					},
 				}, nil)
-				computeClient.EXPECT().DeleteServerGroup(oldServerGroupID).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), oldServerGroupID).Return(nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
 
 				err := workerDelegate.PostReconcileHook(ctx)
@@ -361,7 +363,7 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().ListServerGroups().Return([]servergroups.ServerGroup{
+				computeClient.EXPECT().ListServerGroups(gomock.Any()).Return([]servergroups.ServerGroup{
 					{
 						ID:   serverGroupID1,
 						Name: poolName1,
@@ -371,8 +373,8 @@ var _ = Describe("#MachineDependencies", func() {
 						Name: poolName2,
 					},
 				}, nil)
-				computeClient.EXPECT().DeleteServerGroup(serverGroupID1).Return(nil)
-				computeClient.EXPECT().DeleteServerGroup(serverGroupID2).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), serverGroupID1).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), serverGroupID2).Return(nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
 
 				err := workerDelegate.PostReconcileHook(ctx)
@@ -416,13 +418,13 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().ListServerGroups().Return([]servergroups.ServerGroup{
+				computeClient.EXPECT().ListServerGroups(gomock.Any()).Return([]servergroups.ServerGroup{
 					{
 						ID:   serverGroupID,
 						Name: serverGroupName,
 					},
 				}, nil)
-				computeClient.EXPECT().DeleteServerGroup(serverGroupID).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), serverGroupID).Return(nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
 
 				err := workerDelegate.PostDeleteHook(ctx)
@@ -471,7 +473,7 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().ListServerGroups().Return([]servergroups.ServerGroup{
+				computeClient.EXPECT().ListServerGroups(gomock.Any()).Return([]servergroups.ServerGroup{
 					{
 						ID:   serverGroupID,
 						Name: serverGroupName,
@@ -481,7 +483,7 @@ var _ = Describe("#MachineDependencies", func() {
 						Name: oldServerGroupName,
 					},
 				}, nil)
-				computeClient.EXPECT().DeleteServerGroup(oldServerGroupID).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), oldServerGroupID).Return(nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
 
 				err := workerDelegate.PostDeleteHook(ctx)
@@ -534,7 +536,7 @@ var _ = Describe("#MachineDependencies", func() {
 					osFactory,
 				)
 
-				computeClient.EXPECT().ListServerGroups().Return([]servergroups.ServerGroup{
+				computeClient.EXPECT().ListServerGroups(gomock.Any()).Return([]servergroups.ServerGroup{
 					{
 						ID:   serverGroupID1,
 						Name: poolName1,
@@ -544,8 +546,8 @@ var _ = Describe("#MachineDependencies", func() {
 						Name: poolName2,
 					},
 				}, nil)
-				computeClient.EXPECT().DeleteServerGroup(serverGroupID1).Return(nil)
-				computeClient.EXPECT().DeleteServerGroup(serverGroupID2).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), serverGroupID1).Return(nil)
+				computeClient.EXPECT().DeleteServerGroup(gomock.Any(), serverGroupID2).Return(nil)
 				expectStatusUpdateToSucceed(ctx, statusCl)
 
 				err := workerDelegate.PostDeleteHook(ctx)
@@ -556,6 +558,74 @@ var _ = Describe("#MachineDependencies", func() {
 			})
 		})
 	})
+
+	Context("#ServerGroups with the fake cloud", func() {
+		var (
+			ctx         = context.Background()
+			clusterName = "shoot--foobar--openstack"
+			cloud       *fake.Cloud
+			compute     osclient.Compute
+			w           *extensionsv1alpha1.Worker
+		)
+
+		BeforeEach(func() {
+			cloud = fake.NewCloud()
+			var err error
+			compute, err = cloud.Factory().Compute()
+			Expect(err).NotTo(HaveOccurred())
+			w = &extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Namespace: clusterName}}
+			statusCl.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&extensionsv1alpha1.Worker{}), gomock.Any()).Return(nil).AnyTimes()
+		})
+
+		newWorkerDelegate := func() genericactuator.WorkerDelegate {
+			workerDelegate, err := worker.NewWorkerDelegate(cl, scheme, nil, "", w, newClusterWithDefaultCloudProfileConfig(clusterName), cloud.Factory())
+			Expect(err).NotTo(HaveOccurred())
+			return workerDelegate
+		}
+
+		serverGroupNames := func() []string {
+			groups, err := compute.ListServerGroups(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for _, group := range groups {
+				names = append(names, group.Name+"/"+group.Policies[0])
+			}
+			return names
+		}
+
+		It("should manage the server groups of the worker pools", func() {
+			_, err := compute.CreateServerGroup(ctx, "shoot--foobar--other-pool-1-abcde", osclient.ServerGroupPolicyAffinity)
+			Expect(err).NotTo(HaveOccurred())
+			affinity, antiAffinity := osclient.ServerGroupPolicyAffinity, osclient.ServerGroupPolicyAntiAffinity
+			w.Spec.Pools = []extensionsv1alpha1.WorkerPool{*newWorkerPoolWithPolicy("pool-1", &affinity), *newWorkerPoolWithPolicy("pool-2", &antiAffinity)}
+
+			Expect(newWorkerDelegate().PreReconcileHook(ctx)).To(Succeed())
+			Expect(newWorkerDelegate().PostReconcileHook(ctx)).To(Succeed())
+			Expect(serverGroupNames()).To(ConsistOf(
+				"shoot--foobar--other-pool-1-abcde/affinity",
+				HavePrefix(serverGroupPrefix(clusterName, "pool-1")),
+				And(HavePrefix(serverGroupPrefix(clusterName, "pool-2")), HaveSuffix("/anti-affinity")),
+			))
+
+			By("changing the policy of a pool and removing another one")
+			w.Spec.Pools = []extensionsv1alpha1.WorkerPool{*newWorkerPoolWithPolicy("pool-1", &antiAffinity)}
+			Expect(newWorkerDelegate().PreReconcileHook(ctx)).To(Succeed())
+			Expect(newWorkerDelegate().PostReconcileHook(ctx)).To(Succeed())
+			Expect(serverGroupNames()).To(ConsistOf(
+				"shoot--foobar--other-pool-1-abcde/affinity",
+				And(HavePrefix(serverGroupPrefix(clusterName, "pool-1")), HaveSuffix("/anti-affinity")),
+			))
+			workerStatus := w.Status.ProviderStatus.Object.(*apiv1alpha1.WorkerStatus)
+			Expect(workerStatus.ServerGroupDependencies).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"PoolName": Equal("pool-1")})))
+
+			By("deleting the worker")
+			w.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			Expect(newWorkerDelegate().PostDeleteHook(ctx)).To(Succeed())
+			Expect(serverGroupNames()).To(ConsistOf("shoot--foobar--other-pool-1-abcde/affinity"))
+			workerStatus = w.Status.ProviderStatus.Object.(*apiv1alpha1.WorkerStatus)
+			Expect(workerStatus.ServerGroupDependencies).To(BeEmpty())
+		})
+	})
 })
 
 func newWorkerPoolWithPolicy(name string, policy *string) *extensionsv1alpha1.WorkerPool {
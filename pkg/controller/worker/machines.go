@@ -89,8 +89,7 @@ func (w *workerDelegate) generateMachineConfig() error {
 		return err
 	}
 
-	subnet, err := helper.FindSubnetByPurpose(infrastructureStatus.Networks.Subnets, api.PurposeNodes)
-	if err != nil {
+	if _, err := helper.FindSubnetByPurpose(infrastructureStatus.Networks.Subnets, api.PurposeNodes); err != nil {
 		return err
 	}
 
@@ -137,6 +136,10 @@ func (w *workerDelegate) generateMachineConfig() error {
 
 		for zoneIndex, zone := range pool.Zones {
 			zoneIdx := int32(zoneIndex)
+			subnet, err := helper.FindSubnetByPurposeAndZone(infrastructureStatus.Networks.Subnets, api.PurposeNodes, zone)
+			if err != nil {
+				return err
+			}
 			machineClassSpec := map[string]interface{}{
 				"region":           w.worker.Spec.Region,
 				"availabilityZone": zone,
@@ -152,6 +155,7 @@ func (w *workerDelegate) generateMachineConfig() error {
 						fmt.Sprintf("kubernetes.io-cluster-%s", w.worker.Namespace): "1",
 						"kubernetes.io-role-node":                                   "1",
 					},
+					openstack.ResourceMetadata(w.worker.Namespace, openstack.SeedNameFromCluster(w.cluster), openstack.TagPurposeNodes),
 				),
 				"credentialsSecretRef": map[string]interface{}{
 					"name":      w.worker.Spec.SecretRef.Name,
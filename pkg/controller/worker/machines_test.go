@@ -383,6 +383,8 @@ var _ = Describe("Machines", func() {
 						"tags": map[string]string{
 							fmt.Sprintf("kubernetes.io-cluster-%s", namespace): "1",
 							"kubernetes.io-role-node":                          "1",
+							"gardener-shoot":                                   namespace,
+							"gardener-purpose":                                 "nodes",
 						},
 						"secret": map[string]interface{}{
 							"cloudConfig": string(userData),
@@ -609,6 +611,61 @@ var _ = Describe("Machines", func() {
 					Expect(result).To(Equal(machineDeployments))
 				})
 
+				It("should use the subnet of the availability zone if present", func() {
+					setup(region, machineImage, "")
+
+					zoneSubnetID := "zoneSubnetID"
+					workerWithZoneSubnet := w.DeepCopy()
+					workerWithZoneSubnet.Spec.InfrastructureProviderStatus = &runtime.RawExtension{
+						Raw: encode(&api.InfrastructureStatus{
+							SecurityGroups: []api.SecurityGroup{
+								{
+									Purpose: api.PurposeNodes,
+									Name:    securityGroupName,
+								},
+							},
+							Node: api.NodeStatus{
+								KeyName: keyName,
+							},
+							Networks: api.NetworkStatus{
+								ID: networkID,
+								Subnets: []api.Subnet{
+									{
+										Purpose: api.PurposeNodes,
+										ID:      subnetID,
+									},
+									{
+										Purpose: api.PurposeNodes,
+										ID:      zoneSubnetID,
+										Zone:    ptr.To(zone1),
+									},
+								},
+							},
+						}),
+					}
+					workerDelegate, _ := NewWorkerDelegate(c, scheme, chartApplier, "", workerWithZoneSubnet, cluster, nil)
+
+					classes := machineClasses["machineClasses"].([]map[string]interface{})
+					classes[0]["subnetID"] = zoneSubnetID
+					classes[2]["subnetID"] = zoneSubnetID
+
+					chartApplier.
+						EXPECT().
+						ApplyFromEmbeddedFS(
+							context.TODO(),
+							charts.InternalChart,
+							filepath.Join("internal", "machineclass"),
+							namespace,
+							"machineclass",
+							kubernetes.Values(machineClasses),
+						).
+						Return(nil)
+
+					err := workerDelegate.DeployMachineClasses(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(classes[1]["subnetID"]).To(Equal(subnetID))
+				})
+
 				Context("Server Groups", func() {
 					It("should create the expected machine classes with server group configurations", func() {
 						var (
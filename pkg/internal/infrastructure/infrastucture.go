@@ -8,7 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net"
+	"net/netip"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -31,7 +32,7 @@ const (
 // Note that this deletion may still leave some leftover resources like the floating IPs. This is intentional because the users may want to preserve them but without the k8s
 // service object we cannot decide that - therefore the floating IPs will be untouched.
 func CleanupKubernetesLoadbalancers(ctx context.Context, log logr.Logger, client openstackclient.Loadbalancing, subnetID, clusterName string) error {
-	lbList, err := client.ListLoadbalancers(loadbalancers.ListOpts{
+	lbList, err := client.ListLoadbalancers(ctx, loadbalancers.ListOpts{
 		VipSubnetID: subnetID,
 	})
 
@@ -62,13 +63,13 @@ func CleanupKubernetesLoadbalancers(ctx context.Context, log logr.Logger, client
 		w.Add(1)
 		go func() {
 			defer w.Done()
-			if err := client.DeleteLoadbalancer(lb.ID, loadbalancers.DeleteOpts{Cascade: true}); err != nil {
+			if err := client.DeleteLoadbalancer(ctx, lb.ID, loadbalancers.DeleteOpts{Cascade: true}); err != nil {
 				res <- err
 				return
 			}
 
-			err := wait.ExponentialBackoffWithContext(ctx, b, func(_ context.Context) (done bool, err error) {
-				lb, err := client.GetLoadbalancer(lb.ID)
+			err := wait.ExponentialBackoffWithContext(ctx, b, func(ctx context.Context) (done bool, err error) {
+				lb, err := client.GetLoadbalancer(ctx, lb.ID)
 				if err != nil {
 					return false, err
 				}
@@ -90,29 +91,58 @@ func CleanupKubernetesLoadbalancers(ctx context.Context, log logr.Logger, client
 	return err
 }
 
-// CleanupKubernetesRoutes deletes all routes from the router which have a nextHop in the subnet.
-func CleanupKubernetesRoutes(_ context.Context, client openstackclient.Networking, routerID, workers string) error {
-	router, err := client.GetRouterByID(routerID)
+// DeleteKubernetesLoadbalancers deletes the loadbalancers of the cluster like CleanupKubernetesLoadbalancers, but it
+// neither waits for the deletion nor stops at loadbalancers in an unexpected provisioning state. It is meant for
+// a best-effort cleanup, which should not be blocked by a single loadbalancer.
+func DeleteKubernetesLoadbalancers(ctx context.Context, log logr.Logger, client openstackclient.Loadbalancing, subnetID, clusterName string) error {
+	lbList, err := client.ListLoadbalancers(ctx, loadbalancers.ListOpts{
+		VipSubnetID: subnetID,
+	})
 	if err != nil {
 		return err
 	}
 
-	if router == nil {
-		return nil
+	var errs []error
+	for _, lb := range lbList {
+		if !strings.HasPrefix(lb.Name, servicePrefix+clusterName) {
+			continue
+		}
+		log.Info("deleting orphan loadbalancer", "ID", lb.ID, "name", lb.Name)
+		if err := client.DeleteLoadbalancer(ctx, lb.ID, loadbalancers.DeleteOpts{Cascade: true}); openstackclient.IgnoreNotFoundError(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete loadbalancer %s: %w", lb.ID, err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	routes := []routers.Route{}
-	_, workersNet, err := net.ParseCIDR(workers)
+// CleanupKubernetesRoutes deletes all routes from the router which have a nextHop in one of the given worker subnets,
+// e.g. the IPv4 and the IPv6 subnet of dual-stack clusters.
+func CleanupKubernetesRoutes(ctx context.Context, client openstackclient.Networking, routerID string, workers ...string) error {
+	router, err := client.GetRouterByID(ctx, routerID)
 	if err != nil {
 		return err
 	}
 
+	if router == nil {
+		return nil
+	}
+
+	var workersNets []netip.Prefix
+	for _, cidr := range workers {
+		workersNet, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return err
+		}
+		workersNets = append(workersNets, workersNet)
+	}
+
+	routes := []routers.Route{}
 	for _, route := range router.Routes {
-		ipNode, _, err := net.ParseCIDR(route.NextHop + "/32")
+		ipNode, err := netip.ParseAddr(route.NextHop)
 		if err != nil {
 			return err
 		}
-		if !workersNet.Contains(ipNode) {
+		if !slices.ContainsFunc(workersNets, func(workersNet netip.Prefix) bool { return workersNet.Contains(ipNode) }) {
 			routes = append(routes, route)
 		}
 	}
@@ -122,7 +152,7 @@ func CleanupKubernetesRoutes(_ context.Context, client openstackclient.Networkin
 		return nil
 	}
 
-	if _, err := client.UpdateRoutesForRouter(routes, routerID); err != nil {
+	if _, err := client.UpdateRoutesForRouter(ctx, routes, routerID); err != nil {
 		return err
 	}
 	return nil
@@ -41,18 +41,18 @@ var _ = Describe("Infrastructure", func() {
 		})
 
 		type args struct {
-			workers string
+			workers []string
 			prep    func()
 		}
 
 		prepRoutes := func(routes ...routers.Route) {
 			router.Routes = routes
-			nw.EXPECT().GetRouterByID(routerID).Return(router, nil)
+			nw.EXPECT().GetRouterByID(gomock.Any(), routerID).Return(router, nil)
 		}
 
 		DescribeTable("#RouteCleanup", func(a args, expErr error) {
 			a.prep()
-			err := CleanupKubernetesRoutes(ctx, nw, routerID, a.workers)
+			err := CleanupKubernetesRoutes(ctx, nw, routerID, a.workers...)
 			if expErr == nil {
 				Expect(err).To(BeNil())
 			} else {
@@ -60,11 +60,11 @@ var _ = Describe("Infrastructure", func() {
 			}
 		},
 			Entry("no update request if no routes exist", args{
-				workers: defaultWorker,
+				workers: []string{defaultWorker},
 				prep:    func() { prepRoutes() },
 			}, nil),
 			Entry("no update request if no routes need change", args{
-				workers: defaultWorker,
+				workers: []string{defaultWorker},
 				prep: func() {
 					prepRoutes(
 						routers.Route{NextHop: "10.11.0.0"},
@@ -73,7 +73,7 @@ var _ = Describe("Infrastructure", func() {
 					)
 				}}, nil),
 			Entry("expect update request", args{
-				workers: defaultWorker,
+				workers: []string{defaultWorker},
 				prep: func() {
 					prepRoutes(
 						routers.Route{NextHop: "10.0.0.0"},
@@ -82,7 +82,18 @@ var _ = Describe("Infrastructure", func() {
 						// plus one more that needs to be preserved
 						routers.Route{NextHop: "10.11.2.0"},
 					)
-					nw.EXPECT().UpdateRoutesForRouter([]routers.Route{{NextHop: "10.11.2.0"}}, routerID).Return(router, nil)
+					nw.EXPECT().UpdateRoutesForRouter(gomock.Any(), []routers.Route{{NextHop: "10.11.2.0"}}, routerID).Return(router, nil)
+				}}, nil),
+			Entry("expect update request for routes in the IPv6 subnet", args{
+				workers: []string{defaultWorker, "2001:db8::/64"},
+				prep: func() {
+					prepRoutes(
+						routers.Route{NextHop: "10.0.0.5"},
+						routers.Route{NextHop: "2001:db8::5"},
+						// plus one more that needs to be preserved
+						routers.Route{NextHop: "2001:db8:1::5"},
+					)
+					nw.EXPECT().UpdateRoutesForRouter(gomock.Any(), []routers.Route{{NextHop: "2001:db8:1::5"}}, routerID).Return(router, nil)
 				}}, nil),
 		)
 	})
@@ -115,15 +126,30 @@ var _ = Describe("Infrastructure", func() {
 		})
 
 		It("should delete all the kubernetes loadbalancers", func() {
-			lbclient.EXPECT().ListLoadbalancers(gomock.Any()).Return(lbs, nil)
-			lbclient.EXPECT().DeleteLoadbalancer("k8s", loadbalancers.DeleteOpts{Cascade: true}).Return(nil)
+			lbclient.EXPECT().ListLoadbalancers(gomock.Any(), gomock.Any()).Return(lbs, nil)
+			lbclient.EXPECT().DeleteLoadbalancer(gomock.Any(), "k8s", loadbalancers.DeleteOpts{Cascade: true}).Return(nil)
 			// first call to Get will return active state
 			gomock.InOrder(
-				lbclient.EXPECT().GetLoadbalancer("k8s").Return(&lbs[0], nil),
-				lbclient.EXPECT().GetLoadbalancer("k8s").Return(nil, nil),
+				lbclient.EXPECT().GetLoadbalancer(gomock.Any(), "k8s").Return(&lbs[0], nil),
+				lbclient.EXPECT().GetLoadbalancer(gomock.Any(), "k8s").Return(nil, nil),
 			)
 			err := CleanupKubernetesLoadbalancers(ctx, log, lbclient, subnetID, clusterName)
 			Expect(err).To(BeNil())
 		})
+
+		It("should delete the kubernetes loadbalancers without waiting and aggregate the errors", func() {
+			lbs[0].ProvisioningStatus = "PENDING_UPDATE"
+			lbs = append(lbs, loadbalancers.LoadBalancer{
+				ProvisioningStatus: "ACTIVE",
+				Name:               fmt.Sprintf("kube_service_%s_%s", clusterName, "other"),
+				ID:                 "k8s-other",
+			})
+			lbclient.EXPECT().ListLoadbalancers(gomock.Any(), gomock.Any()).Return(lbs, nil)
+			lbclient.EXPECT().DeleteLoadbalancer(gomock.Any(), "k8s", loadbalancers.DeleteOpts{Cascade: true}).Return(fmt.Errorf("conflict"))
+			lbclient.EXPECT().DeleteLoadbalancer(gomock.Any(), "k8s-other", loadbalancers.DeleteOpts{Cascade: true}).Return(nil)
+
+			err := DeleteKubernetesLoadbalancers(ctx, log, lbclient, subnetID, clusterName)
+			Expect(err).To(MatchError(ContainSubstring("failed to delete loadbalancer k8s: conflict")))
+		})
 	})
 })
@@ -89,7 +89,7 @@ func ComputeTerraformerTemplateValues(
 		return nil, err
 	}
 
-	if config.Networks.Router != nil {
+	if helper.UsesExistingRouter(config) {
 		createRouter = false
 		routerConfig["id"] = strconv.Quote(config.Networks.Router.ID)
 	}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+
+	os "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+// authenticate authenticates the provider client with the given credentials. The auth options are computed from the
+// credentials and contain the identity endpoint and the project scope.
+func authenticate(provider *gophercloud.ProviderClient, credentials *os.Credentials, authOpts *gophercloud.AuthOptions) error {
+	switch {
+	case credentials.AccessToken != "":
+		return authenticateWithAccessToken(provider, credentials, authOpts)
+	case credentials.TrustID != "":
+		return authenticateWithTrust(provider, credentials, authOpts)
+	default:
+		return openstack.Authenticate(provider, *authOpts)
+	}
+}
+
+// authenticateWithTrust requests a token scoped to the trust of the credentials. The trustee authenticates with
+// username/password or a token, the project is given by the trust.
+func authenticateWithTrust(provider *gophercloud.ProviderClient, credentials *os.Credentials, authOpts *gophercloud.AuthOptions) error {
+	trustOpts := trusts.AuthOptsExt{
+		AuthOptionsBuilder: &tokens.AuthOptions{
+			IdentityEndpoint: authOpts.IdentityEndpoint,
+			Username:         authOpts.Username,
+			Password:         authOpts.Password,
+			DomainName:       authOpts.DomainName,
+			TokenID:          authOpts.TokenID,
+			AllowReauth:      authOpts.AllowReauth,
+		},
+		TrustID: credentials.TrustID,
+	}
+	return openstack.AuthenticateV3(provider, trustOpts, gophercloud.EndpointOpts{})
+}
+
+// authenticateWithAccessToken exchanges the OIDC access token of the credentials for an unscoped token at the identity
+// provider and requests a project scoped token with it. The client re-authenticates the same way, as the unscoped
+// token may already have expired.
+func authenticateWithAccessToken(provider *gophercloud.ProviderClient, credentials *os.Credentials, authOpts *gophercloud.AuthOptions) error {
+	scopedOpts := *authOpts
+	scopedOpts.AllowReauth = false
+	// the domain of the user must not be given together with a token
+	scopedOpts.DomainID = ""
+	scopedOpts.DomainName = ""
+
+	authFunc := func(provider *gophercloud.ProviderClient) error {
+		tokenID, err := exchangeAccessToken(provider, credentials)
+		if err != nil {
+			return err
+		}
+		opts := scopedOpts
+		opts.TokenID = tokenID
+		return openstack.Authenticate(provider, opts)
+	}
+	if err := authFunc(provider); err != nil {
+		return err
+	}
+
+	if authOpts.AllowReauth {
+		// use a throw-away client like gophercloud does, so that failing requests are not re-authenticated again
+		throwaway := *provider
+		throwaway.SetThrowaway(true)
+		throwaway.ReauthFunc = nil
+		provider.ReauthFunc = func() error {
+			if err := throwaway.SetTokenAndAuthResult(nil); err != nil {
+				return err
+			}
+			if err := authFunc(&throwaway); err != nil {
+				return err
+			}
+			provider.CopyTokenFrom(&throwaway)
+			return nil
+		}
+	}
+	return nil
+}
+
+// exchangeAccessToken returns an unscoped token for the OIDC access token of the credentials, see
+// https://docs.openstack.org/keystone/latest/admin/federation/configure_federation.html#authenticating
+func exchangeAccessToken(provider *gophercloud.ProviderClient, credentials *os.Credentials) (string, error) {
+	identityClient, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		return "", err
+	}
+	url := identityClient.ServiceURL("OS-FEDERATION", "identity_providers", credentials.IdentityProvider, "protocols", credentials.Protocol, "auth")
+	resp, err := identityClient.Post(url, nil, nil, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Authorization": "Bearer " + credentials.AccessToken},
+		OkCodes:     []int{http.StatusCreated},
+	})
+	if err != nil {
+		return "", err
+	}
+	tokenID := resp.Header.Get("X-Subject-Token")
+	if tokenID == "" {
+		return "", fmt.Errorf("identity provider %q did not return a token", credentials.IdentityProvider)
+	}
+	return tokenID, nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+const (
+	// DefaultFactoryCacheIdleTimeout is the default duration after which unused entries are evicted from the cache of a
+	// caching FactoryFactory.
+	DefaultFactoryCacheIdleTimeout = 30 * time.Minute
+	// tokenExpirationSkew is the minimal remaining lifetime of the token of a cached Factory. Factories whose token
+	// expires earlier are authenticated again.
+	tokenExpirationSkew = 5 * time.Minute
+)
+
+// tokenExpirer is implemented by Factory implementations that know the expiration time of their token.
+type tokenExpirer interface {
+	tokenExpiration() time.Time
+}
+
+// CachingFactoryFactory is a FactoryFactory that caches the authenticated Factory implementations per set of credentials
+// and FactoryOptions. Cached factories are reused until their token is about to expire, the credentials of the same
+// principal change or they have not been used for the idle timeout.
+type CachingFactoryFactory struct {
+	factoryFactory FactoryFactory
+	idleTimeout    time.Duration
+	clock          clock.PassiveClock
+
+	lock       sync.Mutex
+	entries    map[string]*factoryCacheEntry
+	principals map[string]string
+}
+
+type factoryCacheEntry struct {
+	lock     sync.Mutex
+	factory  Factory
+	lastUsed time.Time
+}
+
+var _ FactoryFactory = &CachingFactoryFactory{}
+
+// NewCachingFactoryFactory returns a CachingFactoryFactory that creates new Factory implementations with the given
+// FactoryFactory. A non-positive idleTimeout defaults to DefaultFactoryCacheIdleTimeout.
+func NewCachingFactoryFactory(factoryFactory FactoryFactory, idleTimeout time.Duration) *CachingFactoryFactory {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultFactoryCacheIdleTimeout
+	}
+	return &CachingFactoryFactory{
+		factoryFactory: factoryFactory,
+		idleTimeout:    idleTimeout,
+		clock:          clock.RealClock{},
+		entries:        map[string]*factoryCacheEntry{},
+		principals:     map[string]string{},
+	}
+}
+
+// NewFactory returns the cached Factory for the given credentials and options or creates a new one.
+func (c *CachingFactoryFactory) NewFactory(credentials *openstack.Credentials, options ...FactoryOption) (Factory, error) {
+	key, principal, err := cacheKeys(credentials, newFactoryOptions(options...))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := c.getEntry(key, principal)
+
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	if entry.factory != nil && !c.isExpiring(entry.factory) {
+		return entry.factory, nil
+	}
+
+	factory, err := c.factoryFactory.NewFactory(credentials, options...)
+	if err != nil {
+		entry.factory = nil
+		return nil, err
+	}
+	entry.factory = factory
+	return factory, nil
+}
+
+// getEntry returns the cache entry for the given key and marks it as used. Entries for previous credentials of the same
+// principal and idle entries are dropped.
+func (c *CachingFactoryFactory) getEntry(key, principal string) *factoryCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Now()
+	for k, entry := range c.entries {
+		if k != key && now.Sub(entry.lastUsed) > c.idleTimeout {
+			c.deleteEntry(k)
+		}
+	}
+
+	if previous, ok := c.principals[principal]; ok && previous != key {
+		// the credentials of the principal have been changed, e.g. because the secret was updated
+		c.deleteEntry(previous)
+	}
+	c.principals[principal] = key
+
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &factoryCacheEntry{}
+		c.entries[key] = entry
+	}
+	entry.lastUsed = now
+	return entry
+}
+
+func (c *CachingFactoryFactory) deleteEntry(key string) {
+	delete(c.entries, key)
+	for principal, k := range c.principals {
+		if k == key {
+			delete(c.principals, principal)
+		}
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *CachingFactoryFactory) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.entries)
+}
+
+func (c *CachingFactoryFactory) isExpiring(factory Factory) bool {
+	expirer, ok := factory.(tokenExpirer)
+	if !ok {
+		return false
+	}
+	expiration := expirer.tokenExpiration()
+	return !expiration.IsZero() && c.clock.Now().Add(tokenExpirationSkew).After(expiration)
+}
+
+// cacheKeys returns a hash of the given credentials and options, as well as a hash identifying the principal the
+// credentials belong to.
+func cacheKeys(credentials *openstack.Credentials, opts *FactoryOptions) (string, string, error) {
+	key, err := hash(credentials, fmt.Sprintf("%+v", *opts))
+	if err != nil {
+		return "", "", err
+	}
+	principal, err := hash(
+		credentials.AuthURL,
+		credentials.DomainName,
+		credentials.TenantName,
+		credentials.ProjectID,
+		credentials.UserDomainID,
+		credentials.ProjectDomainID,
+		credentials.Username,
+		credentials.ApplicationCredentialID,
+		credentials.ApplicationCredentialName,
+		credentials.IdentityProvider,
+		credentials.TrustID,
+		fmt.Sprintf("%+v", *opts),
+	)
+	if err != nil {
+		return "", "", err
+	}
+	return key, principal, nil
+}
+
+func hash(values ...any) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
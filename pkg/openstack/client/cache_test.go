@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+type fakeFactory struct {
+	Factory
+	id         int
+	expiration time.Time
+}
+
+func (f *fakeFactory) tokenExpiration() time.Time {
+	return f.expiration
+}
+
+var _ = Describe("CachingFactoryFactory", func() {
+	var (
+		fakeClock   *testclock.FakeClock
+		created     int
+		expiration  time.Time
+		createErr   error
+		cache       *CachingFactoryFactory
+		credentials *openstack.Credentials
+	)
+
+	BeforeEach(func() {
+		fakeClock = testclock.NewFakeClock(time.Now())
+		created = 0
+		expiration = time.Time{}
+		createErr = nil
+		cache = NewCachingFactoryFactory(FactoryFactoryFunc(func(_ *openstack.Credentials, _ ...FactoryOption) (Factory, error) {
+			if createErr != nil {
+				return nil, createErr
+			}
+			created++
+			return &fakeFactory{id: created, expiration: expiration}, nil
+		}), time.Hour)
+		cache.clock = fakeClock
+		credentials = &openstack.Credentials{
+			AuthURL:    "https://keystone/v3",
+			DomainName: "domain",
+			TenantName: "project",
+			Username:   "user",
+			Password:   "password",
+		}
+	})
+
+	It("should reuse the factory for the same credentials", func() {
+		f1, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+		copied := *credentials
+		f2, err := cache.NewFactory(&copied)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(f2).To(BeIdenticalTo(f1))
+		Expect(created).To(Equal(1))
+	})
+
+	It("should create separate factories for different options", func() {
+		f1, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+		f2, err := cache.NewFactory(credentials, WithController("foo"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(f2).NotTo(BeIdenticalTo(f1))
+		Expect(cache.Len()).To(Equal(2))
+	})
+
+	It("should drop the entry of the previous credentials of the same principal", func() {
+		f1, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+
+		changed := *credentials
+		changed.Password = "new-password"
+		f2, err := cache.NewFactory(&changed)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(f2).NotTo(BeIdenticalTo(f1))
+		Expect(cache.Len()).To(Equal(1))
+	})
+
+	It("should keep entries of different principals", func() {
+		_, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+
+		other := *credentials
+		other.TenantName = "other-project"
+		_, err = cache.NewFactory(&other)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cache.Len()).To(Equal(2))
+	})
+
+	It("should evict idle entries", func() {
+		_, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock.Step(2 * time.Hour)
+		other := *credentials
+		other.TenantName = "other-project"
+		_, err = cache.NewFactory(&other)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cache.Len()).To(Equal(1))
+	})
+
+	It("should authenticate again if the token is about to expire", func() {
+		expiration = fakeClock.Now().Add(time.Hour)
+		f1, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock.Step(30 * time.Minute)
+		f2, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f2).To(BeIdenticalTo(f1))
+
+		fakeClock.Step(27 * time.Minute)
+		f3, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f3).NotTo(BeIdenticalTo(f1))
+		Expect(created).To(Equal(2))
+	})
+
+	It("should not cache errors", func() {
+		createErr = fmt.Errorf("unauthorized")
+		_, err := cache.NewFactory(credentials)
+		Expect(err).To(MatchError("unauthorized"))
+
+		createErr = nil
+		f, err := cache.NewFactory(credentials)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).NotTo(BeNil())
+	})
+})
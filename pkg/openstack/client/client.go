@@ -11,9 +11,11 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/utils/openstack/clientconfig"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,22 +24,40 @@ import (
 )
 
 // NewOpenstackClientFromCredentials returns a Factory implementation that can be used to create clients for OpenStack services.
-// TODO: respect CloudProfile's requestTimeout for the OpenStack client.
-// see https://github.com/kubernetes/cloud-provider-openstack/blob/c44d941cdb5c7fe651f5cb9191d0af23e266c7cb/pkg/openstack/openstack.go#L257
-func NewOpenstackClientFromCredentials(credentials *os.Credentials) (Factory, error) {
+// It authenticates with username/password, application credentials, a pre-issued token or an OIDC access token, optionally
+// scoped to a trust.
+// The clients use the endpoints of the region and interface of the credentials unless overwritten by Options. The
+// endpoint overrides of the credentials take precedence over the service catalog.
+// All requests issued by the clients use per-request timeouts and retries as configured by the given FactoryOptions and
+// are recorded in the OpenStack API request metrics.
+func NewOpenstackClientFromCredentials(credentials *os.Credentials, options ...FactoryOption) (Factory, error) {
 	opts := &clientconfig.ClientOpts{
 		AuthInfo: &clientconfig.AuthInfo{
 			AuthURL:                     credentials.AuthURL,
 			Username:                    credentials.Username,
 			Password:                    credentials.Password,
 			ProjectName:                 credentials.TenantName,
+			ProjectID:                   credentials.ProjectID,
 			DomainName:                  credentials.DomainName,
+			UserDomainID:                credentials.UserDomainID,
+			ProjectDomainID:             credentials.ProjectDomainID,
 			ApplicationCredentialID:     credentials.ApplicationCredentialID,
 			ApplicationCredentialName:   credentials.ApplicationCredentialName,
 			ApplicationCredentialSecret: credentials.ApplicationCredentialSecret,
 		},
 	}
 
+	if credentials.UserDomainID != "" || credentials.ProjectDomainID != "" {
+		// the domain name only applies to the user or the project whose domain is not given by id
+		opts.AuthInfo.DomainName = ""
+		if credentials.UserDomainID == "" {
+			opts.AuthInfo.UserDomainName = credentials.DomainName
+		}
+		if credentials.ProjectDomainID == "" {
+			opts.AuthInfo.ProjectDomainName = credentials.DomainName
+		}
+	}
+
 	config := &tls.Config{
 		InsecureSkipVerify: credentials.Insecure,
 	}
@@ -52,11 +72,15 @@ func NewOpenstackClientFromCredentials(credentials *os.Credentials) (Factory, er
 
 	if opts.AuthInfo.ApplicationCredentialSecret != "" {
 		opts.AuthType = clientconfig.AuthV3ApplicationCredential
+	} else if credentials.Token != "" {
+		opts.AuthInfo.Token = credentials.Token
+		opts.AuthType = clientconfig.AuthV3Token
 	}
 
+	endpoints := &serviceEndpoints{}
 	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: config}
 	opts.HTTPClient = &http.Client{
-		Transport: transport,
+		Transport: newRoundTripper(transport, newFactoryOptions(options...), endpoints),
 	}
 
 	authOpts, err := clientconfig.AuthOptions(opts)
@@ -75,20 +99,55 @@ func NewOpenstackClientFromCredentials(credentials *os.Credentials) (Factory, er
 	}
 
 	provider.HTTPClient = *opts.HTTPClient
+	endpoints.register(ServiceIdentity, "", provider.IdentityBase)
 
-	err = openstack.Authenticate(provider, *authOpts)
+	err = authenticate(provider, credentials, authOpts)
 	if err != nil {
 		return nil, err
 	}
+	provider.EndpointLocator = withEndpointOverrides(provider.EndpointLocator, credentials.EndpointOverrides)
+
+	var defaultOptions []Option
+	if credentials.Region != "" {
+		defaultOptions = append(defaultOptions, WithRegion(credentials.Region))
+	}
+	if credentials.Interface != "" {
+		defaultOptions = append(defaultOptions, WithInterface(credentials.Interface))
+	}
 
 	return &OpenstackClientFactory{
 		providerClient: provider,
+		endpoints:      endpoints,
+		defaultOptions: defaultOptions,
 	}, nil
 }
 
+// withEndpointOverrides returns an EndpointLocator which returns the endpoint URLs of the overrides by service type
+// and falls back to the given locator, i.e. the service catalog, for all other services. Re-authentication does not
+// replace the locator of the provider client, so the overrides stay in place.
+func withEndpointOverrides(locator gophercloud.EndpointLocator, overrides map[string]string) gophercloud.EndpointLocator {
+	if len(overrides) == 0 {
+		return locator
+	}
+	return func(eo gophercloud.EndpointOpts) (string, error) {
+		if endpoint, ok := overrides[eo.Type]; ok {
+			return gophercloud.NormalizeURL(endpoint), nil
+		}
+		return locator(eo)
+	}
+}
+
+// NewFactoryFactory returns a FactoryFactory that creates Factory implementations with the given default FactoryOptions.
+// Options passed to NewFactory are applied after the default options.
+func NewFactoryFactory(defaults ...FactoryOption) FactoryFactory {
+	return FactoryFactoryFunc(func(credentials *os.Credentials, options ...FactoryOption) (Factory, error) {
+		return NewOpenstackClientFromCredentials(credentials, append(append([]FactoryOption{}, defaults...), options...)...)
+	})
+}
+
 // NewOpenStackClientFromSecretRef returns a Factory implementation that can be used to create clients for OpenStack services.
 // The credentials are fetched from the Kubernetes secret referenced by <secretRef>.
-func NewOpenStackClientFromSecretRef(ctx context.Context, c client.Client, secretRef corev1.SecretReference, keyStoneUrl *string) (Factory, error) {
+func NewOpenStackClientFromSecretRef(ctx context.Context, c client.Client, secretRef corev1.SecretReference, keyStoneUrl *string, options ...FactoryOption) (Factory, error) {
 	creds, err := os.GetCredentials(ctx, c, secretRef, false)
 	if err != nil {
 		return nil, err
@@ -97,7 +156,7 @@ func NewOpenStackClientFromSecretRef(ctx context.Context, c client.Client, secre
 	if len(strings.TrimSpace(creds.AuthURL)) == 0 && keyStoneUrl != nil {
 		creds.AuthURL = *keyStoneUrl
 	}
-	return NewOpenstackClientFromCredentials(creds)
+	return NewOpenstackClientFromCredentials(creds, options...)
 }
 
 // WithRegion returns an Option that can modify the region a client targets.
@@ -108,16 +167,36 @@ func WithRegion(region string) Option {
 	}
 }
 
-// Storage returns a Storage client. The client uses Swift v1 API for issuing calls.
-func (oc *OpenstackClientFactory) Storage(options ...Option) (Storage, error) {
+// WithInterface returns an Option that can modify the interface of the endpoint a client targets, i.e. public,
+// internal or admin.
+func WithInterface(endpointInterface string) Option {
+	return func(opts gophercloud.EndpointOpts) gophercloud.EndpointOpts {
+		opts.Availability = gophercloud.Availability(endpointInterface)
+		return opts
+	}
+}
+
+// endpointOpts returns the endpoint options for a client. The given options are applied after the default options
+// of the factory, which are derived from the credentials.
+func (oc *OpenstackClientFactory) endpointOpts(options []Option) gophercloud.EndpointOpts {
 	eo := gophercloud.EndpointOpts{}
+	for _, opt := range oc.defaultOptions {
+		eo = opt(eo)
+	}
 	for _, opt := range options {
 		eo = opt(eo)
 	}
+	return eo
+}
+
+// Storage returns a Storage client. The client uses Swift v1 API for issuing calls.
+func (oc *OpenstackClientFactory) Storage(options ...Option) (Storage, error) {
+	eo := oc.endpointOpts(options)
 	storageClient, err := openstack.NewObjectStorageV1(oc.providerClient, eo)
 	if err != nil {
 		return nil, err
 	}
+	oc.registerEndpoint(ServiceObjectStore, eo, storageClient)
 
 	return &StorageClient{
 		client: storageClient,
@@ -126,15 +205,13 @@ func (oc *OpenstackClientFactory) Storage(options ...Option) (Storage, error) {
 
 // Compute returns a Compute client. The client uses Nova v2 API for issuing calls.
 func (oc *OpenstackClientFactory) Compute(options ...Option) (Compute, error) {
-	eo := gophercloud.EndpointOpts{}
-	for _, opt := range options {
-		eo = opt(eo)
-	}
+	eo := oc.endpointOpts(options)
 
 	client, err := openstack.NewComputeV2(oc.providerClient, eo)
 	if err != nil {
 		return nil, err
 	}
+	oc.registerEndpoint(ServiceCompute, eo, client)
 
 	return &ComputeClient{
 		client: client,
@@ -143,15 +220,13 @@ func (oc *OpenstackClientFactory) Compute(options ...Option) (Compute, error) {
 
 // DNS returns a DNS client. The client uses Designate v2 API for issuing calls.
 func (oc *OpenstackClientFactory) DNS(options ...Option) (DNS, error) {
-	eo := gophercloud.EndpointOpts{}
-	for _, opt := range options {
-		eo = opt(eo)
-	}
+	eo := oc.endpointOpts(options)
 
 	client, err := openstack.NewDNSV2(oc.providerClient, eo)
 	if err != nil {
 		return nil, err
 	}
+	oc.registerEndpoint(ServiceDNS, eo, client)
 
 	return &DNSClient{
 		client: client,
@@ -160,15 +235,13 @@ func (oc *OpenstackClientFactory) DNS(options ...Option) (DNS, error) {
 
 // Networking returns a Networking client. The client uses Neutron v2 API for issuing calls.
 func (oc *OpenstackClientFactory) Networking(options ...Option) (Networking, error) {
-	eo := gophercloud.EndpointOpts{}
-	for _, opt := range options {
-		eo = opt(eo)
-	}
+	eo := oc.endpointOpts(options)
 
 	client, err := openstack.NewNetworkV2(oc.providerClient, eo)
 	if err != nil {
 		return nil, err
 	}
+	oc.registerEndpoint(ServiceNetwork, eo, client)
 
 	return &NetworkingClient{
 		client: client,
@@ -177,15 +250,13 @@ func (oc *OpenstackClientFactory) Networking(options ...Option) (Networking, err
 
 // Loadbalancing creates a Loadbalancing client.
 func (oc *OpenstackClientFactory) Loadbalancing(options ...Option) (Loadbalancing, error) {
-	eo := gophercloud.EndpointOpts{}
-	for _, opt := range options {
-		eo = opt(eo)
-	}
+	eo := oc.endpointOpts(options)
 
 	client, err := openstack.NewLoadBalancerV2(oc.providerClient, eo)
 	if err != nil {
 		return nil, err
 	}
+	oc.registerEndpoint(ServiceLoadBalancer, eo, client)
 
 	return &LoadbalancingClient{
 		client: client,
@@ -194,21 +265,90 @@ func (oc *OpenstackClientFactory) Loadbalancing(options ...Option) (Loadbalancin
 
 // SharedFilesystem creates a new Manila client.
 func (oc *OpenstackClientFactory) SharedFilesystem(options ...Option) (SharedFilesystem, error) {
-	eo := gophercloud.EndpointOpts{}
-	for _, opt := range options {
-		eo = opt(eo)
-	}
+	eo := oc.endpointOpts(options)
 
 	client, err := openstack.NewSharedFileSystemV2(oc.providerClient, eo)
 	if err != nil {
 		return nil, err
 	}
+	oc.registerEndpoint(ServiceSharedFileSystem, eo, client)
 
 	return &SharedFilesystemClient{
 		client: client,
 	}, nil
 }
 
+// Identity creates a new Keystone client. The client manages the application credentials of the user the factory
+// authenticated as.
+func (oc *OpenstackClientFactory) Identity(options ...Option) (Identity, error) {
+	eo := oc.endpointOpts(options)
+
+	client, err := openstack.NewIdentityV3(oc.providerClient, eo)
+	if err != nil {
+		return nil, err
+	}
+	oc.registerEndpoint(ServiceIdentity, eo, client)
+
+	result, ok := oc.providerClient.GetAuthResult().(tokens.CreateResult)
+	if !ok {
+		return nil, fmt.Errorf("could not determine user of the token")
+	}
+	user, err := result.ExtractUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return &IdentityClient{
+		client: client,
+		userID: user.ID,
+	}, nil
+}
+
+// withContext returns a copy of the given service client whose requests are bound to ctx, i.e. they are cancelled
+// as soon as ctx is done. The copy shares the token of the original client and re-authenticates through it.
+func withContext(ctx context.Context, client *gophercloud.ServiceClient) *gophercloud.ServiceClient {
+	original := client.ProviderClient
+	provider := *original
+	// the copy needs its own locks, otherwise re-authentication would dead-lock on the locks of the original client
+	provider.UseTokenLock()
+	provider.CopyTokenFrom(original)
+	provider.Context = ctx
+	if original.ReauthFunc != nil {
+		provider.ReauthFunc = func() error {
+			if err := original.Reauthenticate(provider.Token()); err != nil {
+				return err
+			}
+			provider.CopyTokenFrom(original)
+			return nil
+		}
+	}
+
+	serviceClient := *client
+	serviceClient.ProviderClient = &provider
+	return &serviceClient
+}
+
+// tokenExpiration returns the expiration time of the Keystone token used by the factory or the zero time if unknown.
+func (oc *OpenstackClientFactory) tokenExpiration() time.Time {
+	result, ok := oc.providerClient.GetAuthResult().(tokens.CreateResult)
+	if !ok {
+		return time.Time{}
+	}
+	token, err := result.ExtractToken()
+	if err != nil {
+		return time.Time{}
+	}
+	return token.ExpiresAt
+}
+
+// registerEndpoint makes the endpoint of the given service client known to the request metrics.
+func (oc *OpenstackClientFactory) registerEndpoint(service string, eo gophercloud.EndpointOpts, client *gophercloud.ServiceClient) {
+	if oc.endpoints == nil {
+		return
+	}
+	oc.endpoints.register(service, eo.Region, client.Endpoint)
+}
+
 // IsNotFoundError checks if an error returned by OpenStack is caused by HTTP 404 status code.
 func IsNotFoundError(err error) bool {
 	if err == nil {
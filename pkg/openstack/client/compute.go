@@ -5,6 +5,8 @@
 package client
 
 import (
+	"context"
+
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
@@ -30,9 +32,10 @@ const (
 )
 
 // CreateServerGroup creates a server group with the specified policy.
-func (c *ComputeClient) CreateServerGroup(name, policy string) (*servergroups.ServerGroup, error) {
+func (c *ComputeClient) CreateServerGroup(ctx context.Context, name, policy string) (*servergroups.ServerGroup, error) {
+	client := withContext(ctx, c.client)
 	if policy != ServerGroupPolicyAffinity && policy != ServerGroupPolicyAntiAffinity {
-		c.client.Microversion = softPolicyMicroversion
+		client.Microversion = softPolicyMicroversion
 	}
 
 	createOpts := servergroups.CreateOpts{
@@ -40,17 +43,17 @@ func (c *ComputeClient) CreateServerGroup(name, policy string) (*servergroups.Se
 		Policies: []string{policy},
 	}
 
-	return servergroups.Create(c.client, createOpts).Extract()
+	return servergroups.Create(client, createOpts).Extract()
 }
 
 // GetServerGroup retrieves the server group with the specified id.
-func (c *ComputeClient) GetServerGroup(id string) (*servergroups.ServerGroup, error) {
-	return servergroups.Get(c.client, id).Extract()
+func (c *ComputeClient) GetServerGroup(ctx context.Context, id string) (*servergroups.ServerGroup, error) {
+	return servergroups.Get(withContext(ctx, c.client), id).Extract()
 }
 
 // DeleteServerGroup deletes the server group with the specified id. It returns nil if the server group could not be found.
-func (c *ComputeClient) DeleteServerGroup(id string) error {
-	err := servergroups.Delete(c.client, id).ExtractErr()
+func (c *ComputeClient) DeleteServerGroup(ctx context.Context, id string) error {
+	err := servergroups.Delete(withContext(ctx, c.client), id).ExtractErr()
 	if err != nil && !IsNotFoundError(err) {
 		return err
 	}
@@ -59,8 +62,8 @@ func (c *ComputeClient) DeleteServerGroup(id string) error {
 }
 
 // ListServerGroups retrieves the list of server groups.
-func (c *ComputeClient) ListServerGroups() ([]servergroups.ServerGroup, error) {
-	pages, err := servergroups.List(c.client, nil).AllPages()
+func (c *ComputeClient) ListServerGroups(ctx context.Context) ([]servergroups.ServerGroup, error) {
+	pages, err := servergroups.List(withContext(ctx, c.client), nil).AllPages()
 	if err != nil {
 		return nil, err
 	}
@@ -69,21 +72,30 @@ func (c *ComputeClient) ListServerGroups() ([]servergroups.ServerGroup, error) {
 }
 
 // CreateServer retrieves the Create of Compute service.
-func (c *ComputeClient) CreateServer(createOpts servers.CreateOpts) (*servers.Server, error) {
-	return servers.Create(c.client, createOpts).Extract()
+func (c *ComputeClient) CreateServer(ctx context.Context, createOpts servers.CreateOpts) (*servers.Server, error) {
+	return servers.Create(withContext(ctx, c.client), createOpts).Extract()
 }
 
 // DeleteServer delete the Compute service.
-func (c *ComputeClient) DeleteServer(id string) error {
-	return servers.Delete(c.client, id).ExtractErr()
+func (c *ComputeClient) DeleteServer(ctx context.Context, id string) error {
+	return servers.Delete(withContext(ctx, c.client), id).ExtractErr()
+}
+
+// ListServers lists the servers matching the given options. The name option is a regular expression.
+func (c *ComputeClient) ListServers(ctx context.Context, listOpts servers.ListOpts) ([]servers.Server, error) {
+	allPages, err := servers.List(withContext(ctx, c.client), listOpts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return servers.ExtractServers(allPages)
 }
 
 // FindServersByName retrieves the Compute Server by Name
-func (c *ComputeClient) FindServersByName(name string) ([]servers.Server, error) {
+func (c *ComputeClient) FindServersByName(ctx context.Context, name string) ([]servers.Server, error) {
 	listOpts := servers.ListOpts{
 		Name: name,
 	}
-	allPages, err := servers.List(c.client, listOpts).AllPages()
+	allPages, err := servers.List(withContext(ctx, c.client), listOpts).AllPages()
 	if err != nil {
 		return nil, err
 	}
@@ -96,13 +108,13 @@ func (c *ComputeClient) FindServersByName(name string) ([]servers.Server, error)
 }
 
 // AssociateFIPWithInstance associate floating ip with instance
-func (c *ComputeClient) AssociateFIPWithInstance(serverID string, associateOpts floatingips.AssociateOpts) error {
-	return floatingips.AssociateInstance(c.client, serverID, associateOpts).ExtractErr()
+func (c *ComputeClient) AssociateFIPWithInstance(ctx context.Context, serverID string, associateOpts floatingips.AssociateOpts) error {
+	return floatingips.AssociateInstance(withContext(ctx, c.client), serverID, associateOpts).ExtractErr()
 }
 
 // FindFloatingIDByInstanceID find floating id by instance id
-func (c *ComputeClient) FindFloatingIDByInstanceID(id string) (string, error) {
-	allPages, err := floatingips.List(c.client).AllPages()
+func (c *ComputeClient) FindFloatingIDByInstanceID(ctx context.Context, id string) (string, error) {
+	allPages, err := floatingips.List(withContext(ctx, c.client)).AllPages()
 	if err != nil {
 		return "", err
 	}
@@ -121,21 +133,21 @@ func (c *ComputeClient) FindFloatingIDByInstanceID(id string) (string, error) {
 }
 
 // FindFlavorID find flavor ID by flavor name
-func (c *ComputeClient) FindFlavorID(name string) (string, error) {
-	return flavorutils.IDFromName(c.client, name)
+func (c *ComputeClient) FindFlavorID(ctx context.Context, name string) (string, error) {
+	return flavorutils.IDFromName(withContext(ctx, c.client), name)
 }
 
 // FindImages find image ID by images name
-func (c *ComputeClient) FindImages(name string) ([]images.Image, error) {
+func (c *ComputeClient) FindImages(ctx context.Context, name string) ([]images.Image, error) {
 	listOpts := images.ListOpts{
 		Name: name,
 	}
-	return c.ListImages(listOpts)
+	return c.ListImages(ctx, listOpts)
 }
 
 // ListImages list all images
-func (c *ComputeClient) ListImages(listOpts images.ListOpts) ([]images.Image, error) {
-	allPages, err := images.ListDetail(c.client, listOpts).AllPages()
+func (c *ComputeClient) ListImages(ctx context.Context, listOpts images.ListOpts) ([]images.Image, error) {
+	allPages, err := images.ListDetail(withContext(ctx, c.client), listOpts).AllPages()
 	if err != nil {
 		return nil, err
 	}
@@ -143,27 +155,36 @@ func (c *ComputeClient) ListImages(listOpts images.ListOpts) ([]images.Image, er
 }
 
 // FindImageByID returns the image with the given ID. It returns nil if the image is not found.
-func (c *ComputeClient) FindImageByID(id string) (*images.Image, error) {
-	image, err := images.Get(c.client, id).Extract()
+func (c *ComputeClient) FindImageByID(ctx context.Context, id string) (*images.Image, error) {
+	image, err := images.Get(withContext(ctx, c.client), id).Extract()
 	return image, IgnoreNotFoundError(err)
 }
 
 // CreateKeyPair creates an SSH key pair
-func (c *ComputeClient) CreateKeyPair(name, publicKey string) (*keypairs.KeyPair, error) {
+func (c *ComputeClient) CreateKeyPair(ctx context.Context, name, publicKey string) (*keypairs.KeyPair, error) {
 	opts := keypairs.CreateOpts{
 		Name:      name,
 		PublicKey: publicKey,
 	}
-	return keypairs.Create(c.client, opts).Extract()
+	return keypairs.Create(withContext(ctx, c.client), opts).Extract()
 }
 
 // GetKeyPair gets an SSH key pair by name
-func (c *ComputeClient) GetKeyPair(name string) (*keypairs.KeyPair, error) {
-	keypair, err := keypairs.Get(c.client, name, nil).Extract()
+func (c *ComputeClient) GetKeyPair(ctx context.Context, name string) (*keypairs.KeyPair, error) {
+	keypair, err := keypairs.Get(withContext(ctx, c.client), name, nil).Extract()
 	return keypair, IgnoreNotFoundError(err)
 }
 
+// ListKeyPairs lists the SSH key pairs of the user
+func (c *ComputeClient) ListKeyPairs(ctx context.Context) ([]keypairs.KeyPair, error) {
+	allPages, err := keypairs.List(withContext(ctx, c.client), nil).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return keypairs.ExtractKeyPairs(allPages)
+}
+
 // DeleteKeyPair deletes an SSH key pair by name
-func (c *ComputeClient) DeleteKeyPair(name string) error {
-	return keypairs.Delete(c.client, name, nil).ExtractErr()
+func (c *ComputeClient) DeleteKeyPair(ctx context.Context, name string) error {
+	return keypairs.Delete(withContext(ctx, c.client), name, nil).ExtractErr()
 }
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ComputeClient", func() {
+	var (
+		server   *httptest.Server
+		release  chan struct{}
+		provider *gophercloud.ProviderClient
+		compute  *ComputeClient
+	)
+
+	BeforeEach(func() {
+		release = make(chan struct{})
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Auth-Token") != "new-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"keypair": {"name": "foo"}}`))
+		}))
+
+		provider = &gophercloud.ProviderClient{}
+		provider.UseTokenLock()
+		provider.SetToken("old-token")
+		provider.ReauthFunc = func() error {
+			provider.SetToken("new-token")
+			return nil
+		}
+		compute = &ComputeClient{client: &gophercloud.ServiceClient{ProviderClient: provider, Endpoint: server.URL + "/"}}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should cancel requests when the context is done", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := compute.GetKeyPair(ctx, "foo")
+		// the error of the request retried after the re-authentication is wrapped by gophercloud
+		var reauthErr *gophercloud.ErrErrorAfterReauthentication
+		Expect(errors.As(err, &reauthErr)).To(BeTrue())
+		Expect(errors.Is(reauthErr.ErrOriginal, context.DeadlineExceeded)).To(BeTrue())
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		Expect(provider.Context).To(BeNil())
+	})
+
+	It("should re-authenticate through the original provider client", func() {
+		close(release)
+
+		keypair, err := compute.GetKeyPair(context.Background(), "foo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keypair.Name).To(Equal("foo"))
+		Expect(provider.Token()).To(Equal("new-token"))
+	})
+})
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/gophercloud/gophercloud"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Endpoints", func() {
+	Describe("#endpointOpts", func() {
+		It("should apply the options after the default options of the factory", func() {
+			factory := &OpenstackClientFactory{defaultOptions: []Option{WithRegion("eu-1"), WithInterface("internal")}}
+
+			Expect(factory.endpointOpts(nil)).To(Equal(gophercloud.EndpointOpts{Region: "eu-1", Availability: gophercloud.AvailabilityInternal}))
+			Expect(factory.endpointOpts([]Option{WithRegion("eu-2")})).To(Equal(gophercloud.EndpointOpts{Region: "eu-2", Availability: gophercloud.AvailabilityInternal}))
+		})
+	})
+
+	Describe("#withEndpointOverrides", func() {
+		catalog := func(eo gophercloud.EndpointOpts) (string, error) {
+			return "https://catalog.example.com/" + eo.Type + "/", nil
+		}
+
+		It("should return the overridden endpoints and fall back to the catalog", func() {
+			locator := withEndpointOverrides(catalog, map[string]string{"network": "https://neutron.example.com:9696"})
+
+			Expect(locator(gophercloud.EndpointOpts{Type: "network"})).To(Equal("https://neutron.example.com:9696/"))
+			Expect(locator(gophercloud.EndpointOpts{Type: "compute"})).To(Equal("https://catalog.example.com/compute/"))
+		})
+	})
+})
@@ -0,0 +1,480 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+
+	"github.com/gophercloud/gophercloud"
+	computefip "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+type computeClient struct {
+	cloud *Cloud
+}
+
+var _ client.Compute = &computeClient{}
+
+// CreateServerGroup creates a server group with the given policy.
+func (c *computeClient) CreateServerGroup(ctx context.Context, name, policy string) (*servergroups.ServerGroup, error) {
+	if err := c.cloud.before(ctx, "CreateServerGroup"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	if !slices.Contains([]string{client.ServerGroupPolicyAffinity, client.ServerGroupPolicyAntiAffinity, "soft-affinity", "soft-anti-affinity"}, policy) {
+		return nil, badRequestError("BadRequest", fmt.Sprintf("Invalid input for field/attribute policies: %q is not one of the allowed values.", policy))
+	}
+	serverGroup := &servergroups.ServerGroup{
+		ID:       c.cloud.newID(),
+		Name:     name,
+		Policies: []string{policy},
+	}
+	c.cloud.serverGroups[serverGroup.ID] = serverGroup
+	result := copyServerGroup(serverGroup)
+	return &result, nil
+}
+
+// GetServerGroup returns the server group with the given ID.
+func (c *computeClient) GetServerGroup(ctx context.Context, id string) (*servergroups.ServerGroup, error) {
+	if err := c.cloud.before(ctx, "GetServerGroup"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	serverGroup, ok := c.cloud.serverGroups[id]
+	if !ok {
+		return nil, NotFoundError("ServerGroup", id)
+	}
+	result := copyServerGroup(serverGroup)
+	return &result, nil
+}
+
+// DeleteServerGroup deletes the server group with the given ID. Like the real client, it ignores missing server groups.
+func (c *computeClient) DeleteServerGroup(ctx context.Context, id string) error {
+	if err := c.cloud.before(ctx, "DeleteServerGroup"); err != nil {
+		return err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	delete(c.cloud.serverGroups, id)
+	return nil
+}
+
+// ListServerGroups returns all server groups.
+func (c *computeClient) ListServerGroups(ctx context.Context) ([]servergroups.ServerGroup, error) {
+	if err := c.cloud.before(ctx, "ListServerGroups"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	var result []servergroups.ServerGroup
+	for _, serverGroup := range sortedValues(c.cloud.serverGroups) {
+		result = append(result, copyServerGroup(serverGroup))
+	}
+	return result, nil
+}
+
+// CreateServer creates a server with a port in each of the given networks.
+func (c *computeClient) CreateServer(ctx context.Context, createOpts servers.CreateOpts) (*servers.Server, error) {
+	if err := c.cloud.before(ctx, "CreateServer"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	if _, ok := c.cloud.flavors[createOpts.FlavorRef]; !ok {
+		return nil, badRequestError("BadRequest", fmt.Sprintf("Flavor %s could not be found.", createOpts.FlavorRef))
+	}
+	if _, ok := c.cloud.images[createOpts.ImageRef]; !ok {
+		return nil, badRequestError("BadRequest", fmt.Sprintf("Image %s could not be found.", createOpts.ImageRef))
+	}
+	var securityGroupIDs []string
+	for _, nameOrID := range createOpts.SecurityGroups {
+		id, ok := c.cloud.findSecurityGroup(nameOrID)
+		if !ok {
+			return nil, badRequestError("BadRequest", fmt.Sprintf("Security group %s not found.", nameOrID))
+		}
+		securityGroupIDs = append(securityGroupIDs, id)
+	}
+	nets, ok := createOpts.Networks.([]servers.Network)
+	if !ok && createOpts.Networks != nil {
+		return nil, badRequestError("BadRequest", fmt.Sprintf("Unsupported networks %v.", createOpts.Networks))
+	}
+
+	server := &servers.Server{
+		ID:       c.cloud.newID(),
+		Name:     createOpts.Name,
+		Status:   "ACTIVE",
+		Flavor:   map[string]interface{}{"id": createOpts.FlavorRef},
+		Image:    map[string]interface{}{"id": createOpts.ImageRef},
+		Metadata: createOpts.Metadata,
+	}
+
+	var serverPorts []*ports.Port
+	for _, net := range nets {
+		port, err := c.cloud.newServerPort(server.ID, net, securityGroupIDs)
+		if err != nil {
+			return nil, err
+		}
+		serverPorts = append(serverPorts, port)
+	}
+	for _, port := range serverPorts {
+		c.cloud.ports[port.ID] = port
+	}
+	c.cloud.servers[server.ID] = server
+	return c.cloud.copyServer(server), nil
+}
+
+func (c *Cloud) newServerPort(serverID string, net servers.Network, securityGroupIDs []string) (*ports.Port, error) {
+	if _, ok := c.networks[net.UUID]; !ok {
+		return nil, badRequestError("BadRequest", fmt.Sprintf("Network %s could not be found.", net.UUID))
+	}
+	for _, subnet := range sortedValues(c.subnets) {
+		if subnet.NetworkID != net.UUID {
+			continue
+		}
+		ip := net.FixedIP
+		if ip == "" {
+			var err error
+			if ip, err = c.allocateIP(subnet); err != nil {
+				return nil, err
+			}
+		}
+		return &ports.Port{
+			ID:             c.newID(),
+			NetworkID:      net.UUID,
+			AdminStateUp:   true,
+			Status:         "ACTIVE",
+			MACAddress:     c.newMACAddress(),
+			FixedIPs:       []ports.IP{{SubnetID: subnet.ID, IPAddress: ip}},
+			DeviceOwner:    deviceOwnerCompute,
+			DeviceID:       serverID,
+			SecurityGroups: slices.Clone(securityGroupIDs),
+		}, nil
+	}
+	return nil, badRequestError("BadRequest", fmt.Sprintf("Network %s requires a subnet in order to boot instances on.", net.UUID))
+}
+
+func (c *Cloud) findSecurityGroup(nameOrID string) (string, bool) {
+	if _, ok := c.securityGroups[nameOrID]; ok {
+		return nameOrID, true
+	}
+	for _, group := range sortedValues(c.securityGroups) {
+		if group.Name == nameOrID {
+			return group.ID, true
+		}
+	}
+	return "", false
+}
+
+// DeleteServer deletes a server together with its ports. Floating IPs associated with the server are disassociated.
+func (c *computeClient) DeleteServer(ctx context.Context, id string) error {
+	if err := c.cloud.before(ctx, "DeleteServer"); err != nil {
+		return err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	if _, ok := c.cloud.servers[id]; !ok {
+		return NotFoundError("Server", id)
+	}
+	for portID, port := range c.cloud.ports {
+		if port.DeviceID != id {
+			continue
+		}
+		for _, fip := range c.cloud.floatingIPs {
+			if fip.PortID == portID {
+				fip.PortID, fip.FixedIP, fip.Status = "", "", "DOWN"
+			}
+		}
+		delete(c.cloud.ports, portID)
+	}
+	delete(c.cloud.servers, id)
+	return nil
+}
+
+// ListServers returns the servers whose names match the regular expression of the name option like Nova does. The
+// other options are ignored.
+func (c *computeClient) ListServers(ctx context.Context, listOpts servers.ListOpts) ([]servers.Server, error) {
+	if err := c.cloud.before(ctx, "ListServers"); err != nil {
+		return nil, err
+	}
+	name, err := regexp.Compile(listOpts.Name)
+	if err != nil {
+		return nil, badRequestError("BadRequest", "Invalid regular expression for name.")
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	var result []servers.Server
+	for _, server := range sortedValues(c.cloud.servers) {
+		if name.MatchString(server.Name) {
+			result = append(result, *c.cloud.copyServer(server))
+		}
+	}
+	return result, nil
+}
+
+// FindServersByName returns the servers with the given name.
+func (c *computeClient) FindServersByName(ctx context.Context, name string) ([]servers.Server, error) {
+	if err := c.cloud.before(ctx, "FindServersByName"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	var result []servers.Server
+	for _, server := range sortedValues(c.cloud.servers) {
+		if server.Name == name {
+			result = append(result, *c.cloud.copyServer(server))
+		}
+	}
+	return result, nil
+}
+
+// AssociateFIPWithInstance associates the floating IP with the given address with a server.
+func (c *computeClient) AssociateFIPWithInstance(ctx context.Context, serverID string, associateOpts computefip.AssociateOpts) error {
+	if err := c.cloud.before(ctx, "AssociateFIPWithInstance"); err != nil {
+		return err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	if _, ok := c.cloud.servers[serverID]; !ok {
+		return NotFoundError("Server", serverID)
+	}
+	var port *ports.Port
+	for _, p := range sortedValues(c.cloud.ports) {
+		if p.DeviceID == serverID && (associateOpts.FixedIP == "" || p.FixedIPs[0].IPAddress == associateOpts.FixedIP) {
+			port = p
+			break
+		}
+	}
+	if port == nil {
+		return badRequestError("BadRequest", fmt.Sprintf("Server %s has no port for fixed IP %q.", serverID, associateOpts.FixedIP))
+	}
+	for _, fip := range c.cloud.floatingIPs {
+		if fip.FloatingIP == associateOpts.FloatingIP {
+			fip.PortID = port.ID
+			fip.FixedIP = port.FixedIPs[0].IPAddress
+			fip.Status = "ACTIVE"
+			return nil
+		}
+	}
+	return NotFoundError("FloatingIP", associateOpts.FloatingIP)
+}
+
+// FindFloatingIDByInstanceID returns the ID of the floating IP associated with the given server or an empty string.
+func (c *computeClient) FindFloatingIDByInstanceID(ctx context.Context, id string) (string, error) {
+	if err := c.cloud.before(ctx, "FindFloatingIDByInstanceID"); err != nil {
+		return "", err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	for _, fip := range sortedValues(c.cloud.floatingIPs) {
+		if port, ok := c.cloud.ports[fip.PortID]; ok && port.DeviceID == id {
+			return fip.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// FindFlavorID returns the ID of the flavor with the given name.
+func (c *computeClient) FindFlavorID(ctx context.Context, name string) (string, error) {
+	if err := c.cloud.before(ctx, "FindFlavorID"); err != nil {
+		return "", err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	var ids []string
+	for _, id := range sortedKeys(c.cloud.flavors) {
+		if c.cloud.flavors[id] == name {
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", gophercloud.ErrResourceNotFound{Name: name, ResourceType: "flavor"}
+	case 1:
+		return ids[0], nil
+	default:
+		return "", gophercloud.ErrMultipleResourcesFound{Name: name, Count: len(ids), ResourceType: "flavor"}
+	}
+}
+
+// FindImages returns the images with the given name.
+func (c *computeClient) FindImages(ctx context.Context, name string) ([]images.Image, error) {
+	return c.ListImages(ctx, images.ListOpts{Name: name})
+}
+
+// FindImageByID returns the image with the given ID or nil if it does not exist.
+func (c *computeClient) FindImageByID(ctx context.Context, id string) (*images.Image, error) {
+	if err := c.cloud.before(ctx, "FindImageByID"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	image, ok := c.cloud.images[id]
+	if !ok {
+		return nil, nil
+	}
+	result := *image
+	return &result, nil
+}
+
+// ListImages returns the images matching the given options.
+func (c *computeClient) ListImages(ctx context.Context, listOpts images.ListOpts) ([]images.Image, error) {
+	if err := c.cloud.before(ctx, "ListImages"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	var result []images.Image
+	for _, image := range sortedValues(c.cloud.images) {
+		if matches(listOpts.Name, image.Name) && matches(listOpts.Status, image.Status) {
+			result = append(result, *image)
+		}
+	}
+	return result, nil
+}
+
+// CreateKeyPair creates an SSH key pair. It fails if a key pair with the same name exists.
+func (c *computeClient) CreateKeyPair(ctx context.Context, name, publicKey string) (*keypairs.KeyPair, error) {
+	if err := c.cloud.before(ctx, "CreateKeyPair"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	if _, ok := c.cloud.keyPairs[name]; ok {
+		return nil, ConflictError("Conflict", fmt.Sprintf("Key pair '%s' already exists.", name))
+	}
+	keyPair := &keypairs.KeyPair{
+		Name:      name,
+		PublicKey: publicKey,
+		Type:      "ssh",
+	}
+	c.cloud.keyPairs[name] = keyPair
+	result := *keyPair
+	return &result, nil
+}
+
+// GetKeyPair returns the SSH key pair with the given name or nil if it does not exist.
+func (c *computeClient) GetKeyPair(ctx context.Context, name string) (*keypairs.KeyPair, error) {
+	if err := c.cloud.before(ctx, "GetKeyPair"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	keyPair, ok := c.cloud.keyPairs[name]
+	if !ok {
+		return nil, nil
+	}
+	result := *keyPair
+	return &result, nil
+}
+
+// ListKeyPairs returns all SSH key pairs.
+func (c *computeClient) ListKeyPairs(ctx context.Context) ([]keypairs.KeyPair, error) {
+	if err := c.cloud.before(ctx, "ListKeyPairs"); err != nil {
+		return nil, err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	var result []keypairs.KeyPair
+	for _, keyPair := range sortedValues(c.cloud.keyPairs) {
+		result = append(result, *keyPair)
+	}
+	return result, nil
+}
+
+// DeleteKeyPair deletes the SSH key pair with the given name.
+func (c *computeClient) DeleteKeyPair(ctx context.Context, name string) error {
+	if err := c.cloud.before(ctx, "DeleteKeyPair"); err != nil {
+		return err
+	}
+	c.cloud.lock.Lock()
+	defer c.cloud.lock.Unlock()
+
+	if _, ok := c.cloud.keyPairs[name]; !ok {
+		return NotFoundError("KeyPair", name)
+	}
+	delete(c.cloud.keyPairs, name)
+	return nil
+}
+
+// copyServer returns a copy of the server with the addresses of its ports and associated floating IPs.
+func (c *Cloud) copyServer(server *servers.Server) *servers.Server {
+	result := *server
+	result.Addresses = map[string]interface{}{}
+	result.SecurityGroups = nil
+	for _, port := range sortedValues(c.ports) {
+		if port.DeviceID != server.ID {
+			continue
+		}
+		var networkName string
+		if network, ok := c.networks[port.NetworkID]; ok {
+			networkName = network.Name
+		}
+		addresses, _ := result.Addresses[networkName].([]interface{})
+		for _, ip := range port.FixedIPs {
+			addresses = append(addresses, address(ip.IPAddress, "fixed", port.MACAddress))
+		}
+		for _, fip := range sortedValues(c.floatingIPs) {
+			if fip.PortID == port.ID {
+				addresses = append(addresses, address(fip.FloatingIP, "floating", port.MACAddress))
+			}
+		}
+		result.Addresses[networkName] = addresses
+		for _, id := range port.SecurityGroups {
+			if group, ok := c.securityGroups[id]; ok {
+				result.SecurityGroups = append(result.SecurityGroups, map[string]interface{}{"name": group.Name})
+			}
+		}
+	}
+	return &result
+}
+
+func address(ip, addressType, macAddress string) map[string]interface{} {
+	version := 4
+	if addr, err := netip.ParseAddr(ip); err == nil && addr.Is6() {
+		version = 6
+	}
+	return map[string]interface{}{
+		"addr":                    ip,
+		"version":                 version,
+		"OS-EXT-IPS:type":         addressType,
+		"OS-EXT-IPS-MAC:mac_addr": macAddress,
+	}
+}
+
+func copyServerGroup(serverGroup *servergroups.ServerGroup) servergroups.ServerGroup {
+	result := *serverGroup
+	result.Policies = slices.Clone(serverGroup.Policies)
+	result.Members = slices.Clone(serverGroup.Members)
+	return result
+}
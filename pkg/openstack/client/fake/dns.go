@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+type zone struct {
+	name       string
+	recordSets map[recordSetKey]*recordSet
+}
+
+type recordSetKey struct {
+	name       string
+	recordType string
+}
+
+type recordSet struct {
+	id      string
+	records []string
+	ttl     int
+}
+
+type dnsClient struct {
+	cloud *Cloud
+}
+
+var _ client.DNS = &dnsClient{}
+
+// GetZones returns the names of all zones mapped to their IDs.
+func (d *dnsClient) GetZones(ctx context.Context) (map[string]string, error) {
+	if err := d.cloud.before(ctx, "GetZones"); err != nil {
+		return nil, err
+	}
+	d.cloud.lock.Lock()
+	defer d.cloud.lock.Unlock()
+
+	result := map[string]string{}
+	for id, zone := range d.cloud.zones {
+		result[strings.TrimSuffix(zone.name, ".")] = id
+	}
+	return result, nil
+}
+
+// CreateOrUpdateRecordSet creates or updates the record set with the given name and type in the given zone.
+func (d *dnsClient) CreateOrUpdateRecordSet(ctx context.Context, zoneID, name, recordType string, records []string, ttl int) error {
+	if err := d.cloud.before(ctx, "CreateOrUpdateRecordSet"); err != nil {
+		return err
+	}
+	d.cloud.lock.Lock()
+	defer d.cloud.lock.Unlock()
+
+	zone, ok := d.cloud.zones[zoneID]
+	if !ok {
+		return NotFoundError("Zone", zoneID)
+	}
+	if recordType == "CNAME" {
+		records = []string{ensureTrailingDot(records[0])}
+	}
+	key := recordSetKey{name: ensureTrailingDot(name), recordType: recordType}
+	if rs, ok := zone.recordSets[key]; ok {
+		rs.records, rs.ttl = slices.Clone(records), ttl
+		return nil
+	}
+	zone.recordSets[key] = &recordSet{
+		id:      d.cloud.newID(),
+		records: slices.Clone(records),
+		ttl:     ttl,
+	}
+	return nil
+}
+
+// DeleteRecordSet deletes the record set with the given name and type in the given zone if it exists.
+func (d *dnsClient) DeleteRecordSet(ctx context.Context, zoneID, name, recordType string) error {
+	if err := d.cloud.before(ctx, "DeleteRecordSet"); err != nil {
+		return err
+	}
+	d.cloud.lock.Lock()
+	defer d.cloud.lock.Unlock()
+
+	zone, ok := d.cloud.zones[zoneID]
+	if !ok {
+		return NotFoundError("Zone", zoneID)
+	}
+	delete(zone.recordSets, recordSetKey{name: ensureTrailingDot(name), recordType: recordType})
+	return nil
+}
+
+// RecordSet returns the records and TTL of the record set with the given name and type in the given zone. The last
+// return value is false if the record set does not exist.
+func (c *Cloud) RecordSet(zoneID, name, recordType string) ([]string, int, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	zone, ok := c.zones[zoneID]
+	if !ok {
+		return nil, 0, false
+	}
+	rs, ok := zone.recordSets[recordSetKey{name: ensureTrailingDot(name), recordType: recordType}]
+	if !ok {
+		return nil, 0, false
+	}
+	return slices.Clone(rs.records), rs.ttl, true
+}
+
+func ensureTrailingDot(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// NotFoundError returns the error of the OpenStack API for a resource which does not exist.
+func NotFoundError(resource, id string) error {
+	return gophercloud.ErrDefault404{ErrUnexpectedResponseCode: unexpectedResponseCode(http.StatusNotFound,
+		fmt.Sprintf("%sNotFound", resource), fmt.Sprintf("%s %s could not be found.", resource, id))}
+}
+
+// ConflictError returns the error of the OpenStack API for a request conflicting with the current state of a resource.
+// The errorType is reported as type of the Neutron error, e.g. "IpAddressGenerationFailure".
+func ConflictError(errorType, message string) error {
+	return gophercloud.ErrDefault409{ErrUnexpectedResponseCode: unexpectedResponseCode(http.StatusConflict, errorType, message)}
+}
+
+// QuotaExceededError returns the error of the OpenStack API if the quota of the given resource is exceeded.
+func QuotaExceededError(resource string) error {
+	return ConflictError("OverQuota", fmt.Sprintf("Quota exceeded for resources: ['%s'].", resource))
+}
+
+// RateLimitError returns the error of the OpenStack API if the rate limit has been exceeded.
+func RateLimitError() error {
+	return gophercloud.ErrDefault429{ErrUnexpectedResponseCode: unexpectedResponseCode(http.StatusTooManyRequests,
+		"OverLimit", "Too many requests.")}
+}
+
+// ServerError returns the error of the OpenStack API for an internal server error.
+func ServerError() error {
+	return gophercloud.ErrDefault500{ErrUnexpectedResponseCode: unexpectedResponseCode(http.StatusInternalServerError,
+		"HTTPInternalServerError", "Request Failed: internal server error while processing your request.")}
+}
+
+func unauthorizedError() error {
+	return gophercloud.ErrDefault401{ErrUnexpectedResponseCode: unexpectedResponseCode(http.StatusUnauthorized,
+		"Unauthorized", "The request you have made requires authentication.")}
+}
+
+func badRequestError(errorType, message string) error {
+	return gophercloud.ErrDefault400{ErrUnexpectedResponseCode: unexpectedResponseCode(http.StatusBadRequest, errorType, message)}
+}
+
+func unexpectedResponseCode(code int, errorType, message string) gophercloud.ErrUnexpectedResponseCode {
+	// the body mimics the errors of Neutron, which are decoded to decide about retries
+	body, _ := json.Marshal(map[string]any{
+		"NeutronError": map[string]string{
+			"type":    errorType,
+			"message": message,
+		},
+	})
+	return gophercloud.ErrUnexpectedResponseCode{
+		Expected: []int{http.StatusOK},
+		Actual:   code,
+		Body:     body,
+	}
+}
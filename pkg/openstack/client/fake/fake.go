@@ -0,0 +1,396 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fake contains a stateful in-memory implementation of the OpenStack client interfaces. In contrast to the
+// gomock mocks it keeps the resources created through the clients, so that tests can run complete reconciliation
+// and deletion logic against it and verify the resulting state of the cloud.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+// AnyOperation can be passed to Cloud.InjectFault to inject a fault into all operations.
+const AnyOperation = "*"
+
+// Fault describes a failure which is injected into operations of a Cloud.
+type Fault struct {
+	// Latency delays the operation. If the context of the operation is done earlier, the operation fails with the
+	// error of the context.
+	Latency time.Duration
+	// Err is returned by the operation instead of performing it.
+	Err error
+	// Times limits how often the fault is applied. Zero means that the fault is applied until the faults are cleared.
+	Times int
+}
+
+// Cloud is an in-memory OpenStack cloud. All clients created by the factories of a Cloud share its state.
+type Cloud struct {
+	lock sync.Mutex
+
+	lastID      int
+	calls       map[string]int
+	faults      map[string][]*Fault
+	allocations map[string]int
+
+	networks       map[string]*network
+	subnets        map[string]*subnets.Subnet
+	routers        map[string]*routers.Router
+	ports          map[string]*ports.Port
+	floatingIPs    map[string]*floatingips.FloatingIP
+	securityGroups map[string]*groups.SecGroup
+	rules          map[string]*rules.SecGroupRule
+	subnetPools    map[string]*subnetPool
+
+	servers      map[string]*servers.Server
+	serverGroups map[string]*servergroups.ServerGroup
+	keyPairs     map[string]*keypairs.KeyPair
+	flavors      map[string]string
+	images       map[string]*images.Image
+
+	loadBalancers map[string]*loadbalancers.LoadBalancer
+	shareNetworks map[string]*sharenetworks.ShareNetwork
+	zones         map[string]*zone
+	containers    map[string]map[string][]byte
+
+	applicationCredentials map[string]*applicationcredentials.ApplicationCredential
+}
+
+type network struct {
+	networks.Network
+	external bool
+}
+
+// subnetPool is a minimal Neutron subnet pool from which subnets without an explicit CIDR are allocated.
+type subnetPool struct {
+	id               string
+	name             string
+	prefix           netip.Prefix
+	defaultPrefixLen int
+}
+
+// NewCloud returns a new empty Cloud.
+func NewCloud() *Cloud {
+	return &Cloud{
+		calls:          map[string]int{},
+		faults:         map[string][]*Fault{},
+		allocations:    map[string]int{},
+		networks:       map[string]*network{},
+		subnets:        map[string]*subnets.Subnet{},
+		routers:        map[string]*routers.Router{},
+		ports:          map[string]*ports.Port{},
+		floatingIPs:    map[string]*floatingips.FloatingIP{},
+		securityGroups: map[string]*groups.SecGroup{},
+		rules:          map[string]*rules.SecGroupRule{},
+		subnetPools:    map[string]*subnetPool{},
+		servers:        map[string]*servers.Server{},
+		serverGroups:   map[string]*servergroups.ServerGroup{},
+		keyPairs:       map[string]*keypairs.KeyPair{},
+		flavors:        map[string]string{},
+		images:         map[string]*images.Image{},
+		loadBalancers:  map[string]*loadbalancers.LoadBalancer{},
+		shareNetworks:  map[string]*sharenetworks.ShareNetwork{},
+		zones:          map[string]*zone{},
+		containers:     map[string]map[string][]byte{},
+
+		applicationCredentials: map[string]*applicationcredentials.ApplicationCredential{},
+	}
+}
+
+// Factory implements client.Factory for a Cloud.
+type Factory struct {
+	cloud *Cloud
+}
+
+var _ client.Factory = &Factory{}
+
+// Factory returns a client.Factory whose clients operate on the cloud.
+func (c *Cloud) Factory() *Factory {
+	return &Factory{cloud: c}
+}
+
+// FactoryFactory returns a client.FactoryFactory which returns factories for the cloud regardless of the given
+// credentials.
+func (c *Cloud) FactoryFactory() client.FactoryFactory {
+	return client.FactoryFactoryFunc(func(_ *openstack.Credentials, _ ...client.FactoryOption) (client.Factory, error) {
+		if err := c.before(context.Background(), "NewFactory"); err != nil {
+			return nil, err
+		}
+		return c.Factory(), nil
+	})
+}
+
+// Compute returns a Compute client for the cloud.
+func (f *Factory) Compute(_ ...client.Option) (client.Compute, error) {
+	return &computeClient{cloud: f.cloud}, nil
+}
+
+// Storage returns a Storage client for the cloud.
+func (f *Factory) Storage(_ ...client.Option) (client.Storage, error) {
+	return &storageClient{cloud: f.cloud}, nil
+}
+
+// DNS returns a DNS client for the cloud.
+func (f *Factory) DNS(_ ...client.Option) (client.DNS, error) {
+	return &dnsClient{cloud: f.cloud}, nil
+}
+
+// Networking returns a Networking client for the cloud.
+func (f *Factory) Networking(_ ...client.Option) (client.Networking, error) {
+	return &networkingClient{cloud: f.cloud}, nil
+}
+
+// Loadbalancing returns a Loadbalancing client for the cloud.
+func (f *Factory) Loadbalancing(_ ...client.Option) (client.Loadbalancing, error) {
+	return &loadbalancingClient{cloud: f.cloud}, nil
+}
+
+// SharedFilesystem returns a SharedFilesystem client for the cloud.
+func (f *Factory) SharedFilesystem(_ ...client.Option) (client.SharedFilesystem, error) {
+	return &sharedFilesystemClient{cloud: f.cloud}, nil
+}
+
+// Identity returns an Identity client for the cloud.
+func (f *Factory) Identity(_ ...client.Option) (client.Identity, error) {
+	return &identityClient{cloud: f.cloud}, nil
+}
+
+// InjectFault injects a fault into the operation with the given name, e.g. "CreateRouter" or AnyOperation. The names
+// of the operations are the names of the methods of the client interfaces. Multiple faults for the same operation are
+// applied in the order they were injected.
+func (c *Cloud) InjectFault(operation string, fault Fault) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.faults[operation] = append(c.faults[operation], &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (c *Cloud) ClearFaults() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.faults = map[string][]*Fault{}
+}
+
+// Calls returns how often the operation with the given name has been called.
+func (c *Cloud) Calls(operation string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.calls[operation]
+}
+
+// before records a call of the given operation and applies the injected faults. It must be called without holding
+// the lock of the cloud.
+func (c *Cloud) before(ctx context.Context, operation string) error {
+	c.lock.Lock()
+	c.calls[operation]++
+	fault := c.nextFault(operation)
+	if fault == nil {
+		fault = c.nextFault(AnyOperation)
+	}
+	c.lock.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if fault == nil {
+		return nil
+	}
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return fault.Err
+}
+
+func (c *Cloud) nextFault(operation string) *Fault {
+	faults := c.faults[operation]
+	if len(faults) == 0 {
+		return nil
+	}
+	fault := faults[0]
+	if fault.Times > 0 {
+		fault.Times--
+		if fault.Times == 0 {
+			c.faults[operation] = faults[1:]
+		}
+	}
+	return fault
+}
+
+// newID returns a new unique identifier in the format of a UUID.
+func (c *Cloud) newID() string {
+	c.lastID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", c.lastID)
+}
+
+func (c *Cloud) newMACAddress() string {
+	c.lastID++
+	return fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", (c.lastID>>16)&0xff, (c.lastID>>8)&0xff, c.lastID&0xff)
+}
+
+// allocateIP returns the next free address of the given subnet. The first address is reserved for the gateway.
+func (c *Cloud) allocateIP(subnet *subnets.Subnet) (string, error) {
+	prefix, err := netip.ParsePrefix(subnet.CIDR)
+	if err != nil {
+		return "", err
+	}
+	c.allocations[subnet.ID]++
+	addr := prefix.Masked().Addr().Next()
+	for i := 0; i < c.allocations[subnet.ID]; i++ {
+		addr = addr.Next()
+	}
+	if !prefix.Contains(addr) {
+		return "", ConflictError("IpAddressGenerationFailure", fmt.Sprintf("No more IP addresses available on network %s.", subnet.NetworkID))
+	}
+	return addr.String(), nil
+}
+
+// allocateExternalIP allocates an address in one of the subnets of the given external network. If subnetID is not
+// empty, the address is allocated in this subnet.
+func (c *Cloud) allocateExternalIP(networkID, subnetID string) (*routers.ExternalFixedIP, error) {
+	for _, subnet := range sortedValues(c.subnets) {
+		if subnet.NetworkID != networkID || (subnetID != "" && subnet.ID != subnetID) {
+			continue
+		}
+		ip, err := c.allocateIP(subnet)
+		if err != nil {
+			continue
+		}
+		return &routers.ExternalFixedIP{IPAddress: ip, SubnetID: subnet.ID}, nil
+	}
+	return nil, badRequestError("ExternalIpAddressExhausted", fmt.Sprintf("Unable to find any IP address on external network %s.", networkID))
+}
+
+// AddExternalNetwork adds an external network with the given name and returns its ID.
+func (c *Cloud) AddExternalNetwork(name string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	id := c.newID()
+	c.networks[id] = &network{
+		Network: networks.Network{
+			ID:           id,
+			Name:         name,
+			AdminStateUp: true,
+			Status:       "ACTIVE",
+		},
+		external: true,
+	}
+	return id
+}
+
+// AddSubnet adds a subnet with the given name and CIDR to the network with the given ID, e.g. to provide the floating
+// pool subnets of an external network. It returns the ID of the subnet.
+func (c *Cloud) AddSubnet(networkID, name, cidr string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	subnet := c.addSubnet(subnets.CreateOpts{NetworkID: networkID, Name: name, CIDR: cidr, IPVersion: gophercloud.IPv4})
+	return subnet.ID
+}
+
+// AddSubnetPool adds a subnet pool with the given name and prefix, e.g. "2001:db8::/56", from which subnets with
+// the given default prefix length are allocated. It returns the ID of the subnet pool.
+func (c *Cloud) AddSubnetPool(name, prefix string, defaultPrefixLen int) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	id := c.newID()
+	c.subnetPools[id] = &subnetPool{
+		id:               id,
+		name:             name,
+		prefix:           netip.MustParsePrefix(prefix).Masked(),
+		defaultPrefixLen: defaultPrefixLen,
+	}
+	return id
+}
+
+// AddFlavor adds a flavor with the given name and returns its ID.
+func (c *Cloud) AddFlavor(name string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	id := c.newID()
+	c.flavors[id] = name
+	return id
+}
+
+// AddImage adds an image with the given name and returns its ID.
+func (c *Cloud) AddImage(name string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	id := c.newID()
+	c.images[id] = &images.Image{ID: id, Name: name, Status: "ACTIVE"}
+	return id
+}
+
+// SetFloatingIPStatus sets the status of the floating IP with the given ID, e.g. to simulate a backend which reports
+// floating IPs without associated port as "ACTIVE" instead of "DOWN".
+func (c *Cloud) SetFloatingIPStatus(id, status string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if fip, ok := c.floatingIPs[id]; ok {
+		fip.Status = status
+	}
+}
+
+// AddLoadBalancer adds the given load balancer, e.g. to simulate a load balancer created by the cloud controller
+// manager. An empty ID and provisioning status are defaulted. It returns the ID of the load balancer.
+func (c *Cloud) AddLoadBalancer(lb loadbalancers.LoadBalancer) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if lb.ID == "" {
+		lb.ID = c.newID()
+	}
+	if lb.ProvisioningStatus == "" {
+		lb.ProvisioningStatus = "ACTIVE"
+	}
+	c.loadBalancers[lb.ID] = &lb
+	return lb.ID
+}
+
+// AddApplicationCredential adds the given application credential, e.g. the credential a cloud provider secret was
+// created with. An empty ID is defaulted. It returns the ID of the application credential.
+func (c *Cloud) AddApplicationCredential(credential applicationcredentials.ApplicationCredential) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if credential.ID == "" {
+		credential.ID = c.newID()
+	}
+	c.applicationCredentials[credential.ID] = &credential
+	return credential.ID
+}
+
+// AddZone adds a DNS zone with the given name and returns its ID.
+func (c *Cloud) AddZone(name string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	id := c.newID()
+	c.zones[id] = &zone{name: ensureTrailingDot(name), recordSets: map[recordSetKey]*recordSet{}}
+	return id
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	computefip "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+var _ = Describe("Cloud", func() {
+	var (
+		ctx        context.Context
+		cloud      *fake.Cloud
+		networking openstackclient.Networking
+		compute    openstackclient.Compute
+
+		externalNetworkID string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cloud = fake.NewCloud()
+		externalNetworkID = cloud.AddExternalNetwork("public")
+		cloud.AddSubnet(externalNetworkID, "public-subnet", "172.24.4.0/24")
+
+		var err error
+		networking, err = cloud.Factory().Networking()
+		Expect(err).NotTo(HaveOccurred())
+		compute, err = cloud.Factory().Compute()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	createNetworkWithSubnet := func(name, cidr string) (*networks.Network, *subnets.Subnet) {
+		network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: name})
+		Expect(err).NotTo(HaveOccurred())
+		subnet, err := networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, Name: name, CIDR: cidr, IPVersion: 4})
+		Expect(err).NotTo(HaveOccurred())
+		return network, subnet
+	}
+
+	Describe("Networking", func() {
+		It("should allocate an external address for the router gateway", func() {
+			router, err := networking.CreateRouter(ctx, routers.CreateOpts{
+				Name:        "router",
+				GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetworkID},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(router.GatewayInfo.ExternalFixedIPs).To(HaveLen(1))
+			Expect(router.GatewayInfo.ExternalFixedIPs[0].IPAddress).To(Equal("172.24.4.2"))
+
+			list, err := networking.ListRouters(ctx, routers.ListOpts{Name: "router"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(*router))
+		})
+
+		It("should refuse to delete a router with interfaces", func() {
+			_, subnet := createNetworkWithSubnet("shoot", "10.250.0.0/16")
+			router, err := networking.CreateRouter(ctx, routers.CreateOpts{Name: "router"})
+			Expect(err).NotTo(HaveOccurred())
+			info, err := networking.AddRouterInterface(ctx, router.ID, routers.AddInterfaceOpts{SubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+
+			port, err := networking.GetRouterInterfacePort(ctx, router.ID, subnet.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port.ID).To(Equal(info.PortID))
+			Expect(port.FixedIPs[0].IPAddress).To(Equal(subnet.GatewayIP))
+
+			err = networking.DeleteRouter(ctx, router.ID)
+			Expect(err).To(BeAssignableToTypeOf(gophercloud.ErrDefault409{}))
+
+			_, err = networking.RemoveRouterInterface(ctx, router.ID, routers.RemoveInterfaceOpts{SubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networking.DeleteRouter(ctx, router.ID)).To(Succeed())
+		})
+
+		It("should delete the subnets of a deleted network", func() {
+			network, _ := createNetworkWithSubnet("shoot", "10.250.0.0/16")
+
+			Expect(networking.DeleteNetwork(ctx, network.ID)).To(Succeed())
+
+			list, err := networking.ListSubnets(ctx, subnets.ListOpts{NetworkID: network.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		})
+
+		It("should allocate subnets from subnet pools", func() {
+			poolID := cloud.AddSubnetPool("ipv6", "2001:db8::/56", 64)
+			network, _ := createNetworkWithSubnet("shoot", "10.250.0.0/16")
+
+			first, err := networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, SubnetPoolID: poolID, IPVersion: 6})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.CIDR).To(Equal("2001:db8::/64"))
+			Expect(first.SubnetPoolID).To(Equal(poolID))
+
+			second, err := networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, SubnetPoolID: poolID, IPVersion: 6})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.CIDR).To(Equal("2001:db8:0:1::/64"))
+		})
+
+		It("should create security groups with the default egress rules", func() {
+			group, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(group.Rules).To(HaveLen(2))
+
+			_, err = networking.CreateRule(ctx, rules.CreateOpts{
+				SecGroupID: group.ID,
+				Direction:  rules.DirEgress,
+				EtherType:  rules.EtherType4,
+			})
+			Expect(openstackclient.IsNotFoundError(err)).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring("already exists")))
+
+			Expect(networking.DeleteSecurityGroup(ctx, group.ID)).To(Succeed())
+			list, err := networking.ListRules(ctx, rules.ListOpts{SecGroupID: group.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		})
+
+		It("should delete the rules referring to a deleted security group as remote group", func() {
+			group, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			remote, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: "bastion"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.CreateRule(ctx, rules.CreateOpts{
+				SecGroupID:    group.ID,
+				Direction:     rules.DirIngress,
+				EtherType:     rules.EtherType4,
+				RemoteGroupID: remote.ID,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(networking.DeleteSecurityGroup(ctx, remote.ID)).To(Succeed())
+			list, err := networking.ListRules(ctx, rules.ListOpts{SecGroupID: group.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(HaveLen(2))
+		})
+
+		It("should return not found errors", func() {
+			_, err := networking.GetSecurityGroup(ctx, "foo")
+			Expect(openstackclient.IsNotFoundError(err)).To(BeTrue())
+
+			router, err := networking.GetRouterByID(ctx, "foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(router).To(BeNil())
+		})
+	})
+
+	Describe("Compute", func() {
+		It("should associate floating IPs with servers", func() {
+			network, _ := createNetworkWithSubnet("shoot", "10.250.0.0/16")
+			flavorID := cloud.AddFlavor("small")
+			imageID := cloud.AddImage("gardenlinux")
+			group, err := networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: "bastion"})
+			Expect(err).NotTo(HaveOccurred())
+
+			foundFlavorID, err := compute.FindFlavorID(ctx, "small")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(foundFlavorID).To(Equal(flavorID))
+
+			server, err := compute.CreateServer(ctx, servers.CreateOpts{
+				Name:           "bastion",
+				FlavorRef:      flavorID,
+				ImageRef:       imageID,
+				SecurityGroups: []string{"bastion"},
+				Networks:       []servers.Network{{UUID: network.ID}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Addresses).To(HaveKeyWithValue("shoot", ConsistOf(HaveKeyWithValue("addr", "10.250.0.2"))))
+
+			fip, err := networking.CreateFloatingIP(ctx, floatingips.CreateOpts{FloatingNetworkID: externalNetworkID, Description: "bastion"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fip.Status).To(Equal("DOWN"))
+			Expect(compute.AssociateFIPWithInstance(ctx, server.ID, computefip.AssociateOpts{FloatingIP: fip.FloatingIP})).To(Succeed())
+
+			fipID, err := compute.FindFloatingIDByInstanceID(ctx, server.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fipID).To(Equal(fip.ID))
+			list, err := compute.FindServersByName(ctx, "bastion")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(HaveLen(1))
+			Expect(list[0].Addresses["shoot"]).To(ContainElement(HaveKeyWithValue("OS-EXT-IPS:type", "floating")))
+
+			Expect(list[0].SecurityGroups).To(ConsistOf(HaveKeyWithValue("name", "bastion")))
+			Expect(networking.DeleteSecurityGroup(ctx, group.ID)).To(BeAssignableToTypeOf(gophercloud.ErrDefault409{}))
+			Expect(compute.DeleteServer(ctx, server.ID)).To(Succeed())
+			fips, err := networking.GetFipByName(ctx, "bastion")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fips).To(ConsistOf(HaveField("Status", "DOWN")))
+		})
+
+		It("should refuse to create key pairs twice", func() {
+			_, err := compute.CreateKeyPair(ctx, "shoot", "ssh-rsa AAAA")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = compute.CreateKeyPair(ctx, "shoot", "ssh-rsa AAAA")
+			Expect(err).To(HaveOccurred())
+
+			Expect(compute.DeleteKeyPair(ctx, "shoot")).To(Succeed())
+			keyPair, err := compute.GetKeyPair(ctx, "shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair).To(BeNil())
+		})
+	})
+
+	Describe("#InjectFault", func() {
+		It("should fail the operation the given number of times", func() {
+			cloud.InjectFault("CreateNetwork", fake.Fault{Err: fake.QuotaExceededError("network"), Times: 2})
+
+			for i := 0; i < 2; i++ {
+				_, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+				Expect(err).To(MatchError(ContainSubstring("Quota exceeded")))
+			}
+			_, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloud.Calls("CreateNetwork")).To(Equal(3))
+
+			list, err := networking.GetNetworkByName(ctx, "shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(HaveLen(1))
+		})
+
+		It("should fail all operations until the faults are cleared", func() {
+			cloud.InjectFault(fake.AnyOperation, fake.Fault{Err: fake.ServerError()})
+
+			_, err := networking.ListNetwork(ctx, networks.ListOpts{})
+			Expect(err).To(HaveOccurred())
+			_, err = compute.ListServerGroups(ctx)
+			Expect(err).To(HaveOccurred())
+
+			cloud.ClearFaults()
+			_, err = compute.ListServerGroups(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should delay operations until the context is done", func() {
+			cloud.InjectFault("ListRouters", fake.Fault{Latency: time.Hour})
+			ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+
+			_, err := networking.ListRouters(ctx, routers.ListOpts{})
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+})
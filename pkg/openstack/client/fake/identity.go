@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+type identityClient struct {
+	cloud *Cloud
+}
+
+var _ client.Identity = &identityClient{}
+
+// GetApplicationCredential returns the application credential with the given ID. Like Keystone, it does not return
+// the secret of the credential.
+func (i *identityClient) GetApplicationCredential(ctx context.Context, id string) (*applicationcredentials.ApplicationCredential, error) {
+	if err := i.cloud.before(ctx, "GetApplicationCredential"); err != nil {
+		return nil, err
+	}
+	i.cloud.lock.Lock()
+	defer i.cloud.lock.Unlock()
+
+	credential, ok := i.cloud.applicationCredentials[id]
+	if !ok {
+		return nil, NotFoundError("ApplicationCredential", id)
+	}
+	result := copyApplicationCredential(credential)
+	result.Secret = ""
+	return &result, nil
+}
+
+// CreateApplicationCredential creates an application credential. A secret is generated unless given by the options.
+func (i *identityClient) CreateApplicationCredential(ctx context.Context, opts applicationcredentials.CreateOpts) (*applicationcredentials.ApplicationCredential, error) {
+	if err := i.cloud.before(ctx, "CreateApplicationCredential"); err != nil {
+		return nil, err
+	}
+	i.cloud.lock.Lock()
+	defer i.cloud.lock.Unlock()
+
+	for _, credential := range i.cloud.applicationCredentials {
+		if credential.Name == opts.Name {
+			return nil, ConflictError("Conflict", fmt.Sprintf("Duplicate entry found with name %s.", opts.Name))
+		}
+	}
+	credential := &applicationcredentials.ApplicationCredential{
+		ID:           i.cloud.newID(),
+		Name:         opts.Name,
+		Description:  opts.Description,
+		Unrestricted: opts.Unrestricted,
+		Secret:       opts.Secret,
+		ProjectID:    serverProjectID,
+		Roles:        slices.Clone(opts.Roles),
+	}
+	if credential.Secret == "" {
+		credential.Secret = "secret-" + credential.ID
+	}
+	if opts.ExpiresAt != nil {
+		credential.ExpiresAt = opts.ExpiresAt.UTC()
+	}
+	i.cloud.applicationCredentials[credential.ID] = credential
+	result := copyApplicationCredential(credential)
+	return &result, nil
+}
+
+// DeleteApplicationCredential deletes the application credential with the given ID.
+func (i *identityClient) DeleteApplicationCredential(ctx context.Context, id string) error {
+	if err := i.cloud.before(ctx, "DeleteApplicationCredential"); err != nil {
+		return err
+	}
+	i.cloud.lock.Lock()
+	defer i.cloud.lock.Unlock()
+
+	if _, ok := i.cloud.applicationCredentials[id]; !ok {
+		return NotFoundError("ApplicationCredential", id)
+	}
+	delete(i.cloud.applicationCredentials, id)
+	return nil
+}
+
+// validApplicationCredential returns true if the application credential with the given ID exists, has the given secret
+// and has not expired. The cloud must be locked.
+func (c *Cloud) validApplicationCredential(id, secret string) bool {
+	credential, ok := c.applicationCredentials[id]
+	if !ok || credential.Secret != secret {
+		return false
+	}
+	return credential.ExpiresAt.IsZero() || time.Now().Before(credential.ExpiresAt)
+}
+
+func copyApplicationCredential(credential *applicationcredentials.ApplicationCredential) applicationcredentials.ApplicationCredential {
+	result := *credential
+	result.Roles = slices.Clone(credential.Roles)
+	return result
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+type loadbalancingClient struct {
+	cloud *Cloud
+}
+
+var _ client.Loadbalancing = &loadbalancingClient{}
+
+// ListLoadbalancers returns the load balancers matching the given options.
+func (l *loadbalancingClient) ListLoadbalancers(ctx context.Context, opts loadbalancers.ListOpts) ([]loadbalancers.LoadBalancer, error) {
+	if err := l.cloud.before(ctx, "ListLoadbalancers"); err != nil {
+		return nil, err
+	}
+	l.cloud.lock.Lock()
+	defer l.cloud.lock.Unlock()
+
+	var result []loadbalancers.LoadBalancer
+	for _, lb := range sortedValues(l.cloud.loadBalancers) {
+		if matches(opts.ID, lb.ID) && matches(opts.Name, lb.Name) && matches(opts.VipSubnetID, lb.VipSubnetID) &&
+			matches(opts.VipNetworkID, lb.VipNetworkID) && matches(opts.ProvisioningStatus, lb.ProvisioningStatus) {
+			result = append(result, *lb)
+		}
+	}
+	return result, nil
+}
+
+// DeleteLoadbalancer deletes the load balancer with the given ID. Like the real client, it ignores missing load
+// balancers.
+func (l *loadbalancingClient) DeleteLoadbalancer(ctx context.Context, id string, _ loadbalancers.DeleteOpts) error {
+	if err := l.cloud.before(ctx, "DeleteLoadbalancer"); err != nil {
+		return err
+	}
+	l.cloud.lock.Lock()
+	defer l.cloud.lock.Unlock()
+
+	if lb, ok := l.cloud.loadBalancers[id]; ok && lb.ProvisioningStatus != "ACTIVE" && lb.ProvisioningStatus != "ERROR" {
+		return ConflictError("Conflict", fmt.Sprintf("Invalid state %s of loadbalancer resource %s.", lb.ProvisioningStatus, id))
+	}
+	delete(l.cloud.loadBalancers, id)
+	return nil
+}
+
+// GetLoadbalancer returns the load balancer with the given ID or nil if it does not exist.
+func (l *loadbalancingClient) GetLoadbalancer(ctx context.Context, id string) (*loadbalancers.LoadBalancer, error) {
+	if err := l.cloud.before(ctx, "GetLoadbalancer"); err != nil {
+		return nil, err
+	}
+	l.cloud.lock.Lock()
+	defer l.cloud.lock.Unlock()
+
+	lb, ok := l.cloud.loadBalancers[id]
+	if !ok {
+		return nil, nil
+	}
+	result := *lb
+	return &result, nil
+}
@@ -0,0 +1,1061 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+const (
+	deviceOwnerRouterInterface = "network:router_interface"
+	deviceOwnerCompute         = "compute:nova"
+
+	// maxTagLength is the maximum length of Neutron tags.
+	maxTagLength = 60
+)
+
+type networkingClient struct {
+	cloud *Cloud
+}
+
+var _ client.Networking = &networkingClient{}
+
+// GetExternalNetworkNames returns the names of all external networks.
+func (n *networkingClient) GetExternalNetworkNames(ctx context.Context) ([]string, error) {
+	if err := n.cloud.before(ctx, "GetExternalNetworkNames"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var names []string
+	for _, network := range sortedValues(n.cloud.networks) {
+		if network.external {
+			names = append(names, network.Name)
+		}
+	}
+	return names, nil
+}
+
+// GetExternalNetworkByName returns the external network with the given name or nil if it does not exist.
+func (n *networkingClient) GetExternalNetworkByName(ctx context.Context, name string) (*networks.Network, error) {
+	if err := n.cloud.before(ctx, "GetExternalNetworkByName"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []networks.Network
+	for _, network := range sortedValues(n.cloud.networks) {
+		if network.external && network.Name == name {
+			result = append(result, copyNetwork(network))
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	if len(result) > 1 {
+		return nil, fmt.Errorf("duplicate external network name: %s (%d)", name, len(result))
+	}
+	return &result[0], nil
+}
+
+// CreateNetwork creates a network.
+func (n *networkingClient) CreateNetwork(ctx context.Context, opts networks.CreateOpts) (*networks.Network, error) {
+	if err := n.cloud.before(ctx, "CreateNetwork"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	network := &network{Network: networks.Network{
+		ID:           n.cloud.newID(),
+		Name:         opts.Name,
+		Description:  opts.Description,
+		AdminStateUp: ptr.Deref(opts.AdminStateUp, true),
+		Status:       "ACTIVE",
+		TenantID:     opts.TenantID,
+		ProjectID:    opts.ProjectID,
+	}}
+	n.cloud.networks[network.ID] = network
+	result := copyNetwork(network)
+	return &result, nil
+}
+
+// ListNetwork returns the networks matching the given options.
+func (n *networkingClient) ListNetwork(ctx context.Context, listOpts networks.ListOpts) ([]networks.Network, error) {
+	if err := n.cloud.before(ctx, "ListNetwork"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []networks.Network
+	for _, network := range sortedValues(n.cloud.networks) {
+		if matches(listOpts.ID, network.ID) && matches(listOpts.Name, network.Name) && matches(listOpts.Status, network.Status) &&
+			(listOpts.AdminStateUp == nil || *listOpts.AdminStateUp == network.AdminStateUp) && matchesTags(listOpts.Tags, network.Tags) {
+			result = append(result, copyNetwork(network))
+		}
+	}
+	return result, nil
+}
+
+// UpdateNetwork updates a network.
+func (n *networkingClient) UpdateNetwork(ctx context.Context, networkID string, opts networks.UpdateOpts) (*networks.Network, error) {
+	if err := n.cloud.before(ctx, "UpdateNetwork"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	network, ok := n.cloud.networks[networkID]
+	if !ok {
+		return nil, NotFoundError("Network", networkID)
+	}
+	if opts.Name != nil {
+		network.Name = *opts.Name
+	}
+	if opts.Description != nil {
+		network.Description = *opts.Description
+	}
+	if opts.AdminStateUp != nil {
+		network.AdminStateUp = *opts.AdminStateUp
+	}
+	result := copyNetwork(network)
+	return &result, nil
+}
+
+// GetNetworkByName returns the networks with the given name.
+func (n *networkingClient) GetNetworkByName(ctx context.Context, name string) ([]networks.Network, error) {
+	return n.ListNetwork(ctx, networks.ListOpts{Name: name})
+}
+
+// DeleteNetwork deletes a network together with its subnets. It fails if ports are still in use.
+func (n *networkingClient) DeleteNetwork(ctx context.Context, networkID string) error {
+	if err := n.cloud.before(ctx, "DeleteNetwork"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	if _, ok := n.cloud.networks[networkID]; !ok {
+		return NotFoundError("Network", networkID)
+	}
+	for _, port := range n.cloud.ports {
+		if port.NetworkID == networkID {
+			return ConflictError("NetworkInUse", fmt.Sprintf("Unable to complete operation on network %s. There are one or more ports still in use on the network.", networkID))
+		}
+	}
+	for _, subnet := range n.cloud.subnets {
+		if subnet.NetworkID == networkID {
+			if err := n.cloud.checkSubnetUnused(subnet.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for id, subnet := range n.cloud.subnets {
+		if subnet.NetworkID == networkID {
+			delete(n.cloud.subnets, id)
+		}
+	}
+	delete(n.cloud.networks, networkID)
+	return nil
+}
+
+// CreateFloatingIP creates a floating IP in the given floating network.
+func (n *networkingClient) CreateFloatingIP(ctx context.Context, createOpts floatingips.CreateOpts) (*floatingips.FloatingIP, error) {
+	if err := n.cloud.before(ctx, "CreateFloatingIP"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	network, ok := n.cloud.networks[createOpts.FloatingNetworkID]
+	if !ok || !network.external {
+		return nil, NotFoundError("ExternalNetwork", createOpts.FloatingNetworkID)
+	}
+	fixedIP, err := n.cloud.allocateExternalIP(network.ID, createOpts.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+	fip := &floatingips.FloatingIP{
+		ID:                n.cloud.newID(),
+		Description:       createOpts.Description,
+		FloatingNetworkID: network.ID,
+		FloatingIP:        fixedIP.IPAddress,
+		TenantID:          createOpts.TenantID,
+		ProjectID:         createOpts.ProjectID,
+		Status:            "DOWN",
+	}
+	n.cloud.floatingIPs[fip.ID] = fip
+	result := *fip
+	return &result, nil
+}
+
+// DeleteFloatingIP deletes a floating IP.
+func (n *networkingClient) DeleteFloatingIP(ctx context.Context, id string) error {
+	if err := n.cloud.before(ctx, "DeleteFloatingIP"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	if _, ok := n.cloud.floatingIPs[id]; !ok {
+		return NotFoundError("FloatingIP", id)
+	}
+	delete(n.cloud.floatingIPs, id)
+	return nil
+}
+
+// ListFip returns the floating IPs matching the given options.
+func (n *networkingClient) ListFip(ctx context.Context, listOpts floatingips.ListOpts) ([]floatingips.FloatingIP, error) {
+	if err := n.cloud.before(ctx, "ListFip"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []floatingips.FloatingIP
+	for _, fip := range sortedValues(n.cloud.floatingIPs) {
+		if matches(listOpts.ID, fip.ID) && matches(listOpts.Description, fip.Description) &&
+			matches(listOpts.FloatingNetworkID, fip.FloatingNetworkID) && matches(listOpts.PortID, fip.PortID) &&
+			matches(listOpts.FixedIP, fip.FixedIP) && matches(listOpts.FloatingIP, fip.FloatingIP) &&
+			matches(listOpts.Status, fip.Status) && matchesTags(listOpts.Tags, fip.Tags) {
+			result = append(result, *fip)
+		}
+	}
+	return result, nil
+}
+
+// GetFipByName returns the floating IPs with the given description.
+func (n *networkingClient) GetFipByName(ctx context.Context, name string) ([]floatingips.FloatingIP, error) {
+	return n.ListFip(ctx, floatingips.ListOpts{Description: name})
+}
+
+// CreateSecurityGroup creates a security group with the default egress rules.
+func (n *networkingClient) CreateSecurityGroup(ctx context.Context, createOpts groups.CreateOpts) (*groups.SecGroup, error) {
+	if err := n.cloud.before(ctx, "CreateSecurityGroup"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	group := &groups.SecGroup{
+		ID:          n.cloud.newID(),
+		Name:        createOpts.Name,
+		Description: createOpts.Description,
+		TenantID:    createOpts.TenantID,
+		ProjectID:   createOpts.ProjectID,
+	}
+	n.cloud.securityGroups[group.ID] = group
+	for _, etherType := range []rules.RuleEtherType{rules.EtherType4, rules.EtherType6} {
+		rule := &rules.SecGroupRule{
+			ID:         n.cloud.newID(),
+			Direction:  string(rules.DirEgress),
+			EtherType:  string(etherType),
+			SecGroupID: group.ID,
+			TenantID:   group.TenantID,
+			ProjectID:  group.ProjectID,
+		}
+		n.cloud.rules[rule.ID] = rule
+	}
+	return n.cloud.copySecurityGroup(group), nil
+}
+
+// DeleteSecurityGroup deletes a security group and its rules. It fails if the group is still used by a server.
+func (n *networkingClient) DeleteSecurityGroup(ctx context.Context, groupID string) error {
+	if err := n.cloud.before(ctx, "DeleteSecurityGroup"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	group, ok := n.cloud.securityGroups[groupID]
+	if !ok {
+		return NotFoundError("SecurityGroup", groupID)
+	}
+	for _, port := range n.cloud.ports {
+		if slices.Contains(port.SecurityGroups, group.ID) {
+			return ConflictError("SecurityGroupInUse", fmt.Sprintf("Security Group %s in use.", groupID))
+		}
+	}
+	// like Neutron, the rules of other groups referring to the group as remote group are deleted as well
+	for id, rule := range n.cloud.rules {
+		if rule.SecGroupID == groupID || rule.RemoteGroupID == groupID {
+			delete(n.cloud.rules, id)
+		}
+	}
+	delete(n.cloud.securityGroups, groupID)
+	return nil
+}
+
+// ListSecurityGroup returns the security groups matching the given options.
+func (n *networkingClient) ListSecurityGroup(ctx context.Context, listOpts groups.ListOpts) ([]groups.SecGroup, error) {
+	if err := n.cloud.before(ctx, "ListSecurityGroup"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []groups.SecGroup
+	for _, group := range sortedValues(n.cloud.securityGroups) {
+		if matches(listOpts.ID, group.ID) && matches(listOpts.Name, group.Name) && matches(listOpts.Description, group.Description) &&
+			matches(listOpts.ProjectID, group.ProjectID) && matchesTags(listOpts.Tags, group.Tags) {
+			result = append(result, *n.cloud.copySecurityGroup(group))
+		}
+	}
+	return result, nil
+}
+
+// GetSecurityGroup returns the security group with the given ID.
+func (n *networkingClient) GetSecurityGroup(ctx context.Context, groupID string) (*groups.SecGroup, error) {
+	if err := n.cloud.before(ctx, "GetSecurityGroup"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	group, ok := n.cloud.securityGroups[groupID]
+	if !ok {
+		return nil, NotFoundError("SecurityGroup", groupID)
+	}
+	return n.cloud.copySecurityGroup(group), nil
+}
+
+// GetSecurityGroupByName returns the security groups with the given name.
+func (n *networkingClient) GetSecurityGroupByName(ctx context.Context, name string) ([]groups.SecGroup, error) {
+	return n.ListSecurityGroup(ctx, groups.ListOpts{Name: name})
+}
+
+// CreateRule creates a security group rule. It fails if an equal rule already exists.
+func (n *networkingClient) CreateRule(ctx context.Context, createOpts rules.CreateOpts) (*rules.SecGroupRule, error) {
+	if err := n.cloud.before(ctx, "CreateRule"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	group, ok := n.cloud.securityGroups[createOpts.SecGroupID]
+	if !ok {
+		return nil, NotFoundError("SecurityGroup", createOpts.SecGroupID)
+	}
+	if createOpts.RemoteGroupID != "" {
+		if _, ok := n.cloud.securityGroups[createOpts.RemoteGroupID]; !ok {
+			return nil, NotFoundError("SecurityGroup", createOpts.RemoteGroupID)
+		}
+	}
+	rule := &rules.SecGroupRule{
+		Direction:      string(createOpts.Direction),
+		Description:    createOpts.Description,
+		EtherType:      string(createOpts.EtherType),
+		SecGroupID:     group.ID,
+		PortRangeMin:   createOpts.PortRangeMin,
+		PortRangeMax:   createOpts.PortRangeMax,
+		Protocol:       string(createOpts.Protocol),
+		RemoteGroupID:  createOpts.RemoteGroupID,
+		RemoteIPPrefix: createOpts.RemoteIPPrefix,
+		TenantID:       group.TenantID,
+		ProjectID:      group.ProjectID,
+	}
+	if createOpts.ProjectID != "" {
+		rule.ProjectID = createOpts.ProjectID
+	}
+	for _, existing := range n.cloud.rules {
+		if existing.SecGroupID == rule.SecGroupID && existing.Direction == rule.Direction && existing.EtherType == rule.EtherType &&
+			existing.Protocol == rule.Protocol && existing.PortRangeMin == rule.PortRangeMin && existing.PortRangeMax == rule.PortRangeMax &&
+			existing.RemoteGroupID == rule.RemoteGroupID && existing.RemoteIPPrefix == rule.RemoteIPPrefix {
+			return nil, ConflictError("SecurityGroupRuleExists", fmt.Sprintf("Security group rule already exists. Rule id is %s.", existing.ID))
+		}
+	}
+	rule.ID = n.cloud.newID()
+	n.cloud.rules[rule.ID] = rule
+	result := *rule
+	return &result, nil
+}
+
+// ListRules returns the security group rules matching the given options.
+func (n *networkingClient) ListRules(ctx context.Context, listOpts rules.ListOpts) ([]rules.SecGroupRule, error) {
+	if err := n.cloud.before(ctx, "ListRules"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []rules.SecGroupRule
+	for _, rule := range sortedValues(n.cloud.rules) {
+		if matches(listOpts.ID, rule.ID) && matches(listOpts.SecGroupID, rule.SecGroupID) && matches(listOpts.Direction, rule.Direction) &&
+			matches(listOpts.EtherType, rule.EtherType) && matches(listOpts.Protocol, rule.Protocol) &&
+			matches(listOpts.RemoteGroupID, rule.RemoteGroupID) && matches(listOpts.RemoteIPPrefix, rule.RemoteIPPrefix) &&
+			matches(listOpts.Description, rule.Description) {
+			result = append(result, *rule)
+		}
+	}
+	return result, nil
+}
+
+// DeleteRule deletes a security group rule.
+func (n *networkingClient) DeleteRule(ctx context.Context, ruleID string) error {
+	if err := n.cloud.before(ctx, "DeleteRule"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	if _, ok := n.cloud.rules[ruleID]; !ok {
+		return NotFoundError("SecurityGroupRule", ruleID)
+	}
+	delete(n.cloud.rules, ruleID)
+	return nil
+}
+
+// GetRouterByID returns the router with the given ID or nil if it does not exist.
+func (n *networkingClient) GetRouterByID(ctx context.Context, id string) (*routers.Router, error) {
+	if err := n.cloud.before(ctx, "GetRouterByID"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	router, ok := n.cloud.routers[id]
+	if !ok {
+		return nil, nil
+	}
+	result := copyRouter(router)
+	return &result, nil
+}
+
+// ListRouters returns the routers matching the given options.
+func (n *networkingClient) ListRouters(ctx context.Context, listOpts routers.ListOpts) ([]routers.Router, error) {
+	if err := n.cloud.before(ctx, "ListRouters"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []routers.Router
+	for _, router := range sortedValues(n.cloud.routers) {
+		if matches(listOpts.ID, router.ID) && matches(listOpts.Name, router.Name) && matches(listOpts.Status, router.Status) &&
+			matches(listOpts.ProjectID, router.ProjectID) && matchesTags(listOpts.Tags, router.Tags) {
+			result = append(result, copyRouter(router))
+		}
+	}
+	return result, nil
+}
+
+// UpdateRoutesForRouter replaces the routes of a router.
+func (n *networkingClient) UpdateRoutesForRouter(ctx context.Context, routes []routers.Route, routerID string) (*routers.Router, error) {
+	return n.updateRouter(ctx, "UpdateRoutesForRouter", routerID, routers.UpdateOpts{Routes: &routes})
+}
+
+// UpdateRouter updates a router.
+func (n *networkingClient) UpdateRouter(ctx context.Context, routerID string, updateOpts routers.UpdateOpts) (*routers.Router, error) {
+	return n.updateRouter(ctx, "UpdateRouter", routerID, updateOpts)
+}
+
+func (n *networkingClient) updateRouter(ctx context.Context, operation, routerID string, updateOpts routers.UpdateOpts) (*routers.Router, error) {
+	if err := n.cloud.before(ctx, operation); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	router, ok := n.cloud.routers[routerID]
+	if !ok {
+		return nil, NotFoundError("Router", routerID)
+	}
+	if updateOpts.GatewayInfo != nil {
+		gatewayInfo, err := n.cloud.gatewayInfo(updateOpts.GatewayInfo, &router.GatewayInfo)
+		if err != nil {
+			return nil, err
+		}
+		router.GatewayInfo = *gatewayInfo
+	}
+	if updateOpts.Routes != nil {
+		for _, route := range *updateOpts.Routes {
+			if _, err := netip.ParsePrefix(route.DestinationCIDR); err != nil {
+				return nil, badRequestError("InvalidInput", fmt.Sprintf("Invalid input for routes: %s", err))
+			}
+			if _, err := netip.ParseAddr(route.NextHop); err != nil {
+				return nil, badRequestError("InvalidInput", fmt.Sprintf("Invalid input for routes: %s", err))
+			}
+		}
+		router.Routes = slices.Clone(*updateOpts.Routes)
+	}
+	if updateOpts.Name != "" {
+		router.Name = updateOpts.Name
+	}
+	if updateOpts.Description != nil {
+		router.Description = *updateOpts.Description
+	}
+	if updateOpts.AdminStateUp != nil {
+		router.AdminStateUp = *updateOpts.AdminStateUp
+	}
+	result := copyRouter(router)
+	return &result, nil
+}
+
+// CreateRouter creates a router. If gateway information is given, an address of the external network is allocated.
+func (n *networkingClient) CreateRouter(ctx context.Context, createOpts routers.CreateOpts) (*routers.Router, error) {
+	if err := n.cloud.before(ctx, "CreateRouter"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	router := &routers.Router{
+		ID:           n.cloud.newID(),
+		Name:         createOpts.Name,
+		Description:  createOpts.Description,
+		AdminStateUp: ptr.Deref(createOpts.AdminStateUp, true),
+		Status:       "ACTIVE",
+		TenantID:     createOpts.TenantID,
+		ProjectID:    createOpts.ProjectID,
+	}
+	if createOpts.GatewayInfo != nil {
+		gatewayInfo, err := n.cloud.gatewayInfo(createOpts.GatewayInfo, nil)
+		if err != nil {
+			return nil, err
+		}
+		router.GatewayInfo = *gatewayInfo
+	}
+	n.cloud.routers[router.ID] = router
+	result := copyRouter(router)
+	return &result, nil
+}
+
+// gatewayInfo returns the gateway information of a router for the desired one. The external addresses of the
+// current gateway are kept if the external network is unchanged.
+func (c *Cloud) gatewayInfo(desired, current *routers.GatewayInfo) (*routers.GatewayInfo, error) {
+	network, ok := c.networks[desired.NetworkID]
+	if !ok || !network.external {
+		return nil, NotFoundError("ExternalNetwork", desired.NetworkID)
+	}
+	result := &routers.GatewayInfo{
+		NetworkID:  desired.NetworkID,
+		EnableSNAT: ptr.To(ptr.Deref(desired.EnableSNAT, true)),
+	}
+	if current != nil && current.NetworkID == desired.NetworkID && len(desired.ExternalFixedIPs) == 0 {
+		result.ExternalFixedIPs = slices.Clone(current.ExternalFixedIPs)
+		return result, nil
+	}
+	var subnetID string
+	if len(desired.ExternalFixedIPs) > 0 {
+		if current != nil && slices.Equal(current.ExternalFixedIPs, desired.ExternalFixedIPs) {
+			result.ExternalFixedIPs = slices.Clone(current.ExternalFixedIPs)
+			return result, nil
+		}
+		subnetID = desired.ExternalFixedIPs[0].SubnetID
+	}
+	fixedIP, err := c.allocateExternalIP(network.ID, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	result.ExternalFixedIPs = []routers.ExternalFixedIP{*fixedIP}
+	return result, nil
+}
+
+// DeleteRouter deletes a router. It fails if the router still has interfaces.
+func (n *networkingClient) DeleteRouter(ctx context.Context, routerID string) error {
+	if err := n.cloud.before(ctx, "DeleteRouter"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	if _, ok := n.cloud.routers[routerID]; !ok {
+		return NotFoundError("Router", routerID)
+	}
+	for _, port := range n.cloud.ports {
+		if port.DeviceID == routerID {
+			return ConflictError("RouterInUse", fmt.Sprintf("Router %s still has ports.", routerID))
+		}
+	}
+	delete(n.cloud.routers, routerID)
+	return nil
+}
+
+// AddRouterInterface attaches a subnet to a router.
+func (n *networkingClient) AddRouterInterface(ctx context.Context, routerID string, addOpts routers.AddInterfaceOpts) (*routers.InterfaceInfo, error) {
+	if err := n.cloud.before(ctx, "AddRouterInterface"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	router, ok := n.cloud.routers[routerID]
+	if !ok {
+		return nil, NotFoundError("Router", routerID)
+	}
+	subnet, ok := n.cloud.subnets[addOpts.SubnetID]
+	if !ok {
+		return nil, NotFoundError("Subnet", addOpts.SubnetID)
+	}
+	for _, port := range n.cloud.ports {
+		if port.DeviceID == router.ID && port.DeviceOwner == deviceOwnerRouterInterface && hasFixedIPInSubnet(port, subnet.ID) {
+			return nil, badRequestError("BadRequest", fmt.Sprintf("Router already has a port on subnet %s.", subnet.ID))
+		}
+	}
+	port := &ports.Port{
+		ID:           n.cloud.newID(),
+		NetworkID:    subnet.NetworkID,
+		AdminStateUp: true,
+		Status:       "ACTIVE",
+		MACAddress:   n.cloud.newMACAddress(),
+		FixedIPs:     []ports.IP{{SubnetID: subnet.ID, IPAddress: subnet.GatewayIP}},
+		TenantID:     router.TenantID,
+		ProjectID:    router.ProjectID,
+		DeviceOwner:  deviceOwnerRouterInterface,
+		DeviceID:     router.ID,
+	}
+	n.cloud.ports[port.ID] = port
+	return &routers.InterfaceInfo{
+		SubnetID: subnet.ID,
+		PortID:   port.ID,
+		ID:       router.ID,
+		TenantID: router.TenantID,
+	}, nil
+}
+
+// RemoveRouterInterface detaches a subnet from a router.
+func (n *networkingClient) RemoveRouterInterface(ctx context.Context, routerID string, removeOpts routers.RemoveInterfaceOpts) (*routers.InterfaceInfo, error) {
+	if err := n.cloud.before(ctx, "RemoveRouterInterface"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	router, ok := n.cloud.routers[routerID]
+	if !ok {
+		return nil, NotFoundError("Router", routerID)
+	}
+	for _, port := range sortedValues(n.cloud.ports) {
+		if port.DeviceID != router.ID || port.DeviceOwner != deviceOwnerRouterInterface {
+			continue
+		}
+		if (removeOpts.PortID == "" || removeOpts.PortID == port.ID) &&
+			(removeOpts.SubnetID == "" || hasFixedIPInSubnet(port, removeOpts.SubnetID)) {
+			delete(n.cloud.ports, port.ID)
+			return &routers.InterfaceInfo{
+				SubnetID: port.FixedIPs[0].SubnetID,
+				PortID:   port.ID,
+				ID:       router.ID,
+				TenantID: router.TenantID,
+			}, nil
+		}
+	}
+	return nil, NotFoundError("RouterInterface", removeOpts.SubnetID+removeOpts.PortID)
+}
+
+// CreateSubnet creates a subnet.
+func (n *networkingClient) CreateSubnet(ctx context.Context, createOpts subnets.CreateOpts) (*subnets.Subnet, error) {
+	if err := n.cloud.before(ctx, "CreateSubnet"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	if _, ok := n.cloud.networks[createOpts.NetworkID]; !ok {
+		return nil, NotFoundError("Network", createOpts.NetworkID)
+	}
+	if createOpts.CIDR == "" && createOpts.SubnetPoolID != "" {
+		cidr, err := n.cloud.allocateSubnetCIDR(createOpts.SubnetPoolID, createOpts.NetworkID)
+		if err != nil {
+			return nil, err
+		}
+		createOpts.CIDR = cidr
+	}
+	prefix, err := netip.ParsePrefix(createOpts.CIDR)
+	if err != nil {
+		return nil, badRequestError("InvalidInput", fmt.Sprintf("Invalid input for cidr. Reason: '%s' is not a valid IP subnet.", createOpts.CIDR))
+	}
+	if (createOpts.IPVersion == gophercloud.IPv6) != prefix.Addr().Is6() {
+		return nil, badRequestError("InvalidInput", fmt.Sprintf("Invalid input for operation: cidr %s does not match IP version %d.", createOpts.CIDR, createOpts.IPVersion))
+	}
+	for _, subnet := range n.cloud.subnets {
+		if subnet.NetworkID != createOpts.NetworkID {
+			continue
+		}
+		if existing, err := netip.ParsePrefix(subnet.CIDR); err == nil && existing.Overlaps(prefix) {
+			return nil, badRequestError("InvalidInput", fmt.Sprintf("Invalid input for operation: Requested subnet with cidr: %s for network: %s overlaps with another subnet.", createOpts.CIDR, createOpts.NetworkID))
+		}
+	}
+	subnet := n.cloud.addSubnet(createOpts)
+	result := copySubnet(subnet)
+	return &result, nil
+}
+
+// allocateSubnetCIDR returns the first prefix of the default length of the subnet pool which does not overlap with
+// the subnets of the network.
+func (c *Cloud) allocateSubnetCIDR(subnetPoolID, networkID string) (string, error) {
+	pool, ok := c.subnetPools[subnetPoolID]
+	if !ok {
+		return "", NotFoundError("SubnetPool", subnetPoolID)
+	}
+	candidate := netip.PrefixFrom(pool.prefix.Addr(), pool.defaultPrefixLen)
+	for candidate.IsValid() && pool.prefix.Contains(candidate.Addr()) {
+		free := true
+		for _, subnet := range c.subnets {
+			if existing, err := netip.ParsePrefix(subnet.CIDR); err == nil && subnet.NetworkID == networkID && existing.Overlaps(candidate) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate.String(), nil
+		}
+		candidate = nextPrefix(candidate)
+	}
+	return "", ConflictError("SubnetAllocationError", fmt.Sprintf("Insufficient prefix space to allocate subnet size /%d.", pool.defaultPrefixLen))
+}
+
+// nextPrefix returns the prefix of the same length following the given one. The result is invalid if there is none.
+func nextPrefix(prefix netip.Prefix) netip.Prefix {
+	addr := prefix.Addr().AsSlice()
+	bits := prefix.Bits()
+	// add one at the last bit of the prefix and carry over to the leading bytes
+	byteIndex, increment := (bits-1)/8, byte(1)<<(7-(bits-1)%8)
+	for ; byteIndex >= 0; byteIndex-- {
+		sum := addr[byteIndex] + increment
+		overflow := sum < addr[byteIndex]
+		addr[byteIndex] = sum
+		if !overflow {
+			next, _ := netip.AddrFromSlice(addr)
+			return netip.PrefixFrom(next, bits)
+		}
+		increment = 1
+	}
+	return netip.Prefix{}
+}
+
+func (c *Cloud) addSubnet(createOpts subnets.CreateOpts) *subnets.Subnet {
+	subnet := &subnets.Subnet{
+		ID:              c.newID(),
+		NetworkID:       createOpts.NetworkID,
+		Name:            createOpts.Name,
+		Description:     createOpts.Description,
+		IPVersion:       int(createOpts.IPVersion),
+		CIDR:            createOpts.CIDR,
+		DNSNameservers:  slices.Clone(createOpts.DNSNameservers),
+		AllocationPools: slices.Clone(createOpts.AllocationPools),
+		HostRoutes:      slices.Clone(createOpts.HostRoutes),
+		EnableDHCP:      ptr.Deref(createOpts.EnableDHCP, true),
+		TenantID:        createOpts.TenantID,
+		ProjectID:       createOpts.ProjectID,
+		IPv6AddressMode: createOpts.IPv6AddressMode,
+		IPv6RAMode:      createOpts.IPv6RAMode,
+		SubnetPoolID:    createOpts.SubnetPoolID,
+	}
+	if subnet.IPVersion == 0 {
+		subnet.IPVersion = int(gophercloud.IPv4)
+	}
+	if createOpts.GatewayIP != nil {
+		subnet.GatewayIP = *createOpts.GatewayIP
+	} else if prefix, err := netip.ParsePrefix(createOpts.CIDR); err == nil {
+		subnet.GatewayIP = prefix.Masked().Addr().Next().String()
+	}
+	c.subnets[subnet.ID] = subnet
+	if network, ok := c.networks[subnet.NetworkID]; ok {
+		network.Subnets = append(network.Subnets, subnet.ID)
+	}
+	return subnet
+}
+
+// ListSubnets returns the subnets matching the given options.
+func (n *networkingClient) ListSubnets(ctx context.Context, listOpts subnets.ListOpts) ([]subnets.Subnet, error) {
+	if err := n.cloud.before(ctx, "ListSubnets"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []subnets.Subnet
+	for _, subnet := range sortedValues(n.cloud.subnets) {
+		if matches(listOpts.ID, subnet.ID) && matches(listOpts.Name, subnet.Name) && matches(listOpts.NetworkID, subnet.NetworkID) &&
+			matches(listOpts.CIDR, subnet.CIDR) && (listOpts.IPVersion == 0 || listOpts.IPVersion == subnet.IPVersion) &&
+			matchesTags(listOpts.Tags, subnet.Tags) {
+			result = append(result, copySubnet(subnet))
+		}
+	}
+	return result, nil
+}
+
+// UpdateSubnet updates a subnet.
+func (n *networkingClient) UpdateSubnet(ctx context.Context, subnetID string, updateOpts subnets.UpdateOpts) (*subnets.Subnet, error) {
+	if err := n.cloud.before(ctx, "UpdateSubnet"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	subnet, ok := n.cloud.subnets[subnetID]
+	if !ok {
+		return nil, NotFoundError("Subnet", subnetID)
+	}
+	if updateOpts.Name != nil {
+		subnet.Name = *updateOpts.Name
+	}
+	if updateOpts.Description != nil {
+		subnet.Description = *updateOpts.Description
+	}
+	if updateOpts.DNSNameservers != nil {
+		subnet.DNSNameservers = slices.Clone(*updateOpts.DNSNameservers)
+	}
+	if updateOpts.HostRoutes != nil {
+		subnet.HostRoutes = slices.Clone(*updateOpts.HostRoutes)
+	}
+	if updateOpts.GatewayIP != nil {
+		subnet.GatewayIP = *updateOpts.GatewayIP
+	}
+	if updateOpts.EnableDHCP != nil {
+		subnet.EnableDHCP = *updateOpts.EnableDHCP
+	}
+	result := copySubnet(subnet)
+	return &result, nil
+}
+
+// DeleteSubnet deletes a subnet. It fails if ports or load balancers are still using it.
+func (n *networkingClient) DeleteSubnet(ctx context.Context, subnetID string) error {
+	if err := n.cloud.before(ctx, "DeleteSubnet"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	subnet, ok := n.cloud.subnets[subnetID]
+	if !ok {
+		return NotFoundError("Subnet", subnetID)
+	}
+	if err := n.cloud.checkSubnetUnused(subnetID); err != nil {
+		return err
+	}
+	if network, ok := n.cloud.networks[subnet.NetworkID]; ok {
+		network.Subnets = slices.DeleteFunc(network.Subnets, func(id string) bool { return id == subnetID })
+	}
+	delete(n.cloud.subnets, subnetID)
+	return nil
+}
+
+func (c *Cloud) checkSubnetUnused(subnetID string) error {
+	for _, port := range c.ports {
+		if hasFixedIPInSubnet(port, subnetID) {
+			return ConflictError("SubnetInUse", fmt.Sprintf("Unable to complete operation on subnet %s: One or more ports have an IP allocation from this subnet.", subnetID))
+		}
+	}
+	for _, lb := range c.loadBalancers {
+		if lb.VipSubnetID == subnetID {
+			return ConflictError("SubnetInUse", fmt.Sprintf("Unable to complete operation on subnet %s: One or more ports have an IP allocation from this subnet.", subnetID))
+		}
+	}
+	return nil
+}
+
+// GetPort returns the port with the given ID.
+func (n *networkingClient) GetPort(ctx context.Context, portID string) (*ports.Port, error) {
+	if err := n.cloud.before(ctx, "GetPort"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	port, ok := n.cloud.ports[portID]
+	if !ok {
+		return nil, NotFoundError("Port", portID)
+	}
+	result := copyPort(port)
+	return &result, nil
+}
+
+// ListPorts returns the ports matching the given options. The fixed IPs of the options are only matched by subnet
+// and IP address.
+func (n *networkingClient) ListPorts(ctx context.Context, listOpts ports.ListOpts) ([]ports.Port, error) {
+	if err := n.cloud.before(ctx, "ListPorts"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var result []ports.Port
+	for _, port := range sortedValues(n.cloud.ports) {
+		if !matches(listOpts.ID, port.ID) || !matches(listOpts.Name, port.Name) || !matches(listOpts.NetworkID, port.NetworkID) ||
+			!matches(listOpts.DeviceOwner, port.DeviceOwner) || !matches(listOpts.DeviceID, port.DeviceID) ||
+			!matches(listOpts.MACAddress, port.MACAddress) || !matches(listOpts.Status, port.Status) ||
+			!matchesFixedIPs(port, listOpts.FixedIPs) {
+			continue
+		}
+		result = append(result, copyPort(port))
+	}
+	return result, nil
+}
+
+// DeletePort deletes a port and disassociates its floating IPs. Router interfaces cannot be deleted directly.
+func (n *networkingClient) DeletePort(ctx context.Context, portID string) error {
+	if err := n.cloud.before(ctx, "DeletePort"); err != nil {
+		return err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	port, ok := n.cloud.ports[portID]
+	if !ok {
+		return NotFoundError("Port", portID)
+	}
+	if port.DeviceOwner == deviceOwnerRouterInterface {
+		return ConflictError("PortInUse", fmt.Sprintf("Port %s cannot be deleted directly via the port API: has device owner %s.", portID, port.DeviceOwner))
+	}
+	for _, fip := range n.cloud.floatingIPs {
+		if fip.PortID == portID {
+			fip.PortID = ""
+			fip.FixedIP = ""
+		}
+	}
+	delete(n.cloud.ports, portID)
+	return nil
+}
+
+// GetRouterInterfacePort returns the port of the interface of the router in the given subnet or nil if it does not exist.
+// If the router ID is empty, the interface of any router is returned.
+func (n *networkingClient) GetRouterInterfacePort(ctx context.Context, routerID, subnetID string) (*ports.Port, error) {
+	if err := n.cloud.before(ctx, "GetRouterInterfacePort"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	for _, port := range sortedValues(n.cloud.ports) {
+		if (routerID == "" || port.DeviceID == routerID) && port.DeviceOwner == deviceOwnerRouterInterface && hasFixedIPInSubnet(port, subnetID) {
+			result := copyPort(port)
+			return &result, nil
+		}
+	}
+	return nil, nil
+}
+
+// ReplaceAllTags replaces the tags of the network, subnet, router, security group or floating IP with the given id.
+func (n *networkingClient) ReplaceAllTags(ctx context.Context, resourceType, resourceID string, tags []string) ([]string, error) {
+	if err := n.cloud.before(ctx, "ReplaceAllTags"); err != nil {
+		return nil, err
+	}
+	n.cloud.lock.Lock()
+	defer n.cloud.lock.Unlock()
+
+	var target *[]string
+	switch resourceType {
+	case client.ResourceTypeNetworks:
+		if network, ok := n.cloud.networks[resourceID]; ok {
+			target = &network.Tags
+		}
+	case client.ResourceTypeSubnets:
+		if subnet, ok := n.cloud.subnets[resourceID]; ok {
+			target = &subnet.Tags
+		}
+	case client.ResourceTypeRouters:
+		if router, ok := n.cloud.routers[resourceID]; ok {
+			target = &router.Tags
+		}
+	case client.ResourceTypeSecurityGroups:
+		if group, ok := n.cloud.securityGroups[resourceID]; ok {
+			target = &group.Tags
+		}
+	case client.ResourceTypeFloatingIPs:
+		if fip, ok := n.cloud.floatingIPs[resourceID]; ok {
+			target = &fip.Tags
+		}
+	default:
+		return nil, badRequestError("BadRequest", fmt.Sprintf("Resource type %s does not support tags.", resourceType))
+	}
+	for _, tag := range tags {
+		if len(tag) > maxTagLength || strings.Contains(tag, ",") {
+			return nil, badRequestError("InvalidInput", fmt.Sprintf("Invalid input for tags: %q is not a valid tag.", tag))
+		}
+	}
+	if target == nil {
+		return nil, NotFoundError(resourceType, resourceID)
+	}
+	*target = slices.Clone(tags)
+	return slices.Clone(tags), nil
+}
+
+func (c *Cloud) copySecurityGroup(group *groups.SecGroup) *groups.SecGroup {
+	result := *group
+	result.Tags = slices.Clone(group.Tags)
+	result.Rules = nil
+	for _, rule := range sortedValues(c.rules) {
+		if rule.SecGroupID == group.ID {
+			result.Rules = append(result.Rules, *rule)
+		}
+	}
+	return &result
+}
+
+func copyNetwork(network *network) networks.Network {
+	result := network.Network
+	result.Subnets = slices.Clone(network.Subnets)
+	result.Tags = slices.Clone(network.Tags)
+	return result
+}
+
+func copySubnet(subnet *subnets.Subnet) subnets.Subnet {
+	result := *subnet
+	result.DNSNameservers = slices.Clone(subnet.DNSNameservers)
+	result.AllocationPools = slices.Clone(subnet.AllocationPools)
+	result.HostRoutes = slices.Clone(subnet.HostRoutes)
+	result.Tags = slices.Clone(subnet.Tags)
+	return result
+}
+
+func copyRouter(router *routers.Router) routers.Router {
+	result := *router
+	result.GatewayInfo.ExternalFixedIPs = slices.Clone(router.GatewayInfo.ExternalFixedIPs)
+	if router.GatewayInfo.EnableSNAT != nil {
+		result.GatewayInfo.EnableSNAT = ptr.To(*router.GatewayInfo.EnableSNAT)
+	}
+	result.Routes = slices.Clone(router.Routes)
+	result.Tags = slices.Clone(router.Tags)
+	return result
+}
+
+func copyPort(port *ports.Port) ports.Port {
+	result := *port
+	result.FixedIPs = slices.Clone(port.FixedIPs)
+	result.SecurityGroups = slices.Clone(port.SecurityGroups)
+	return result
+}
+
+// matchesFixedIPs returns true if the port has a fixed IP matching each of the given filters.
+func matchesFixedIPs(port *ports.Port, filters []ports.FixedIPOpts) bool {
+	for _, filter := range filters {
+		if !slices.ContainsFunc(port.FixedIPs, func(ip ports.IP) bool {
+			return matches(filter.SubnetID, ip.SubnetID) && matches(filter.IPAddress, ip.IPAddress)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasFixedIPInSubnet(port *ports.Port, subnetID string) bool {
+	for _, ip := range port.FixedIPs {
+		if ip.SubnetID == subnetID {
+			return true
+		}
+	}
+	return false
+}
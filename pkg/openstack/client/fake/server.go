@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+)
+
+const (
+	serverRegion      = "local"
+	serverDomainID    = "default"
+	serverDomainName  = "Default"
+	serverProjectID   = "00000000000040008000000000000001"
+	serverProjectName = "gardener"
+	serverUserID      = "00000000000040008000000000000002"
+	serverUserName    = "gardener"
+	serverPassword    = "secret"
+
+	serverApplicationCredentialID     = "00000000000040008000000000000003"
+	serverApplicationCredentialName   = "gardener"
+	serverApplicationCredentialSecret = "application-credential-secret"
+
+	serverTrustID          = "00000000000040008000000000000004"
+	serverIdentityProvider = "gardener"
+	serverProtocol         = "openid"
+	serverAccessToken      = "oidc-access-token"
+
+	tokenLifetime = time.Hour
+)
+
+// Server serves the subset of the Keystone, Neutron, Nova, Octavia, Designate, Manila and Swift APIs used by the
+// extension on top of a Cloud. It allows to run code which creates its own clients from credentials, e.g. the
+// controllers started by the integration tests, against a Cloud without access to a real OpenStack installation.
+// Requests to the APIs are subject to the faults injected into the Cloud.
+type Server struct {
+	cloud  *Cloud
+	server *httptest.Server
+
+	lock      sync.Mutex
+	lastToken int
+	tokens    map[string]time.Time
+}
+
+// NewServer starts a Server for the cloud. It must be closed after usage.
+func (c *Cloud) NewServer() *Server {
+	s := &Server{
+		cloud:  c,
+		tokens: map[string]time.Time{},
+	}
+	mux := http.NewServeMux()
+	s.registerIdentity(mux)
+	s.registerNetworking(mux)
+	s.registerCompute(mux)
+	s.registerLoadbalancing(mux)
+	s.registerDNS(mux)
+	s.registerSharedFilesystem(mux)
+	s.registerObjectStorage(mux)
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// AuthURL returns the URL of the Keystone v3 API of the server.
+func (s *Server) AuthURL() string {
+	return s.server.URL + "/identity/v3"
+}
+
+// Region returns the region of the endpoints in the service catalog.
+func (s *Server) Region() string {
+	return serverRegion
+}
+
+// DomainName returns the name of the domain of the user and the project.
+func (s *Server) DomainName() string {
+	return serverDomainName
+}
+
+// TenantName returns the name of the project.
+func (s *Server) TenantName() string {
+	return serverProjectName
+}
+
+// Credentials returns credentials with user name and password which are accepted by the server.
+func (s *Server) Credentials() *openstack.Credentials {
+	return &openstack.Credentials{
+		AuthURL:    s.AuthURL(),
+		DomainName: serverDomainName,
+		TenantName: serverProjectName,
+		Username:   serverUserName,
+		Password:   serverPassword,
+	}
+}
+
+// ApplicationCredentials returns credentials with an application credential which are accepted by the server.
+func (s *Server) ApplicationCredentials() *openstack.Credentials {
+	return &openstack.Credentials{
+		AuthURL:                     s.AuthURL(),
+		DomainName:                  serverDomainName,
+		TenantName:                  serverProjectName,
+		ApplicationCredentialID:     serverApplicationCredentialID,
+		ApplicationCredentialSecret: serverApplicationCredentialSecret,
+	}
+}
+
+// TokenCredentials returns credentials with a token which is issued by the server.
+func (s *Server) TokenCredentials() *openstack.Credentials {
+	token, _ := s.issueToken()
+	return &openstack.Credentials{
+		AuthURL:    s.AuthURL(),
+		DomainName: serverDomainName,
+		TenantName: serverProjectName,
+		Token:      token,
+	}
+}
+
+// TrustCredentials returns credentials with user name and password for a trust which are accepted by the server.
+func (s *Server) TrustCredentials() *openstack.Credentials {
+	credentials := s.Credentials()
+	credentials.TrustID = serverTrustID
+	return credentials
+}
+
+// OIDCCredentials returns credentials with an OIDC access token of an identity provider which are accepted by the server.
+func (s *Server) OIDCCredentials() *openstack.Credentials {
+	return &openstack.Credentials{
+		AuthURL:          s.AuthURL(),
+		DomainName:       serverDomainName,
+		TenantName:       serverProjectName,
+		IdentityProvider: serverIdentityProvider,
+		Protocol:         serverProtocol,
+		AccessToken:      serverAccessToken,
+	}
+}
+
+// RevokeTokens invalidates all tokens issued by the server, so that clients have to re-authenticate.
+func (s *Server) RevokeTokens() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens = map[string]time.Time{}
+}
+
+func (s *Server) issueToken() (string, time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastToken++
+	token := fmt.Sprintf("token-%d", s.lastToken)
+	expiresAt := time.Now().Add(tokenLifetime).UTC()
+	s.tokens[token] = expiresAt
+	return token, expiresAt
+}
+
+func (s *Server) validToken(token string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	expiresAt, ok := s.tokens[token]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// handlerFunc handles an API request. It returns the status code and the body of the response. The body is written
+// as text if it is a string and encoded as JSON otherwise. A nil body results in an empty response.
+type handlerFunc func(r *http.Request) (int, any, error)
+
+// handle registers a handler for requests which require a valid token.
+func (s *Server) handle(mux *http.ServeMux, pattern string, handler handlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if !s.validToken(r.Header.Get("X-Auth-Token")) {
+			writeError(w, unauthorizedError())
+			return
+		}
+		s.serve(w, r, handler)
+	})
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, handler handlerFunc) {
+	code, body, err := handler(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	switch body := body.(type) {
+	case nil:
+		w.WriteHeader(code)
+	case string:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(code)
+		_, _ = io.WriteString(w, body)
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = w.Write(data)
+	}
+}
+
+// writeError writes the status code and body of errors returned by the OpenStack API, e.g. injected faults. Other
+// errors are reported as internal server errors.
+func writeError(w http.ResponseWriter, err error) {
+	code, body := http.StatusInternalServerError, []byte(err.Error())
+	if responseErr, ok := unexpectedResponseCodeOf(err); ok {
+		code, body = responseErr.Actual, responseErr.Body
+	} else if statusCodeErr, ok := err.(gophercloud.StatusCodeError); ok {
+		code = statusCodeErr.GetStatusCode()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func unexpectedResponseCodeOf(err error) (gophercloud.ErrUnexpectedResponseCode, bool) {
+	switch err := err.(type) {
+	case gophercloud.ErrDefault400:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault401:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault403:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault404:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault409:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault429:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault500:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrDefault503:
+		return err.ErrUnexpectedResponseCode, true
+	case gophercloud.ErrUnexpectedResponseCode:
+		return err, true
+	}
+	return gophercloud.ErrUnexpectedResponseCode{}, false
+}
+
+// decodeBody decodes the object with the given key of the JSON request body into v. The request bodies of the
+// OpenStack APIs mostly match the JSON encoding of the gophercloud options, so v is usually a pointer to them.
+func decodeBody(r *http.Request, key string, v any) error {
+	var body map[string]json.RawMessage
+	if err := decodeJSON(r, &body); err != nil {
+		return err
+	}
+	raw, ok := body[key]
+	if !ok {
+		return badRequestError("BadRequest", fmt.Sprintf("Missing %q in request body.", key))
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return badRequestError("BadRequest", fmt.Sprintf("Invalid %q in request body: %s.", key, err))
+	}
+	return nil
+}
+
+// decodeJSON decodes the JSON request body into v.
+func decodeJSON(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequestError("BadRequest", fmt.Sprintf("Malformed request body: %s.", err))
+	}
+	return nil
+}
+
+// decodeQuery sets the fields of the gophercloud list options opts from the query parameters named by their "q" tags.
+// Only fields of type string, int, bool and *bool are supported.
+func decodeQuery(values url.Values, opts any) {
+	v := reflect.ValueOf(opts).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("q"), ",")
+		value := values.Get(name)
+		if name == "" || value == "" {
+			continue
+		}
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.String:
+			field.SetString(value)
+		case field.Kind() == reflect.Int:
+			if n, err := strconv.Atoi(value); err == nil {
+				field.SetInt(int64(n))
+			}
+		case field.Kind() == reflect.Bool:
+			field.SetBool(value == "true")
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Bool:
+			b := value == "true"
+			field.Set(reflect.ValueOf(&b))
+		}
+	}
+}
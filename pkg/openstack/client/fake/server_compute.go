@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"net/http"
+
+	computefip "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+)
+
+const computePrefix = "/compute/v2.1"
+
+// serverResponse adds the image of the server, which is not encoded by servers.Server.
+type serverResponse struct {
+	servers.Server
+	Image map[string]interface{} `json:"image"`
+}
+
+// serverCreateRequest is the request body for creating a server. It differs from the JSON encoding of
+// servers.CreateOpts in the security groups and networks.
+type serverCreateRequest struct {
+	Name             string            `json:"name"`
+	ImageRef         string            `json:"imageRef"`
+	FlavorRef        string            `json:"flavorRef"`
+	AvailabilityZone string            `json:"availability_zone"`
+	Metadata         map[string]string `json:"metadata"`
+	UserData         []byte            `json:"user_data"`
+	SecurityGroups   []struct {
+		Name string `json:"name"`
+	} `json:"security_groups"`
+	Networks []struct {
+		UUID    string `json:"uuid"`
+		Port    string `json:"port"`
+		FixedIP string `json:"fixed_ip"`
+	} `json:"networks"`
+}
+
+func (s *Server) registerCompute(mux *http.ServeMux) {
+	c := &computeClient{cloud: s.cloud}
+
+	s.handle(mux, "GET "+computePrefix+"/os-server-groups", func(r *http.Request) (int, any, error) {
+		list, err := c.ListServerGroups(r.Context())
+		return http.StatusOK, map[string]any{"server_groups": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "POST "+computePrefix+"/os-server-groups", func(r *http.Request) (int, any, error) {
+		var req struct {
+			Name     string   `json:"name"`
+			Policy   string   `json:"policy"`
+			Policies []string `json:"policies"`
+		}
+		if err := decodeBody(r, "server_group", &req); err != nil {
+			return 0, nil, err
+		}
+		// the policy is given as list before microversion 2.64
+		if len(req.Policies) > 0 {
+			req.Policy = req.Policies[0]
+		}
+		serverGroup, err := c.CreateServerGroup(r.Context(), req.Name, req.Policy)
+		return http.StatusOK, map[string]any{"server_group": serverGroup}, err
+	})
+	s.handle(mux, "GET "+computePrefix+"/os-server-groups/{id}", func(r *http.Request) (int, any, error) {
+		serverGroup, err := c.GetServerGroup(r.Context(), r.PathValue("id"))
+		return http.StatusOK, map[string]any{"server_group": serverGroup}, err
+	})
+	s.handle(mux, "DELETE "+computePrefix+"/os-server-groups/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, c.DeleteServerGroup(r.Context(), r.PathValue("id"))
+	})
+
+	s.handle(mux, "POST "+computePrefix+"/servers", func(r *http.Request) (int, any, error) {
+		var req serverCreateRequest
+		if err := decodeBody(r, "server", &req); err != nil {
+			return 0, nil, err
+		}
+		opts := servers.CreateOpts{
+			Name:             req.Name,
+			ImageRef:         req.ImageRef,
+			FlavorRef:        req.FlavorRef,
+			AvailabilityZone: req.AvailabilityZone,
+			Metadata:         req.Metadata,
+			UserData:         req.UserData,
+		}
+		for _, group := range req.SecurityGroups {
+			opts.SecurityGroups = append(opts.SecurityGroups, group.Name)
+		}
+		var nets []servers.Network
+		for _, net := range req.Networks {
+			nets = append(nets, servers.Network{UUID: net.UUID, Port: net.Port, FixedIP: net.FixedIP})
+		}
+		opts.Networks = nets
+		server, err := c.CreateServer(r.Context(), opts)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, map[string]any{"server": serverResponse{Server: *server, Image: server.Image}}, nil
+	})
+	s.handle(mux, "GET "+computePrefix+"/servers/detail", s.listServers)
+	s.handle(mux, "GET "+computePrefix+"/servers/{id}", func(r *http.Request) (int, any, error) {
+		if err := s.cloud.before(r.Context(), "GetServer"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		server, ok := s.cloud.servers[r.PathValue("id")]
+		if !ok {
+			return 0, nil, NotFoundError("Server", r.PathValue("id"))
+		}
+		result := s.cloud.copyServer(server)
+		return http.StatusOK, map[string]any{"server": serverResponse{Server: *result, Image: result.Image}}, nil
+	})
+	s.handle(mux, "DELETE "+computePrefix+"/servers/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, c.DeleteServer(r.Context(), r.PathValue("id"))
+	})
+	s.handle(mux, "POST "+computePrefix+"/servers/{id}/action", func(r *http.Request) (int, any, error) {
+		var opts computefip.AssociateOpts
+		if err := decodeBody(r, "addFloatingIp", &opts); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, nil, c.AssociateFIPWithInstance(r.Context(), r.PathValue("id"), opts)
+	})
+	s.handle(mux, "GET "+computePrefix+"/os-floating-ips", s.listComputeFloatingIPs)
+
+	s.handle(mux, "GET "+computePrefix+"/flavors/detail", func(r *http.Request) (int, any, error) {
+		if err := s.cloud.before(r.Context(), "ListFlavors"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		result := []map[string]any{}
+		for _, id := range sortedKeys(s.cloud.flavors) {
+			result = append(result, map[string]any{"id": id, "name": s.cloud.flavors[id], "vcpus": 2, "ram": 4096, "disk": 20})
+		}
+		return http.StatusOK, map[string]any{"flavors": result}, nil
+	})
+	s.handle(mux, "GET "+computePrefix+"/images/detail", func(r *http.Request) (int, any, error) {
+		var opts images.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := c.ListImages(r.Context(), opts)
+		return http.StatusOK, map[string]any{"images": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "GET "+computePrefix+"/images/{id}", func(r *http.Request) (int, any, error) {
+		image, err := c.FindImageByID(r.Context(), r.PathValue("id"))
+		if err == nil && image == nil {
+			err = NotFoundError("Image", r.PathValue("id"))
+		}
+		return http.StatusOK, map[string]any{"image": image}, err
+	})
+
+	s.handle(mux, "POST "+computePrefix+"/os-keypairs", func(r *http.Request) (int, any, error) {
+		var opts keypairs.CreateOpts
+		if err := decodeBody(r, "keypair", &opts); err != nil {
+			return 0, nil, err
+		}
+		keyPair, err := c.CreateKeyPair(r.Context(), opts.Name, opts.PublicKey)
+		return http.StatusOK, map[string]any{"keypair": keyPair}, err
+	})
+	s.handle(mux, "GET "+computePrefix+"/os-keypairs", func(r *http.Request) (int, any, error) {
+		list, err := c.ListKeyPairs(r.Context())
+		// Nova wraps each key pair of the list in an object
+		result := []map[string]any{}
+		for _, keyPair := range list {
+			result = append(result, map[string]any{"keypair": keyPair})
+		}
+		return http.StatusOK, map[string]any{"keypairs": result}, err
+	})
+	s.handle(mux, "GET "+computePrefix+"/os-keypairs/{name}", func(r *http.Request) (int, any, error) {
+		keyPair, err := c.GetKeyPair(r.Context(), r.PathValue("name"))
+		if err == nil && keyPair == nil {
+			err = NotFoundError("KeyPair", r.PathValue("name"))
+		}
+		return http.StatusOK, map[string]any{"keypair": keyPair}, err
+	})
+	s.handle(mux, "DELETE "+computePrefix+"/os-keypairs/{name}", func(r *http.Request) (int, any, error) {
+		return http.StatusAccepted, nil, c.DeleteKeyPair(r.Context(), r.PathValue("name"))
+	})
+}
+
+// listServers lists the servers whose names match the regular expression of the name parameter like Nova does.
+func (s *Server) listServers(r *http.Request) (int, any, error) {
+	list, err := (&computeClient{cloud: s.cloud}).ListServers(r.Context(), servers.ListOpts{Name: r.URL.Query().Get("name")})
+	if err != nil {
+		return 0, nil, err
+	}
+	result := []serverResponse{}
+	for _, server := range list {
+		result = append(result, serverResponse{Server: server, Image: server.Image})
+	}
+	return http.StatusOK, map[string]any{"servers": result}, nil
+}
+
+// listComputeFloatingIPs lists the floating IPs through the deprecated proxy API of Nova.
+func (s *Server) listComputeFloatingIPs(r *http.Request) (int, any, error) {
+	if err := s.cloud.before(r.Context(), "ListComputeFloatingIPs"); err != nil {
+		return 0, nil, err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	result := []map[string]any{}
+	for _, fip := range sortedValues(s.cloud.floatingIPs) {
+		var pool, instanceID string
+		if network, ok := s.cloud.networks[fip.FloatingNetworkID]; ok {
+			pool = network.Name
+		}
+		if port, ok := s.cloud.ports[fip.PortID]; ok {
+			instanceID = port.DeviceID
+		}
+		result = append(result, map[string]any{
+			"id":          fip.ID,
+			"pool":        pool,
+			"ip":          fip.FloatingIP,
+			"fixed_ip":    fip.FixedIP,
+			"instance_id": instanceID,
+		})
+	}
+	return http.StatusOK, map[string]any{"floating_ips": result}, nil
+}
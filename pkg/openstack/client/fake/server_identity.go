@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	"k8s.io/utils/ptr"
+)
+
+type authDomain struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type authUser struct {
+	ID       string      `json:"id,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Password string      `json:"password,omitempty"`
+	Domain   *authDomain `json:"domain,omitempty"`
+}
+
+type authRequest struct {
+	Auth struct {
+		Identity struct {
+			Methods  []string `json:"methods"`
+			Password *struct {
+				User authUser `json:"user"`
+			} `json:"password"`
+			ApplicationCredential *struct {
+				ID     string    `json:"id"`
+				Name   string    `json:"name"`
+				Secret string    `json:"secret"`
+				User   *authUser `json:"user"`
+			} `json:"application_credential"`
+			Token *struct {
+				ID string `json:"id"`
+			} `json:"token"`
+		} `json:"identity"`
+		Scope *struct {
+			Project *struct {
+				ID     string      `json:"id"`
+				Name   string      `json:"name"`
+				Domain *authDomain `json:"domain"`
+			} `json:"project"`
+			Trust *struct {
+				ID string `json:"id"`
+			} `json:"OS-TRUST:trust"`
+		} `json:"scope"`
+	} `json:"auth"`
+}
+
+// catalogServices are the services in the service catalog mapped to the paths of their endpoints.
+var catalogServices = []struct {
+	serviceType, name, path string
+}{
+	{"identity", "keystone", "/identity/v3/"},
+	{"network", "neutron", "/network/"},
+	{"compute", "nova", "/compute/v2.1/"},
+	{"load-balancer", "octavia", "/load-balancer/"},
+	{"dns", "designate", "/dns/"},
+	{"sharev2", "manilav2", "/share/v2/"},
+	{"object-store", "swift", "/object-store/v1/AUTH_" + serverProjectID + "/"},
+}
+
+func (s *Server) registerIdentity(mux *http.ServeMux) {
+	c := &identityClient{cloud: s.cloud}
+
+	mux.HandleFunc("POST /identity/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, func(r *http.Request) (int, any, error) {
+			token, body, err := s.createToken(r)
+			if err != nil {
+				return 0, nil, err
+			}
+			w.Header().Set("X-Subject-Token", token)
+			return http.StatusCreated, body, nil
+		})
+	})
+	s.handle(mux, "GET /identity/v3/users/{user}/application_credentials/{id}", func(r *http.Request) (int, any, error) {
+		if r.PathValue("user") != serverUserID {
+			return 0, nil, NotFoundError("User", r.PathValue("user"))
+		}
+		credential, err := c.GetApplicationCredential(r.Context(), r.PathValue("id"))
+		return http.StatusOK, map[string]any{"application_credential": applicationCredentialResponse(credential)}, err
+	})
+	s.handle(mux, "POST /identity/v3/users/{user}/application_credentials", func(r *http.Request) (int, any, error) {
+		if r.PathValue("user") != serverUserID {
+			return 0, nil, NotFoundError("User", r.PathValue("user"))
+		}
+		var req applicationCredentialCreateRequest
+		if err := decodeBody(r, "application_credential", &req); err != nil {
+			return 0, nil, err
+		}
+		opts := applicationcredentials.CreateOpts{
+			Name:         req.Name,
+			Description:  req.Description,
+			Unrestricted: req.Unrestricted,
+			Secret:       req.Secret,
+			Roles:        req.Roles,
+		}
+		if req.ExpiresAt != "" {
+			expiresAt, err := time.Parse(gophercloud.RFC3339MilliNoZ, req.ExpiresAt)
+			if err != nil {
+				return 0, nil, badRequestError("ValidationError", "Invalid expires_at.")
+			}
+			opts.ExpiresAt = &expiresAt
+		}
+		credential, err := c.CreateApplicationCredential(r.Context(), opts)
+		return http.StatusCreated, map[string]any{"application_credential": applicationCredentialResponse(credential)}, err
+	})
+	s.handle(mux, "DELETE /identity/v3/users/{user}/application_credentials/{id}", func(r *http.Request) (int, any, error) {
+		if r.PathValue("user") != serverUserID {
+			return 0, nil, NotFoundError("User", r.PathValue("user"))
+		}
+		return http.StatusNoContent, nil, c.DeleteApplicationCredential(r.Context(), r.PathValue("id"))
+	})
+	mux.HandleFunc("POST /identity/v3/OS-FEDERATION/identity_providers/{idp}/protocols/{protocol}/auth", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, func(r *http.Request) (int, any, error) {
+			token, err := s.federatedToken(r)
+			if err != nil {
+				return 0, nil, err
+			}
+			w.Header().Set("X-Subject-Token", token)
+			return http.StatusCreated, nil, nil
+		})
+	})
+}
+
+// applicationCredentialCreateRequest is the request body for creating an application credential. It differs from the
+// JSON encoding of applicationcredentials.CreateOpts in the expiration time.
+type applicationCredentialCreateRequest struct {
+	Name         string                        `json:"name"`
+	Description  string                        `json:"description"`
+	Unrestricted bool                          `json:"unrestricted"`
+	Secret       string                        `json:"secret"`
+	Roles        []applicationcredentials.Role `json:"roles"`
+	ExpiresAt    string                        `json:"expires_at"`
+}
+
+// applicationCredentialResponse encodes the expiration time of the application credential, which is not encoded by
+// applicationcredentials.ApplicationCredential.
+func applicationCredentialResponse(credential *applicationcredentials.ApplicationCredential) any {
+	if credential == nil {
+		return nil
+	}
+	var expiresAt *string
+	if !credential.ExpiresAt.IsZero() {
+		expiresAt = ptr.To(credential.ExpiresAt.UTC().Format(gophercloud.RFC3339MilliNoZ))
+	}
+	return struct {
+		applicationcredentials.ApplicationCredential
+		ExpiresAt *string `json:"expires_at"`
+	}{*credential, expiresAt}
+}
+
+// federatedToken issues an unscoped token for the OIDC access token returned by Server.OIDCCredentials.
+func (s *Server) federatedToken(r *http.Request) (string, error) {
+	if r.PathValue("idp") != serverIdentityProvider || r.PathValue("protocol") != serverProtocol {
+		return "", NotFoundError("IdentityProvider", r.PathValue("idp"))
+	}
+	if r.Header.Get("Authorization") != "Bearer "+serverAccessToken {
+		return "", unauthorizedError()
+	}
+	if err := s.cloud.before(r.Context(), "CreateFederatedToken"); err != nil {
+		return "", err
+	}
+	token, _ := s.issueToken()
+	return token, nil
+}
+
+// createToken issues a project or trust scoped token for the user name and password, the application credential or a
+// valid token, see the credentials returned by Server.Credentials, Server.ApplicationCredentials and
+// Server.TokenCredentials. It returns the token and the body of the response.
+func (s *Server) createToken(r *http.Request) (string, any, error) {
+	var req authRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return "", nil, badRequestError("BadRequest", "Malformed request body.")
+	}
+	identity := req.Auth.Identity
+	switch {
+	case slices.Contains(identity.Methods, "password") && identity.Password != nil:
+		user := identity.Password.User
+		if !validUser(&user) || user.Password != serverPassword || !validScope(req) {
+			return "", nil, unauthorizedError()
+		}
+	case slices.Contains(identity.Methods, "application_credential") && identity.ApplicationCredential != nil:
+		credential := identity.ApplicationCredential
+		validID := credential.ID == serverApplicationCredentialID
+		validName := credential.Name == serverApplicationCredentialName && credential.User != nil && validUser(credential.User)
+		validSecret := (validID || validName) && credential.Secret == serverApplicationCredentialSecret
+		if !(validSecret || s.validApplicationCredential(credential.ID, credential.Secret)) || !validScope(req) {
+			return "", nil, unauthorizedError()
+		}
+	case slices.Contains(identity.Methods, "token") && identity.Token != nil:
+		if !s.validToken(identity.Token.ID) || !validScope(req) {
+			return "", nil, unauthorizedError()
+		}
+	default:
+		return "", nil, unauthorizedError()
+	}
+
+	if err := s.cloud.before(r.Context(), "CreateToken"); err != nil {
+		return "", nil, err
+	}
+	token, expiresAt := s.issueToken()
+	domain := map[string]string{"id": serverDomainID, "name": serverDomainName}
+	body := map[string]any{
+		"methods":    identity.Methods,
+		"issued_at":  time.Now().UTC().Format(time.RFC3339),
+		"expires_at": expiresAt.Format(time.RFC3339),
+		"user":       map[string]any{"id": serverUserID, "name": serverUserName, "domain": domain},
+		"project":    map[string]any{"id": serverProjectID, "name": serverProjectName, "domain": domain},
+		"catalog":    s.catalog(),
+	}
+	if req.Auth.Scope != nil && req.Auth.Scope.Trust != nil {
+		body["OS-TRUST:trust"] = map[string]any{"id": serverTrustID}
+	}
+	return token, map[string]any{"token": body}, nil
+}
+
+func (s *Server) validApplicationCredential(id, secret string) bool {
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+	return s.cloud.validApplicationCredential(id, secret)
+}
+
+func validUser(user *authUser) bool {
+	if user.ID != "" {
+		return user.ID == serverUserID
+	}
+	return user.Name == serverUserName && user.Domain != nil &&
+		(user.Domain.ID == serverDomainID || user.Domain.Name == serverDomainName)
+}
+
+// validScope returns true if the request is not scoped or scoped to the project or the trust of the server.
+func validScope(req authRequest) bool {
+	if req.Auth.Scope != nil && req.Auth.Scope.Trust != nil {
+		return req.Auth.Scope.Project == nil && req.Auth.Scope.Trust.ID == serverTrustID
+	}
+	if req.Auth.Scope == nil || req.Auth.Scope.Project == nil {
+		return true
+	}
+	project := req.Auth.Scope.Project
+	if project.ID != "" {
+		return project.ID == serverProjectID
+	}
+	return project.Name == serverProjectName && project.Domain != nil &&
+		(project.Domain.ID == serverDomainID || project.Domain.Name == serverDomainName)
+}
+
+func (s *Server) catalog() []map[string]any {
+	var entries []map[string]any
+	for _, service := range catalogServices {
+		var endpoints []map[string]any
+		for _, endpointInterface := range []string{"public", "internal", "admin"} {
+			endpoints = append(endpoints, map[string]any{
+				"id":        service.name + "-" + endpointInterface,
+				"interface": endpointInterface,
+				"region":    serverRegion,
+				"region_id": serverRegion,
+				"url":       s.server.URL + service.path,
+			})
+		}
+		entries = append(entries, map[string]any{
+			"id":        service.name,
+			"name":      service.name,
+			"type":      service.serviceType,
+			"endpoints": endpoints,
+		})
+	}
+	return entries
+}
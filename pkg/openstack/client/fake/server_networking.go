@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+const networkingPrefix = "/network/v2.0"
+
+// networkResponse adds the attribute of the external network extension, which is not part of networks.Network.
+type networkResponse struct {
+	networks.Network
+	External bool `json:"router:external"`
+}
+
+func (s *Server) registerNetworking(mux *http.ServeMux) {
+	n := &networkingClient{cloud: s.cloud}
+
+	s.handle(mux, "GET "+networkingPrefix+"/networks", func(r *http.Request) (int, any, error) {
+		var opts networks.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := n.ListNetwork(r.Context(), opts)
+		if err != nil {
+			return 0, nil, err
+		}
+		external := r.URL.Query().Get("router:external")
+		result := []networkResponse{}
+		for _, network := range list {
+			response := s.networkResponse(network)
+			if external == "" || external == strconv.FormatBool(response.External) {
+				result = append(result, response)
+			}
+		}
+		return http.StatusOK, map[string]any{"networks": result}, nil
+	})
+	s.handle(mux, "POST "+networkingPrefix+"/networks", func(r *http.Request) (int, any, error) {
+		var opts struct {
+			networks.CreateOpts
+			External bool `json:"router:external"`
+		}
+		if err := decodeBody(r, "network", &opts); err != nil {
+			return 0, nil, err
+		}
+		network, err := n.CreateNetwork(r.Context(), opts.CreateOpts)
+		if err != nil {
+			return 0, nil, err
+		}
+		if opts.External {
+			s.cloud.lock.Lock()
+			s.cloud.networks[network.ID].external = true
+			s.cloud.lock.Unlock()
+		}
+		return http.StatusCreated, map[string]any{"network": s.networkResponse(*network)}, nil
+	})
+	s.handle(mux, "GET "+networkingPrefix+"/networks/{id}", func(r *http.Request) (int, any, error) {
+		if err := s.cloud.before(r.Context(), "GetNetwork"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		network, ok := s.cloud.networks[r.PathValue("id")]
+		if !ok {
+			return 0, nil, NotFoundError("Network", r.PathValue("id"))
+		}
+		return http.StatusOK, map[string]any{"network": networkResponse{Network: copyNetwork(network), External: network.external}}, nil
+	})
+	s.handle(mux, "PUT "+networkingPrefix+"/networks/{id}", func(r *http.Request) (int, any, error) {
+		var opts networks.UpdateOpts
+		if err := decodeBody(r, "network", &opts); err != nil {
+			return 0, nil, err
+		}
+		network, err := n.UpdateNetwork(r.Context(), r.PathValue("id"), opts)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, map[string]any{"network": s.networkResponse(*network)}, nil
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/networks/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeleteNetwork(r.Context(), r.PathValue("id"))
+	})
+
+	s.handle(mux, "GET "+networkingPrefix+"/subnets", func(r *http.Request) (int, any, error) {
+		var opts subnets.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := n.ListSubnets(r.Context(), opts)
+		return http.StatusOK, map[string]any{"subnets": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "POST "+networkingPrefix+"/subnets", func(r *http.Request) (int, any, error) {
+		var opts subnets.CreateOpts
+		if err := decodeBody(r, "subnet", &opts); err != nil {
+			return 0, nil, err
+		}
+		subnet, err := n.CreateSubnet(r.Context(), opts)
+		return http.StatusCreated, map[string]any{"subnet": subnet}, err
+	})
+	s.handle(mux, "GET "+networkingPrefix+"/subnets/{id}", func(r *http.Request) (int, any, error) {
+		if err := s.cloud.before(r.Context(), "GetSubnet"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		subnet, ok := s.cloud.subnets[r.PathValue("id")]
+		if !ok {
+			return 0, nil, NotFoundError("Subnet", r.PathValue("id"))
+		}
+		return http.StatusOK, map[string]any{"subnet": copySubnet(subnet)}, nil
+	})
+	s.handle(mux, "PUT "+networkingPrefix+"/subnets/{id}", func(r *http.Request) (int, any, error) {
+		var opts subnets.UpdateOpts
+		if err := decodeBody(r, "subnet", &opts); err != nil {
+			return 0, nil, err
+		}
+		subnet, err := n.UpdateSubnet(r.Context(), r.PathValue("id"), opts)
+		return http.StatusOK, map[string]any{"subnet": subnet}, err
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/subnets/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeleteSubnet(r.Context(), r.PathValue("id"))
+	})
+
+	s.handle(mux, "GET "+networkingPrefix+"/routers", func(r *http.Request) (int, any, error) {
+		var opts routers.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := n.ListRouters(r.Context(), opts)
+		return http.StatusOK, map[string]any{"routers": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "POST "+networkingPrefix+"/routers", func(r *http.Request) (int, any, error) {
+		var opts routers.CreateOpts
+		if err := decodeBody(r, "router", &opts); err != nil {
+			return 0, nil, err
+		}
+		router, err := n.CreateRouter(r.Context(), opts)
+		return http.StatusCreated, map[string]any{"router": router}, err
+	})
+	s.handle(mux, "GET "+networkingPrefix+"/routers/{id}", func(r *http.Request) (int, any, error) {
+		router, err := n.GetRouterByID(r.Context(), r.PathValue("id"))
+		if err == nil && router == nil {
+			err = NotFoundError("Router", r.PathValue("id"))
+		}
+		return http.StatusOK, map[string]any{"router": router}, err
+	})
+	s.handle(mux, "PUT "+networkingPrefix+"/routers/{id}", func(r *http.Request) (int, any, error) {
+		var opts routers.UpdateOpts
+		if err := decodeBody(r, "router", &opts); err != nil {
+			return 0, nil, err
+		}
+		router, err := n.UpdateRouter(r.Context(), r.PathValue("id"), opts)
+		return http.StatusOK, map[string]any{"router": router}, err
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/routers/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeleteRouter(r.Context(), r.PathValue("id"))
+	})
+	s.handle(mux, "PUT "+networkingPrefix+"/routers/{id}/add_router_interface", func(r *http.Request) (int, any, error) {
+		var opts routers.AddInterfaceOpts
+		if err := decodeJSON(r, &opts); err != nil {
+			return 0, nil, err
+		}
+		info, err := n.AddRouterInterface(r.Context(), r.PathValue("id"), opts)
+		return http.StatusOK, info, err
+	})
+	s.handle(mux, "PUT "+networkingPrefix+"/routers/{id}/remove_router_interface", func(r *http.Request) (int, any, error) {
+		var opts routers.RemoveInterfaceOpts
+		if err := decodeJSON(r, &opts); err != nil {
+			return 0, nil, err
+		}
+		info, err := n.RemoveRouterInterface(r.Context(), r.PathValue("id"), opts)
+		return http.StatusOK, info, err
+	})
+
+	s.handle(mux, "GET "+networkingPrefix+"/ports", s.listPorts)
+	s.handle(mux, "GET "+networkingPrefix+"/ports/{id}", func(r *http.Request) (int, any, error) {
+		port, err := n.GetPort(r.Context(), r.PathValue("id"))
+		return http.StatusOK, map[string]any{"port": port}, err
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/ports/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeletePort(r.Context(), r.PathValue("id"))
+	})
+
+	s.handle(mux, "GET "+networkingPrefix+"/floatingips", func(r *http.Request) (int, any, error) {
+		var opts floatingips.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := n.ListFip(r.Context(), opts)
+		return http.StatusOK, map[string]any{"floatingips": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "POST "+networkingPrefix+"/floatingips", func(r *http.Request) (int, any, error) {
+		var opts floatingips.CreateOpts
+		if err := decodeBody(r, "floatingip", &opts); err != nil {
+			return 0, nil, err
+		}
+		fip, err := n.CreateFloatingIP(r.Context(), opts)
+		return http.StatusCreated, map[string]any{"floatingip": fip}, err
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/floatingips/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeleteFloatingIP(r.Context(), r.PathValue("id"))
+	})
+
+	s.handle(mux, "GET "+networkingPrefix+"/security-groups", func(r *http.Request) (int, any, error) {
+		var opts groups.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := n.ListSecurityGroup(r.Context(), opts)
+		return http.StatusOK, map[string]any{"security_groups": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "POST "+networkingPrefix+"/security-groups", func(r *http.Request) (int, any, error) {
+		var opts groups.CreateOpts
+		if err := decodeBody(r, "security_group", &opts); err != nil {
+			return 0, nil, err
+		}
+		group, err := n.CreateSecurityGroup(r.Context(), opts)
+		return http.StatusCreated, map[string]any{"security_group": group}, err
+	})
+	s.handle(mux, "GET "+networkingPrefix+"/security-groups/{id}", func(r *http.Request) (int, any, error) {
+		group, err := n.GetSecurityGroup(r.Context(), r.PathValue("id"))
+		return http.StatusOK, map[string]any{"security_group": group}, err
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/security-groups/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeleteSecurityGroup(r.Context(), r.PathValue("id"))
+	})
+
+	for _, resourceType := range []string{"networks", "subnets", "routers", "security-groups", "floatingips"} {
+		s.handle(mux, "PUT "+networkingPrefix+"/"+resourceType+"/{id}/tags", func(r *http.Request) (int, any, error) {
+			var tags []string
+			if err := decodeBody(r, "tags", &tags); err != nil {
+				return 0, nil, err
+			}
+			tags, err := n.ReplaceAllTags(r.Context(), resourceType, r.PathValue("id"), tags)
+			return http.StatusOK, map[string]any{"tags": tags}, err
+		})
+	}
+
+	s.handle(mux, "GET "+networkingPrefix+"/security-group-rules", func(r *http.Request) (int, any, error) {
+		var opts rules.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := n.ListRules(r.Context(), opts)
+		return http.StatusOK, map[string]any{"security_group_rules": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "POST "+networkingPrefix+"/security-group-rules", func(r *http.Request) (int, any, error) {
+		var opts rules.CreateOpts
+		if err := decodeBody(r, "security_group_rule", &opts); err != nil {
+			return 0, nil, err
+		}
+		rule, err := n.CreateRule(r.Context(), opts)
+		return http.StatusCreated, map[string]any{"security_group_rule": rule}, err
+	})
+	s.handle(mux, "DELETE "+networkingPrefix+"/security-group-rules/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusNoContent, nil, n.DeleteRule(r.Context(), r.PathValue("id"))
+	})
+}
+
+func (s *Server) networkResponse(network networks.Network) networkResponse {
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+	response := networkResponse{Network: network}
+	if n, ok := s.cloud.networks[network.ID]; ok {
+		response.External = n.external
+	}
+	return response
+}
+
+// listPorts lists the ports matching the query. The fixed_ips parameter can be given multiple times to filter by
+// "subnet_id=<id>" or "ip_address=<address>".
+func (s *Server) listPorts(r *http.Request) (int, any, error) {
+	var opts ports.ListOpts
+	decodeQuery(r.URL.Query(), &opts)
+	for _, filter := range r.URL.Query()["fixed_ips"] {
+		key, value, _ := strings.Cut(filter, "=")
+		switch key {
+		case "subnet_id":
+			opts.FixedIPs = append(opts.FixedIPs, ports.FixedIPOpts{SubnetID: value})
+		case "ip_address":
+			opts.FixedIPs = append(opts.FixedIPs, ports.FixedIPOpts{IPAddress: value})
+		}
+	}
+	list, err := (&networkingClient{cloud: s.cloud}).ListPorts(r.Context(), opts)
+	return http.StatusOK, map[string]any{"ports": emptyIfNil(list)}, err
+}
+
+// emptyIfNil returns an empty slice for nil, so that lists are never encoded as null.
+func emptyIfNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
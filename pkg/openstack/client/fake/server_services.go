@@ -0,0 +1,310 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+)
+
+const (
+	loadbalancingPrefix    = "/load-balancer/v2.0/lbaas"
+	dnsPrefix              = "/dns/v2"
+	sharedFilesystemPrefix = "/share/v2"
+	objectStoragePrefix    = "/object-store/v1/{account}"
+)
+
+func (s *Server) registerLoadbalancing(mux *http.ServeMux) {
+	l := &loadbalancingClient{cloud: s.cloud}
+
+	s.handle(mux, "GET "+loadbalancingPrefix+"/loadbalancers", func(r *http.Request) (int, any, error) {
+		var opts loadbalancers.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := l.ListLoadbalancers(r.Context(), opts)
+		return http.StatusOK, map[string]any{"loadbalancers": emptyIfNil(list)}, err
+	})
+	s.handle(mux, "GET "+loadbalancingPrefix+"/loadbalancers/{id}", func(r *http.Request) (int, any, error) {
+		lb, err := l.GetLoadbalancer(r.Context(), r.PathValue("id"))
+		if err == nil && lb == nil {
+			err = NotFoundError("LoadBalancer", r.PathValue("id"))
+		}
+		return http.StatusOK, map[string]any{"loadbalancer": lb}, err
+	})
+	s.handle(mux, "DELETE "+loadbalancingPrefix+"/loadbalancers/{id}", func(r *http.Request) (int, any, error) {
+		opts := loadbalancers.DeleteOpts{Cascade: r.URL.Query().Get("cascade") == "true"}
+		return http.StatusNoContent, nil, l.DeleteLoadbalancer(r.Context(), r.PathValue("id"), opts)
+	})
+}
+
+func (s *Server) registerDNS(mux *http.ServeMux) {
+	d := &dnsClient{cloud: s.cloud}
+
+	s.handle(mux, "GET "+dnsPrefix+"/zones", func(r *http.Request) (int, any, error) {
+		zones, err := d.GetZones(r.Context())
+		if err != nil {
+			return 0, nil, err
+		}
+		result := []map[string]any{}
+		for _, name := range sortedKeys(zones) {
+			result = append(result, map[string]any{"id": zones[name], "name": ensureTrailingDot(name), "type": "PRIMARY", "status": "ACTIVE"})
+		}
+		return http.StatusOK, map[string]any{"zones": result}, nil
+	})
+	s.handle(mux, "GET "+dnsPrefix+"/zones/{zone}/recordsets", s.listRecordSets)
+	s.handle(mux, "POST "+dnsPrefix+"/zones/{zone}/recordsets", func(r *http.Request) (int, any, error) {
+		var opts recordsets.CreateOpts
+		if err := decodeJSON(r, &opts); err != nil {
+			return 0, nil, err
+		}
+		zoneID := r.PathValue("zone")
+		if _, _, ok := s.cloud.RecordSet(zoneID, opts.Name, opts.Type); ok {
+			return 0, nil, ConflictError("DuplicateRecordSet", fmt.Sprintf("Duplicate RecordSet %s.", opts.Name))
+		}
+		if err := d.CreateOrUpdateRecordSet(r.Context(), zoneID, opts.Name, opts.Type, opts.Records, opts.TTL); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, s.recordSetResponse(zoneID, recordSetKey{name: ensureTrailingDot(opts.Name), recordType: opts.Type}), nil
+	})
+	s.handle(mux, "PUT "+dnsPrefix+"/zones/{zone}/recordsets/{id}", func(r *http.Request) (int, any, error) {
+		var opts recordsets.UpdateOpts
+		if err := decodeJSON(r, &opts); err != nil {
+			return 0, nil, err
+		}
+		zoneID := r.PathValue("zone")
+		key, rs, err := s.findRecordSet(zoneID, r.PathValue("id"))
+		if err != nil {
+			return 0, nil, err
+		}
+		if opts.Records != nil {
+			rs.records = opts.Records
+		}
+		if opts.TTL != nil {
+			rs.ttl = *opts.TTL
+		}
+		if err := d.CreateOrUpdateRecordSet(r.Context(), zoneID, key.name, key.recordType, rs.records, rs.ttl); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, s.recordSetResponse(zoneID, key), nil
+	})
+	s.handle(mux, "DELETE "+dnsPrefix+"/zones/{zone}/recordsets/{id}", func(r *http.Request) (int, any, error) {
+		key, _, err := s.findRecordSet(r.PathValue("zone"), r.PathValue("id"))
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, nil, d.DeleteRecordSet(r.Context(), r.PathValue("zone"), key.name, key.recordType)
+	})
+}
+
+func (s *Server) listRecordSets(r *http.Request) (int, any, error) {
+	if err := s.cloud.before(r.Context(), "ListRecordSets"); err != nil {
+		return 0, nil, err
+	}
+	zoneID := r.PathValue("zone")
+	s.cloud.lock.Lock()
+	zone, ok := s.cloud.zones[zoneID]
+	if !ok {
+		s.cloud.lock.Unlock()
+		return 0, nil, NotFoundError("Zone", zoneID)
+	}
+	var keys []recordSetKey
+	for key := range zone.recordSets {
+		if (r.URL.Query().Get("name") == "" || key.name == ensureTrailingDot(r.URL.Query().Get("name"))) &&
+			matches(r.URL.Query().Get("type"), key.recordType) {
+			keys = append(keys, key)
+		}
+	}
+	s.cloud.lock.Unlock()
+
+	slices.SortFunc(keys, func(a, b recordSetKey) int {
+		return strings.Compare(a.name+" "+a.recordType, b.name+" "+b.recordType)
+	})
+	result := []map[string]any{}
+	for _, key := range keys {
+		if response := s.recordSetResponse(zoneID, key); response != nil {
+			result = append(result, response)
+		}
+	}
+	return http.StatusOK, map[string]any{"recordsets": result}, nil
+}
+
+// findRecordSet returns the key and a copy of the record set with the given ID.
+func (s *Server) findRecordSet(zoneID, id string) (recordSetKey, recordSet, error) {
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	zone, ok := s.cloud.zones[zoneID]
+	if !ok {
+		return recordSetKey{}, recordSet{}, NotFoundError("Zone", zoneID)
+	}
+	for key, rs := range zone.recordSets {
+		if rs.id == id {
+			return key, recordSet{id: rs.id, records: slices.Clone(rs.records), ttl: rs.ttl}, nil
+		}
+	}
+	return recordSetKey{}, recordSet{}, NotFoundError("RecordSet", id)
+}
+
+func (s *Server) recordSetResponse(zoneID string, key recordSetKey) map[string]any {
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	zone, ok := s.cloud.zones[zoneID]
+	if !ok {
+		return nil
+	}
+	rs, ok := zone.recordSets[key]
+	if !ok {
+		return nil
+	}
+	return map[string]any{
+		"id":        rs.id,
+		"zone_id":   zoneID,
+		"zone_name": zone.name,
+		"name":      key.name,
+		"type":      key.recordType,
+		"records":   slices.Clone(rs.records),
+		"ttl":       rs.ttl,
+		"status":    "ACTIVE",
+	}
+}
+
+func (s *Server) registerSharedFilesystem(mux *http.ServeMux) {
+	m := &sharedFilesystemClient{cloud: s.cloud}
+
+	s.handle(mux, "POST "+sharedFilesystemPrefix+"/share-networks", func(r *http.Request) (int, any, error) {
+		var opts sharenetworks.CreateOpts
+		if err := decodeBody(r, "share_network", &opts); err != nil {
+			return 0, nil, err
+		}
+		shareNetwork, err := m.CreateShareNetwork(r.Context(), opts)
+		return http.StatusOK, map[string]any{"share_network": shareNetwork}, err
+	})
+	s.handle(mux, "GET "+sharedFilesystemPrefix+"/share-networks/detail", func(r *http.Request) (int, any, error) {
+		var opts sharenetworks.ListOpts
+		decodeQuery(r.URL.Query(), &opts)
+		list, err := m.ListShareNetworks(r.Context(), opts)
+		if err != nil {
+			return 0, nil, err
+		}
+		// gophercloud requests the next page with the offset of the last page even if no limit is given
+		if offset, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil {
+			list = list[min(max(offset, 0), len(list)):]
+		}
+		return http.StatusOK, map[string]any{"share_networks": emptyIfNil(list)}, nil
+	})
+	s.handle(mux, "GET "+sharedFilesystemPrefix+"/share-networks/{id}", func(r *http.Request) (int, any, error) {
+		shareNetwork, err := m.GetShareNetwork(r.Context(), r.PathValue("id"))
+		if err == nil && shareNetwork == nil {
+			err = NotFoundError("ShareNetwork", r.PathValue("id"))
+		}
+		return http.StatusOK, map[string]any{"share_network": shareNetwork}, err
+	})
+	s.handle(mux, "PUT "+sharedFilesystemPrefix+"/share-networks/{id}", func(r *http.Request) (int, any, error) {
+		var opts sharenetworks.UpdateOpts
+		if err := decodeBody(r, "share_network", &opts); err != nil {
+			return 0, nil, err
+		}
+		shareNetwork, err := m.UpdateShareNetwork(r.Context(), r.PathValue("id"), opts)
+		return http.StatusOK, map[string]any{"share_network": shareNetwork}, err
+	})
+	s.handle(mux, "DELETE "+sharedFilesystemPrefix+"/share-networks/{id}", func(r *http.Request) (int, any, error) {
+		return http.StatusAccepted, nil, m.DeleteShareNetwork(r.Context(), r.PathValue("id"))
+	})
+}
+
+func (s *Server) registerObjectStorage(mux *http.ServeMux) {
+	st := &storageClient{cloud: s.cloud}
+
+	s.handle(mux, "PUT "+objectStoragePrefix+"/{container}", func(r *http.Request) (int, any, error) {
+		return http.StatusCreated, nil, st.CreateContainerIfNotExists(r.Context(), r.PathValue("container"))
+	})
+	s.handle(mux, "DELETE "+objectStoragePrefix+"/{container}", func(r *http.Request) (int, any, error) {
+		if err := s.cloud.before(r.Context(), "DeleteContainer"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		container := r.PathValue("container")
+		objects, ok := s.cloud.containers[container]
+		if !ok {
+			return 0, nil, NotFoundError("Container", container)
+		}
+		if len(objects) > 0 {
+			return 0, nil, ConflictError("Conflict", "There was a conflict when trying to complete your request.")
+		}
+		delete(s.cloud.containers, container)
+		return http.StatusNoContent, nil, nil
+	})
+	s.handle(mux, "GET "+objectStoragePrefix+"/{container}", s.listObjects)
+	s.handle(mux, "PUT "+objectStoragePrefix+"/{container}/{object...}", func(r *http.Request) (int, any, error) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return 0, nil, err
+		}
+		if err := s.cloud.before(r.Context(), "CreateObject"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		objects, ok := s.cloud.containers[r.PathValue("container")]
+		if !ok {
+			return 0, nil, NotFoundError("Container", r.PathValue("container"))
+		}
+		objects[r.PathValue("object")] = data
+		return http.StatusCreated, nil, nil
+	})
+	s.handle(mux, "DELETE "+objectStoragePrefix+"/{container}/{object...}", func(r *http.Request) (int, any, error) {
+		if err := s.cloud.before(r.Context(), "DeleteObject"); err != nil {
+			return 0, nil, err
+		}
+		s.cloud.lock.Lock()
+		defer s.cloud.lock.Unlock()
+
+		objects, ok := s.cloud.containers[r.PathValue("container")]
+		if !ok {
+			return 0, nil, NotFoundError("Container", r.PathValue("container"))
+		}
+		if _, ok := objects[r.PathValue("object")]; !ok {
+			return 0, nil, NotFoundError("Object", r.PathValue("object"))
+		}
+		delete(objects, r.PathValue("object"))
+		return http.StatusNoContent, nil, nil
+	})
+}
+
+// listObjects lists the names of the objects of a container after the given marker as plain text like Swift does.
+func (s *Server) listObjects(r *http.Request) (int, any, error) {
+	if err := s.cloud.before(r.Context(), "ListObjects"); err != nil {
+		return 0, nil, err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	objects, ok := s.cloud.containers[r.PathValue("container")]
+	if !ok {
+		return 0, nil, NotFoundError("Container", r.PathValue("container"))
+	}
+	prefix, marker := r.URL.Query().Get("prefix"), r.URL.Query().Get("marker")
+	var names []string
+	for _, name := range sortedKeys(objects) {
+		if strings.HasPrefix(name, prefix) && name > marker {
+			names = append(names, name+"\n")
+		}
+	}
+	if len(names) == 0 {
+		return http.StatusNoContent, nil, nil
+	}
+	return http.StatusOK, strings.Join(names, ""), nil
+}
@@ -0,0 +1,394 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	computefip "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		ctx     context.Context
+		cloud   *fake.Cloud
+		server  *fake.Server
+		factory openstackclient.Factory
+
+		externalNetworkID string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cloud = fake.NewCloud()
+		externalNetworkID = cloud.AddExternalNetwork("public")
+		cloud.AddSubnet(externalNetworkID, "public-subnet", "172.24.4.0/24")
+		server = cloud.NewServer()
+		DeferCleanup(server.Close)
+
+		var err error
+		factory, err = openstackclient.NewOpenstackClientFromCredentials(server.Credentials())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Identity", func() {
+		It("should authenticate with application credentials", func() {
+			factory, err := openstackclient.NewOpenstackClientFromCredentials(server.ApplicationCredentials())
+			Expect(err).NotTo(HaveOccurred())
+			networking, err := factory.Networking(openstackclient.WithRegion(server.Region()))
+			Expect(err).NotTo(HaveOccurred())
+
+			names, err := networking.GetExternalNetworkNames(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(ConsistOf("public"))
+		})
+
+		DescribeTable("should authenticate with other credentials",
+			func(credentialsOf func(*fake.Server) *openstack.Credentials) {
+				factory, err := openstackclient.NewOpenstackClientFromCredentials(credentialsOf(server))
+				Expect(err).NotTo(HaveOccurred())
+				networking, err := factory.Networking(openstackclient.WithRegion(server.Region()))
+				Expect(err).NotTo(HaveOccurred())
+
+				names, err := networking.GetExternalNetworkNames(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names).To(ConsistOf("public"))
+			},
+			Entry("token", (*fake.Server).TokenCredentials),
+			Entry("trust", (*fake.Server).TrustCredentials),
+			Entry("OIDC access token", (*fake.Server).OIDCCredentials),
+		)
+
+		It("should reject invalid OIDC access tokens", func() {
+			credentials := server.OIDCCredentials()
+			credentials.AccessToken = "invalid"
+
+			_, err := openstackclient.NewOpenstackClientFromCredentials(credentials)
+			Expect(err).To(BeAssignableToTypeOf(gophercloud.ErrDefault401{}))
+		})
+
+		It("should exchange the OIDC access token again after the tokens have been revoked", func() {
+			factory, err := openstackclient.NewOpenstackClientFromCredentials(server.OIDCCredentials())
+			Expect(err).NotTo(HaveOccurred())
+			networking, err := factory.Networking()
+			Expect(err).NotTo(HaveOccurred())
+
+			server.RevokeTokens()
+			_, err = networking.ListNetwork(ctx, networks.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloud.Calls("CreateFederatedToken")).To(Equal(2))
+		})
+
+		It("should reject invalid credentials", func() {
+			credentials := server.Credentials()
+			credentials.Password = "invalid"
+
+			_, err := openstackclient.NewOpenstackClientFromCredentials(credentials)
+			Expect(err).To(BeAssignableToTypeOf(gophercloud.ErrDefault401{}))
+		})
+
+		It("should re-authenticate after the tokens have been revoked", func() {
+			networking, err := factory.Networking()
+			Expect(err).NotTo(HaveOccurred())
+
+			server.RevokeTokens()
+			_, err = networking.ListNetwork(ctx, networks.ListOpts{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloud.Calls("CreateToken")).To(Equal(2))
+		})
+
+		It("should manage application credentials", func() {
+			identity, err := factory.Identity()
+			Expect(err).NotTo(HaveOccurred())
+
+			expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+			credential, err := identity.CreateApplicationCredential(ctx, applicationcredentials.CreateOpts{
+				Name:      "rotated",
+				ExpiresAt: &expiresAt,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(credential.Secret).NotTo(BeEmpty())
+
+			credentials := server.Credentials()
+			credentials.Username, credentials.Password = "", ""
+			credentials.ApplicationCredentialID, credentials.ApplicationCredentialSecret = credential.ID, credential.Secret
+			_, err = openstackclient.NewOpenstackClientFromCredentials(credentials)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := identity.GetApplicationCredential(ctx, credential.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Name).To(Equal("rotated"))
+			Expect(result.ExpiresAt).To(BeTemporally("==", expiresAt))
+
+			Expect(identity.DeleteApplicationCredential(ctx, credential.ID)).To(Succeed())
+			_, err = identity.GetApplicationCredential(ctx, credential.ID)
+			Expect(openstackclient.IsNotFoundError(err)).To(BeTrue())
+			_, err = openstackclient.NewOpenstackClientFromCredentials(credentials)
+			Expect(err).To(BeAssignableToTypeOf(gophercloud.ErrDefault401{}))
+		})
+	})
+
+	Describe("Networking", func() {
+		It("should manage routers and their interfaces", func() {
+			networking, err := factory.Networking(openstackclient.WithRegion(server.Region()))
+			Expect(err).NotTo(HaveOccurred())
+
+			external, err := networking.GetExternalNetworkByName(ctx, "public")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(external.ID).To(Equal(externalNetworkID))
+
+			network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			subnet, err := networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, Name: "shoot", CIDR: "10.250.0.0/16", IPVersion: 4})
+			Expect(err).NotTo(HaveOccurred())
+			router, err := networking.CreateRouter(ctx, routers.CreateOpts{Name: "shoot", GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetworkID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(router.GatewayInfo.ExternalFixedIPs).To(ConsistOf(HaveField("IPAddress", "172.24.4.2")))
+			info, err := networking.AddRouterInterface(ctx, router.ID, routers.AddInterfaceOpts{SubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+
+			port, err := networking.GetRouterInterfacePort(ctx, router.ID, subnet.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(port.ID).To(Equal(info.PortID))
+			list, err := networking.ListPorts(ctx, ports.ListOpts{FixedIPs: []ports.FixedIPOpts{{SubnetID: subnet.ID}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(HaveField("ID", info.PortID)))
+			Expect(networking.DeletePort(ctx, info.PortID)).To(BeAssignableToTypeOf(gophercloud.ErrDefault409{}))
+			Expect(networking.DeleteRouter(ctx, router.ID)).To(BeAssignableToTypeOf(gophercloud.ErrDefault409{}))
+
+			_, err = networking.RemoveRouterInterface(ctx, router.ID, routers.RemoveInterfaceOpts{SubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networking.DeleteRouter(ctx, router.ID)).To(Succeed())
+			router, err = networking.GetRouterByID(ctx, router.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(router).To(BeNil())
+		})
+
+		It("should replace tags and filter by them", func() {
+			networking, err := factory.Networking(openstackclient.WithRegion(server.Region()))
+			Expect(err).NotTo(HaveOccurred())
+
+			network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+
+			tags, err := networking.ReplaceAllTags(ctx, "networks", network.ID, []string{"gardener-shoot=shoot--foo--bar", "gardener-purpose=nodes"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(HaveLen(2))
+
+			list, err := networking.ListNetwork(ctx, networks.ListOpts{Tags: "gardener-shoot=shoot--foo--bar,gardener-purpose=nodes"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(HaveField("ID", network.ID)))
+			list, err = networking.ListNetwork(ctx, networks.ListOpts{Tags: "gardener-shoot=shoot--foo--bar,gardener-purpose=bastion"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+
+			_, err = networking.ReplaceAllTags(ctx, "networks", "foo", []string{"gardener-shoot=shoot--foo--bar"})
+			Expect(openstackclient.IsNotFoundError(err)).To(BeTrue())
+		})
+
+		It("should return the injected faults", func() {
+			cloud.InjectFault("CreateNetwork", fake.Fault{Err: fake.QuotaExceededError("network"), Times: 1})
+			networking, err := factory.Networking()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).To(BeAssignableToTypeOf(gophercloud.ErrDefault409{}))
+			Expect(err).To(MatchError(ContainSubstring("Quota exceeded")))
+
+			_, err = networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Compute", func() {
+		It("should create servers with floating IPs", func() {
+			networking, err := factory.Networking()
+			Expect(err).NotTo(HaveOccurred())
+			compute, err := factory.Compute()
+			Expect(err).NotTo(HaveOccurred())
+			network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, Name: "shoot", CIDR: "10.250.0.0/16", IPVersion: 4})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = networking.CreateSecurityGroup(ctx, groups.CreateOpts{Name: "bastion"})
+			Expect(err).NotTo(HaveOccurred())
+			cloud.AddFlavor("small")
+			imageID := cloud.AddImage("gardenlinux")
+
+			flavorID, err := compute.FindFlavorID(ctx, "small")
+			Expect(err).NotTo(HaveOccurred())
+			image, err := compute.FindImageByID(ctx, imageID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(image.Name).To(Equal("gardenlinux"))
+
+			server, err := compute.CreateServer(ctx, servers.CreateOpts{
+				Name:           "bastion",
+				FlavorRef:      flavorID,
+				ImageRef:       imageID,
+				SecurityGroups: []string{"bastion"},
+				Networks:       []servers.Network{{UUID: network.ID}},
+				UserData:       []byte("#!/bin/bash"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Image).To(HaveKeyWithValue("id", imageID))
+
+			fip, err := networking.CreateFloatingIP(ctx, floatingips.CreateOpts{FloatingNetworkID: externalNetworkID, Description: "bastion"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(compute.AssociateFIPWithInstance(ctx, server.ID, computefip.AssociateOpts{FloatingIP: fip.FloatingIP})).To(Succeed())
+			fipID, err := compute.FindFloatingIDByInstanceID(ctx, server.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fipID).To(Equal(fip.ID))
+
+			list, err := compute.FindServersByName(ctx, "bastion")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(HaveLen(1))
+			Expect(list[0].Addresses["shoot"]).To(ContainElement(HaveKeyWithValue("OS-EXT-IPS:type", "floating")))
+			all, err := compute.ListServers(ctx, servers.ListOpts{Name: "^bast"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(all).To(ConsistOf(HaveField("ID", server.ID)))
+
+			Expect(compute.DeleteServer(ctx, server.ID)).To(Succeed())
+			list, err = compute.FindServersByName(ctx, "bastion")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		})
+
+		It("should manage key pairs and server groups", func() {
+			compute, err := factory.Compute()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = compute.CreateKeyPair(ctx, "shoot", "ssh-rsa AAAA")
+			Expect(err).NotTo(HaveOccurred())
+			keyPair, err := compute.GetKeyPair(ctx, "shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair.PublicKey).To(Equal("ssh-rsa AAAA"))
+			keyPairs, err := compute.ListKeyPairs(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPairs).To(ConsistOf(HaveField("Name", "shoot")))
+			Expect(compute.DeleteKeyPair(ctx, "shoot")).To(Succeed())
+			keyPair, err = compute.GetKeyPair(ctx, "shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyPair).To(BeNil())
+
+			serverGroup, err := compute.CreateServerGroup(ctx, "shoot", "soft-anti-affinity")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serverGroup.Policies).To(ConsistOf("soft-anti-affinity"))
+			Expect(compute.DeleteServerGroup(ctx, serverGroup.ID)).To(Succeed())
+			list, err := compute.ListServerGroups(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(BeEmpty())
+		})
+	})
+
+	Describe("Loadbalancing", func() {
+		It("should list and delete load balancers", func() {
+			id := cloud.AddLoadBalancer(loadbalancers.LoadBalancer{Name: "kube_service_shoot"})
+			loadbalancing, err := factory.Loadbalancing()
+			Expect(err).NotTo(HaveOccurred())
+
+			list, err := loadbalancing.ListLoadbalancers(ctx, loadbalancers.ListOpts{Name: "kube_service_shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(HaveField("ID", id)))
+			Expect(loadbalancing.DeleteLoadbalancer(ctx, id, loadbalancers.DeleteOpts{Cascade: true})).To(Succeed())
+			lb, err := loadbalancing.GetLoadbalancer(ctx, id)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lb).To(BeNil())
+		})
+	})
+
+	Describe("DNS", func() {
+		It("should create, update and delete record sets", func() {
+			zoneID := cloud.AddZone("example.com")
+			dns, err := factory.DNS()
+			Expect(err).NotTo(HaveOccurred())
+
+			zones, err := dns.GetZones(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zones).To(HaveKeyWithValue("example.com", zoneID))
+
+			Expect(dns.CreateOrUpdateRecordSet(ctx, zoneID, "api.example.com", "A", []string{"1.2.3.4"}, 120)).To(Succeed())
+			Expect(dns.CreateOrUpdateRecordSet(ctx, zoneID, "api.example.com", "A", []string{"5.6.7.8"}, 120)).To(Succeed())
+			records, ttl, ok := cloud.RecordSet(zoneID, "api.example.com", "A")
+			Expect(ok).To(BeTrue())
+			Expect(records).To(ConsistOf("5.6.7.8"))
+			Expect(ttl).To(Equal(120))
+
+			Expect(dns.DeleteRecordSet(ctx, zoneID, "api.example.com", "A")).To(Succeed())
+			_, _, ok = cloud.RecordSet(zoneID, "api.example.com", "A")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("SharedFilesystem", func() {
+		It("should manage share networks", func() {
+			networking, err := factory.Networking()
+			Expect(err).NotTo(HaveOccurred())
+			sharedFilesystem, err := factory.SharedFilesystem()
+			Expect(err).NotTo(HaveOccurred())
+			network, err := networking.CreateNetwork(ctx, networks.CreateOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			subnet, err := networking.CreateSubnet(ctx, subnets.CreateOpts{NetworkID: network.ID, Name: "shoot", CIDR: "10.250.0.0/16", IPVersion: 4})
+			Expect(err).NotTo(HaveOccurred())
+
+			shareNetwork, err := sharedFilesystem.CreateShareNetwork(ctx, sharenetworks.CreateOpts{Name: "shoot", NeutronNetID: network.ID, NeutronSubnetID: subnet.ID})
+			Expect(err).NotTo(HaveOccurred())
+			list, err := sharedFilesystem.ListShareNetworks(ctx, sharenetworks.ListOpts{Name: "shoot"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(ConsistOf(HaveField("ID", shareNetwork.ID)))
+
+			shareNetwork, err = sharedFilesystem.UpdateShareNetwork(ctx, shareNetwork.ID, sharenetworks.UpdateOpts{Description: ptr.To("gardener-shoot=shoot")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shareNetwork.Name).To(Equal("shoot"))
+			Expect(shareNetwork.Description).To(Equal("gardener-shoot=shoot"))
+
+			Expect(sharedFilesystem.DeleteShareNetwork(ctx, shareNetwork.ID)).To(Succeed())
+			shareNetwork, err = sharedFilesystem.GetShareNetwork(ctx, shareNetwork.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shareNetwork).To(BeNil())
+		})
+	})
+
+	Describe("Storage", func() {
+		It("should delete containers with their objects", func() {
+			storage, err := factory.Storage()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(storage.CreateContainerIfNotExists(ctx, "backups")).To(Succeed())
+			cloud.PutObject("backups", "shoot/etcd-1", []byte("data"))
+			cloud.PutObject("backups", "shoot/etcd-2", []byte("data"))
+			cloud.PutObject("backups", "other/etcd-1", []byte("data"))
+
+			Expect(storage.DeleteObjectsWithPrefix(ctx, "backups", "shoot/")).To(Succeed())
+			objects, _ := cloud.Objects("backups")
+			Expect(objects).To(ConsistOf("other/etcd-1"))
+
+			Expect(storage.DeleteContainerIfExists(ctx, "backups")).To(Succeed())
+			_, ok := cloud.Objects("backups")
+			Expect(ok).To(BeFalse())
+			Expect(storage.DeleteContainerIfExists(ctx, "backups")).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+type sharedFilesystemClient struct {
+	cloud *Cloud
+}
+
+var _ client.SharedFilesystem = &sharedFilesystemClient{}
+
+// GetShareNetwork returns the share network with the given ID or nil if it does not exist.
+func (s *sharedFilesystemClient) GetShareNetwork(ctx context.Context, id string) (*sharenetworks.ShareNetwork, error) {
+	if err := s.cloud.before(ctx, "GetShareNetwork"); err != nil {
+		return nil, err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	shareNetwork, ok := s.cloud.shareNetworks[id]
+	if !ok {
+		return nil, nil
+	}
+	result := *shareNetwork
+	return &result, nil
+}
+
+// CreateShareNetwork creates a share network.
+func (s *sharedFilesystemClient) CreateShareNetwork(ctx context.Context, createOpts sharenetworks.CreateOpts) (*sharenetworks.ShareNetwork, error) {
+	if err := s.cloud.before(ctx, "CreateShareNetwork"); err != nil {
+		return nil, err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	if _, ok := s.cloud.networks[createOpts.NeutronNetID]; !ok {
+		return nil, NotFoundError("Network", createOpts.NeutronNetID)
+	}
+	subnet, ok := s.cloud.subnets[createOpts.NeutronSubnetID]
+	if !ok || subnet.NetworkID != createOpts.NeutronNetID {
+		return nil, NotFoundError("Subnet", createOpts.NeutronSubnetID)
+	}
+	shareNetwork := &sharenetworks.ShareNetwork{
+		ID:              s.cloud.newID(),
+		Name:            createOpts.Name,
+		Description:     createOpts.Description,
+		NeutronNetID:    createOpts.NeutronNetID,
+		NeutronSubnetID: createOpts.NeutronSubnetID,
+		CIDR:            subnet.CIDR,
+		IPVersion:       subnet.IPVersion,
+	}
+	s.cloud.shareNetworks[shareNetwork.ID] = shareNetwork
+	result := *shareNetwork
+	return &result, nil
+}
+
+// ListShareNetworks returns the share networks matching the given options.
+func (s *sharedFilesystemClient) ListShareNetworks(ctx context.Context, listOpts sharenetworks.ListOpts) ([]sharenetworks.ShareNetwork, error) {
+	if err := s.cloud.before(ctx, "ListShareNetworks"); err != nil {
+		return nil, err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	var result []sharenetworks.ShareNetwork
+	for _, shareNetwork := range sortedValues(s.cloud.shareNetworks) {
+		if matches(listOpts.Name, shareNetwork.Name) && matches(listOpts.NeutronNetID, shareNetwork.NeutronNetID) &&
+			matches(listOpts.NeutronSubnetID, shareNetwork.NeutronSubnetID) {
+			result = append(result, *shareNetwork)
+		}
+	}
+	return result, nil
+}
+
+// UpdateShareNetwork updates the name and the description of the share network with the given ID.
+func (s *sharedFilesystemClient) UpdateShareNetwork(ctx context.Context, id string, updateOpts sharenetworks.UpdateOpts) (*sharenetworks.ShareNetwork, error) {
+	if err := s.cloud.before(ctx, "UpdateShareNetwork"); err != nil {
+		return nil, err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	shareNetwork, ok := s.cloud.shareNetworks[id]
+	if !ok {
+		return nil, NotFoundError("ShareNetwork", id)
+	}
+	if updateOpts.Name != nil {
+		shareNetwork.Name = *updateOpts.Name
+	}
+	if updateOpts.Description != nil {
+		shareNetwork.Description = *updateOpts.Description
+	}
+	result := *shareNetwork
+	return &result, nil
+}
+
+// DeleteShareNetwork deletes the share network with the given ID.
+func (s *sharedFilesystemClient) DeleteShareNetwork(ctx context.Context, id string) error {
+	if err := s.cloud.before(ctx, "DeleteShareNetwork"); err != nil {
+		return err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	if _, ok := s.cloud.shareNetworks[id]; !ok {
+		return NotFoundError("ShareNetwork", id)
+	}
+	delete(s.cloud.shareNetworks, id)
+	return nil
+}
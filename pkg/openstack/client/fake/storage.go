@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+type storageClient struct {
+	cloud *Cloud
+}
+
+var _ client.Storage = &storageClient{}
+
+// DeleteObjectsWithPrefix deletes the objects with the given prefix from a container.
+func (s *storageClient) DeleteObjectsWithPrefix(ctx context.Context, container, prefix string) error {
+	if err := s.cloud.before(ctx, "DeleteObjectsWithPrefix"); err != nil {
+		return err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	objects, ok := s.cloud.containers[container]
+	if !ok {
+		return NotFoundError("Container", container)
+	}
+	for name := range objects {
+		if strings.HasPrefix(name, prefix) {
+			delete(objects, name)
+		}
+	}
+	return nil
+}
+
+// CreateContainerIfNotExists creates a container if it does not exist yet.
+func (s *storageClient) CreateContainerIfNotExists(ctx context.Context, container string) error {
+	if err := s.cloud.before(ctx, "CreateContainerIfNotExists"); err != nil {
+		return err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	if _, ok := s.cloud.containers[container]; !ok {
+		s.cloud.containers[container] = map[string][]byte{}
+	}
+	return nil
+}
+
+// DeleteContainerIfExists deletes a container together with its objects if it exists.
+func (s *storageClient) DeleteContainerIfExists(ctx context.Context, container string) error {
+	if err := s.cloud.before(ctx, "DeleteContainerIfExists"); err != nil {
+		return err
+	}
+	s.cloud.lock.Lock()
+	defer s.cloud.lock.Unlock()
+
+	delete(s.cloud.containers, container)
+	return nil
+}
+
+// PutObject stores an object in a container, e.g. to simulate a backup. The container is created if it does not
+// exist.
+func (c *Cloud) PutObject(container, name string, data []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.containers[container]; !ok {
+		c.containers[container] = map[string][]byte{}
+	}
+	c.containers[container][name] = data
+}
+
+// Objects returns the names of the objects in a container. The last return value is false if the container does not
+// exist.
+func (c *Cloud) Objects(container string) ([]string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	objects, ok := c.containers[container]
+	if !ok {
+		return nil, false
+	}
+	return sortedKeys(objects), true
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"slices"
+	"strings"
+)
+
+// matches returns true if the filter of a list option is empty or equal to the value.
+func matches(filter, value string) bool {
+	return filter == "" || filter == value
+}
+
+// matchesTags returns true if the tags filter of a list option is empty or all of its comma-separated tags are set.
+func matchesTags(filter string, tags []string) bool {
+	if filter == "" {
+		return true
+	}
+	for _, tag := range strings.Split(filter, ",") {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the keys of the map in ascending order. As identifiers are generated in ascending order, the
+// resources are returned in the order of their creation.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func sortedValues[T any](m map[string]T) []T {
+	values := make([]T, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		values = append(values, m[key])
+	}
+	return values
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
+)
+
+// GetApplicationCredential returns the application credential with the given ID.
+func (c *IdentityClient) GetApplicationCredential(ctx context.Context, id string) (*applicationcredentials.ApplicationCredential, error) {
+	return applicationcredentials.Get(withContext(ctx, c.client), c.userID, id).Extract()
+}
+
+// CreateApplicationCredential creates an application credential. The secret of the credential is only returned by
+// this call.
+func (c *IdentityClient) CreateApplicationCredential(ctx context.Context, opts applicationcredentials.CreateOpts) (*applicationcredentials.ApplicationCredential, error) {
+	return applicationcredentials.Create(withContext(ctx, c.client), c.userID, opts).Extract()
+}
+
+// DeleteApplicationCredential deletes the application credential with the given ID.
+func (c *IdentityClient) DeleteApplicationCredential(ctx context.Context, id string) error {
+	return applicationcredentials.Delete(withContext(ctx, c.client), c.userID, id).ExtractErr()
+}
@@ -5,12 +5,14 @@
 package client
 
 import (
+	"context"
+
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
 )
 
 // ListLoadbalancers returns a list of all loadbalancers info by listOpts
-func (c *LoadbalancingClient) ListLoadbalancers(listOpts loadbalancers.ListOpts) ([]loadbalancers.LoadBalancer, error) {
-	pages, err := loadbalancers.List(c.client, listOpts).AllPages()
+func (c *LoadbalancingClient) ListLoadbalancers(ctx context.Context, listOpts loadbalancers.ListOpts) ([]loadbalancers.LoadBalancer, error) {
+	pages, err := loadbalancers.List(withContext(ctx, c.client), listOpts).AllPages()
 	if err != nil {
 		return nil, err
 	}
@@ -19,8 +21,8 @@ func (c *LoadbalancingClient) ListLoadbalancers(listOpts loadbalancers.ListOpts)
 }
 
 // DeleteLoadbalancer deletes the loadbalancer with the specified ID.
-func (c *LoadbalancingClient) DeleteLoadbalancer(id string, opts loadbalancers.DeleteOpts) error {
-	err := loadbalancers.Delete(c.client, id, opts).ExtractErr()
+func (c *LoadbalancingClient) DeleteLoadbalancer(ctx context.Context, id string, opts loadbalancers.DeleteOpts) error {
+	err := loadbalancers.Delete(withContext(ctx, c.client), id, opts).ExtractErr()
 	if err != nil && !IsNotFoundError(err) {
 		return err
 	}
@@ -28,8 +30,8 @@ func (c *LoadbalancingClient) DeleteLoadbalancer(id string, opts loadbalancers.D
 }
 
 // GetLoadbalancer returns the loadbalancer with the specified ID.
-func (c *LoadbalancingClient) GetLoadbalancer(id string) (*loadbalancers.LoadBalancer, error) {
-	lb, err := loadbalancers.Get(c.client, id).Extract()
+func (c *LoadbalancingClient) GetLoadbalancer(ctx context.Context, id string) (*loadbalancers.LoadBalancer, error) {
+	lb, err := loadbalancers.Get(withContext(ctx, c.client), id).Extract()
 	if err != nil && !IsNotFoundError(err) {
 		return nil, err
 	}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "openstack"
+	metricsSubsystem = "api"
+
+	// ServiceIdentity is the service label value for requests against Keystone.
+	ServiceIdentity = "identity"
+	// ServiceCompute is the service label value for requests against Nova.
+	ServiceCompute = "compute"
+	// ServiceNetwork is the service label value for requests against Neutron.
+	ServiceNetwork = "network"
+	// ServiceDNS is the service label value for requests against Designate.
+	ServiceDNS = "dns"
+	// ServiceLoadBalancer is the service label value for requests against Octavia.
+	ServiceLoadBalancer = "load-balancer"
+	// ServiceSharedFileSystem is the service label value for requests against Manila.
+	ServiceSharedFileSystem = "shared-file-system"
+	// ServiceObjectStore is the service label value for requests against Swift.
+	ServiceObjectStore = "object-store"
+
+	unknownLabelValue = "unknown"
+)
+
+var (
+	versionRegex = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)?$`)
+
+	// apiCollections maps the collections of the OpenStack APIs used by the extension to the placeholder of the path
+	// segment following them.
+	apiCollections = map[string]string{
+		"application_credentials": "{id}",
+		"flavors":                 "{id}",
+		"floatingips":             "{id}",
+		"images":                  "{id}",
+		"listeners":               "{id}",
+		"loadbalancers":           "{id}",
+		"members":                 "{id}",
+		"networks":                "{id}",
+		"os-floating-ips":         "{id}",
+		"os-keypairs":             "{name}",
+		"os-server-groups":        "{id}",
+		"pools":                   "{id}",
+		"ports":                   "{id}",
+		"projects":                "{id}",
+		"recordsets":              "{id}",
+		"routers":                 "{id}",
+		"security-group-rules":    "{id}",
+		"security-groups":         "{id}",
+		"servers":                 "{id}",
+		"share-networks":          "{id}",
+		"subnetpools":             "{id}",
+		"subnets":                 "{id}",
+		"tags":                    "{tag}",
+		"users":                   "{id}",
+		"zones":                   "{id}",
+	}
+	// apiActions are the path segments of the OpenStack APIs used by the extension which are no identifiers, even if
+	// they follow a collection.
+	apiActions = sets.New(
+		"action",
+		"add_router_interface",
+		"add_routes",
+		"auth",
+		"cascade",
+		"detail",
+		"extensions",
+		"lbaas",
+		"os-availability-zone",
+		"remove_router_interface",
+		"remove_routes",
+		"status",
+		"tokens",
+	)
+
+	metricLabels = []string{"controller", "service", "region", "operation", "code"}
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "requests_total",
+		Help:      "Total number of requests sent to the OpenStack API.",
+	}, metricLabels)
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests sent to the OpenStack API until the response headers are received.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, metricLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, requestDuration)
+}
+
+// serviceEndpoint describes the OpenStack service behind an endpoint URL.
+type serviceEndpoint struct {
+	baseURL string
+	service string
+	region  string
+}
+
+// serviceEndpoints maps request URLs to the OpenStack services known to a Factory.
+type serviceEndpoints struct {
+	lock      sync.RWMutex
+	endpoints []serviceEndpoint
+}
+
+// register adds the endpoint for the given service. Longer base URLs take precedence on lookup.
+func (s *serviceEndpoints) register(service, region, baseURL string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, ep := range s.endpoints {
+		if ep.baseURL == baseURL {
+			return
+		}
+	}
+	s.endpoints = append(s.endpoints, serviceEndpoint{baseURL: baseURL, service: service, region: region})
+	sort.SliceStable(s.endpoints, func(i, j int) bool {
+		return len(s.endpoints[i].baseURL) > len(s.endpoints[j].baseURL)
+	})
+}
+
+// lookup returns the service endpoint for the given URL and the URL path relative to it.
+func (s *serviceEndpoints) lookup(url string) (*serviceEndpoint, string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for i := range s.endpoints {
+		ep := &s.endpoints[i]
+		if strings.HasPrefix(url, ep.baseURL) {
+			return ep, strings.TrimPrefix(url[len(ep.baseURL):], "/")
+		}
+	}
+	return nil, ""
+}
+
+// metricsRoundTripper records the number and the latency of all request attempts.
+type metricsRoundTripper struct {
+	next       http.RoundTripper
+	controller string
+	endpoints  *serviceEndpoints
+}
+
+func (m *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := m.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	service, region, operation := unknownLabelValue, "", req.Method
+	url := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+	if ep, path := m.endpoints.lookup(url); ep != nil {
+		service, region = ep.service, ep.region
+		operation = req.Method + " " + operationPath(service, path)
+	}
+
+	controller := m.controller
+	if controller == "" {
+		controller = unknownLabelValue
+	}
+	requestsTotal.WithLabelValues(controller, service, region, operation, code).Inc()
+	requestDuration.WithLabelValues(controller, service, region, operation, code).Observe(duration.Seconds())
+	return resp, err
+}
+
+// operationPath returns a path template for the given path relative to the service endpoint.
+// Every segment following a known collection is replaced by a placeholder, as are all segments which are neither a
+// version, a collection nor an action, to keep the cardinality of the metrics low.
+func operationPath(service, path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	if service == ServiceObjectStore {
+		// Swift URLs consist of container and object names only
+		if len(segments) == 1 {
+			return "{container}"
+		}
+		return "{container}/{object}"
+	}
+	template := make([]string, len(segments))
+	for i, segment := range segments {
+		if i > 0 {
+			if placeholder, ok := apiCollections[segments[i-1]]; ok && !apiActions.Has(segment) {
+				template[i] = placeholder
+				continue
+			}
+		}
+		if _, ok := apiCollections[segment]; ok || apiActions.Has(segment) || versionRegex.MatchString(segment) {
+			template[i] = segment
+			continue
+		}
+		template[i] = "{id}"
+	}
+	return strings.Join(template, "/")
+}
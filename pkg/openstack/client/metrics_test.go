@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Metrics", func() {
+	DescribeTable("#operationPath",
+		func(service, path, expected string) {
+			Expect(operationPath(service, path)).To(Equal(expected))
+		},
+		Entry("root", ServiceIdentity, "", "/"),
+		Entry("keystone token", ServiceIdentity, "v3/auth/tokens", "v3/auth/tokens"),
+		Entry("neutron collection", ServiceNetwork, "v2.0/networks", "v2.0/networks"),
+		Entry("neutron resource", ServiceNetwork, "v2.0/routers/8f2a7e3c-6e0f-4a8e-9d6c-4f7f0f3b1c2d/add_router_interface", "v2.0/routers/{id}/add_router_interface"),
+		Entry("nova keypair", ServiceCompute, "os-keypairs/shoot--foo--bar", "os-keypairs/{name}"),
+		Entry("nova server action", ServiceCompute, "servers/1234/action", "servers/{id}/action"),
+		Entry("nova server list", ServiceCompute, "servers/detail", "servers/detail"),
+		Entry("octavia load balancer", ServiceLoadBalancer, "v2/lbaas/loadbalancers/lb/status", "v2/lbaas/loadbalancers/{id}/status"),
+		Entry("neutron resource without digits", ServiceNetwork, "v2.0/networks/foo", "v2.0/networks/{id}"),
+		Entry("neutron tag", ServiceNetwork, "v2.0/networks/foo/tags/gardener-shoot=shoot--foo--bar", "v2.0/networks/{id}/tags/{tag}"),
+		Entry("keystone application credential", ServiceIdentity, "v3/users/abc/application_credentials/def", "v3/users/{id}/application_credentials/{id}"),
+		Entry("unknown segments", ServiceDNS, "v2/unknown/foo", "v2/{id}/{id}"),
+		Entry("swift container", ServiceObjectStore, "my-bucket", "{container}"),
+		Entry("swift object", ServiceObjectStore, "my-bucket/some/object", "{container}/{object}"),
+	)
+
+	Describe("#metricsRoundTripper", func() {
+		var (
+			server    *httptest.Server
+			endpoints *serviceEndpoints
+			client    *http.Client
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/network/v2.0/ports" {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			endpoints = &serviceEndpoints{}
+			endpoints.register(ServiceIdentity, "", server.URL+"/")
+			endpoints.register(ServiceNetwork, "eu-1", server.URL+"/network/")
+			client = &http.Client{Transport: newRoundTripper(http.DefaultTransport, &FactoryOptions{Controller: "test"}, endpoints)}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should record requests by service, region and operation", func() {
+			networks := requestsTotal.WithLabelValues("test", ServiceNetwork, "eu-1", "GET v2.0/networks/{id}", "200")
+			ports := requestsTotal.WithLabelValues("test", ServiceNetwork, "eu-1", "GET v2.0/ports", "429")
+			tokens := requestsTotal.WithLabelValues("test", ServiceIdentity, "", "GET v3/auth/tokens", "200")
+			networksBefore, portsBefore, tokensBefore := testutil.ToFloat64(networks), testutil.ToFloat64(ports), testutil.ToFloat64(tokens)
+
+			for _, path := range []string{"/network/v2.0/networks/net-1", "/network/v2.0/ports", "/v3/auth/tokens"} {
+				resp, err := client.Get(server.URL + path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Body.Close()).To(Succeed())
+			}
+
+			Expect(testutil.ToFloat64(networks) - networksBefore).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(ports) - portsBefore).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(tokens) - tokensBefore).To(Equal(float64(1)))
+		})
+
+		It("should label requests to unknown endpoints", func() {
+			unknown := requestsTotal.WithLabelValues(unknownLabelValue, unknownLabelValue, "", "GET", "200")
+			before := testutil.ToFloat64(unknown)
+
+			client.Transport = newRoundTripper(http.DefaultTransport, &FactoryOptions{}, &serviceEndpoints{})
+			resp, err := client.Get(server.URL + "/foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+
+			Expect(testutil.ToFloat64(unknown) - before).To(Equal(float64(1)))
+		})
+	})
+})
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client (interfaces: Factory,FactoryFactory,Compute,DNS,Networking,Loadbalancing,SharedFilesystem)
+// Source: github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client (interfaces: Factory,FactoryFactory,Compute,DNS,Networking,Loadbalancing,SharedFilesystem,Identity)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/client_mocks.go -package=mocks . Factory,FactoryFactory,Compute,DNS,Networking,Loadbalancing,SharedFilesystem
+//	mockgen -destination=mocks/client_mocks.go -package=mocks . Factory,FactoryFactory,Compute,DNS,Networking,Loadbalancing,SharedFilesystem,Identity
 //
 
 // Package mocks is a generated GoMock package.
@@ -20,6 +20,7 @@ import (
 	servergroups "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
 	images "github.com/gophercloud/gophercloud/openstack/compute/v2/images"
 	servers "github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	applicationcredentials "github.com/gophercloud/gophercloud/openstack/identity/v3/applicationcredentials"
 	loadbalancers "github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
 	floatingips0 "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	routers "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
@@ -93,6 +94,25 @@ func (mr *MockFactoryMockRecorder) DNS(arg0 ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DNS", reflect.TypeOf((*MockFactory)(nil).DNS), arg0...)
 }
 
+// Identity mocks base method.
+func (m *MockFactory) Identity(arg0 ...client.Option) (client.Identity, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Identity", varargs...)
+	ret0, _ := ret[0].(client.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Identity indicates an expected call of Identity.
+func (mr *MockFactoryMockRecorder) Identity(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Identity", reflect.TypeOf((*MockFactory)(nil).Identity), arg0...)
+}
+
 // Loadbalancing mocks base method.
 func (m *MockFactory) Loadbalancing(arg0 ...client.Option) (client.Loadbalancing, error) {
 	m.ctrl.T.Helper()
@@ -193,18 +213,23 @@ func (m *MockFactoryFactory) EXPECT() *MockFactoryFactoryMockRecorder {
 }
 
 // NewFactory mocks base method.
-func (m *MockFactoryFactory) NewFactory(arg0 *openstack.Credentials) (client.Factory, error) {
+func (m *MockFactoryFactory) NewFactory(arg0 *openstack.Credentials, arg1 ...client.FactoryOption) (client.Factory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewFactory", arg0)
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "NewFactory", varargs...)
 	ret0, _ := ret[0].(client.Factory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // NewFactory indicates an expected call of NewFactory.
-func (mr *MockFactoryFactoryMockRecorder) NewFactory(arg0 any) *gomock.Call {
+func (mr *MockFactoryFactoryMockRecorder) NewFactory(arg0 any, arg1 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewFactory", reflect.TypeOf((*MockFactoryFactory)(nil).NewFactory), arg0)
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewFactory", reflect.TypeOf((*MockFactoryFactory)(nil).NewFactory), varargs...)
 }
 
 // MockCompute is a mock of Compute interface.
@@ -231,239 +256,269 @@ func (m *MockCompute) EXPECT() *MockComputeMockRecorder {
 }
 
 // AssociateFIPWithInstance mocks base method.
-func (m *MockCompute) AssociateFIPWithInstance(arg0 string, arg1 floatingips.AssociateOpts) error {
+func (m *MockCompute) AssociateFIPWithInstance(arg0 context.Context, arg1 string, arg2 floatingips.AssociateOpts) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssociateFIPWithInstance", arg0, arg1)
+	ret := m.ctrl.Call(m, "AssociateFIPWithInstance", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AssociateFIPWithInstance indicates an expected call of AssociateFIPWithInstance.
-func (mr *MockComputeMockRecorder) AssociateFIPWithInstance(arg0, arg1 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) AssociateFIPWithInstance(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateFIPWithInstance", reflect.TypeOf((*MockCompute)(nil).AssociateFIPWithInstance), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssociateFIPWithInstance", reflect.TypeOf((*MockCompute)(nil).AssociateFIPWithInstance), arg0, arg1, arg2)
 }
 
 // CreateKeyPair mocks base method.
-func (m *MockCompute) CreateKeyPair(arg0, arg1 string) (*keypairs.KeyPair, error) {
+func (m *MockCompute) CreateKeyPair(arg0 context.Context, arg1, arg2 string) (*keypairs.KeyPair, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateKeyPair", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateKeyPair", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*keypairs.KeyPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateKeyPair indicates an expected call of CreateKeyPair.
-func (mr *MockComputeMockRecorder) CreateKeyPair(arg0, arg1 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) CreateKeyPair(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeyPair", reflect.TypeOf((*MockCompute)(nil).CreateKeyPair), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeyPair", reflect.TypeOf((*MockCompute)(nil).CreateKeyPair), arg0, arg1, arg2)
 }
 
 // CreateServer mocks base method.
-func (m *MockCompute) CreateServer(arg0 servers.CreateOpts) (*servers.Server, error) {
+func (m *MockCompute) CreateServer(arg0 context.Context, arg1 servers.CreateOpts) (*servers.Server, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateServer", arg0)
+	ret := m.ctrl.Call(m, "CreateServer", arg0, arg1)
 	ret0, _ := ret[0].(*servers.Server)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateServer indicates an expected call of CreateServer.
-func (mr *MockComputeMockRecorder) CreateServer(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) CreateServer(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServer", reflect.TypeOf((*MockCompute)(nil).CreateServer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServer", reflect.TypeOf((*MockCompute)(nil).CreateServer), arg0, arg1)
 }
 
 // CreateServerGroup mocks base method.
-func (m *MockCompute) CreateServerGroup(arg0, arg1 string) (*servergroups.ServerGroup, error) {
+func (m *MockCompute) CreateServerGroup(arg0 context.Context, arg1, arg2 string) (*servergroups.ServerGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateServerGroup", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateServerGroup", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*servergroups.ServerGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateServerGroup indicates an expected call of CreateServerGroup.
-func (mr *MockComputeMockRecorder) CreateServerGroup(arg0, arg1 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) CreateServerGroup(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServerGroup", reflect.TypeOf((*MockCompute)(nil).CreateServerGroup), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServerGroup", reflect.TypeOf((*MockCompute)(nil).CreateServerGroup), arg0, arg1, arg2)
 }
 
 // DeleteKeyPair mocks base method.
-func (m *MockCompute) DeleteKeyPair(arg0 string) error {
+func (m *MockCompute) DeleteKeyPair(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteKeyPair", arg0)
+	ret := m.ctrl.Call(m, "DeleteKeyPair", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteKeyPair indicates an expected call of DeleteKeyPair.
-func (mr *MockComputeMockRecorder) DeleteKeyPair(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) DeleteKeyPair(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeyPair", reflect.TypeOf((*MockCompute)(nil).DeleteKeyPair), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeyPair", reflect.TypeOf((*MockCompute)(nil).DeleteKeyPair), arg0, arg1)
 }
 
 // DeleteServer mocks base method.
-func (m *MockCompute) DeleteServer(arg0 string) error {
+func (m *MockCompute) DeleteServer(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteServer", arg0)
+	ret := m.ctrl.Call(m, "DeleteServer", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteServer indicates an expected call of DeleteServer.
-func (mr *MockComputeMockRecorder) DeleteServer(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) DeleteServer(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServer", reflect.TypeOf((*MockCompute)(nil).DeleteServer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServer", reflect.TypeOf((*MockCompute)(nil).DeleteServer), arg0, arg1)
 }
 
 // DeleteServerGroup mocks base method.
-func (m *MockCompute) DeleteServerGroup(arg0 string) error {
+func (m *MockCompute) DeleteServerGroup(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteServerGroup", arg0)
+	ret := m.ctrl.Call(m, "DeleteServerGroup", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteServerGroup indicates an expected call of DeleteServerGroup.
-func (mr *MockComputeMockRecorder) DeleteServerGroup(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) DeleteServerGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServerGroup", reflect.TypeOf((*MockCompute)(nil).DeleteServerGroup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServerGroup", reflect.TypeOf((*MockCompute)(nil).DeleteServerGroup), arg0, arg1)
 }
 
 // FindFlavorID mocks base method.
-func (m *MockCompute) FindFlavorID(arg0 string) (string, error) {
+func (m *MockCompute) FindFlavorID(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindFlavorID", arg0)
+	ret := m.ctrl.Call(m, "FindFlavorID", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindFlavorID indicates an expected call of FindFlavorID.
-func (mr *MockComputeMockRecorder) FindFlavorID(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) FindFlavorID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFlavorID", reflect.TypeOf((*MockCompute)(nil).FindFlavorID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFlavorID", reflect.TypeOf((*MockCompute)(nil).FindFlavorID), arg0, arg1)
 }
 
 // FindFloatingIDByInstanceID mocks base method.
-func (m *MockCompute) FindFloatingIDByInstanceID(arg0 string) (string, error) {
+func (m *MockCompute) FindFloatingIDByInstanceID(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindFloatingIDByInstanceID", arg0)
+	ret := m.ctrl.Call(m, "FindFloatingIDByInstanceID", arg0, arg1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindFloatingIDByInstanceID indicates an expected call of FindFloatingIDByInstanceID.
-func (mr *MockComputeMockRecorder) FindFloatingIDByInstanceID(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) FindFloatingIDByInstanceID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFloatingIDByInstanceID", reflect.TypeOf((*MockCompute)(nil).FindFloatingIDByInstanceID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFloatingIDByInstanceID", reflect.TypeOf((*MockCompute)(nil).FindFloatingIDByInstanceID), arg0, arg1)
 }
 
 // FindImageByID mocks base method.
-func (m *MockCompute) FindImageByID(arg0 string) (*images.Image, error) {
+func (m *MockCompute) FindImageByID(arg0 context.Context, arg1 string) (*images.Image, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindImageByID", arg0)
+	ret := m.ctrl.Call(m, "FindImageByID", arg0, arg1)
 	ret0, _ := ret[0].(*images.Image)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindImageByID indicates an expected call of FindImageByID.
-func (mr *MockComputeMockRecorder) FindImageByID(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) FindImageByID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImageByID", reflect.TypeOf((*MockCompute)(nil).FindImageByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImageByID", reflect.TypeOf((*MockCompute)(nil).FindImageByID), arg0, arg1)
 }
 
 // FindImages mocks base method.
-func (m *MockCompute) FindImages(arg0 string) ([]images.Image, error) {
+func (m *MockCompute) FindImages(arg0 context.Context, arg1 string) ([]images.Image, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindImages", arg0)
+	ret := m.ctrl.Call(m, "FindImages", arg0, arg1)
 	ret0, _ := ret[0].([]images.Image)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindImages indicates an expected call of FindImages.
-func (mr *MockComputeMockRecorder) FindImages(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) FindImages(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImages", reflect.TypeOf((*MockCompute)(nil).FindImages), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImages", reflect.TypeOf((*MockCompute)(nil).FindImages), arg0, arg1)
 }
 
 // FindServersByName mocks base method.
-func (m *MockCompute) FindServersByName(arg0 string) ([]servers.Server, error) {
+func (m *MockCompute) FindServersByName(arg0 context.Context, arg1 string) ([]servers.Server, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindServersByName", arg0)
+	ret := m.ctrl.Call(m, "FindServersByName", arg0, arg1)
 	ret0, _ := ret[0].([]servers.Server)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindServersByName indicates an expected call of FindServersByName.
-func (mr *MockComputeMockRecorder) FindServersByName(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) FindServersByName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServersByName", reflect.TypeOf((*MockCompute)(nil).FindServersByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServersByName", reflect.TypeOf((*MockCompute)(nil).FindServersByName), arg0, arg1)
 }
 
 // GetKeyPair mocks base method.
-func (m *MockCompute) GetKeyPair(arg0 string) (*keypairs.KeyPair, error) {
+func (m *MockCompute) GetKeyPair(arg0 context.Context, arg1 string) (*keypairs.KeyPair, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKeyPair", arg0)
+	ret := m.ctrl.Call(m, "GetKeyPair", arg0, arg1)
 	ret0, _ := ret[0].(*keypairs.KeyPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetKeyPair indicates an expected call of GetKeyPair.
-func (mr *MockComputeMockRecorder) GetKeyPair(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) GetKeyPair(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyPair", reflect.TypeOf((*MockCompute)(nil).GetKeyPair), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyPair", reflect.TypeOf((*MockCompute)(nil).GetKeyPair), arg0, arg1)
 }
 
 // GetServerGroup mocks base method.
-func (m *MockCompute) GetServerGroup(arg0 string) (*servergroups.ServerGroup, error) {
+func (m *MockCompute) GetServerGroup(arg0 context.Context, arg1 string) (*servergroups.ServerGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetServerGroup", arg0)
+	ret := m.ctrl.Call(m, "GetServerGroup", arg0, arg1)
 	ret0, _ := ret[0].(*servergroups.ServerGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServerGroup indicates an expected call of GetServerGroup.
-func (mr *MockComputeMockRecorder) GetServerGroup(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) GetServerGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerGroup", reflect.TypeOf((*MockCompute)(nil).GetServerGroup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerGroup", reflect.TypeOf((*MockCompute)(nil).GetServerGroup), arg0, arg1)
 }
 
 // ListImages mocks base method.
-func (m *MockCompute) ListImages(arg0 images.ListOpts) ([]images.Image, error) {
+func (m *MockCompute) ListImages(arg0 context.Context, arg1 images.ListOpts) ([]images.Image, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListImages", arg0)
+	ret := m.ctrl.Call(m, "ListImages", arg0, arg1)
 	ret0, _ := ret[0].([]images.Image)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListImages indicates an expected call of ListImages.
-func (mr *MockComputeMockRecorder) ListImages(arg0 any) *gomock.Call {
+func (mr *MockComputeMockRecorder) ListImages(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockCompute)(nil).ListImages), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockCompute)(nil).ListImages), arg0, arg1)
+}
+
+// ListKeyPairs mocks base method.
+func (m *MockCompute) ListKeyPairs(arg0 context.Context) ([]keypairs.KeyPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeyPairs", arg0)
+	ret0, _ := ret[0].([]keypairs.KeyPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeyPairs indicates an expected call of ListKeyPairs.
+func (mr *MockComputeMockRecorder) ListKeyPairs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeyPairs", reflect.TypeOf((*MockCompute)(nil).ListKeyPairs), arg0)
 }
 
 // ListServerGroups mocks base method.
-func (m *MockCompute) ListServerGroups() ([]servergroups.ServerGroup, error) {
+func (m *MockCompute) ListServerGroups(arg0 context.Context) ([]servergroups.ServerGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListServerGroups")
+	ret := m.ctrl.Call(m, "ListServerGroups", arg0)
 	ret0, _ := ret[0].([]servergroups.ServerGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListServerGroups indicates an expected call of ListServerGroups.
-func (mr *MockComputeMockRecorder) ListServerGroups() *gomock.Call {
+func (mr *MockComputeMockRecorder) ListServerGroups(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServerGroups", reflect.TypeOf((*MockCompute)(nil).ListServerGroups))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServerGroups", reflect.TypeOf((*MockCompute)(nil).ListServerGroups), arg0)
+}
+
+// ListServers mocks base method.
+func (m *MockCompute) ListServers(arg0 context.Context, arg1 servers.ListOpts) ([]servers.Server, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServers", arg0, arg1)
+	ret0, _ := ret[0].([]servers.Server)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServers indicates an expected call of ListServers.
+func (mr *MockComputeMockRecorder) ListServers(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServers", reflect.TypeOf((*MockCompute)(nil).ListServers), arg0, arg1)
 }
 
 // MockDNS is a mock of DNS interface.
@@ -556,207 +611,221 @@ func (m *MockNetworking) EXPECT() *MockNetworkingMockRecorder {
 }
 
 // AddRouterInterface mocks base method.
-func (m *MockNetworking) AddRouterInterface(arg0 string, arg1 routers.AddInterfaceOpts) (*routers.InterfaceInfo, error) {
+func (m *MockNetworking) AddRouterInterface(arg0 context.Context, arg1 string, arg2 routers.AddInterfaceOpts) (*routers.InterfaceInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddRouterInterface", arg0, arg1)
+	ret := m.ctrl.Call(m, "AddRouterInterface", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*routers.InterfaceInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddRouterInterface indicates an expected call of AddRouterInterface.
-func (mr *MockNetworkingMockRecorder) AddRouterInterface(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) AddRouterInterface(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRouterInterface", reflect.TypeOf((*MockNetworking)(nil).AddRouterInterface), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRouterInterface", reflect.TypeOf((*MockNetworking)(nil).AddRouterInterface), arg0, arg1, arg2)
 }
 
 // CreateFloatingIP mocks base method.
-func (m *MockNetworking) CreateFloatingIP(arg0 floatingips0.CreateOpts) (*floatingips0.FloatingIP, error) {
+func (m *MockNetworking) CreateFloatingIP(arg0 context.Context, arg1 floatingips0.CreateOpts) (*floatingips0.FloatingIP, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFloatingIP", arg0)
+	ret := m.ctrl.Call(m, "CreateFloatingIP", arg0, arg1)
 	ret0, _ := ret[0].(*floatingips0.FloatingIP)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateFloatingIP indicates an expected call of CreateFloatingIP.
-func (mr *MockNetworkingMockRecorder) CreateFloatingIP(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) CreateFloatingIP(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFloatingIP", reflect.TypeOf((*MockNetworking)(nil).CreateFloatingIP), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFloatingIP", reflect.TypeOf((*MockNetworking)(nil).CreateFloatingIP), arg0, arg1)
 }
 
 // CreateNetwork mocks base method.
-func (m *MockNetworking) CreateNetwork(arg0 networks.CreateOpts) (*networks.Network, error) {
+func (m *MockNetworking) CreateNetwork(arg0 context.Context, arg1 networks.CreateOpts) (*networks.Network, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNetwork", arg0)
+	ret := m.ctrl.Call(m, "CreateNetwork", arg0, arg1)
 	ret0, _ := ret[0].(*networks.Network)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNetwork indicates an expected call of CreateNetwork.
-func (mr *MockNetworkingMockRecorder) CreateNetwork(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) CreateNetwork(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetwork", reflect.TypeOf((*MockNetworking)(nil).CreateNetwork), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetwork", reflect.TypeOf((*MockNetworking)(nil).CreateNetwork), arg0, arg1)
 }
 
 // CreateRouter mocks base method.
-func (m *MockNetworking) CreateRouter(arg0 routers.CreateOpts) (*routers.Router, error) {
+func (m *MockNetworking) CreateRouter(arg0 context.Context, arg1 routers.CreateOpts) (*routers.Router, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRouter", arg0)
+	ret := m.ctrl.Call(m, "CreateRouter", arg0, arg1)
 	ret0, _ := ret[0].(*routers.Router)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRouter indicates an expected call of CreateRouter.
-func (mr *MockNetworkingMockRecorder) CreateRouter(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) CreateRouter(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRouter", reflect.TypeOf((*MockNetworking)(nil).CreateRouter), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRouter", reflect.TypeOf((*MockNetworking)(nil).CreateRouter), arg0, arg1)
 }
 
 // CreateRule mocks base method.
-func (m *MockNetworking) CreateRule(arg0 rules.CreateOpts) (*rules.SecGroupRule, error) {
+func (m *MockNetworking) CreateRule(arg0 context.Context, arg1 rules.CreateOpts) (*rules.SecGroupRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRule", arg0)
+	ret := m.ctrl.Call(m, "CreateRule", arg0, arg1)
 	ret0, _ := ret[0].(*rules.SecGroupRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRule indicates an expected call of CreateRule.
-func (mr *MockNetworkingMockRecorder) CreateRule(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) CreateRule(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockNetworking)(nil).CreateRule), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockNetworking)(nil).CreateRule), arg0, arg1)
 }
 
 // CreateSecurityGroup mocks base method.
-func (m *MockNetworking) CreateSecurityGroup(arg0 groups.CreateOpts) (*groups.SecGroup, error) {
+func (m *MockNetworking) CreateSecurityGroup(arg0 context.Context, arg1 groups.CreateOpts) (*groups.SecGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSecurityGroup", arg0)
+	ret := m.ctrl.Call(m, "CreateSecurityGroup", arg0, arg1)
 	ret0, _ := ret[0].(*groups.SecGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSecurityGroup indicates an expected call of CreateSecurityGroup.
-func (mr *MockNetworkingMockRecorder) CreateSecurityGroup(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) CreateSecurityGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).CreateSecurityGroup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).CreateSecurityGroup), arg0, arg1)
 }
 
 // CreateSubnet mocks base method.
-func (m *MockNetworking) CreateSubnet(arg0 subnets.CreateOpts) (*subnets.Subnet, error) {
+func (m *MockNetworking) CreateSubnet(arg0 context.Context, arg1 subnets.CreateOpts) (*subnets.Subnet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubnet", arg0)
+	ret := m.ctrl.Call(m, "CreateSubnet", arg0, arg1)
 	ret0, _ := ret[0].(*subnets.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSubnet indicates an expected call of CreateSubnet.
-func (mr *MockNetworkingMockRecorder) CreateSubnet(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) CreateSubnet(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockNetworking)(nil).CreateSubnet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubnet", reflect.TypeOf((*MockNetworking)(nil).CreateSubnet), arg0, arg1)
 }
 
 // DeleteFloatingIP mocks base method.
-func (m *MockNetworking) DeleteFloatingIP(arg0 string) error {
+func (m *MockNetworking) DeleteFloatingIP(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFloatingIP", arg0)
+	ret := m.ctrl.Call(m, "DeleteFloatingIP", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFloatingIP indicates an expected call of DeleteFloatingIP.
-func (mr *MockNetworkingMockRecorder) DeleteFloatingIP(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) DeleteFloatingIP(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFloatingIP", reflect.TypeOf((*MockNetworking)(nil).DeleteFloatingIP), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFloatingIP", reflect.TypeOf((*MockNetworking)(nil).DeleteFloatingIP), arg0, arg1)
 }
 
 // DeleteNetwork mocks base method.
-func (m *MockNetworking) DeleteNetwork(arg0 string) error {
+func (m *MockNetworking) DeleteNetwork(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNetwork", arg0)
+	ret := m.ctrl.Call(m, "DeleteNetwork", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNetwork indicates an expected call of DeleteNetwork.
-func (mr *MockNetworkingMockRecorder) DeleteNetwork(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) DeleteNetwork(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetwork", reflect.TypeOf((*MockNetworking)(nil).DeleteNetwork), arg0, arg1)
+}
+
+// DeletePort mocks base method.
+func (m *MockNetworking) DeletePort(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePort", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePort indicates an expected call of DeletePort.
+func (mr *MockNetworkingMockRecorder) DeletePort(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetwork", reflect.TypeOf((*MockNetworking)(nil).DeleteNetwork), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePort", reflect.TypeOf((*MockNetworking)(nil).DeletePort), arg0, arg1)
 }
 
 // DeleteRouter mocks base method.
-func (m *MockNetworking) DeleteRouter(arg0 string) error {
+func (m *MockNetworking) DeleteRouter(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRouter", arg0)
+	ret := m.ctrl.Call(m, "DeleteRouter", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRouter indicates an expected call of DeleteRouter.
-func (mr *MockNetworkingMockRecorder) DeleteRouter(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) DeleteRouter(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRouter", reflect.TypeOf((*MockNetworking)(nil).DeleteRouter), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRouter", reflect.TypeOf((*MockNetworking)(nil).DeleteRouter), arg0, arg1)
 }
 
 // DeleteRule mocks base method.
-func (m *MockNetworking) DeleteRule(arg0 string) error {
+func (m *MockNetworking) DeleteRule(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRule", arg0)
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRule indicates an expected call of DeleteRule.
-func (mr *MockNetworkingMockRecorder) DeleteRule(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) DeleteRule(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockNetworking)(nil).DeleteRule), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockNetworking)(nil).DeleteRule), arg0, arg1)
 }
 
 // DeleteSecurityGroup mocks base method.
-func (m *MockNetworking) DeleteSecurityGroup(arg0 string) error {
+func (m *MockNetworking) DeleteSecurityGroup(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSecurityGroup", arg0)
+	ret := m.ctrl.Call(m, "DeleteSecurityGroup", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSecurityGroup indicates an expected call of DeleteSecurityGroup.
-func (mr *MockNetworkingMockRecorder) DeleteSecurityGroup(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) DeleteSecurityGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).DeleteSecurityGroup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).DeleteSecurityGroup), arg0, arg1)
 }
 
 // DeleteSubnet mocks base method.
-func (m *MockNetworking) DeleteSubnet(arg0 string) error {
+func (m *MockNetworking) DeleteSubnet(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSubnet", arg0)
+	ret := m.ctrl.Call(m, "DeleteSubnet", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSubnet indicates an expected call of DeleteSubnet.
-func (mr *MockNetworkingMockRecorder) DeleteSubnet(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) DeleteSubnet(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockNetworking)(nil).DeleteSubnet), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubnet", reflect.TypeOf((*MockNetworking)(nil).DeleteSubnet), arg0, arg1)
 }
 
 // GetExternalNetworkByName mocks base method.
-func (m *MockNetworking) GetExternalNetworkByName(arg0 string) (*networks.Network, error) {
+func (m *MockNetworking) GetExternalNetworkByName(arg0 context.Context, arg1 string) (*networks.Network, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExternalNetworkByName", arg0)
+	ret := m.ctrl.Call(m, "GetExternalNetworkByName", arg0, arg1)
 	ret0, _ := ret[0].(*networks.Network)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetExternalNetworkByName indicates an expected call of GetExternalNetworkByName.
-func (mr *MockNetworkingMockRecorder) GetExternalNetworkByName(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetExternalNetworkByName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalNetworkByName", reflect.TypeOf((*MockNetworking)(nil).GetExternalNetworkByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalNetworkByName", reflect.TypeOf((*MockNetworking)(nil).GetExternalNetworkByName), arg0, arg1)
 }
 
 // GetExternalNetworkNames mocks base method.
@@ -775,273 +844,303 @@ func (mr *MockNetworkingMockRecorder) GetExternalNetworkNames(arg0 any) *gomock.
 }
 
 // GetFipByName mocks base method.
-func (m *MockNetworking) GetFipByName(arg0 string) ([]floatingips0.FloatingIP, error) {
+func (m *MockNetworking) GetFipByName(arg0 context.Context, arg1 string) ([]floatingips0.FloatingIP, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFipByName", arg0)
+	ret := m.ctrl.Call(m, "GetFipByName", arg0, arg1)
 	ret0, _ := ret[0].([]floatingips0.FloatingIP)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFipByName indicates an expected call of GetFipByName.
-func (mr *MockNetworkingMockRecorder) GetFipByName(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetFipByName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFipByName", reflect.TypeOf((*MockNetworking)(nil).GetFipByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFipByName", reflect.TypeOf((*MockNetworking)(nil).GetFipByName), arg0, arg1)
 }
 
 // GetNetworkByName mocks base method.
-func (m *MockNetworking) GetNetworkByName(arg0 string) ([]networks.Network, error) {
+func (m *MockNetworking) GetNetworkByName(arg0 context.Context, arg1 string) ([]networks.Network, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNetworkByName", arg0)
+	ret := m.ctrl.Call(m, "GetNetworkByName", arg0, arg1)
 	ret0, _ := ret[0].([]networks.Network)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNetworkByName indicates an expected call of GetNetworkByName.
-func (mr *MockNetworkingMockRecorder) GetNetworkByName(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetNetworkByName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkByName", reflect.TypeOf((*MockNetworking)(nil).GetNetworkByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkByName", reflect.TypeOf((*MockNetworking)(nil).GetNetworkByName), arg0, arg1)
 }
 
 // GetPort mocks base method.
-func (m *MockNetworking) GetPort(arg0 string) (*ports.Port, error) {
+func (m *MockNetworking) GetPort(arg0 context.Context, arg1 string) (*ports.Port, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPort", arg0)
+	ret := m.ctrl.Call(m, "GetPort", arg0, arg1)
 	ret0, _ := ret[0].(*ports.Port)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPort indicates an expected call of GetPort.
-func (mr *MockNetworkingMockRecorder) GetPort(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetPort(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPort", reflect.TypeOf((*MockNetworking)(nil).GetPort), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPort", reflect.TypeOf((*MockNetworking)(nil).GetPort), arg0, arg1)
 }
 
 // GetRouterByID mocks base method.
-func (m *MockNetworking) GetRouterByID(arg0 string) (*routers.Router, error) {
+func (m *MockNetworking) GetRouterByID(arg0 context.Context, arg1 string) (*routers.Router, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRouterByID", arg0)
+	ret := m.ctrl.Call(m, "GetRouterByID", arg0, arg1)
 	ret0, _ := ret[0].(*routers.Router)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRouterByID indicates an expected call of GetRouterByID.
-func (mr *MockNetworkingMockRecorder) GetRouterByID(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetRouterByID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterByID", reflect.TypeOf((*MockNetworking)(nil).GetRouterByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterByID", reflect.TypeOf((*MockNetworking)(nil).GetRouterByID), arg0, arg1)
 }
 
 // GetRouterInterfacePort mocks base method.
-func (m *MockNetworking) GetRouterInterfacePort(arg0, arg1 string) (*ports.Port, error) {
+func (m *MockNetworking) GetRouterInterfacePort(arg0 context.Context, arg1, arg2 string) (*ports.Port, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRouterInterfacePort", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetRouterInterfacePort", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*ports.Port)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRouterInterfacePort indicates an expected call of GetRouterInterfacePort.
-func (mr *MockNetworkingMockRecorder) GetRouterInterfacePort(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetRouterInterfacePort(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterInterfacePort", reflect.TypeOf((*MockNetworking)(nil).GetRouterInterfacePort), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterInterfacePort", reflect.TypeOf((*MockNetworking)(nil).GetRouterInterfacePort), arg0, arg1, arg2)
 }
 
 // GetSecurityGroup mocks base method.
-func (m *MockNetworking) GetSecurityGroup(arg0 string) (*groups.SecGroup, error) {
+func (m *MockNetworking) GetSecurityGroup(arg0 context.Context, arg1 string) (*groups.SecGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSecurityGroup", arg0)
+	ret := m.ctrl.Call(m, "GetSecurityGroup", arg0, arg1)
 	ret0, _ := ret[0].(*groups.SecGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSecurityGroup indicates an expected call of GetSecurityGroup.
-func (mr *MockNetworkingMockRecorder) GetSecurityGroup(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetSecurityGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).GetSecurityGroup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).GetSecurityGroup), arg0, arg1)
 }
 
 // GetSecurityGroupByName mocks base method.
-func (m *MockNetworking) GetSecurityGroupByName(arg0 string) ([]groups.SecGroup, error) {
+func (m *MockNetworking) GetSecurityGroupByName(arg0 context.Context, arg1 string) ([]groups.SecGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSecurityGroupByName", arg0)
+	ret := m.ctrl.Call(m, "GetSecurityGroupByName", arg0, arg1)
 	ret0, _ := ret[0].([]groups.SecGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSecurityGroupByName indicates an expected call of GetSecurityGroupByName.
-func (mr *MockNetworkingMockRecorder) GetSecurityGroupByName(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) GetSecurityGroupByName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupByName", reflect.TypeOf((*MockNetworking)(nil).GetSecurityGroupByName), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityGroupByName", reflect.TypeOf((*MockNetworking)(nil).GetSecurityGroupByName), arg0, arg1)
 }
 
 // ListFip mocks base method.
-func (m *MockNetworking) ListFip(arg0 floatingips0.ListOpts) ([]floatingips0.FloatingIP, error) {
+func (m *MockNetworking) ListFip(arg0 context.Context, arg1 floatingips0.ListOpts) ([]floatingips0.FloatingIP, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFip", arg0)
+	ret := m.ctrl.Call(m, "ListFip", arg0, arg1)
 	ret0, _ := ret[0].([]floatingips0.FloatingIP)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListFip indicates an expected call of ListFip.
-func (mr *MockNetworkingMockRecorder) ListFip(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) ListFip(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFip", reflect.TypeOf((*MockNetworking)(nil).ListFip), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFip", reflect.TypeOf((*MockNetworking)(nil).ListFip), arg0, arg1)
 }
 
 // ListNetwork mocks base method.
-func (m *MockNetworking) ListNetwork(arg0 networks.ListOpts) ([]networks.Network, error) {
+func (m *MockNetworking) ListNetwork(arg0 context.Context, arg1 networks.ListOpts) ([]networks.Network, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNetwork", arg0)
+	ret := m.ctrl.Call(m, "ListNetwork", arg0, arg1)
 	ret0, _ := ret[0].([]networks.Network)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListNetwork indicates an expected call of ListNetwork.
-func (mr *MockNetworkingMockRecorder) ListNetwork(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) ListNetwork(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetwork", reflect.TypeOf((*MockNetworking)(nil).ListNetwork), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetwork", reflect.TypeOf((*MockNetworking)(nil).ListNetwork), arg0, arg1)
+}
+
+// ListPorts mocks base method.
+func (m *MockNetworking) ListPorts(arg0 context.Context, arg1 ports.ListOpts) ([]ports.Port, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPorts", arg0, arg1)
+	ret0, _ := ret[0].([]ports.Port)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPorts indicates an expected call of ListPorts.
+func (mr *MockNetworkingMockRecorder) ListPorts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPorts", reflect.TypeOf((*MockNetworking)(nil).ListPorts), arg0, arg1)
 }
 
 // ListRouters mocks base method.
-func (m *MockNetworking) ListRouters(arg0 routers.ListOpts) ([]routers.Router, error) {
+func (m *MockNetworking) ListRouters(arg0 context.Context, arg1 routers.ListOpts) ([]routers.Router, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRouters", arg0)
+	ret := m.ctrl.Call(m, "ListRouters", arg0, arg1)
 	ret0, _ := ret[0].([]routers.Router)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListRouters indicates an expected call of ListRouters.
-func (mr *MockNetworkingMockRecorder) ListRouters(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) ListRouters(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouters", reflect.TypeOf((*MockNetworking)(nil).ListRouters), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRouters", reflect.TypeOf((*MockNetworking)(nil).ListRouters), arg0, arg1)
 }
 
 // ListRules mocks base method.
-func (m *MockNetworking) ListRules(arg0 rules.ListOpts) ([]rules.SecGroupRule, error) {
+func (m *MockNetworking) ListRules(arg0 context.Context, arg1 rules.ListOpts) ([]rules.SecGroupRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRules", arg0)
+	ret := m.ctrl.Call(m, "ListRules", arg0, arg1)
 	ret0, _ := ret[0].([]rules.SecGroupRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListRules indicates an expected call of ListRules.
-func (mr *MockNetworkingMockRecorder) ListRules(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) ListRules(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockNetworking)(nil).ListRules), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockNetworking)(nil).ListRules), arg0, arg1)
 }
 
 // ListSecurityGroup mocks base method.
-func (m *MockNetworking) ListSecurityGroup(arg0 groups.ListOpts) ([]groups.SecGroup, error) {
+func (m *MockNetworking) ListSecurityGroup(arg0 context.Context, arg1 groups.ListOpts) ([]groups.SecGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSecurityGroup", arg0)
+	ret := m.ctrl.Call(m, "ListSecurityGroup", arg0, arg1)
 	ret0, _ := ret[0].([]groups.SecGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListSecurityGroup indicates an expected call of ListSecurityGroup.
-func (mr *MockNetworkingMockRecorder) ListSecurityGroup(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) ListSecurityGroup(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).ListSecurityGroup), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityGroup", reflect.TypeOf((*MockNetworking)(nil).ListSecurityGroup), arg0, arg1)
 }
 
 // ListSubnets mocks base method.
-func (m *MockNetworking) ListSubnets(arg0 subnets.ListOpts) ([]subnets.Subnet, error) {
+func (m *MockNetworking) ListSubnets(arg0 context.Context, arg1 subnets.ListOpts) ([]subnets.Subnet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSubnets", arg0)
+	ret := m.ctrl.Call(m, "ListSubnets", arg0, arg1)
 	ret0, _ := ret[0].([]subnets.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListSubnets indicates an expected call of ListSubnets.
-func (mr *MockNetworkingMockRecorder) ListSubnets(arg0 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) ListSubnets(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnets", reflect.TypeOf((*MockNetworking)(nil).ListSubnets), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubnets", reflect.TypeOf((*MockNetworking)(nil).ListSubnets), arg0, arg1)
 }
 
 // RemoveRouterInterface mocks base method.
-func (m *MockNetworking) RemoveRouterInterface(arg0 string, arg1 routers.RemoveInterfaceOpts) (*routers.InterfaceInfo, error) {
+func (m *MockNetworking) RemoveRouterInterface(arg0 context.Context, arg1 string, arg2 routers.RemoveInterfaceOpts) (*routers.InterfaceInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveRouterInterface", arg0, arg1)
+	ret := m.ctrl.Call(m, "RemoveRouterInterface", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*routers.InterfaceInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveRouterInterface indicates an expected call of RemoveRouterInterface.
-func (mr *MockNetworkingMockRecorder) RemoveRouterInterface(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) RemoveRouterInterface(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRouterInterface", reflect.TypeOf((*MockNetworking)(nil).RemoveRouterInterface), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRouterInterface", reflect.TypeOf((*MockNetworking)(nil).RemoveRouterInterface), arg0, arg1, arg2)
+}
+
+// ReplaceAllTags mocks base method.
+func (m *MockNetworking) ReplaceAllTags(arg0 context.Context, arg1, arg2 string, arg3 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAllTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceAllTags indicates an expected call of ReplaceAllTags.
+func (mr *MockNetworkingMockRecorder) ReplaceAllTags(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAllTags", reflect.TypeOf((*MockNetworking)(nil).ReplaceAllTags), arg0, arg1, arg2, arg3)
 }
 
 // UpdateNetwork mocks base method.
-func (m *MockNetworking) UpdateNetwork(arg0 string, arg1 networks.UpdateOpts) (*networks.Network, error) {
+func (m *MockNetworking) UpdateNetwork(arg0 context.Context, arg1 string, arg2 networks.UpdateOpts) (*networks.Network, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNetwork", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateNetwork", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*networks.Network)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateNetwork indicates an expected call of UpdateNetwork.
-func (mr *MockNetworkingMockRecorder) UpdateNetwork(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) UpdateNetwork(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetwork", reflect.TypeOf((*MockNetworking)(nil).UpdateNetwork), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetwork", reflect.TypeOf((*MockNetworking)(nil).UpdateNetwork), arg0, arg1, arg2)
 }
 
 // UpdateRouter mocks base method.
-func (m *MockNetworking) UpdateRouter(arg0 string, arg1 routers.UpdateOpts) (*routers.Router, error) {
+func (m *MockNetworking) UpdateRouter(arg0 context.Context, arg1 string, arg2 routers.UpdateOpts) (*routers.Router, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRouter", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateRouter", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*routers.Router)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRouter indicates an expected call of UpdateRouter.
-func (mr *MockNetworkingMockRecorder) UpdateRouter(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) UpdateRouter(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRouter", reflect.TypeOf((*MockNetworking)(nil).UpdateRouter), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRouter", reflect.TypeOf((*MockNetworking)(nil).UpdateRouter), arg0, arg1, arg2)
 }
 
 // UpdateRoutesForRouter mocks base method.
-func (m *MockNetworking) UpdateRoutesForRouter(arg0 []routers.Route, arg1 string) (*routers.Router, error) {
+func (m *MockNetworking) UpdateRoutesForRouter(arg0 context.Context, arg1 []routers.Route, arg2 string) (*routers.Router, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRoutesForRouter", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateRoutesForRouter", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*routers.Router)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRoutesForRouter indicates an expected call of UpdateRoutesForRouter.
-func (mr *MockNetworkingMockRecorder) UpdateRoutesForRouter(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) UpdateRoutesForRouter(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoutesForRouter", reflect.TypeOf((*MockNetworking)(nil).UpdateRoutesForRouter), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoutesForRouter", reflect.TypeOf((*MockNetworking)(nil).UpdateRoutesForRouter), arg0, arg1, arg2)
 }
 
 // UpdateSubnet mocks base method.
-func (m *MockNetworking) UpdateSubnet(arg0 string, arg1 subnets.UpdateOpts) (*subnets.Subnet, error) {
+func (m *MockNetworking) UpdateSubnet(arg0 context.Context, arg1 string, arg2 subnets.UpdateOpts) (*subnets.Subnet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSubnet", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateSubnet", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*subnets.Subnet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateSubnet indicates an expected call of UpdateSubnet.
-func (mr *MockNetworkingMockRecorder) UpdateSubnet(arg0, arg1 any) *gomock.Call {
+func (mr *MockNetworkingMockRecorder) UpdateSubnet(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnet", reflect.TypeOf((*MockNetworking)(nil).UpdateSubnet), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnet", reflect.TypeOf((*MockNetworking)(nil).UpdateSubnet), arg0, arg1, arg2)
 }
 
 // MockLoadbalancing is a mock of Loadbalancing interface.
@@ -1068,47 +1167,47 @@ func (m *MockLoadbalancing) EXPECT() *MockLoadbalancingMockRecorder {
 }
 
 // DeleteLoadbalancer mocks base method.
-func (m *MockLoadbalancing) DeleteLoadbalancer(arg0 string, arg1 loadbalancers.DeleteOpts) error {
+func (m *MockLoadbalancing) DeleteLoadbalancer(arg0 context.Context, arg1 string, arg2 loadbalancers.DeleteOpts) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLoadbalancer", arg0, arg1)
+	ret := m.ctrl.Call(m, "DeleteLoadbalancer", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteLoadbalancer indicates an expected call of DeleteLoadbalancer.
-func (mr *MockLoadbalancingMockRecorder) DeleteLoadbalancer(arg0, arg1 any) *gomock.Call {
+func (mr *MockLoadbalancingMockRecorder) DeleteLoadbalancer(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadbalancer", reflect.TypeOf((*MockLoadbalancing)(nil).DeleteLoadbalancer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadbalancer", reflect.TypeOf((*MockLoadbalancing)(nil).DeleteLoadbalancer), arg0, arg1, arg2)
 }
 
 // GetLoadbalancer mocks base method.
-func (m *MockLoadbalancing) GetLoadbalancer(arg0 string) (*loadbalancers.LoadBalancer, error) {
+func (m *MockLoadbalancing) GetLoadbalancer(arg0 context.Context, arg1 string) (*loadbalancers.LoadBalancer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLoadbalancer", arg0)
+	ret := m.ctrl.Call(m, "GetLoadbalancer", arg0, arg1)
 	ret0, _ := ret[0].(*loadbalancers.LoadBalancer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLoadbalancer indicates an expected call of GetLoadbalancer.
-func (mr *MockLoadbalancingMockRecorder) GetLoadbalancer(arg0 any) *gomock.Call {
+func (mr *MockLoadbalancingMockRecorder) GetLoadbalancer(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadbalancer", reflect.TypeOf((*MockLoadbalancing)(nil).GetLoadbalancer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadbalancer", reflect.TypeOf((*MockLoadbalancing)(nil).GetLoadbalancer), arg0, arg1)
 }
 
 // ListLoadbalancers mocks base method.
-func (m *MockLoadbalancing) ListLoadbalancers(arg0 loadbalancers.ListOpts) ([]loadbalancers.LoadBalancer, error) {
+func (m *MockLoadbalancing) ListLoadbalancers(arg0 context.Context, arg1 loadbalancers.ListOpts) ([]loadbalancers.LoadBalancer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLoadbalancers", arg0)
+	ret := m.ctrl.Call(m, "ListLoadbalancers", arg0, arg1)
 	ret0, _ := ret[0].([]loadbalancers.LoadBalancer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListLoadbalancers indicates an expected call of ListLoadbalancers.
-func (mr *MockLoadbalancingMockRecorder) ListLoadbalancers(arg0 any) *gomock.Call {
+func (mr *MockLoadbalancingMockRecorder) ListLoadbalancers(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadbalancers", reflect.TypeOf((*MockLoadbalancing)(nil).ListLoadbalancers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadbalancers", reflect.TypeOf((*MockLoadbalancing)(nil).ListLoadbalancers), arg0, arg1)
 }
 
 // MockSharedFilesystem is a mock of SharedFilesystem interface.
@@ -1135,60 +1234,142 @@ func (m *MockSharedFilesystem) EXPECT() *MockSharedFilesystemMockRecorder {
 }
 
 // CreateShareNetwork mocks base method.
-func (m *MockSharedFilesystem) CreateShareNetwork(arg0 sharenetworks.CreateOpts) (*sharenetworks.ShareNetwork, error) {
+func (m *MockSharedFilesystem) CreateShareNetwork(arg0 context.Context, arg1 sharenetworks.CreateOpts) (*sharenetworks.ShareNetwork, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateShareNetwork", arg0)
+	ret := m.ctrl.Call(m, "CreateShareNetwork", arg0, arg1)
 	ret0, _ := ret[0].(*sharenetworks.ShareNetwork)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateShareNetwork indicates an expected call of CreateShareNetwork.
-func (mr *MockSharedFilesystemMockRecorder) CreateShareNetwork(arg0 any) *gomock.Call {
+func (mr *MockSharedFilesystemMockRecorder) CreateShareNetwork(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).CreateShareNetwork), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).CreateShareNetwork), arg0, arg1)
 }
 
 // DeleteShareNetwork mocks base method.
-func (m *MockSharedFilesystem) DeleteShareNetwork(arg0 string) error {
+func (m *MockSharedFilesystem) DeleteShareNetwork(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteShareNetwork", arg0)
+	ret := m.ctrl.Call(m, "DeleteShareNetwork", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteShareNetwork indicates an expected call of DeleteShareNetwork.
-func (mr *MockSharedFilesystemMockRecorder) DeleteShareNetwork(arg0 any) *gomock.Call {
+func (mr *MockSharedFilesystemMockRecorder) DeleteShareNetwork(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).DeleteShareNetwork), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).DeleteShareNetwork), arg0, arg1)
 }
 
 // GetShareNetwork mocks base method.
-func (m *MockSharedFilesystem) GetShareNetwork(arg0 string) (*sharenetworks.ShareNetwork, error) {
+func (m *MockSharedFilesystem) GetShareNetwork(arg0 context.Context, arg1 string) (*sharenetworks.ShareNetwork, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetShareNetwork", arg0)
+	ret := m.ctrl.Call(m, "GetShareNetwork", arg0, arg1)
 	ret0, _ := ret[0].(*sharenetworks.ShareNetwork)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetShareNetwork indicates an expected call of GetShareNetwork.
-func (mr *MockSharedFilesystemMockRecorder) GetShareNetwork(arg0 any) *gomock.Call {
+func (mr *MockSharedFilesystemMockRecorder) GetShareNetwork(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).GetShareNetwork), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).GetShareNetwork), arg0, arg1)
 }
 
 // ListShareNetworks mocks base method.
-func (m *MockSharedFilesystem) ListShareNetworks(arg0 sharenetworks.ListOpts) ([]sharenetworks.ShareNetwork, error) {
+func (m *MockSharedFilesystem) ListShareNetworks(arg0 context.Context, arg1 sharenetworks.ListOpts) ([]sharenetworks.ShareNetwork, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListShareNetworks", arg0)
+	ret := m.ctrl.Call(m, "ListShareNetworks", arg0, arg1)
 	ret0, _ := ret[0].([]sharenetworks.ShareNetwork)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListShareNetworks indicates an expected call of ListShareNetworks.
-func (mr *MockSharedFilesystemMockRecorder) ListShareNetworks(arg0 any) *gomock.Call {
+func (mr *MockSharedFilesystemMockRecorder) ListShareNetworks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShareNetworks", reflect.TypeOf((*MockSharedFilesystem)(nil).ListShareNetworks), arg0, arg1)
+}
+
+// UpdateShareNetwork mocks base method.
+func (m *MockSharedFilesystem) UpdateShareNetwork(arg0 context.Context, arg1 string, arg2 sharenetworks.UpdateOpts) (*sharenetworks.ShareNetwork, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShareNetwork", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*sharenetworks.ShareNetwork)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateShareNetwork indicates an expected call of UpdateShareNetwork.
+func (mr *MockSharedFilesystemMockRecorder) UpdateShareNetwork(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShareNetwork", reflect.TypeOf((*MockSharedFilesystem)(nil).UpdateShareNetwork), arg0, arg1, arg2)
+}
+
+// MockIdentity is a mock of Identity interface.
+type MockIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityMockRecorder
+}
+
+// MockIdentityMockRecorder is the mock recorder for MockIdentity.
+type MockIdentityMockRecorder struct {
+	mock *MockIdentity
+}
+
+// NewMockIdentity creates a new mock instance.
+func NewMockIdentity(ctrl *gomock.Controller) *MockIdentity {
+	mock := &MockIdentity{ctrl: ctrl}
+	mock.recorder = &MockIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentity) EXPECT() *MockIdentityMockRecorder {
+	return m.recorder
+}
+
+// CreateApplicationCredential mocks base method.
+func (m *MockIdentity) CreateApplicationCredential(arg0 context.Context, arg1 applicationcredentials.CreateOpts) (*applicationcredentials.ApplicationCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplicationCredential", arg0, arg1)
+	ret0, _ := ret[0].(*applicationcredentials.ApplicationCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApplicationCredential indicates an expected call of CreateApplicationCredential.
+func (mr *MockIdentityMockRecorder) CreateApplicationCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationCredential", reflect.TypeOf((*MockIdentity)(nil).CreateApplicationCredential), arg0, arg1)
+}
+
+// DeleteApplicationCredential mocks base method.
+func (m *MockIdentity) DeleteApplicationCredential(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApplicationCredential", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteApplicationCredential indicates an expected call of DeleteApplicationCredential.
+func (mr *MockIdentityMockRecorder) DeleteApplicationCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApplicationCredential", reflect.TypeOf((*MockIdentity)(nil).DeleteApplicationCredential), arg0, arg1)
+}
+
+// GetApplicationCredential mocks base method.
+func (m *MockIdentity) GetApplicationCredential(arg0 context.Context, arg1 string) (*applicationcredentials.ApplicationCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationCredential", arg0, arg1)
+	ret0, _ := ret[0].(*applicationcredentials.ApplicationCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationCredential indicates an expected call of GetApplicationCredential.
+func (mr *MockIdentityMockRecorder) GetApplicationCredential(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShareNetworks", reflect.TypeOf((*MockSharedFilesystem)(nil).ListShareNetworks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationCredential", reflect.TypeOf((*MockIdentity)(nil).GetApplicationCredential), arg0, arg1)
 }
@@ -5,31 +5,38 @@
 package client
 
 import (
+	"context"
+
 	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/sharenetworks"
 )
 
 // CreateShareNetwork creates the share network.
-func (c *SharedFilesystemClient) CreateShareNetwork(createOpts sharenetworks.CreateOpts) (*sharenetworks.ShareNetwork, error) {
-	return sharenetworks.Create(c.client, createOpts).Extract()
+func (c *SharedFilesystemClient) CreateShareNetwork(ctx context.Context, createOpts sharenetworks.CreateOpts) (*sharenetworks.ShareNetwork, error) {
+	return sharenetworks.Create(withContext(ctx, c.client), createOpts).Extract()
 }
 
 // ListShareNetworks returns a list of share networks
-func (c *SharedFilesystemClient) ListShareNetworks(listOpts sharenetworks.ListOpts) ([]sharenetworks.ShareNetwork, error) {
-	page, err := sharenetworks.ListDetail(c.client, listOpts).AllPages()
+func (c *SharedFilesystemClient) ListShareNetworks(ctx context.Context, listOpts sharenetworks.ListOpts) ([]sharenetworks.ShareNetwork, error) {
+	page, err := sharenetworks.ListDetail(withContext(ctx, c.client), listOpts).AllPages()
 	if err != nil {
 		return nil, err
 	}
 	return sharenetworks.ExtractShareNetworks(page)
 }
 
+// UpdateShareNetwork updates the share network with the given identifier
+func (c *SharedFilesystemClient) UpdateShareNetwork(ctx context.Context, id string, updateOpts sharenetworks.UpdateOpts) (*sharenetworks.ShareNetwork, error) {
+	return sharenetworks.Update(withContext(ctx, c.client), id, updateOpts).Extract()
+}
+
 // DeleteShareNetwork deletes a share network by identifier
-func (c *SharedFilesystemClient) DeleteShareNetwork(id string) error {
-	return sharenetworks.Delete(c.client, id).ExtractErr()
+func (c *SharedFilesystemClient) DeleteShareNetwork(ctx context.Context, id string) error {
+	return sharenetworks.Delete(withContext(ctx, c.client), id).ExtractErr()
 }
 
 // GetShareNetwork returns a share network by identifier
-func (c *SharedFilesystemClient) GetShareNetwork(id string) (*sharenetworks.ShareNetwork, error) {
-	sn, err := sharenetworks.Get(c.client, id).Extract()
+func (c *SharedFilesystemClient) GetShareNetwork(ctx context.Context, id string) (*sharenetworks.ShareNetwork, error) {
+	sn, err := sharenetworks.Get(withContext(ctx, c.client), id).Extract()
 	if err != nil && !IsNotFoundError(err) {
 		return nil, err
 	}
@@ -16,8 +16,8 @@ import (
 )
 
 // NewStorageClientFromSecretRef retrieves the openstack client from specified by the secret reference.
-func NewStorageClientFromSecretRef(ctx context.Context, c client.Client, secretRef corev1.SecretReference, region string) (Storage, error) {
-	base, err := NewOpenStackClientFromSecretRef(ctx, c, secretRef, nil)
+func NewStorageClientFromSecretRef(ctx context.Context, c client.Client, secretRef corev1.SecretReference, region string, options ...FactoryOption) (Storage, error) {
+	base, err := NewOpenStackClientFromSecretRef(ctx, c, secretRef, nil, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (s *StorageClient) DeleteObjectsWithPrefix(ctx context.Context, container,
 	// In  future if support is added to upstream, we could switch to it.
 
 	// Retrieve a pager (i.e. a paginated collection)
-	pager := objects.List(s.client, container, opts)
+	pager := objects.List(withContext(ctx, s.client), container, opts)
 
 	return pager.EachPage(func(page pagination.Page) (bool, error) {
 		objectList, err := objects.ExtractNames(page)
@@ -56,8 +56,8 @@ func (s *StorageClient) DeleteObjectsWithPrefix(ctx context.Context, container,
 
 // deleteObjectIfExists deletes the openstack object with name <objectName> from <container>. If it does not exist,
 // no error is returned.
-func (s *StorageClient) deleteObjectIfExists(_ context.Context, container, objectName string) error {
-	result := objects.Delete(s.client, container, objectName, nil)
+func (s *StorageClient) deleteObjectIfExists(ctx context.Context, container, objectName string) error {
+	result := objects.Delete(withContext(ctx, s.client), container, objectName, nil)
 	if _, err := result.Extract(); err != nil {
 		if !IsNotFoundError(err) {
 			return err
@@ -68,8 +68,8 @@ func (s *StorageClient) deleteObjectIfExists(_ context.Context, container, objec
 
 // CreateContainerIfNotExists creates the openstack blob container with name <container>. If it already exist,
 // no error is returned.
-func (s *StorageClient) CreateContainerIfNotExists(_ context.Context, container string) error {
-	result := containers.Create(s.client, container, nil)
+func (s *StorageClient) CreateContainerIfNotExists(ctx context.Context, container string) error {
+	result := containers.Create(withContext(ctx, s.client), container, nil)
 	if _, err := result.Extract(); err != nil {
 		// Note: Openstack swift doesn't return any error if container already exists.
 		// So, no special handling added here.
@@ -81,7 +81,7 @@ func (s *StorageClient) CreateContainerIfNotExists(_ context.Context, container
 // DeleteContainerIfExists deletes the openstack blob container with name <container>. If it does not exist,
 // no error is returned.
 func (s *StorageClient) DeleteContainerIfExists(ctx context.Context, container string) error {
-	result := containers.Delete(s.client, container)
+	result := containers.Delete(withContext(ctx, s.client), container)
 	if _, err := result.Extract(); err != nil {
 		switch result.Err.(type) {
 		case gophercloud.ErrDefault404:
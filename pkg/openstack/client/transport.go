@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/helper"
+)
+
+// DefaultRetryPolicy is the RetryPolicy used by Factory implementations if no other policy is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// RetryPolicy configures how idempotent requests against the OpenStack API are retried on transient errors.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries for a single request. Zero disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff delay before the first retry. It is doubled for every subsequent retry.
+	BaseDelay time.Duration
+	// MaxDelay is the upper bound for the backoff delay and for delays requested by the server with a Retry-After header.
+	MaxDelay time.Duration
+}
+
+// FactoryOptions contains the options for the HTTP client used by a Factory.
+type FactoryOptions struct {
+	// RequestTimeout is the timeout for a single request attempt against the OpenStack API. Zero means no timeout.
+	RequestTimeout time.Duration
+	// RetryPolicy configures retries of idempotent requests on transient errors.
+	RetryPolicy RetryPolicy
+	// Controller is the name of the controller using the Factory. It is used as label for the API request metrics.
+	Controller string
+}
+
+// FactoryOption can be passed to the Factory constructors to modify the HTTP client used for all service clients.
+type FactoryOption func(opts *FactoryOptions)
+
+// WithRequestTimeout returns a FactoryOption that sets the timeout for a single request against the OpenStack API,
+// e.g. as configured by the `requestTimeout` field of the CloudProfileConfig. A nil timeout leaves the default unchanged.
+func WithRequestTimeout(timeout *metav1.Duration) FactoryOption {
+	return func(opts *FactoryOptions) {
+		if timeout != nil {
+			opts.RequestTimeout = timeout.Duration
+		}
+	}
+}
+
+// FactoryOptionsFromCluster returns the FactoryOptions configured by the CloudProfileConfig of the given cluster.
+// Clusters without a CloudProfileConfig, e.g. a nil cluster for resources of the seed, use the defaults.
+func FactoryOptionsFromCluster(cluster *controller.Cluster) ([]FactoryOption, error) {
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if cloudProfileConfig == nil {
+		return nil, nil
+	}
+	return []FactoryOption{WithRequestTimeout(cloudProfileConfig.RequestTimeout)}, nil
+}
+
+// WithRetryPolicy returns a FactoryOption that replaces the DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) FactoryOption {
+	return func(opts *FactoryOptions) {
+		opts.RetryPolicy = policy
+	}
+}
+
+// WithController returns a FactoryOption that sets the name of the controller issuing the requests.
+func WithController(name string) FactoryOption {
+	return func(opts *FactoryOptions) {
+		opts.Controller = name
+	}
+}
+
+func newFactoryOptions(options ...FactoryOption) *FactoryOptions {
+	opts := &FactoryOptions{
+		RetryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return opts
+}
+
+// NewRoundTripper wraps the given round-tripper with the per-request timeout and retry behaviour configured in opts.
+func NewRoundTripper(next http.RoundTripper, opts *FactoryOptions) http.RoundTripper {
+	return newRoundTripper(next, opts, nil)
+}
+
+// newRoundTripper additionally records metrics for every request attempt if endpoints is not nil.
+func newRoundTripper(next http.RoundTripper, opts *FactoryOptions, endpoints *serviceEndpoints) http.RoundTripper {
+	rt := next
+	if opts.RequestTimeout > 0 {
+		rt = &timeoutRoundTripper{next: rt, timeout: opts.RequestTimeout}
+	}
+	if endpoints != nil {
+		rt = &metricsRoundTripper{next: rt, controller: opts.Controller, endpoints: endpoints}
+	}
+	if opts.RetryPolicy.MaxRetries > 0 {
+		rt = &retryRoundTripper{next: rt, policy: opts.RetryPolicy}
+	}
+	return rt
+}
+
+// timeoutRoundTripper cancels a request attempt if it does not complete within the timeout. The timeout covers
+// reading the response body, too.
+type timeoutRoundTripper struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// retryRoundTripper retries idempotent requests if the server responds with a status code indicating a transient error.
+type retryRoundTripper struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+}
+
+func (r *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetryable(req) {
+		return r.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := r.next.RoundTrip(attemptReq)
+		if err != nil || attempt >= r.policy.MaxRetries || !isTransientStatusCode(resp.StatusCode) {
+			return resp, err
+		}
+
+		delay := r.delay(attempt, resp)
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the delay before the next retry. A Retry-After header sent by the server takes precedence over the
+// jittered exponential backoff.
+func (r *retryRoundTripper) delay(attempt int, resp *http.Response) time.Duration {
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		return min(d, r.policy.MaxDelay)
+	}
+
+	backoff := r.policy.BaseDelay << attempt
+	if backoff <= 0 || backoff > r.policy.MaxDelay {
+		backoff = r.policy.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// use "equal jitter": half of the backoff is fixed, the other half is random
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// isRetryable returns true if the request is idempotent and its body can be replayed.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func isTransientStatusCode(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+)
+
+var _ = Describe("RoundTripper", func() {
+	var (
+		server    *httptest.Server
+		calls     atomic.Int32
+		handler   func(w http.ResponseWriter, r *http.Request, call int32)
+		policy    openstackclient.RetryPolicy
+		doRequest func(opts *openstackclient.FactoryOptions, method string, body []byte) (*http.Response, error)
+	)
+
+	BeforeEach(func() {
+		calls.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r, calls.Add(1))
+		}))
+		policy = openstackclient.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+		doRequest = func(opts *openstackclient.FactoryOptions, method string, body []byte) (*http.Response, error) {
+			client := &http.Client{Transport: openstackclient.NewRoundTripper(http.DefaultTransport, opts)}
+			var reader io.Reader
+			if body != nil {
+				reader = bytes.NewReader(body)
+			}
+			req, err := http.NewRequest(method, server.URL, reader)
+			Expect(err).NotTo(HaveOccurred())
+			return client.Do(req)
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should retry idempotent requests on transient errors", func() {
+		var bodies []string
+		handler = func(w http.ResponseWriter, r *http.Request, call int32) {
+			data, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+			if call < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+
+		resp, err := doRequest(&openstackclient.FactoryOptions{RetryPolicy: policy}, http.MethodPut, []byte("payload"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(calls.Load()).To(Equal(int32(3)))
+		Expect(bodies).To(ConsistOf("payload", "payload", "payload"))
+	})
+
+	It("should give up after the maximum number of retries", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request, _ int32) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+
+		resp, err := doRequest(&openstackclient.FactoryOptions{RetryPolicy: policy}, http.MethodGet, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(calls.Load()).To(Equal(int32(4)))
+	})
+
+	It("should not retry non-idempotent requests", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request, _ int32) {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+
+		resp, err := doRequest(&openstackclient.FactoryOptions{RetryPolicy: policy}, http.MethodPost, []byte("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("should not retry on other errors", func() {
+		handler = func(w http.ResponseWriter, _ *http.Request, _ int32) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		resp, err := doRequest(&openstackclient.FactoryOptions{RetryPolicy: policy}, http.MethodGet, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+
+	It("should honor the Retry-After header", func() {
+		var times []time.Time
+		handler = func(w http.ResponseWriter, _ *http.Request, call int32) {
+			times = append(times, time.Now())
+			if call == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+
+		policy.MaxDelay = 5 * time.Second
+		resp, err := doRequest(&openstackclient.FactoryOptions{RetryPolicy: policy}, http.MethodGet, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(calls.Load()).To(Equal(int32(2)))
+		Expect(times[1].Sub(times[0])).To(BeNumerically(">=", 900*time.Millisecond))
+	})
+
+	It("should time out slow requests", func() {
+		handler = func(w http.ResponseWriter, r *http.Request, _ int32) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+
+		_, err := doRequest(&openstackclient.FactoryOptions{RequestTimeout: 50 * time.Millisecond}, http.MethodGet, nil)
+		Expect(err).To(MatchError(ContainSubstring("context deadline exceeded")))
+	})
+})
+
+var _ = Describe("FactoryOptionsFromCluster", func() {
+	applyOptions := func(options []openstackclient.FactoryOption) *openstackclient.FactoryOptions {
+		opts := &openstackclient.FactoryOptions{}
+		for _, option := range options {
+			option(opts)
+		}
+		return opts
+	}
+
+	It("should use the defaults without a cluster", func() {
+		options, err := openstackclient.FactoryOptionsFromCluster(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(options).To(BeEmpty())
+	})
+
+	It("should use the request timeout of the cloud profile", func() {
+		cluster := &extensionscontroller.Cluster{
+			CloudProfile: &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","requestTimeout":"42s"}`),
+					},
+				},
+			},
+		}
+
+		options, err := openstackclient.FactoryOptionsFromCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applyOptions(options).RequestTimeout).To(Equal(42 * time.Second))
+	})
+
+	It("should fail for an invalid cloud profile config", func() {
+		cluster := &extensionscontroller.Cluster{
+			CloudProfile: &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"foo/v1","kind":"CloudProfileConfig"}`)},
+				},
+			},
+		}
+
+		_, err := openstackclient.FactoryOptionsFromCluster(cluster)
+		Expect(err).To(HaveOccurred())
+	})
+})
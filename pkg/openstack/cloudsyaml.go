@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// cloudsYAML is the content of a clouds.yaml file, see
+// https://docs.openstack.org/python-openstackclient/latest/configuration/index.html#clouds-yaml
+type cloudsYAML struct {
+	Clouds map[string]cloudsYAMLCloud `json:"clouds"`
+}
+
+type cloudsYAMLCloud struct {
+	clientconfig.Cloud
+	Auth *cloudsYAMLAuth `json:"auth,omitempty"`
+}
+
+// cloudsYAMLAuth adds the options of the v3oidcaccesstoken and the trust plugins to the auth section.
+type cloudsYAMLAuth struct {
+	clientconfig.AuthInfo
+	IdentityProvider string `json:"identity_provider,omitempty"`
+	Protocol         string `json:"protocol,omitempty"`
+	AccessToken      string `json:"access_token,omitempty"`
+	TrustID          string `json:"trust_id,omitempty"`
+}
+
+// extractCredentialsFromCloudsYAML generates a credentials object for the cloud of the clouds.yaml file in the given
+// secret. The cloud can be omitted if the file contains only one cloud. Files referenced by the cloud are looked up by
+// their base name in the secret, e.g. `cacert: /etc/openstack/ca.crt` refers to the key `ca.crt`.
+func extractCredentialsFromCloudsYAML(secret *corev1.Secret) (*Credentials, error) {
+	var clouds cloudsYAML
+	if err := yaml.Unmarshal(secret.Data[CloudsYAML], &clouds); err != nil {
+		return nil, fmt.Errorf("could not parse %q in secret %s/%s: %w", CloudsYAML, secret.Namespace, secret.Name, err)
+	}
+
+	name := string(secret.Data[CloudName])
+	if name == "" {
+		if len(clouds.Clouds) != 1 {
+			return nil, fmt.Errorf("%q is required if %q does not contain exactly one cloud in secret %s/%s", CloudName, CloudsYAML, secret.Namespace, secret.Name)
+		}
+		for cloudName := range clouds.Clouds {
+			name = cloudName
+		}
+	}
+	cloud, ok := clouds.Clouds[name]
+	if !ok {
+		return nil, fmt.Errorf("cloud %q is not contained in %q in secret %s/%s", name, CloudsYAML, secret.Namespace, secret.Name)
+	}
+	if cloud.Profile != "" {
+		return nil, fmt.Errorf("profile of cloud %q in secret %s/%s is not supported", name, secret.Namespace, secret.Name)
+	}
+	if cloud.Auth == nil {
+		return nil, fmt.Errorf("cloud %q in secret %s/%s does not contain an auth section", name, secret.Namespace, secret.Name)
+	}
+	auth := cloud.Auth
+
+	credentials := &Credentials{
+		DomainName:                  firstNonEmpty(auth.ProjectDomainName, auth.UserDomainName, auth.DomainName),
+		TenantName:                  auth.ProjectName,
+		ProjectID:                   auth.ProjectID,
+		UserDomainID:                firstNonEmpty(auth.UserDomainID, auth.DomainID),
+		ProjectDomainID:             firstNonEmpty(auth.ProjectDomainID, auth.DomainID),
+		Username:                    auth.Username,
+		Password:                    auth.Password,
+		ApplicationCredentialID:     auth.ApplicationCredentialID,
+		ApplicationCredentialName:   auth.ApplicationCredentialName,
+		ApplicationCredentialSecret: auth.ApplicationCredentialSecret,
+		Token:                       auth.Token,
+		IdentityProvider:            auth.IdentityProvider,
+		Protocol:                    auth.Protocol,
+		AccessToken:                 auth.AccessToken,
+		TrustID:                     auth.TrustID,
+		AuthURL:                     auth.AuthURL,
+		Insecure:                    cloud.Verify != nil && !*cloud.Verify,
+		Region:                      cloud.RegionName,
+		// the endpoint type takes precedence over the interface like in gophercloud
+		Interface: firstNonEmpty(cloud.EndpointType, cloud.Interface),
+	}
+
+	if credentials.TenantName == "" && credentials.ProjectID == "" {
+		return nil, fmt.Errorf("cloud %q in secret %s/%s must specify the project name or id", name, secret.Namespace, secret.Name)
+	}
+	if credentials.ProjectID == "" && credentials.DomainName == "" && credentials.ProjectDomainID == "" {
+		return nil, fmt.Errorf("cloud %q in secret %s/%s must specify the domain of the project", name, secret.Namespace, secret.Name)
+	}
+
+	if cloud.CACertFile != "" {
+		caCert, err := getReferencedFile(secret, cloud.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		credentials.CACert = caCert
+	} else {
+		credentials.CACert = string(secret.Data[CACert])
+	}
+	return credentials, nil
+}
+
+// getReferencedFile returns the content of the file referenced by the clouds.yaml file in the given secret. PEM
+// encoded content can also be given inline.
+func getReferencedFile(secret *corev1.Secret, file string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(file), "-----BEGIN") {
+		return file, nil
+	}
+	value, ok := secret.Data[path.Base(file)]
+	if !ok {
+		return "", fmt.Errorf("file %q referenced in %q is not contained in secret %s/%s", file, CloudsYAML, secret.Namespace, secret.Name)
+	}
+	return string(value), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
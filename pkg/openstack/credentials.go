@@ -20,6 +20,12 @@ type Credentials struct {
 	DomainName string
 	TenantName string
 
+	// ProjectID identifies the project instead of TenantName, UserDomainID and ProjectDomainID the domains of the
+	// user and the project instead of DomainName.
+	ProjectID       string
+	UserDomainID    string
+	ProjectDomainID string
+
 	// either authenticate with username/password credentials
 	Username string
 	Password string
@@ -29,10 +35,28 @@ type Credentials struct {
 	ApplicationCredentialName   string
 	ApplicationCredentialSecret string
 
+	// or a pre-issued token
+	Token string
+
+	// or an OIDC access token which is exchanged for a token by a federated identity provider, e.g. the service
+	// account token of a Gardener workload identity
+	IdentityProvider string
+	Protocol         string
+	AccessToken      string
+
+	// TrustID scopes the token to a trust instead of the project if authenticating with username/password or a token.
+	TrustID string
+
 	AuthURL string
 	CACert  string
 
 	Insecure bool
+
+	// Region and Interface select the endpoints of the service catalog. The region is only given in clouds.yaml secrets.
+	Region    string
+	Interface string
+	// EndpointOverrides maps service types to the endpoint URLs to use instead of the ones from the service catalog.
+	EndpointOverrides map[string]string
 }
 
 // GetCredentials computes for a given context and infrastructure the corresponding credentials object.
@@ -45,9 +69,26 @@ func GetCredentials(ctx context.Context, c client.Client, secretRef corev1.Secre
 }
 
 // ExtractCredentials generates a credentials object for a given provider secret.
+// The credentials are either given by separate keys or by a clouds.yaml file with the name of the cloud to use.
 func ExtractCredentials(secret *corev1.Secret, allowDNSKeys bool) (*Credentials, error) {
+	if secret.Data == nil {
+		return nil, fmt.Errorf("secret does not contain any data")
+	}
+	if _, ok := secret.Data[CloudsYAML]; ok {
+		credentials, err := extractCredentialsFromCloudsYAML(secret)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateAuthMethod(secret, credentials); err != nil {
+			return nil, err
+		}
+		return credentials, nil
+	}
+
 	var altDomainNameKey, altTenantNameKey, altUserNameKey, altPasswordKey, altAuthURLKey, altCABundleKey *string
 	var altApplicationCredentialID, altApplicationCredentialName, altApplicationCredentialSecret *string
+	var altTokenKey, altTrustIDKey, altIdentityProviderKey, altProtocolKey, altAccessTokenKey *string
+	var altProjectIDKey, altUserDomainIDKey, altProjectDomainIDKey, altInterfaceKey *string
 	if allowDNSKeys {
 		altDomainNameKey = ptr.To(DNSDomainName)
 		altTenantNameKey = ptr.To(DNSTenantName)
@@ -58,18 +99,36 @@ func ExtractCredentials(secret *corev1.Secret, allowDNSKeys bool) (*Credentials,
 		altApplicationCredentialSecret = ptr.To(DNSApplicationCredentialSecret)
 		altAuthURLKey = ptr.To(DNSAuthURL)
 		altCABundleKey = ptr.To(DNS_CA_Bundle)
+		altTokenKey = ptr.To(DNSToken)
+		altTrustIDKey = ptr.To(DNSTrustID)
+		altIdentityProviderKey = ptr.To(DNSIdentityProvider)
+		altProtocolKey = ptr.To(DNSProtocol)
+		altAccessTokenKey = ptr.To(DNSAccessToken)
+		altProjectIDKey = ptr.To(DNSProjectID)
+		altUserDomainIDKey = ptr.To(DNSUserDomainID)
+		altProjectDomainIDKey = ptr.To(DNSProjectDomainID)
+		altInterfaceKey = ptr.To(DNSInterface)
 	}
 
-	if secret.Data == nil {
-		return nil, fmt.Errorf("secret does not contain any data")
-	}
-	domainName, err := getRequired(secret, DomainName, altDomainNameKey)
-	if err != nil {
-		return nil, err
+	projectID := getOptional(secret, ProjectID, altProjectIDKey)
+	userDomainID := getOptional(secret, UserDomainID, altUserDomainIDKey)
+	projectDomainID := getOptional(secret, ProjectDomainID, altProjectDomainIDKey)
+
+	// the domain name is only optional if the domains of the user and the project are given by id
+	domainName := getOptional(secret, DomainName, altDomainNameKey)
+	if userDomainID == "" || (projectID == "" && projectDomainID == "") {
+		var err error
+		if domainName, err = getRequired(secret, DomainName, altDomainNameKey); err != nil {
+			return nil, err
+		}
 	}
-	tenantName, err := getRequired(secret, TenantName, altTenantNameKey)
-	if err != nil {
-		return nil, err
+	// the tenant name is only optional if the project is given by id
+	tenantName := getOptional(secret, TenantName, altTenantNameKey)
+	if projectID == "" {
+		var err error
+		if tenantName, err = getRequired(secret, TenantName, altTenantNameKey); err != nil {
+			return nil, err
+		}
 	}
 	userName := getOptional(secret, UserName, altUserNameKey)
 	password := getOptional(secret, Password, altPasswordKey)
@@ -78,38 +137,104 @@ func ExtractCredentials(secret *corev1.Secret, allowDNSKeys bool) (*Credentials,
 	applicationCredentialSecret := getOptional(secret, ApplicationCredentialSecret, altApplicationCredentialSecret)
 	authURL := getOptional(secret, AuthURL, altAuthURLKey)
 	caCert := getOptional(secret, CACert, altCABundleKey)
+	token := getOptional(secret, Token, altTokenKey)
+	trustID := getOptional(secret, TrustID, altTrustIDKey)
+	identityProvider := getOptional(secret, IdentityProvider, altIdentityProviderKey)
+	protocol := getOptional(secret, Protocol, altProtocolKey)
+	accessToken := getOptional(secret, AccessToken, altAccessTokenKey)
 
-	if password != "" {
-		if applicationCredentialSecret != "" {
-			return nil, fmt.Errorf("cannot specify both '%s' and '%s' in secret %s/%s", Password, ApplicationCredentialSecret, secret.Namespace, secret.Name)
-		}
-		if userName == "" {
-			return nil, fmt.Errorf("'%s' is required if '%s' is given in %s/%s", UserName, Password, secret.Namespace, secret.Name)
+	if secret.Labels[WorkloadIdentityPurposeLabel] == WorkloadIdentityPurposeTokenRequestor {
+		// the token of a workload identity is a service account token which has to be exchanged for a Keystone token
+		if accessToken != "" {
+			return nil, fmt.Errorf("cannot specify '%s' in workload identity secret %s/%s", AccessToken, secret.Namespace, secret.Name)
 		}
-	} else {
-		if applicationCredentialSecret == "" {
-			return nil, fmt.Errorf("must either specify '%s' or '%s' in secret %s/%s", Password, ApplicationCredentialSecret, secret.Namespace, secret.Name)
-		}
-		if applicationCredentialID == "" {
-			if userName == "" || applicationCredentialName == "" {
-				return nil, fmt.Errorf("'%s' and '%s' are required if application credentials are used without '%s' in secret %s/%s", ApplicationCredentialName, UserName,
-					ApplicationCredentialID, secret.Namespace, secret.Name)
+		accessToken, token = token, ""
+	}
+
+	var endpointOverrides map[string]string
+	for key, value := range secret.Data {
+		if service, ok := strings.CutPrefix(key, EndpointOverridePrefix); ok {
+			if endpointOverrides == nil {
+				endpointOverrides = map[string]string{}
 			}
+			endpointOverrides[service] = string(value)
 		}
 	}
 
-	return &Credentials{
+	credentials := &Credentials{
 		DomainName:                  domainName,
 		TenantName:                  tenantName,
+		ProjectID:                   projectID,
+		UserDomainID:                userDomainID,
+		ProjectDomainID:             projectDomainID,
 		Username:                    userName,
 		Password:                    password,
 		ApplicationCredentialID:     applicationCredentialID,
 		ApplicationCredentialName:   applicationCredentialName,
 		ApplicationCredentialSecret: applicationCredentialSecret,
+		Token:                       token,
+		IdentityProvider:            identityProvider,
+		Protocol:                    protocol,
+		AccessToken:                 accessToken,
+		TrustID:                     trustID,
 		AuthURL:                     authURL,
 		CACert:                      caCert,
 		Insecure:                    strings.ToLower(strings.TrimSpace(string(secret.Data[Insecure]))) == "true",
-	}, nil
+		Interface:                   getOptional(secret, Interface, altInterfaceKey),
+		EndpointOverrides:           endpointOverrides,
+	}
+	if err := validateAuthMethod(secret, credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+// validateAuthMethod checks that the credentials contain exactly one complete authentication method and defaults
+// the federation protocol.
+func validateAuthMethod(secret *corev1.Secret, credentials *Credentials) error {
+	var authMethods []string
+	for _, method := range []struct{ key, value string }{
+		{Password, credentials.Password},
+		{ApplicationCredentialSecret, credentials.ApplicationCredentialSecret},
+		{Token, credentials.Token},
+		{AccessToken, credentials.AccessToken},
+	} {
+		if method.value != "" {
+			authMethods = append(authMethods, method.key)
+		}
+	}
+	if len(authMethods) == 0 {
+		return fmt.Errorf("must either specify '%s', '%s', '%s' or '%s' in secret %s/%s", Password, ApplicationCredentialSecret, Token, AccessToken, secret.Namespace, secret.Name)
+	}
+	if len(authMethods) > 1 {
+		return fmt.Errorf("cannot specify both '%s' and '%s' in secret %s/%s", authMethods[0], authMethods[1], secret.Namespace, secret.Name)
+	}
+
+	switch authMethods[0] {
+	case Password:
+		if credentials.Username == "" {
+			return fmt.Errorf("'%s' is required if '%s' is given in %s/%s", UserName, Password, secret.Namespace, secret.Name)
+		}
+	case ApplicationCredentialSecret:
+		if credentials.ApplicationCredentialID == "" {
+			if credentials.Username == "" || credentials.ApplicationCredentialName == "" {
+				return fmt.Errorf("'%s' and '%s' are required if application credentials are used without '%s' in secret %s/%s", ApplicationCredentialName, UserName,
+					ApplicationCredentialID, secret.Namespace, secret.Name)
+			}
+		}
+	case AccessToken:
+		if credentials.IdentityProvider == "" {
+			return fmt.Errorf("'%s' is required if '%s' is given in %s/%s", IdentityProvider, AccessToken, secret.Namespace, secret.Name)
+		}
+		if credentials.Protocol == "" {
+			credentials.Protocol = DefaultProtocol
+		}
+	}
+
+	if credentials.TrustID != "" && authMethods[0] != Password && authMethods[0] != Token {
+		return fmt.Errorf("'%s' can only be used together with '%s' or '%s' in secret %s/%s", TrustID, Password, Token, secret.Namespace, secret.Name)
+	}
+	return nil
 }
 
 // getOptional returns optional value for a corresponding key or empty string
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"slices"
+	"strings"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+)
+
+const (
+	// TagKeyTechnicalID is the key of the tag holding the technical id of the shoot a resource belongs to.
+	TagKeyTechnicalID = "gardener-shoot"
+	// TagKeySeed is the key of the tag holding the name of the seed managing a resource.
+	TagKeySeed = "gardener-seed"
+	// TagKeyPurpose is the key of the tag holding the purpose of a resource, which distinguishes resources of the same
+	// kind belonging to the same shoot.
+	TagKeyPurpose = "gardener-purpose"
+
+	// TagPurposeNodes is the purpose of the resources of the worker nodes.
+	TagPurposeNodes = "nodes"
+	// TagPurposeNodesIPv6 is the purpose of the IPv6 subnet of the worker nodes.
+	TagPurposeNodesIPv6 = "nodes-ipv6"
+	// TagPurposeBastion is the purpose of the resources of bastion hosts.
+	TagPurposeBastion = "bastion"
+
+	// maxTagLength is the maximum length of Neutron tags.
+	maxTagLength = 60
+)
+
+var resourceTagKeys = []string{TagKeyTechnicalID, TagKeySeed, TagKeyPurpose}
+
+// TagPurposeZone returns the purpose of the worker subnet of the given zone.
+func TagPurposeZone(zone string) string {
+	return TagPurposeNodes + "-zone-" + zone
+}
+
+// ResourceTags returns the tags in the form `<key>=<value>` with which the extension marks the Neutron resources it
+// creates. Tags with empty values or exceeding the maximum length of Neutron tags are omitted.
+func ResourceTags(technicalID, seedName, purpose string) []string {
+	var tags []string
+	for key, value := range ResourceMetadata(technicalID, seedName, purpose) {
+		if tag := key + "=" + value; len(tag) <= maxTagLength {
+			tags = append(tags, tag)
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// ResourceLookupTags returns the tags identifying a resource of the given shoot and purpose. The seed is not part of
+// them, so that resources are still found after the control plane has been migrated to another seed.
+// It returns nil if one of them exceeds the maximum length of Neutron tags, as the lookup would be ambiguous then.
+func ResourceLookupTags(technicalID, purpose string) []string {
+	tags := ResourceTags(technicalID, "", purpose)
+	if technicalID == "" || purpose == "" || len(tags) != 2 {
+		return nil
+	}
+	return tags
+}
+
+// ResourceMetadata returns the tags of ResourceTags as key-value pairs, as used for the metadata of Nova servers.
+func ResourceMetadata(technicalID, seedName, purpose string) map[string]string {
+	metadata := map[string]string{}
+	for _, tag := range []struct{ key, value string }{
+		{TagKeyTechnicalID, technicalID},
+		{TagKeySeed, seedName},
+		{TagKeyPurpose, purpose},
+	} {
+		if tag.value != "" {
+			metadata[tag.key] = tag.value
+		}
+	}
+	return metadata
+}
+
+// IsShootResource returns true if the given tags mark a resource as belonging to a shoot, i.e. it carries the technical
+// id tag of the extension.
+func IsShootResource(tags []string) bool {
+	return slices.ContainsFunc(tags, func(tag string) bool {
+		return strings.HasPrefix(tag, TagKeyTechnicalID+"=")
+	})
+}
+
+// ResourceTagValues returns the values of the tags of the extension in the given tags of a resource by their keys.
+func ResourceTagValues(tags []string) map[string]string {
+	values := map[string]string{}
+	for _, tag := range tags {
+		if key, value, ok := strings.Cut(tag, "="); ok && slices.Contains(resourceTagKeys, key) {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// SeedNameFromCluster returns the name of the seed of the given cluster, or an empty string if it is unknown.
+func SeedNameFromCluster(cluster *extensionscontroller.Cluster) string {
+	if cluster == nil || cluster.Seed == nil {
+		return ""
+	}
+	return cluster.Seed.Name
+}
+
+// MergeResourceTags replaces the tags of the extension in the current tags of a resource with the desired ones and
+// keeps all other tags. It returns the sorted result and whether it differs from the current tags.
+func MergeResourceTags(current, desired []string) ([]string, bool) {
+	merged := slices.DeleteFunc(slices.Clone(current), func(tag string) bool {
+		key, _, _ := strings.Cut(tag, "=")
+		return slices.Contains(resourceTagKeys, key)
+	})
+	merged = append(merged, desired...)
+	slices.Sort(merged)
+	merged = slices.Compact(merged)
+
+	sortedCurrent := slices.Clone(current)
+	slices.Sort(sortedCurrent)
+	return merged, !slices.Equal(merged, sortedCurrent)
+}
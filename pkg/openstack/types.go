@@ -51,6 +51,17 @@ const (
 	DomainName = "domainName"
 	// TenantName is a constant for the key in a cloud provider secret that holds the OpenStack tenant name.
 	TenantName = "tenantName"
+	// ProjectID is a constant for the key in a cloud provider secret that holds the OpenStack project id. It can be given instead of the tenant name.
+	ProjectID = "projectID"
+	// UserDomainID is a constant for the key in a cloud provider secret that holds the id of the OpenStack domain of the user.
+	UserDomainID = "userDomainID"
+	// ProjectDomainID is a constant for the key in a cloud provider secret that holds the id of the OpenStack domain of the project.
+	ProjectDomainID = "projectDomainID"
+	// Interface is a constant for the key in a cloud provider secret that holds the interface of the endpoints to use, i.e. public, internal or admin.
+	Interface = "interface"
+	// EndpointOverridePrefix is the prefix for the keys in a cloud provider secret that hold the endpoint URL to use for
+	// a service type instead of the one from the service catalog, e.g. `endpointOverride.network`.
+	EndpointOverridePrefix = "endpointOverride."
 	// UserName is a constant for the key in a cloud provider secret and backup secret that holds the OpenStack username.
 	UserName = "username"
 	// Password is a constant for the key in a cloud provider secret and backup secret that holds the OpenStack password.
@@ -61,6 +72,23 @@ const (
 	ApplicationCredentialName = "applicationCredentialName"
 	// ApplicationCredentialSecret is a constant for the key in a cloud provider secret and backup secret that holds the OpenStack application credential secret.
 	ApplicationCredentialSecret = "applicationCredentialSecret"
+	// Token is a constant for the key in a cloud provider secret that holds a pre-issued OpenStack Keystone token. In
+	// secrets for Gardener workload identity it holds the service account token which is exchanged for a Keystone token.
+	Token = "token"
+	// TrustID is a constant for the key in a cloud provider secret that holds the id of the Keystone trust to authenticate with.
+	TrustID = "trustID"
+	// IdentityProvider is a constant for the key in a cloud provider secret that holds the name of the Keystone identity provider for federated authentication.
+	IdentityProvider = "identityProvider"
+	// Protocol is a constant for the key in a cloud provider secret that holds the name of the Keystone federation protocol.
+	Protocol = "protocol"
+	// AccessToken is a constant for the key in a cloud provider secret that holds the OIDC access token for federated authentication.
+	AccessToken = "accessToken"
+	// DefaultProtocol is the Keystone federation protocol used if the cloud provider secret does not specify one.
+	DefaultProtocol = "openid"
+	// CloudsYAML is a constant for the key in a cloud provider secret that holds a clouds.yaml file with the credentials.
+	CloudsYAML = "clouds.yaml"
+	// CloudName is a constant for the key in a cloud provider secret that holds the name of the cloud in the clouds.yaml file.
+	CloudName = "cloud"
 	// Region is a constant for the key in a backup secret that holds the Openstack region.
 	Region = "region"
 	// Insecure is a constant for the key in a cloud provider secret that configures whether the OpenStack client verifies the server's certificate.
@@ -74,6 +102,14 @@ const (
 	DNSDomainName = "OS_DOMAIN_NAME"
 	// DNSTenantName is a constant for the key in a DNS secret that holds the OpenStack tenant name.
 	DNSTenantName = "OS_PROJECT_NAME"
+	// DNSProjectID is a constant for the key in a DNS secret that holds the OpenStack project id.
+	DNSProjectID = "OS_PROJECT_ID"
+	// DNSUserDomainID is a constant for the key in a DNS secret that holds the id of the OpenStack domain of the user.
+	DNSUserDomainID = "OS_USER_DOMAIN_ID"
+	// DNSProjectDomainID is a constant for the key in a DNS secret that holds the id of the OpenStack domain of the project.
+	DNSProjectDomainID = "OS_PROJECT_DOMAIN_ID"
+	// DNSInterface is a constant for the key in a DNS secret that holds the interface of the endpoints to use.
+	DNSInterface = "OS_INTERFACE"
 	// DNSUserName is a constant for the key in a DNS secret that holds the OpenStack username.
 	DNSUserName = "OS_USERNAME"
 	// DNSPassword is a constant for the key in a DNS secret that holds the OpenStack password.
@@ -84,6 +120,16 @@ const (
 	DNSApplicationCredentialName = "OS_APPLICATION_CREDENTIAL_NAME"
 	// DNSApplicationCredentialSecret is a constant for the key in a DNS secret  that holds the OpenStack application credential secret.
 	DNSApplicationCredentialSecret = "OS_APPLICATION_CREDENTIAL_SECRET"
+	// DNSToken is a constant for the key in a DNS secret that holds a pre-issued OpenStack Keystone token.
+	DNSToken = "OS_TOKEN"
+	// DNSTrustID is a constant for the key in a DNS secret that holds the id of the Keystone trust.
+	DNSTrustID = "OS_TRUST_ID"
+	// DNSIdentityProvider is a constant for the key in a DNS secret that holds the name of the Keystone identity provider.
+	DNSIdentityProvider = "OS_IDENTITY_PROVIDER"
+	// DNSProtocol is a constant for the key in a DNS secret that holds the name of the Keystone federation protocol.
+	DNSProtocol = "OS_PROTOCOL"
+	// DNSAccessToken is a constant for the key in a DNS secret that holds the OIDC access token.
+	DNSAccessToken = "OS_ACCESS_TOKEN"
 	// DNS_CA_Bundle is a constant for the key in a DNS secret that holds the Openstack CA Bundle for the KeyStone server.
 	DNS_CA_Bundle = "OS_CACERT"
 
@@ -138,6 +184,20 @@ const (
 	// CSIManilaSecret is a constant for additional role/rolebiding for CSI manila plugin secret
 	CSIManilaSecret = "csi-manila-secret"
 
+	// WorkloadIdentityPurposeLabel is the label of secrets whose purpose is described by its value.
+	WorkloadIdentityPurposeLabel = "security.gardener.cloud/purpose"
+	// WorkloadIdentityPurposeTokenRequestor is the value of the WorkloadIdentityPurposeLabel for secrets which contain a
+	// service account token issued by Gardener for a workload identity.
+	WorkloadIdentityPurposeTokenRequestor = "workload-identity-token-requestor"
+
+	// ApplicationCredentialRotationAnnotation is the annotation of cloud provider secrets which records the progress of
+	// the rotation of their application credential.
+	ApplicationCredentialRotationAnnotation = "openstack.provider.extensions.gardener.cloud/application-credential-rotation"
+
+	// UseFlowAnnotation is the annotation of Shoots and Infrastructures which switches the infrastructure reconciliation
+	// to the flow with the value "true" or keeps it on the Terraformer with the value "false".
+	UseFlowAnnotation = "openstack.provider.extensions.gardener.cloud/use-flow"
+
 	// PreserveWorkerHashAnnotation controls whether the providerConfig will be included in the hash calculation for the respective worker pool.
 	// Deprecated: It is only introduced to ease the transition to the new hash calculation.
 	// TODO(KA): Remove in release v1.36
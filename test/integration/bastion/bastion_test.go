@@ -43,11 +43,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	controllerconfig "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/config"
 	openstackinstall "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/install"
 	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
 	bastionctrl "github.com/gardener/gardener-extension-provider-openstack/pkg/controller/bastion"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
 	openstackclient "github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
 )
 
 var (
@@ -58,9 +60,37 @@ var (
 	region           = flag.String("region", "", "Openstack region")
 	tenantName       = flag.String("tenant-name", "", "Tenant name for openstack")
 	userName         = flag.String("user-name", "", "User name for openstack")
+	localOpenStack   = flag.Bool("local-openstack", false, "Run against an in-process OpenStack API instead of a real OpenStack installation")
 	userDataConst    = "IyEvYmluL2Jhc2ggLWV1CmlkIGdhcmRlbmVyIHx8IHVzZXJhZGQgZ2FyZGVuZXIgLW1VCm1rZGlyIC1wIC9ob21lL2dhcmRlbmVyLy5zc2gKZWNobyAic3NoLXJzYSBBQUFBQjNOemFDMXljMkVBQUFBREFRQUJBQUFCQVFDazYyeDZrN2orc0lkWG9TN25ITzRrRmM3R0wzU0E2UmtMNEt4VmE5MUQ5RmxhcmtoRzFpeU85WGNNQzZqYnh4SzN3aWt0M3kwVTBkR2h0cFl6Vjh3YmV3Z3RLMWJBWnl1QXJMaUhqbnJnTFVTRDBQazNvWGh6RkpKN0MvRkxNY0tJZFN5bG4vMENKVkVscENIZlU5Y3dqQlVUeHdVQ2pnVXRSYjdZWHN6N1Y5dllIVkdJKzRLaURCd3JzOWtVaTc3QWMyRHQ1UzBJcit5dGN4b0p0bU5tMWgxTjNnNzdlbU8rWXhtWEo4MzFXOThoVFVTeFljTjNXRkhZejR5MWhrRDB2WHE1R1ZXUUtUQ3NzRE1wcnJtN0FjQTBCcVRsQ0xWdWl3dXVmTEJLWGhuRHZRUEQrQ2Jhbk03bUZXRXdLV0xXelZHME45Z1VVMXE1T3hhMzhvODUgbWVAbWFjIiA+IC9ob21lL2dhcmRlbmVyLy5zc2gvYXV0aG9yaXplZF9rZXlzCmNob3duIGdhcmRlbmVyOmdhcmRlbmVyIC9ob21lL2dhcmRlbmVyLy5zc2gvYXV0aG9yaXplZF9rZXlzCmVjaG8gImdhcmRlbmVyIEFMTD0oQUxMKSBOT1BBU1NXRDpBTEwiID4vZXRjL3N1ZG9lcnMuZC85OS1nYXJkZW5lci11c2VyCg=="
 )
 
+const externalNetworkName = "FloatingIP-external-monsoon3-02"
+
+// startLocalOpenStack starts an in-process OpenStack API with the external network, a flavor and an image for the
+// bastion and points the flags to it.
+func startLocalOpenStack() {
+	if len(*floatingPoolName) == 0 {
+		*floatingPoolName = externalNetworkName
+	}
+
+	cloud := fake.NewCloud()
+	externalNetworkID := cloud.AddExternalNetwork(externalNetworkName)
+	cloud.AddSubnet(externalNetworkID, externalNetworkName+"-subnet", "172.24.4.0/24")
+	cloud.AddFlavor("m1.small")
+	imageID := cloud.AddImage("gardenlinux")
+	bastionctrl.DefaultAddOptions.BastionConfig = controllerconfig.BastionConfig{FlavorRef: "m1.small", ImageRef: imageID}
+	server := cloud.NewServer()
+	DeferCleanup(server.Close)
+
+	credentials := server.Credentials()
+	*authURL = credentials.AuthURL
+	*domainName = credentials.DomainName
+	*tenantName = credentials.TenantName
+	*userName = credentials.Username
+	*password = credentials.Password
+	*region = server.Region()
+}
+
 func validateFlags() {
 	if len(*authURL) == 0 {
 		panic("--auth-url flag is not specified")
@@ -111,6 +141,9 @@ var _ = Describe("Bastion tests", func() {
 
 	BeforeSuite(func() {
 		flag.Parse()
+		if *localOpenStack {
+			startLocalOpenStack()
+		}
 		validateFlags()
 
 		repoRoot := filepath.Join("..", "..", "..")
@@ -122,7 +155,7 @@ var _ = Describe("Bastion tests", func() {
 
 		By("starting test environment")
 		testEnv = &envtest.Environment{
-			UseExistingCluster: ptr.To(true),
+			UseExistingCluster: ptr.To(!*localOpenStack),
 			CRDInstallOptions: envtest.CRDInstallOptions{
 				Paths: []string{
 					filepath.Join(repoRoot, "example", "20-crd-extensions.gardener.cloud_clusters.yaml"),
@@ -257,9 +290,12 @@ var _ = Describe("Bastion tests", func() {
 			nil,
 		)).To(Succeed())
 
-		time.Sleep(10 * time.Second)
-		verifyPort22IsOpen(ctx, c, bastion)
-		verifyPort42IsClosed(ctx, c, bastion)
+		// the servers of the local OpenStack API are not reachable
+		if !*localOpenStack {
+			time.Sleep(10 * time.Second)
+			verifyPort22IsOpen(ctx, c, bastion)
+			verifyPort42IsClosed(ctx, c, bastion)
+		}
 
 		By("verify cloud resources")
 		verifyCreation(openstackClient, options)
@@ -305,7 +341,7 @@ func prepareNewRouter(log logr.Logger, routerName, subnetID string, openstackCli
 
 	allPages, err := networks.List(openstackClient.NetworkingClient, external.ListOptsExt{
 		ListOptsBuilder: networks.ListOpts{
-			Name: "FloatingIP-external-monsoon3-02"},
+			Name: externalNetworkName},
 		External: ptr.To(true),
 	}).AllPages()
 	Expect(err).NotTo(HaveOccurred())
@@ -465,7 +501,7 @@ func createInfrastructureConfig() *openstackv1alpha1.InfrastructureConfig {
 			APIVersion: openstackv1alpha1.SchemeGroupVersion.String(),
 			Kind:       "InfrastructureConfig",
 		},
-		FloatingPoolSubnetName: ptr.To("FloatingIP-external-monsoon3-02"),
+		FloatingPoolSubnetName: ptr.To(externalNetworkName),
 	}
 }
 
@@ -45,6 +45,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/controller/infrastructure/infraflow"
 	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack"
+	"github.com/gardener/gardener-extension-provider-openstack/pkg/openstack/client/fake"
 )
 
 type flowUsage int
@@ -68,10 +69,33 @@ var (
 	region           = flag.String("region", "", "Openstack region")
 	tenantName       = flag.String("tenant-name", "", "Tenant name for openstack")
 	userName         = flag.String("user-name", "", "User name for openstack")
+	localOpenStack   = flag.Bool("local-openstack", false, "Run against an in-process OpenStack API instead of a real OpenStack installation")
 
 	floatingPoolID string
 )
 
+// startLocalOpenStack starts an in-process OpenStack API with an external network for the floating pool and points
+// the flags to it.
+func startLocalOpenStack() {
+	if len(*floatingPoolName) == 0 {
+		*floatingPoolName = "public"
+	}
+
+	cloud := fake.NewCloud()
+	externalNetworkID := cloud.AddExternalNetwork(*floatingPoolName)
+	cloud.AddSubnet(externalNetworkID, *floatingPoolName+"-subnet", "172.24.4.0/24")
+	server := cloud.NewServer()
+	DeferCleanup(server.Close)
+
+	credentials := server.Credentials()
+	*authURL = credentials.AuthURL
+	*domainName = credentials.DomainName
+	*tenantName = credentials.TenantName
+	*userName = credentials.Username
+	*password = credentials.Password
+	*region = server.Region()
+}
+
 func validateFlags() {
 	if len(*authURL) == 0 {
 		panic("--auth-url flag is not specified")
@@ -110,6 +134,9 @@ var (
 
 var _ = BeforeSuite(func() {
 	flag.Parse()
+	if *localOpenStack {
+		startLocalOpenStack()
+	}
 	validateFlags()
 
 	repoRoot := filepath.Join("..", "..", "..")
@@ -134,7 +161,7 @@ var _ = BeforeSuite(func() {
 
 	By("starting test environment")
 	testEnv = &envtest.Environment{
-		UseExistingCluster: ptr.To(true),
+		UseExistingCluster: ptr.To(!*localOpenStack),
 		CRDInstallOptions: envtest.CRDInstallOptions{
 			Paths: []string{
 				filepath.Join(repoRoot, "example", "20-crd-extensions.gardener.cloud_clusters.yaml"),
@@ -437,6 +464,10 @@ func runTest(
 	cloudProfileConfig *openstackv1alpha1.CloudProfileConfig,
 	flow flowUsage,
 ) error {
+	if *localOpenStack && (flow == fuUseTerraformer || flow == fuMigrateFromTerraformer) {
+		Skip("the Terraformer cannot run against the local OpenStack API")
+	}
+
 	var (
 		namespace                 *corev1.Namespace
 		cluster                   *extensionsv1alpha1.Cluster